@@ -2,8 +2,15 @@ package builder
 
 import (
 	"context"
+	sqldriver "database/sql/driver"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+	"github.com/carlosnayan/prisma-go-client/internal/limits"
 )
 
 // AggregateResult representa o resultado de uma agregação
@@ -16,13 +23,50 @@ type AggregateResult struct {
 }
 
 // Aggregate executa uma agregação (COUNT, SUM, AVG, MIN, MAX)
+// respeitando os JOINs, WHERE, GROUP BY e HAVING da query.
+// Retorna nil quando o resultado é SQL NULL (ex: SUM sem linhas).
 func (q *Query) Aggregate(ctx context.Context, field string, aggType string) (interface{}, error) {
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	processStart := time.Now()
+	query, args, err := q.buildAggregateQuery(field, aggType)
+	if err != nil {
+		return nil, err
+	}
+
+	queryStart := time.Now()
+	var result interface{}
+	err = q.hooked(q.db).QueryRow(ctx, query, args...).Scan(&result)
+	queryDuration := time.Since(queryStart)
+
+	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+
+	if err != nil {
+		if logger := q.getLogger(); logger != nil {
+			logger.Error("%s query failed: %v", strings.ToUpper(aggType), err)
+		}
+		return nil, errors.SanitizeError(err)
+	}
+	return result, nil
+}
+
+// buildAggregateQuery monta SELECT AGG(campo) FROM ... com o estado atual da query
+func (q *Query) buildAggregateQuery(field string, aggType string) (string, []interface{}, error) {
 	var query string
 	var args []interface{}
 	argIndex := 1
 
-	// Construir SELECT com agregação
-	quotedTable := q.dialect.QuoteIdentifier(q.table)
+	if err := q.checkSubquery(); err != nil {
+		return "", nil, err
+	}
+	if err := q.checkUnlocked(); err != nil {
+		return "", nil, err
+	}
+
+	// Construir SELECT com agregação (a subquery de FromSubquery ocupa os primeiros placeholders)
+	quotedTable, fromArgs := q.fromClause(&argIndex)
+	args = append(args, fromArgs...)
 	aggFunc := strings.ToUpper(aggType)
 	switch aggFunc {
 	case "COUNT":
@@ -36,7 +80,7 @@ func (q *Query) Aggregate(ctx context.Context, field string, aggType string) (in
 		quotedField := q.dialect.QuoteIdentifier(field)
 		query = fmt.Sprintf("SELECT %s(%s) FROM %s", aggFunc, quotedField, quotedTable)
 	default:
-		return nil, fmt.Errorf("tipo de agregação não suportado: %s", aggType)
+		return "", nil, fmt.Errorf("tipo de agregação não suportado: %s", aggType)
 	}
 
 	// Adicionar JOINs
@@ -71,16 +115,187 @@ func (q *Query) Aggregate(ctx context.Context, field string, aggType string) (in
 		args = append(args, havingArgs...)
 	}
 
-	// Executar query
-	row := q.db.QueryRow(ctx, query, args...)
+	return query, args, nil
+}
 
-	var result interface{}
-	err := row.Scan(&result)
+// GroupAggregation descreve uma agregação calculada por grupo em GroupAggregate
+type GroupAggregation struct {
+	Func  string // COUNT, SUM, AVG, MIN ou MAX
+	Field string // Coluna agregada ("" ou "*" para COUNT(*))
+}
+
+// Alias retorna o nome da coluna de resultado: _count, _count_<campo>, _sum_<campo>, etc.
+func (a GroupAggregation) Alias() string {
+	aggFunc := strings.ToLower(a.Func)
+	if a.Field == "" || a.Field == "*" {
+		return "_" + aggFunc
+	}
+	return "_" + aggFunc + "_" + a.Field
+}
+
+// GroupAggregate agrupa as linhas por fields e calcula aggregates para cada grupo,
+// respeitando os JOINs, WHERE, HAVING, ORDER BY e Take/Skip da query.
+// dest deve ser um ponteiro para slice de struct: os campos agrupados são mapeados
+// pelo nome da coluna e as agregações pelo Alias (tags json/db).
+// Exemplo: q.GroupAggregate(ctx, []string{"status"}, []GroupAggregation{{Func: "COUNT"}}, &rows)
+func (q *Query) GroupAggregate(ctx context.Context, fields []string, aggregates []GroupAggregation, dest interface{}) error {
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dest must be a pointer to slice")
+	}
+	sliceVal := destVal.Elem()
+	elemType := sliceVal.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("dest must be a pointer to slice of struct")
+	}
+
+	processStart := time.Now()
+	query, args, err := q.buildGroupAggregateQuery(fields, aggregates)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return result, nil
+	queryStart := time.Now()
+	rows, err := q.hooked(q.db).Query(ctx, query, args...)
+	queryDuration := time.Since(queryStart)
+
+	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+
+	if err != nil {
+		if logger := q.getLogger(); logger != nil {
+			logger.Error("GROUP BY query failed: %v", err)
+		}
+		return errors.SanitizeError(err)
+	}
+	defer rows.Close()
+
+	columns := append([]string{}, fields...)
+	for _, agg := range aggregates {
+		columns = append(columns, agg.Alias())
+	}
+
+	rowCount := 0
+	for rows.Next() {
+		if rowCount >= limits.MaxScanRows {
+			return fmt.Errorf("result set too large: maximum %d rows allowed", limits.MaxScanRows)
+		}
+
+		row := reflect.New(elemType).Elem()
+		targets := make([]interface{}, len(columns))
+		for i, colName := range columns {
+			if field := findFieldByColumn(row, colName); field.IsValid() {
+				targets[i] = scanDestination(field)
+			} else {
+				var dummy interface{}
+				targets[i] = &dummy
+			}
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return errors.SanitizeError(err)
+		}
+
+		rowCount++
+		sliceVal.Set(reflect.Append(sliceVal, row))
+	}
+	return rows.Err()
+}
+
+// buildGroupAggregateQuery monta SELECT campos, AGG(campo) AS alias FROM ... GROUP BY campos
+func (q *Query) buildGroupAggregateQuery(fields []string, aggregates []GroupAggregation) (string, []interface{}, error) {
+	if len(fields) == 0 {
+		return "", nil, fmt.Errorf("%w: GROUP BY requires at least one field", errors.ErrInvalidInput)
+	}
+
+	var args []interface{}
+	argIndex := 1
+
+	selectParts := make([]string, 0, len(fields)+len(aggregates))
+	quotedGroupBy := make([]string, len(fields))
+	for i, field := range fields {
+		quotedGroupBy[i] = q.dialect.QuoteIdentifier(field)
+		selectParts = append(selectParts, quotedGroupBy[i])
+	}
+	for _, agg := range aggregates {
+		aggFunc := strings.ToUpper(agg.Func)
+		var expr string
+		switch aggFunc {
+		case "COUNT":
+			if agg.Field == "" || agg.Field == "*" {
+				expr = "COUNT(*)"
+			} else {
+				expr = fmt.Sprintf("COUNT(%s)", q.dialect.QuoteIdentifier(agg.Field))
+			}
+		case "SUM", "AVG", "MIN", "MAX":
+			if agg.Field == "" || agg.Field == "*" {
+				return "", nil, fmt.Errorf("%w: %s requires a field", errors.ErrInvalidInput, aggFunc)
+			}
+			expr = fmt.Sprintf("%s(%s)", aggFunc, q.dialect.QuoteIdentifier(agg.Field))
+		default:
+			return "", nil, fmt.Errorf("tipo de agregação não suportado: %s", agg.Func)
+		}
+		selectParts = append(selectParts, expr+" AS "+q.dialect.QuoteIdentifier(agg.Alias()))
+	}
+
+	if err := q.checkSubquery(); err != nil {
+		return "", nil, err
+	}
+	if err := q.checkUnlocked(); err != nil {
+		return "", nil, err
+	}
+	fromClause, fromArgs := q.fromClause(&argIndex)
+	args = append(args, fromArgs...)
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(selectParts, ", "), fromClause)
+
+	// Adicionar JOINs
+	for _, join := range q.joins {
+		quotedJoinTable := q.dialect.QuoteIdentifier(join.table)
+		query += fmt.Sprintf(" %s JOIN %s ON %s", join.joinType, quotedJoinTable, join.on)
+		args = append(args, join.args...)
+		argIndex += len(join.args)
+	}
+
+	// Adicionar WHERE
+	if len(q.whereConditions) > 0 {
+		whereClause, whereArgs := q.buildWhereClause(&argIndex)
+		query += " WHERE " + whereClause
+		args = append(args, whereArgs...)
+	}
+
+	query += " GROUP BY " + strings.Join(quotedGroupBy, ", ")
+
+	// Adicionar HAVING
+	if len(q.having) > 0 {
+		havingClause, havingArgs := q.buildHavingClause(&argIndex)
+		query += " HAVING " + havingClause
+		args = append(args, havingArgs...)
+	}
+
+	// Adicionar ORDER BY
+	if len(q.orderBy) > 0 {
+		orderClause, orderArgs := q.buildOrderByClause(&argIndex)
+		query += " ORDER BY " + orderClause
+		args = append(args, orderArgs...)
+	}
+
+	// Adicionar LIMIT/OFFSET
+	if q.take != nil || q.skip != nil {
+		limit, offset := 0, 0
+		if q.take != nil {
+			limit = *q.take
+		}
+		if q.skip != nil {
+			offset = *q.skip
+		}
+		if limitOffset, limitArgs := limitClause(q.dialect, limit, offset, len(q.orderBy) > 0, &argIndex); limitOffset != "" {
+			query += " " + limitOffset
+			args = append(args, limitArgs...)
+		}
+	}
+
+	return query, args, nil
 }
 
 // Count executa COUNT(*)
@@ -95,36 +310,63 @@ func (q *Query) CountAggregate(ctx context.Context) (int64, error) {
 	return 0, fmt.Errorf("resultado inesperado do COUNT")
 }
 
-// Sum executa SUM(field)
+// Sum executa SUM(field). Retorna 0 quando não há linhas (SQL NULL).
 func (q *Query) Sum(ctx context.Context, field string) (float64, error) {
 	result, err := q.Aggregate(ctx, field, "SUM")
 	if err != nil {
 		return 0, err
 	}
-	if sum, ok := result.(float64); ok {
-		return sum, nil
-	}
-	return 0, fmt.Errorf("resultado inesperado do SUM")
+	return aggregateFloat(result, "SUM")
 }
 
-// Avg executa AVG(field)
+// Avg executa AVG(field). Retorna 0 quando não há linhas (SQL NULL).
 func (q *Query) Avg(ctx context.Context, field string) (float64, error) {
 	result, err := q.Aggregate(ctx, field, "AVG")
 	if err != nil {
 		return 0, err
 	}
-	if avg, ok := result.(float64); ok {
-		return avg, nil
-	}
-	return 0, fmt.Errorf("resultado inesperado do AVG")
+	return aggregateFloat(result, "AVG")
 }
 
-// Min executa MIN(field)
+// Min executa MIN(field). O valor mantém o tipo da coluna (número, data, texto)
+// e é nil quando não há linhas (SQL NULL).
 func (q *Query) Min(ctx context.Context, field string) (interface{}, error) {
 	return q.Aggregate(ctx, field, "MIN")
 }
 
-// Max executa MAX(field)
+// Max executa MAX(field). O valor mantém o tipo da coluna (número, data, texto)
+// e é nil quando não há linhas (SQL NULL).
 func (q *Query) Max(ctx context.Context, field string) (interface{}, error) {
 	return q.Aggregate(ctx, field, "MAX")
 }
+
+// aggregateFloat converte o resultado de uma agregação numérica para float64.
+// Os drivers retornam int64 para colunas inteiras e texto/bytes para DECIMAL;
+// SQL NULL vira 0.
+func aggregateFloat(result interface{}, aggFunc string) (float64, error) {
+	switch v := result.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case []byte:
+		return strconv.ParseFloat(string(v), 64)
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case sqldriver.Valuer:
+		value, err := v.Value()
+		if err != nil {
+			return 0, err
+		}
+		return aggregateFloat(value, aggFunc)
+	}
+	return 0, fmt.Errorf("resultado inesperado do %s: %T", aggFunc, result)
+}
@@ -420,6 +420,149 @@ func TestCreateMany_Basic(t *testing.T) {
 	}
 }
 
+// TestUpsert_ConflictAppliesUpdate testa que o Upsert insere a linha e, no conflito,
+// aplica apenas os campos de update
+func TestUpsert_ConflictAppliesUpdate(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+
+			var createTableSQL string
+			switch provider {
+			case "postgresql":
+				createTableSQL = `CREATE TABLE IF NOT EXISTS books (id SERIAL PRIMARY KEY, title VARCHAR(255) NOT NULL, author VARCHAR(255) NOT NULL, isbn VARCHAR(32) NOT NULL UNIQUE)`
+			case "mysql":
+				createTableSQL = `CREATE TABLE IF NOT EXISTS books (id INT AUTO_INCREMENT PRIMARY KEY, title VARCHAR(255) NOT NULL, author VARCHAR(255) NOT NULL, isbn VARCHAR(32) NOT NULL UNIQUE)`
+			case "sqlite":
+				createTableSQL = `CREATE TABLE IF NOT EXISTS books (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL, author TEXT NOT NULL, isbn TEXT NOT NULL UNIQUE)`
+			}
+			if _, err := sqlDB.Exec(createTableSQL); err != nil {
+				t.Fatalf("Failed to create table: %v", err)
+			}
+
+			builder := NewTableQueryBuilder(db, "books", []string{"id", "title", "author", "isbn"})
+			builder.SetDialect(dialect.GetDialect(provider))
+			builder.SetPrimaryKey("id")
+			builder.SetModelType(reflect.TypeOf(Book{}))
+
+			// Primeira execução: não há conflito, a linha é inserida com os dados de create
+			result, err := builder.Upsert(ctx, Book{Title: "Book 1", Author: "Author A", ISBN: "111"}, []string{"isbn"}, map[string]interface{}{"title": "Book 1 (2nd ed.)"})
+			if err != nil {
+				t.Fatalf("Upsert (insert) failed: %v", err)
+			}
+			if book := result.(Book); book.Title != "Book 1" || book.Author != "Author A" {
+				t.Errorf("unexpected inserted book: %+v", book)
+			}
+
+			// Segunda execução: conflito no isbn, apenas o title é atualizado
+			result, err = builder.Upsert(ctx, Book{Title: "Other", Author: "Author B", ISBN: "111"}, []string{"isbn"}, map[string]interface{}{"title": "Book 1 (2nd ed.)"})
+			if err != nil {
+				t.Fatalf("Upsert (update) failed: %v", err)
+			}
+			if book := result.(Book); book.Title != "Book 1 (2nd ed.)" || book.Author != "Author A" {
+				t.Errorf("conflict should only apply the update fields, got %+v", book)
+			}
+
+			var count int
+			if err := sqlDB.QueryRow("SELECT COUNT(*) FROM books").Scan(&count); err != nil {
+				t.Fatalf("Failed to count books: %v", err)
+			}
+			if count != 1 {
+				t.Errorf("Expected 1 book in database, got %d", count)
+			}
+		})
+	}
+}
+
+// TestCreate_ReadBackPerDialect tests that SQL Server reads the created row with OUTPUT INSERTED
+// and that the read-backs use the dialect's limit syntax
+func TestCreate_ReadBackPerDialect(t *testing.T) {
+	ctx := context.Background()
+	newBuilder := func(db DBTX, provider string) *TableQueryBuilder {
+		builder := NewTableQueryBuilder(db, "books", []string{"id", "title"})
+		builder.SetDialect(dialect.GetDialect(provider))
+		builder.SetPrimaryKey("id")
+		return builder
+	}
+	type book struct {
+		ID    int    `db:"id"`
+		Title string `db:"title"`
+	}
+
+	recorder := &routingDB{}
+	newBuilder(recorder, "sqlserver").Create(ctx, book{Title: "Go"})
+	want := []string{"INSERT INTO [books] ([title]) OUTPUT INSERTED.[id], INSERTED.[title] VALUES (@p1)"}
+	if fmt.Sprint(recorder.log) != fmt.Sprint(want) {
+		t.Errorf("sqlserver Create = %q, want %q", recorder.log, want)
+	}
+
+	recorder = &routingDB{}
+	newBuilder(recorder, "mysql").Create(ctx, book{Title: "Go"})
+	want = []string{"INSERT INTO `books` (`title`) VALUES (?)", "SELECT `id`, `title` FROM `books` WHERE `id` = LAST_INSERT_ID() LIMIT ?"}
+	if fmt.Sprint(recorder.log) != fmt.Sprint(want) {
+		t.Errorf("mysql Create = %q, want %q", recorder.log, want)
+	}
+
+	recorder = &routingDB{}
+	newBuilder(recorder, "sqlserver").Create(ctx, book{ID: 7, Title: "Go"})
+	newBuilder(recorder, "sqlite").SetFetchCreated(true).Create(ctx, book{ID: 7, Title: "Go"})
+	if len(recorder.log) != 3 || recorder.log[2] != `SELECT "id", "title" FROM "books" WHERE "id" = ? LIMIT ?` {
+		t.Errorf("sqlite Create read-back = %q", recorder.log)
+	}
+}
+
+// TestCreateMany_RowDefaults tests that the cells a record leaves zero and not provided are
+// DEFAULT, and that SQLite groups the records by the columns they set
+func TestCreateMany_RowDefaults(t *testing.T) {
+	type account struct {
+		ID     int    `db:"id"`
+		Name   string `db:"name"`
+		Active bool   `db:"active"`
+	}
+	data := []interface{}{account{Name: "a", Active: false}, account{Name: "b"}, account{Name: "c", Active: true}}
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"postgresql", []string{`INSERT INTO "accounts" ("name", "active") VALUES ($1, $2), ($3, DEFAULT), ($4, $5)`}},
+		{"mysql", []string{"INSERT INTO `accounts` (`name`, `active`) VALUES (?, ?), (?, DEFAULT), (?, ?)"}},
+		{"sqlite", []string{`INSERT INTO "accounts" ("name", "active") VALUES (?, ?), (?, ?)`, `INSERT INTO "accounts" ("name") VALUES (?)`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			recorder := &routingDB{}
+			builder := NewTableQueryBuilder(recorder, "accounts", []string{"id", "name", "active"})
+			builder.SetDialect(dialect.GetDialect(tt.provider))
+			builder.SetPrimaryKey("id")
+			// Active false foi informado só no primeiro registro
+			builder.SetRowProvidedColumns([]string{"name", "active"}, []string{"name"}, []string{"name", "active"})
+
+			payload, err := builder.CreateMany(context.Background(), data, false)
+			if err != nil {
+				t.Fatalf("CreateMany failed: %v", err)
+			}
+			if fmt.Sprint(recorder.log) != fmt.Sprint(tt.want) {
+				t.Errorf("CreateMany SQL = %q, want %q", recorder.log, tt.want)
+			}
+			if payload.Count != len(tt.want) {
+				t.Errorf("expected count %d, got %d", len(tt.want), payload.Count)
+			}
+		})
+	}
+}
+
 // TestCreateMany_EmptySlice tests CreateMany with empty slice
 func TestCreateMany_EmptySlice(t *testing.T) {
 	db, cleanup := testutil.SetupTestDB(t, "postgresql")
@@ -17,9 +17,18 @@ import (
 // DBTX is an alias for driver.DB for backward compatibility
 type DBTX = driver.DB
 
+// ConnAcquirer is an alias for driver.ConnAcquirer
+type ConnAcquirer = driver.ConnAcquirer
+
 // Result is an alias for driver.Result for use in generated code
 type Result = driver.Result
 
+// CommandTagResult is an alias for driver.CommandTagResult
+type CommandTagResult = driver.CommandTagResult
+
+// ColumnsRows is an alias for driver.ColumnsRows
+type ColumnsRows = driver.ColumnsRows
+
 // Rows is an alias for driver.Rows for use in generated code
 type Rows = driver.Rows
 
@@ -29,6 +38,22 @@ type Row = driver.Row
 // Tx is an alias for driver.Tx for use in generated code
 type Tx = driver.Tx
 
+// ErrUniqueConstraint is returned by Create when the record collides with an existing
+// unique key and the PKConflictError mode is set
+var ErrUniqueConstraint = errors.ErrUniqueConstraint
+
+// ErrDuplicateKey is an alias for ErrUniqueConstraint
+var ErrDuplicateKey = errors.ErrDuplicateKey
+
+// ErrInvalidInput is returned when a query is built with invalid arguments (e.g. a Cursor without Order)
+var ErrInvalidInput = errors.ErrInvalidInput
+
+// ErrNotFound is returned when a record required by the operation does not exist
+var ErrNotFound = errors.ErrNotFound
+
+// ErrReadOnly is returned by the write methods of a read-only Query and by a ReadOnly DBTX
+var ErrReadOnly = errors.ErrReadOnly
+
 // TableQueryBuilder provides a Prisma-like query builder for database tables
 type TableQueryBuilder struct {
 	db         DBTX
@@ -37,6 +62,17 @@ type TableQueryBuilder struct {
 	primaryKey string
 	modelType  reflect.Type
 	dialect    dialect.Dialect
+	pkConflict PKConflictMode
+	// fetchCreated makes Create read the inserted row back on SQLite
+	fetchCreated bool
+	// providedColumns are inserted even when their value is the zero value
+	providedColumns map[string]bool
+	// rowProvidedColumns are the provided columns of each CreateMany record
+	rowProvidedColumns []map[string]bool
+	// hookQuery holds the hooks and arg redaction set by CopyHooks
+	hookQuery *Query
+	// conflictWhere is the predicate of the partial unique index Upsert conflicts on
+	conflictWhere string
 }
 
 // NewTableQueryBuilder creates a new query builder for a table
@@ -67,13 +103,71 @@ func (b *TableQueryBuilder) SetModelType(modelType reflect.Type) *TableQueryBuil
 	return b
 }
 
+// SetPKConflictMode defines how Create handles a pre-set primary key.
+// The default (PKConflictInsert) is a plain INSERT.
+func (b *TableQueryBuilder) SetPKConflictMode(mode PKConflictMode) *TableQueryBuilder {
+	b.pkConflict = mode
+	return b
+}
+
+// SetFetchCreated makes Create return the inserted row on SQLite, where it
+// otherwise only confirms the insert. The row is read back by primary key,
+// using LastInsertId for auto-generated keys.
+func (b *TableQueryBuilder) SetFetchCreated(fetch bool) *TableQueryBuilder {
+	b.fetchCreated = fetch
+	return b
+}
+
+// SetProvidedColumns marks the columns the caller set explicitly. Create, Upsert and
+// CreateMany insert them even when the value is zero (0, false, ""); other zero-valued
+// columns are left out so the database default applies.
+func (b *TableQueryBuilder) SetProvidedColumns(columns ...string) *TableQueryBuilder {
+	b.providedColumns = make(map[string]bool, len(columns))
+	for _, col := range columns {
+		b.providedColumns[col] = true
+	}
+	return b
+}
+
+// SetRowProvidedColumns marks the columns each CreateMany record set explicitly, in the
+// order of the records. Like SetProvidedColumns they are inserted even when zero; a record's
+// other zero-valued columns are DEFAULT.
+func (b *TableQueryBuilder) SetRowProvidedColumns(rows ...[]string) *TableQueryBuilder {
+	b.rowProvidedColumns = make([]map[string]bool, len(rows))
+	for i, columns := range rows {
+		b.rowProvidedColumns[i] = make(map[string]bool, len(columns))
+		for _, col := range columns {
+			b.rowProvidedColumns[i][col] = true
+		}
+	}
+	return b
+}
+
+// SetConflictWhere sets the predicate of the partial unique index Upsert conflicts on, such
+// as "deleted_at IS NULL", so the conflict target matches it: ON CONFLICT (cols) WHERE pred.
+// "" (the default) targets a full unique index.
+func (b *TableQueryBuilder) SetConflictWhere(predicate string) *TableQueryBuilder {
+	b.conflictWhere = predicate
+	return b
+}
+
+// skipInsert reports whether an insert leaves the column out: zero and not provided
+func (b *TableQueryBuilder) skipInsert(column string, value reflect.Value) bool {
+	return value.IsZero() && !b.providedColumns[column]
+}
+
+// providedInRow reports whether column was provided for all records or for CreateMany's record row
+func (b *TableQueryBuilder) providedInRow(row int, column string) bool {
+	return b.providedColumns[column] || (row < len(b.rowProvidedColumns) && b.rowProvidedColumns[row][column])
+}
+
 // FindFirst finds the first record matching the where conditions
 func (b *TableQueryBuilder) FindFirst(ctx context.Context, where Where) (interface{}, error) {
 	ctx, cancel := contextutil.WithQueryTimeout(ctx)
 	defer cancel()
 
 	query, args := b.buildQuery(where, nil, true)
-	row := b.db.QueryRow(ctx, query, args...)
+	row := b.conn().QueryRow(ctx, query, args...)
 
 	if b.modelType == nil {
 		return row, nil
@@ -88,7 +182,7 @@ func (b *TableQueryBuilder) FindMany(ctx context.Context, opts QueryOptions) (in
 	defer cancel()
 
 	query, args := b.buildQuery(opts.Where, &opts, false)
-	rows, err := b.db.Query(ctx, query, args...)
+	rows, err := b.conn().Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +217,7 @@ func (b *TableQueryBuilder) Count(ctx context.Context, where Where) (int, error)
 
 	query := strings.Join(parts, " ")
 	var count int
-	err := b.db.QueryRow(ctx, query, args...).Scan(&count)
+	err := b.conn().QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, errors.SanitizeError(err)
 	}
@@ -158,10 +252,9 @@ func (b *TableQueryBuilder) Create(ctx context.Context, data interface{}) (inter
 		field := typ.Field(i)
 		fieldVal := val.Field(i)
 
-		dbTag := field.Tag.Get("db")
-		fieldName := dbTag
-		if fieldName == "" {
-			fieldName = toSnakeCase(field.Name)
+		fieldName, ok := fieldColumn(field)
+		if !ok {
+			continue
 		}
 
 		if fieldName == b.primaryKey {
@@ -172,13 +265,13 @@ func (b *TableQueryBuilder) Create(ctx context.Context, data interface{}) (inter
 			continue
 		}
 
-		if fieldVal.IsZero() {
+		if b.skipInsert(fieldName, fieldVal) {
 			continue
 		}
 
 		insertColumns = append(insertColumns, fieldName)
 		values = append(values, b.dialect.GetPlaceholder(argIndex))
-		args = append(args, fieldVal.Interface())
+		args = append(args, bindArg(fieldVal.Interface()))
 		argIndex++
 	}
 
@@ -210,64 +303,64 @@ func (b *TableQueryBuilder) Create(ctx context.Context, data interface{}) (inter
 		quotedReturnCols[i] = b.dialect.QuoteIdentifier(col)
 	}
 
+	onConflict := ""
+	if b.pkConflict == PKConflictUpsert && primaryKeyCol != "" && !primaryKeyIsZero {
+		onConflict = b.buildPKConflictClause(insertColumns, primaryKeyCol)
+	}
+
 	var row interface{}
 	if b.dialect.SupportsReturning() {
 		query := fmt.Sprintf(
-			"INSERT INTO %s (%s) VALUES (%s) RETURNING %s",
+			"INSERT INTO %s (%s) VALUES (%s)%s RETURNING %s",
 			quotedTable,
 			strings.Join(quotedInsertCols, ", "),
 			strings.Join(values, ", "),
+			onConflict,
 			strings.Join(quotedReturnCols, ", "),
 		)
-		row = b.db.QueryRow(ctx, query, args...)
+		row = b.conn().QueryRow(ctx, query, args...)
+	} else if b.dialect.Name() == "sqlserver" {
+		// O go-mssqldb não implementa LastInsertId; o OUTPUT devolve a linha inserida direto
+		query := fmt.Sprintf(
+			"INSERT INTO %s (%s)%s VALUES (%s)",
+			quotedTable,
+			strings.Join(quotedInsertCols, ", "),
+			outputInsertedClause(quotedReturnCols),
+			strings.Join(values, ", "),
+		)
+		row = b.conn().QueryRow(ctx, query, args...)
 	} else {
 		query := fmt.Sprintf(
-			"INSERT INTO %s (%s) VALUES (%s)",
+			"INSERT INTO %s (%s) VALUES (%s)%s",
 			quotedTable,
 			strings.Join(quotedInsertCols, ", "),
 			strings.Join(values, ", "),
+			onConflict,
 		)
-		result, err := b.db.Exec(ctx, query, args...)
+		result, err := b.conn().Exec(ctx, query, args...)
 		if err != nil {
-			return nil, err
+			return nil, b.mapCreateError(err)
 		}
 
 		// SQLite não retorna o modelo criado, apenas confirma sucesso
-		if b.dialect.Name() == "sqlite" {
+		if b.dialect.Name() == "sqlite" && !b.fetchCreated {
 			return nil, nil
 		}
 
-		if primaryKeyCol != "" && primaryKeyValue != nil {
-			selectQuery := fmt.Sprintf(
-				"SELECT %s FROM %s WHERE %s = %s LIMIT 1",
-				strings.Join(quotedReturnCols, ", "),
-				quotedTable,
-				b.dialect.QuoteIdentifier(primaryKeyCol),
-				b.dialect.GetPlaceholder(1),
-			)
-			row = b.db.QueryRow(ctx, selectQuery, primaryKeyValue)
+		if primaryKeyCol != "" && primaryKeyValue != nil && !reflect.ValueOf(primaryKeyValue).IsZero() {
+			selectQuery, limitArgs := b.readBackQuery(quotedReturnCols, b.dialect.QuoteIdentifier(primaryKeyCol)+" = "+b.dialect.GetPlaceholder(1), 1)
+			row = b.conn().QueryRow(ctx, selectQuery, append([]interface{}{primaryKeyValue}, limitArgs...)...)
 		} else if primaryKeyCol != "" {
 			if b.dialect.Name() == "mysql" {
-				selectQuery := fmt.Sprintf(
-					"SELECT %s FROM %s WHERE %s = LAST_INSERT_ID() LIMIT 1",
-					strings.Join(quotedReturnCols, ", "),
-					quotedTable,
-					b.dialect.QuoteIdentifier(primaryKeyCol),
-				)
-				row = b.db.QueryRow(ctx, selectQuery)
+				selectQuery, limitArgs := b.readBackQuery(quotedReturnCols, b.dialect.QuoteIdentifier(primaryKeyCol)+" = LAST_INSERT_ID()", 0)
+				row = b.conn().QueryRow(ctx, selectQuery, limitArgs...)
 			} else {
 				lastInsertID, err := result.LastInsertId()
 				if err != nil || lastInsertID == 0 {
 					return nil, fmt.Errorf("cannot retrieve inserted record: primary key was auto-generated but LastInsertId() failed: %v", err)
 				}
-				selectQuery := fmt.Sprintf(
-					"SELECT %s FROM %s WHERE %s = %s LIMIT 1",
-					strings.Join(quotedReturnCols, ", "),
-					quotedTable,
-					b.dialect.QuoteIdentifier(primaryKeyCol),
-					b.dialect.GetPlaceholder(1),
-				)
-				row = b.db.QueryRow(ctx, selectQuery, lastInsertID)
+				selectQuery, limitArgs := b.readBackQuery(quotedReturnCols, b.dialect.QuoteIdentifier(primaryKeyCol)+" = "+b.dialect.GetPlaceholder(1), 1)
+				row = b.conn().QueryRow(ctx, selectQuery, append([]interface{}{lastInsertID}, limitArgs...)...)
 			}
 		} else {
 			return nil, fmt.Errorf("cannot retrieve inserted record: no primary key and dialect does not support RETURNING")
@@ -279,11 +372,216 @@ func (b *TableQueryBuilder) Create(ctx context.Context, data interface{}) (inter
 	}
 
 	if driverRow, ok := row.(driver.Row); ok {
-		return b.scanRow(driverRow)
+		created, err := b.scanRow(driverRow)
+		return created, b.mapCreateError(err)
 	}
 	return nil, fmt.Errorf("invalid row type")
 }
 
+// readBackQuery builds the SELECT that reads a written row back by where, limited to one
+// row through the dialect's limit syntax; where uses placeholders 1..whereArgs
+func (b *TableQueryBuilder) readBackQuery(quotedColumns []string, where string, whereArgs int) (string, []interface{}) {
+	argIndex := whereArgs + 1
+	limit, limitArgs := limitClause(b.dialect, 1, 0, false, &argIndex)
+	return fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s %s",
+		strings.Join(quotedColumns, ", "),
+		b.dialect.QuoteIdentifier(b.table),
+		where,
+		limit,
+	), limitArgs
+}
+
+// outputInsertedClause builds SQL Server's OUTPUT INSERTED.col, ... clause, which returns the
+// inserted row (including an IDENTITY key) since go-mssqldb has no LastInsertId
+func outputInsertedClause(quotedColumns []string) string {
+	inserted := make([]string, len(quotedColumns))
+	for i, col := range quotedColumns {
+		inserted[i] = "INSERTED." + col
+	}
+	return " OUTPUT " + strings.Join(inserted, ", ")
+}
+
+// buildPKConflictClause builds the upsert suffix used by Create in PKConflictUpsert mode
+func (b *TableQueryBuilder) buildPKConflictClause(insertColumns []string, primaryKeyCol string) string {
+	quotedPK := b.dialect.QuoteIdentifier(primaryKeyCol)
+	var updateParts []string
+
+	switch b.dialect.Name() {
+	case "mysql":
+		var updateColumns []string
+		for _, col := range insertColumns {
+			if col != primaryKeyCol {
+				updateColumns = append(updateColumns, col)
+			}
+		}
+		if len(updateColumns) == 0 {
+			return fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", quotedPK, quotedPK)
+		}
+		return mysqlDuplicateKeyUpdate(b.dialect, updateColumns)
+	default:
+		for _, col := range insertColumns {
+			if col == primaryKeyCol {
+				continue
+			}
+			quotedCol := b.dialect.QuoteIdentifier(col)
+			updateParts = append(updateParts, fmt.Sprintf("%s = EXCLUDED.%s", quotedCol, quotedCol))
+		}
+		// DO UPDATE (instead of DO NOTHING) so RETURNING always yields the row
+		if len(updateParts) == 0 {
+			updateParts = append(updateParts, fmt.Sprintf("%s = EXCLUDED.%s", quotedPK, quotedPK))
+		}
+		return fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", quotedPK, strings.Join(updateParts, ", "))
+	}
+}
+
+// mapCreateError converts unique violations into ErrUniqueConstraint in PKConflictError mode
+func (b *TableQueryBuilder) mapCreateError(err error) error {
+	if err != nil && b.pkConflict == PKConflictError && errors.IsUniqueViolation(err) {
+		return fmt.Errorf("%w: table %s", errors.ErrUniqueConstraint, b.table)
+	}
+	return err
+}
+
+// Upsert inserts data or, when it conflicts on conflictColumns, applies only updates to the
+// existing row, in a single INSERT ... ON CONFLICT (ON DUPLICATE KEY on MySQL) statement.
+// A partial unique index needs its predicate set with SetConflictWhere. It returns the resulting row; without RETURNING the row is read back by the conflict columns.
+func (b *TableQueryBuilder) Upsert(ctx context.Context, data interface{}, conflictColumns []string, updates map[string]interface{}) (interface{}, error) {
+	ctx, cancel := contextutil.WithQueryTimeout(ctx)
+	defer cancel()
+
+	query, args, conflictArgs, err := b.buildUpsertQuery(data, conflictColumns, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	quotedReturnCols := make([]string, len(b.columns))
+	for i, col := range b.columns {
+		quotedReturnCols[i] = b.dialect.QuoteIdentifier(col)
+	}
+
+	var row interface{}
+	if b.dialect.SupportsReturning() {
+		row = b.conn().QueryRow(ctx, query+" RETURNING "+strings.Join(quotedReturnCols, ", "), args...)
+	} else {
+		if _, err := b.conn().Exec(ctx, query, args...); err != nil {
+			return nil, errors.SanitizeError(err)
+		}
+
+		conditions := make([]string, len(conflictColumns))
+		for i, col := range conflictColumns {
+			conditions[i] = fmt.Sprintf("%s = %s", b.dialect.QuoteIdentifier(col), b.dialect.GetPlaceholder(i+1))
+		}
+		// O predicado do índice parcial separa a linha viva das removidas; o MySQL não tem índices
+		// parciais, então lá o conflito pode ter sido com qualquer uma delas
+		if b.conflictWhere != "" && b.dialect.Name() != "mysql" {
+			conditions = append(conditions, "("+b.conflictWhere+")")
+		}
+		selectQuery, limitArgs := b.readBackQuery(quotedReturnCols, strings.Join(conditions, " AND "), len(conflictColumns))
+		row = b.conn().QueryRow(ctx, selectQuery, append(conflictArgs, limitArgs...)...)
+	}
+
+	if b.modelType == nil {
+		return row, nil
+	}
+
+	if driverRow, ok := row.(driver.Row); ok {
+		upserted, err := b.scanRow(driverRow)
+		return upserted, errors.SanitizeError(err)
+	}
+	return nil, fmt.Errorf("invalid row type")
+}
+
+// buildUpsertQuery builds the INSERT ... ON CONFLICT statement for Upsert (without RETURNING).
+// conflictArgs are the values the row has in conflictColumns after the statement runs.
+func (b *TableQueryBuilder) buildUpsertQuery(data interface{}, conflictColumns []string, updates map[string]interface{}) (string, []interface{}, []interface{}, error) {
+	if len(conflictColumns) == 0 {
+		return "", nil, nil, fmt.Errorf("%w: upsert requires at least one conflict column", errors.ErrInvalidInput)
+	}
+
+	val := reflect.ValueOf(data)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return "", nil, nil, fmt.Errorf("data must be a struct")
+	}
+
+	var insertColumns []string
+	var values []string
+	var args []interface{}
+	insertValues := make(map[string]interface{})
+	argIndex := 1
+
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+
+		fieldName, ok := fieldColumn(field)
+		if !ok {
+			continue
+		}
+
+		value := bindArg(fieldVal.Interface())
+		if b.skipInsert(fieldName, fieldVal) {
+			if fieldName != b.primaryKey || fieldVal.Kind() != reflect.String {
+				continue
+			}
+			value = uuid.GenerateUUID()
+		}
+
+		insertColumns = append(insertColumns, b.dialect.QuoteIdentifier(fieldName))
+		values = append(values, b.dialect.GetPlaceholder(argIndex))
+		args = append(args, value)
+		insertValues[fieldName] = value
+		argIndex++
+	}
+
+	conflictArgs := make([]interface{}, len(conflictColumns))
+	for i, col := range conflictColumns {
+		value, ok := insertValues[col]
+		if !ok {
+			return "", nil, nil, fmt.Errorf("%w: upsert data must set conflict column %s", errors.ErrInvalidInput, col)
+		}
+		if updated, ok := updates[col]; ok {
+			value = updated
+		}
+		conflictArgs[i] = value
+	}
+
+	// Iterate the model columns so the SET order is deterministic
+	var assignments []string
+	known := 0
+	for _, col := range b.columns {
+		value, ok := updates[col]
+		if !ok {
+			continue
+		}
+		assignments = append(assignments, fmt.Sprintf("%s = %s", b.dialect.QuoteIdentifier(col), b.dialect.GetPlaceholder(argIndex)))
+		args = append(args, bindArg(value))
+		argIndex++
+		known++
+	}
+	if known != len(updates) {
+		return "", nil, nil, fmt.Errorf("%w: upsert update has columns not in table %s", errors.ErrInvalidInput, b.table)
+	}
+
+	upsertClause := b.dialect.GetUpsertClause(conflictColumns, b.conflictWhere, assignments)
+	if upsertClause == "" {
+		return "", nil, nil, fmt.Errorf("%w: upsert is not supported on %s", errors.ErrInvalidInput, b.dialect.Name())
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) %s",
+		b.dialect.QuoteIdentifier(b.table),
+		strings.Join(insertColumns, ", "),
+		strings.Join(values, ", "),
+		upsertClause,
+	)
+	return query, args, conflictArgs, nil
+}
+
 // Update updates a record by primary key and returns the updated model
 func (b *TableQueryBuilder) Update(ctx context.Context, id interface{}, data interface{}) (interface{}, error) {
 	ctx, cancel := contextutil.WithQueryTimeout(ctx)
@@ -310,7 +608,10 @@ func (b *TableQueryBuilder) Update(ctx context.Context, id interface{}, data int
 		field := typ.Field(i)
 		fieldVal := val.Field(i)
 
-		fieldName := toSnakeCase(field.Name)
+		fieldName, ok := fieldColumn(field)
+		if !ok {
+			continue
+		}
 		quotedFieldName := b.dialect.QuoteIdentifier(fieldName)
 
 		if fieldName == b.primaryKey {
@@ -322,7 +623,7 @@ func (b *TableQueryBuilder) Update(ctx context.Context, id interface{}, data int
 		}
 
 		updateColumns = append(updateColumns, fmt.Sprintf("%s = $%d", quotedFieldName, argIndex))
-		args = append(args, fieldVal.Interface())
+		args = append(args, bindArg(fieldVal.Interface()))
 		argIndex++
 	}
 
@@ -349,7 +650,7 @@ func (b *TableQueryBuilder) Update(ctx context.Context, id interface{}, data int
 		strings.Join(returningColumns, ", "),
 	)
 
-	row := b.db.QueryRow(ctx, query, args...)
+	row := b.conn().QueryRow(ctx, query, args...)
 
 	if b.modelType == nil {
 		return row, nil
@@ -376,11 +677,13 @@ func (b *TableQueryBuilder) Delete(ctx context.Context, id interface{}) error {
 	)
 	args := []interface{}{id}
 
-	_, err := b.db.Exec(ctx, query, args...)
+	_, err := b.conn().Exec(ctx, query, args...)
 	return err
 }
 
-// CreateMany inserts multiple records and returns the number of records created
+// CreateMany inserts multiple records and returns the number of records created.
+// The columns a record leaves zero and not provided (see SetRowProvidedColumns) are DEFAULT,
+// so the database default applies even when another record of the batch sets them.
 func (b *TableQueryBuilder) CreateMany(ctx context.Context, data []interface{}, skipDuplicates bool) (*BatchPayload, error) {
 	ctx, cancel := contextutil.WithQueryTimeout(ctx)
 	defer cancel()
@@ -389,181 +692,80 @@ func (b *TableQueryBuilder) CreateMany(ctx context.Context, data []interface{},
 		return &BatchPayload{Count: 0}, nil
 	}
 
-	// Process first record to determine columns
-	firstVal := reflect.ValueOf(data[0])
-	if firstVal.Kind() == reflect.Ptr {
-		firstVal = firstVal.Elem()
-	}
-	if firstVal.Kind() != reflect.Struct {
-		return nil, fmt.Errorf("data must be a slice of structs")
-	}
-
-	// Determine columns from first record
-	var insertColumns []string
-	typ := firstVal.Type()
-	columnMap := make(map[string]bool)
-	var primaryKeyCol string
-	var primaryKeyType reflect.Kind
-
-	// First, identify primary key field
-	if b.primaryKey != "" {
-		for i := 0; i < firstVal.NumField(); i++ {
-			field := typ.Field(i)
-			dbTag := field.Tag.Get("db")
-			fieldName := dbTag
-			if fieldName == "" {
-				fieldName = toSnakeCase(field.Name)
-			}
-			if fieldName == b.primaryKey {
-				primaryKeyCol = fieldName
-				primaryKeyType = firstVal.Field(i).Kind()
-				break
-			}
+	rows := make([]reflect.Value, len(data))
+	for i, item := range data {
+		row := reflect.Indirect(reflect.ValueOf(item))
+		if row.Kind() != reflect.Struct || (i > 0 && row.Type() != rows[0].Type()) {
+			return nil, fmt.Errorf("data must be a slice of structs")
 		}
+		rows[i] = row
 	}
 
-	// Collect all non-primary key columns that are not zero
-	for i := 0; i < firstVal.NumField(); i++ {
-		field := typ.Field(i)
-		dbTag := field.Tag.Get("db")
-		fieldName := dbTag
-		if fieldName == "" {
-			fieldName = toSnakeCase(field.Name)
-		}
-		if fieldName != b.primaryKey && !firstVal.Field(i).IsZero() {
-			insertColumns = append(insertColumns, fieldName)
-			columnMap[fieldName] = true
-		}
+	// Colunas preenchidas (não zero ou informadas) em algum registro; a PK string vazia recebe um UUID por linha
+	typ := rows[0].Type()
+	var columns []string
+	fieldIndexes := make(map[string]int)
+	rowColumns := make([]map[string]bool, len(rows))
+	for r := range rowColumns {
+		rowColumns[r] = make(map[string]bool)
 	}
+	uuidColumn := ""
+	for i := 0; i < typ.NumField(); i++ {
+		column, ok := fieldColumn(typ.Field(i))
+		if !ok {
+			continue
+		}
 
-	// Add primary key if it's set or if it's a string type (for UUID generation)
-	if primaryKeyCol != "" {
-		primaryKeySet := false
-		for i := 0; i < firstVal.NumField(); i++ {
-			field := typ.Field(i)
-			dbTag := field.Tag.Get("db")
-			fieldName := dbTag
-			if fieldName == "" {
-				fieldName = toSnakeCase(field.Name)
-			}
-			if fieldName == primaryKeyCol {
-				if !firstVal.Field(i).IsZero() {
-					insertColumns = append(insertColumns, fieldName)
-					primaryKeySet = true
-				}
-				break
+		isUUID := column == b.primaryKey && typ.Field(i).Type.Kind() == reflect.String
+		isSet := false
+		for r, row := range rows {
+			fieldVal := row.Field(i)
+			if isUUID || !fieldVal.IsZero() || (column != b.primaryKey && b.providedInRow(r, column)) {
+				rowColumns[r][column] = true
+				isSet = true
 			}
 		}
-		// If primary key is string type and not set, we'll generate UUID for each record
-		if !primaryKeySet && primaryKeyType == reflect.String {
-			insertColumns = append(insertColumns, primaryKeyCol)
+		if !isSet {
+			continue
 		}
-	}
-
-	quotedTable := b.dialect.QuoteIdentifier(b.table)
-	quotedInsertCols := make([]string, len(insertColumns))
-	for i, col := range insertColumns {
-		quotedInsertCols[i] = b.dialect.QuoteIdentifier(col)
-	}
-
-	// Batch size for large inserts
-	batchSize := 1000
-	totalCount := 0
-
-	for batchStart := 0; batchStart < len(data); batchStart += batchSize {
-		batchEnd := batchStart + batchSize
-		if batchEnd > len(data) {
-			batchEnd = len(data)
+		if isUUID {
+			uuidColumn = column
 		}
-		batch := data[batchStart:batchEnd]
 
-		var valuesParts []string
-		var allArgs []interface{}
-		argIndex := 1
+		columns = append(columns, column)
+		fieldIndexes[column] = i
+	}
 
-		for _, item := range batch {
-			val := reflect.ValueOf(item)
-			if val.Kind() == reflect.Ptr {
-				val = val.Elem()
+	var suffix func(columns []string) string
+	if skipDuplicates {
+		suffix = func(columns []string) string {
+			if isPostgresFamily(b.dialect) {
+				return " ON CONFLICT DO NOTHING"
 			}
-			itemTyp := val.Type()
-
-			var rowValues []string
-			var rowArgs []interface{}
-
-			for _, col := range insertColumns {
-				if col == primaryKeyCol && primaryKeyType == reflect.String {
-					// Check if primary key is set for this item
-					found := false
-					for i := 0; i < val.NumField(); i++ {
-						field := itemTyp.Field(i)
-						dbTag := field.Tag.Get("db")
-						fieldName := dbTag
-						if fieldName == "" {
-							fieldName = toSnakeCase(field.Name)
-						}
-						if fieldName == col {
-							fieldVal := val.Field(i)
-							if !fieldVal.IsZero() {
-								rowArgs = append(rowArgs, fieldVal.Interface())
-								found = true
-								break
-							}
-						}
-					}
-					if !found {
-						rowArgs = append(rowArgs, uuid.GenerateUUID())
-					}
-				} else {
-					// Find field by column name
-					found := false
-					for i := 0; i < val.NumField(); i++ {
-						field := itemTyp.Field(i)
-						dbTag := field.Tag.Get("db")
-						fieldName := dbTag
-						if fieldName == "" {
-							fieldName = toSnakeCase(field.Name)
-						}
-						if fieldName == col {
-							fieldVal := val.Field(i)
-							rowArgs = append(rowArgs, fieldVal.Interface())
-							found = true
-							break
-						}
-					}
-					if !found {
-						rowArgs = append(rowArgs, nil)
-					}
-				}
-				rowValues = append(rowValues, b.dialect.GetPlaceholder(argIndex))
-				argIndex++
+			if b.dialect.Name() == "mysql" && len(columns) > 0 {
+				quoted := b.dialect.QuoteIdentifier(columns[0])
+				return " ON DUPLICATE KEY UPDATE " + quoted + " = " + quoted
 			}
-			valuesParts = append(valuesParts, "("+strings.Join(rowValues, ", ")+")")
-			allArgs = append(allArgs, rowArgs...)
+			return ""
 		}
+	}
 
-		onConflict := ""
-		if skipDuplicates && b.dialect.Name() == "postgresql" {
-			onConflict = " ON CONFLICT DO NOTHING"
-		} else if skipDuplicates && b.dialect.Name() == "mysql" {
-			onConflict = " ON DUPLICATE KEY UPDATE " + quotedInsertCols[0] + " = " + quotedInsertCols[0]
+	// Lotes de até 1000 registros, abaixo do limite de placeholders do dialeto
+	queries, queryArgs := buildInsertRows(b.dialect, b.table, columns, rowColumns, 1000, func(r int, column string) interface{} {
+		fieldVal := rows[r].Field(fieldIndexes[column])
+		if column == uuidColumn && fieldVal.IsZero() {
+			return uuid.GenerateUUID()
 		}
+		return bindArg(fieldVal.Interface())
+	}, suffix)
 
-		query := fmt.Sprintf(
-			"INSERT INTO %s (%s) VALUES %s%s",
-			quotedTable,
-			strings.Join(quotedInsertCols, ", "),
-			strings.Join(valuesParts, ", "),
-			onConflict,
-		)
-
-		result, err := b.db.Exec(ctx, query, allArgs...)
+	totalCount := 0
+	for i, query := range queries {
+		result, err := b.conn().Exec(ctx, query, queryArgs[i]...)
 		if err != nil {
 			return &BatchPayload{Count: totalCount}, err
 		}
-
-		rowsAffected := result.RowsAffected()
-		totalCount += int(rowsAffected)
+		totalCount += int(result.RowsAffected())
 	}
 
 	return &BatchPayload{Count: totalCount}, nil
@@ -595,10 +797,9 @@ func (b *TableQueryBuilder) UpdateMany(ctx context.Context, where Where, data in
 		field := typ.Field(i)
 		fieldVal := val.Field(i)
 
-		dbTag := field.Tag.Get("db")
-		fieldName := dbTag
-		if fieldName == "" {
-			fieldName = toSnakeCase(field.Name)
+		fieldName, ok := fieldColumn(field)
+		if !ok {
+			continue
 		}
 		quotedFieldName := b.dialect.QuoteIdentifier(fieldName)
 
@@ -611,7 +812,7 @@ func (b *TableQueryBuilder) UpdateMany(ctx context.Context, where Where, data in
 		}
 
 		updateColumns = append(updateColumns, fmt.Sprintf("%s = %s", quotedFieldName, b.dialect.GetPlaceholder(argIndex)))
-		args = append(args, fieldVal.Interface())
+		args = append(args, bindArg(fieldVal.Interface()))
 		argIndex++
 	}
 
@@ -633,7 +834,7 @@ func (b *TableQueryBuilder) UpdateMany(ctx context.Context, where Where, data in
 		whereClause,
 	)
 
-	result, err := b.db.Exec(ctx, query, args...)
+	result, err := b.conn().Exec(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -661,7 +862,6 @@ func (b *TableQueryBuilder) buildQuery(where Where, opts *QueryOptions, single b
 		if whereClause != "" {
 			parts = append(parts, "WHERE "+whereClause)
 			args = append(args, whereArgs...)
-			argIndex += len(whereArgs)
 		}
 	}
 
@@ -692,16 +892,17 @@ func (b *TableQueryBuilder) buildQuery(where Where, opts *QueryOptions, single b
 				hasLimit = true
 			}
 			if hasLimit {
-				limitOffset := b.dialect.GetLimitOffsetSyntax(limit, offset)
+				limitOffset, limitArgs := limitClause(b.dialect, limit, offset, len(opts.OrderBy) > 0, &argIndex)
 				if limitOffset != "" {
 					parts = append(parts, limitOffset)
+					args = append(args, limitArgs...)
 				}
 			}
-			// Note: GetLimitOffsetSyntax already includes the values in the SQL string,
-			// so we don't need to add them to args
 		}
 	} else {
-		parts = append(parts, "LIMIT 1")
+		limitOffset, limitArgs := limitClause(b.dialect, 1, 0, opts != nil && len(opts.OrderBy) > 0, &argIndex)
+		parts = append(parts, limitOffset)
+		args = append(args, limitArgs...)
 	}
 
 	return strings.Join(parts, " "), args
@@ -728,6 +929,20 @@ func (b *TableQueryBuilder) buildWhereFromMap(where Where, argIndex *int) (strin
 					}
 					parts = append(parts, fmt.Sprintf("%s %s (%s)", quotedField, op.GetOp(), strings.Join(placeholders, ", ")))
 				}
+			case "BETWEEN":
+				if values, ok := op.GetValue().([]interface{}); ok && len(values) == 2 {
+					parts = append(parts, fmt.Sprintf("%s BETWEEN $%d AND $%d", quotedField, *argIndex, *argIndex+1))
+					args = append(args, values...)
+					*argIndex += 2
+				}
+			case "LIKE", "ILIKE", "NOT LIKE", "NOT ILIKE":
+				query, arg, ok := likeCondition(b.dialect, field, op)
+				if !ok {
+					query, arg = fmt.Sprintf("%s %s ?", quotedField, op.GetOp()), op.GetValue()
+				}
+				parts = append(parts, strings.Replace(query, "?", fmt.Sprintf("$%d", *argIndex), 1))
+				args = append(args, arg)
+				(*argIndex)++
 			default:
 				parts = append(parts, fmt.Sprintf("%s %s $%d", quotedField, op.GetOp(), *argIndex))
 				args = append(args, op.GetValue())
@@ -756,7 +971,10 @@ func buildColumnToFieldMap(modelType reflect.Type, columns []string) map[string]
 	for i := 0; i < modelType.NumField(); i++ {
 		field := modelType.Field(i)
 		jsonTag := field.Tag.Get("json")
-		dbTag := field.Tag.Get("db")
+		column, ok := fieldColumn(field)
+		if !ok {
+			continue
+		}
 
 		// Remove options from json tag (e.g., "id,omitempty" -> "id")
 		if jsonTag != "" && jsonTag != "-" {
@@ -766,10 +984,8 @@ func buildColumnToFieldMap(modelType reflect.Type, columns []string) map[string]
 		}
 
 		// Map all possible identifiers to this field index
-		// Priority: dbTag > jsonTag > snake_case field name
-		if dbTag != "" {
-			fieldMap[dbTag] = i
-		}
+		// Priority: column > jsonTag > snake_case field name
+		fieldMap[column] = i
 		if jsonTag != "" && jsonTag != "-" {
 			fieldMap[jsonTag] = i
 		}
@@ -809,7 +1025,7 @@ func (b *TableQueryBuilder) scanRow(row driver.Row) (interface{}, error) {
 	for i, colName := range b.columns {
 		if fieldIdx, ok := columnToField[colName]; ok {
 			field := modelValue.Field(fieldIdx)
-			fields[i] = field.Addr().Interface()
+			fields[i] = scanDestination(field)
 			mappedCount++
 		} else {
 			var dummy interface{}
@@ -858,7 +1074,7 @@ func (b *TableQueryBuilder) scanRows(rows driver.Rows) (interface{}, error) {
 		for i, colName := range b.columns {
 			if fieldIdx, ok := columnToField[colName]; ok {
 				field := modelValue.Field(fieldIdx)
-				fields[i] = field.Addr().Interface()
+				fields[i] = scanDestination(field)
 			} else {
 				var dummy interface{}
 				fields[i] = &dummy
@@ -913,3 +1129,16 @@ func toSnakeCase(s string) string {
 	}
 	return strings.ToLower(result.String())
 }
+
+// fieldColumn returns the column a struct field maps to: its db tag, or its snake_case name.
+// ok is false for fields tagged db:"-", such as loaded relations, which have no column.
+func fieldColumn(field reflect.StructField) (column string, ok bool) {
+	column = field.Tag.Get("db")
+	if column == "-" {
+		return "", false
+	}
+	if column == "" {
+		column = toSnakeCase(field.Name)
+	}
+	return column, true
+}
@@ -0,0 +1,131 @@
+package builder
+
+import (
+	"context"
+)
+
+// ToSelectSQL returns the SELECT that Find would run, with its args, without executing it.
+// It returns an empty string when the query is invalid (Find would return the error).
+// Example: sql, args := q.Where("age > ?", 18).Order("name ASC").ToSelectSQL()
+func (q *Query) ToSelectSQL() (string, []interface{}) {
+	c := *q
+	if err := c.prepareSelect(); err != nil {
+		return "", nil
+	}
+	return c.buildSelectQuery(false)
+}
+
+// ToInsertSQL returns the INSERT that Create would run for value, without executing it
+func (q *Query) ToInsertSQL(value interface{}) (string, []interface{}) {
+	query, args, _ := q.buildInsertQuery(value)
+	return query, args
+}
+
+// ToUpdateSQL returns the UPDATE that Updates would run for values, without executing it
+func (q *Query) ToUpdateSQL(values map[string]interface{}) (string, []interface{}) {
+	return q.buildUpdatesQuery(values)
+}
+
+// ToDeleteSQL returns the DELETE that Delete would run, without executing it.
+// With Cascade, only the statement deleting the matched rows is returned.
+func (q *Query) ToDeleteSQL() (string, []interface{}) {
+	return q.buildDeleteQuery()
+}
+
+// DryRun makes the query build its statements without sending them to the database.
+// First, Find, Count, Create, Updates, Delete and the other executing methods succeed
+// with empty results (nothing scanned, no rows affected), and ToSQL returns the last
+// statement they would have run. DryRun(false) restores the connection.
+// Example:
+//
+//	q.DryRun(true).Where("id = ?", id).Updates(ctx, values)
+//	sql, args := q.ToSQL()
+func (q *Query) DryRun(enabled bool) *Query {
+	switch {
+	case enabled && q.dryRun == nil:
+		q.dryRun = &dryRunConn{DBTX: q.db}
+		q.db = q.dryRun
+	case !enabled && q.dryRun != nil:
+		q.db = q.dryRun.DBTX
+		q.dryRun = nil
+	}
+	return q
+}
+
+// ToSQL returns the last statement run in DryRun mode, or the SELECT of ToSelectSQL when
+// none has run yet
+func (q *Query) ToSQL() (string, []interface{}) {
+	if q.dryRun != nil && q.dryRun.sql != "" {
+		return q.dryRun.sql, q.dryRun.args
+	}
+	return q.ToSelectSQL()
+}
+
+// dryRunConn keeps the last statement instead of running it, transactions included
+type dryRunConn struct {
+	DBTX
+	sql  string
+	args []interface{}
+}
+
+func (c *dryRunConn) record(sql string, args []interface{}) {
+	c.sql, c.args = sql, append([]interface{}{}, args...)
+}
+
+func (c *dryRunConn) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	c.record(sql, args)
+	return dryRunResult{}, nil
+}
+
+func (c *dryRunConn) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	c.record(sql, args)
+	return dryRunRows{}, nil
+}
+
+func (c *dryRunConn) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	c.record(sql, args)
+	return dryRunRow{}
+}
+
+func (c *dryRunConn) Begin(ctx context.Context) (Tx, error) {
+	return &dryRunTx{conn: c}, nil
+}
+
+// dryRunTx records the statements of a transaction on its dryRunConn
+type dryRunTx struct {
+	conn *dryRunConn
+}
+
+func (t *dryRunTx) Commit(ctx context.Context) error   { return nil }
+func (t *dryRunTx) Rollback(ctx context.Context) error { return nil }
+
+func (t *dryRunTx) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	return t.conn.Exec(ctx, sql, args...)
+}
+
+func (t *dryRunTx) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	return t.conn.Query(ctx, sql, args...)
+}
+
+func (t *dryRunTx) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	return t.conn.QueryRow(ctx, sql, args...)
+}
+
+// dryRunResult reports no affected rows
+type dryRunResult struct{}
+
+func (dryRunResult) RowsAffected() int64          { return 0 }
+func (dryRunResult) LastInsertId() (int64, error) { return 0, nil }
+
+// dryRunRows is an empty result set
+type dryRunRows struct{}
+
+func (dryRunRows) Close()                         {}
+func (dryRunRows) Err() error                     { return nil }
+func (dryRunRows) Next() bool                     { return false }
+func (dryRunRows) Scan(dest ...interface{}) error { return nil }
+
+// dryRunRow leaves the scan destinations untouched
+type dryRunRow struct{}
+
+func (dryRunRow) Scan(dest ...interface{}) error { return nil }
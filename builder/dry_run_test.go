@@ -0,0 +1,86 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// unreachableDB entra em pânico em qualquer chamada ao banco
+type unreachableDB struct {
+	DBTX
+}
+
+func TestQuery_ToSQL(t *testing.T) {
+	type user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	q := NewQuery(unreachableDB{}, "users", []string{"id", "name"})
+	q.Where("id = ?", 7)
+
+	tests := []struct {
+		name     string
+		build    func() (string, []interface{})
+		wantSQL  string
+		wantArgs string
+	}{
+		{"select", q.ToSelectSQL, `SELECT "id", "name" FROM "users" WHERE id = $1`, "[7]"},
+		{"insert", func() (string, []interface{}) { return q.ToInsertSQL(&user{Name: "a"}) }, `INSERT INTO "users" ("name") VALUES ($1)`, "[a]"},
+		{"update", func() (string, []interface{}) { return q.ToUpdateSQL(map[string]interface{}{"name": "b"}) }, `UPDATE "users" SET "name" = $1 WHERE id = $2`, "[b 7]"},
+		{"delete", q.ToDeleteSQL, `DELETE FROM "users" WHERE id = $1`, "[7]"},
+		{"to sql without dry run", q.ToSQL, `SELECT "id", "name" FROM "users" WHERE id = $1`, "[7]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.build()
+			if sql != tt.wantSQL || fmt.Sprint(args) != tt.wantArgs {
+				t.Errorf("SQL = %s args = %v, want %s %s", sql, args, tt.wantSQL, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestQuery_DryRun(t *testing.T) {
+	type user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	ctx := context.Background()
+	q := NewQuery(unreachableDB{}, "users", []string{"id", "name"}).DryRun(true)
+	q.SetModelType(reflect.TypeOf(user{}))
+
+	// Nada chega ao banco e os métodos terminam sem erro
+	var users []user
+	if err := q.Where("id = ?", 7).Find(ctx, &users); err != nil || len(users) != 0 {
+		t.Fatalf("Find = %v, %v", users, err)
+	}
+	if sql, args := q.ToSQL(); sql != `SELECT "id", "name" FROM "users" WHERE id = $1` || fmt.Sprint(args) != "[7]" {
+		t.Errorf("ToSQL after Find = %s %v", sql, args)
+	}
+
+	if n, err := q.Count(ctx); err != nil || n != 0 {
+		t.Fatalf("Count = %d, %v", n, err)
+	}
+	if n, err := q.UpdatesResult(ctx, map[string]interface{}{"name": "b"}); err != nil || n != 0 {
+		t.Fatalf("UpdatesResult = %d, %v", n, err)
+	}
+	if sql, args := q.ToSQL(); sql != `UPDATE "users" SET "name" = $1 WHERE id = $2` || fmt.Sprint(args) != "[b 7]" {
+		t.Errorf("ToSQL after Updates = %s %v", sql, args)
+	}
+
+	// Transações também ficam no modo dry run
+	err := q.RunInTransaction(ctx, func(db DBTX) error {
+		_, err := db.Exec(ctx, "DELETE FROM users")
+		return err
+	})
+	if sql, _ := q.ToSQL(); err != nil || sql != "DELETE FROM users" {
+		t.Errorf("ToSQL after transaction = %s, %v", sql, err)
+	}
+
+	// DryRun(false) volta para a conexão original
+	if q.DryRun(false).db != (unreachableDB{}) {
+		t.Errorf("DryRun(false) kept %T", q.db)
+	}
+}
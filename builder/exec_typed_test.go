@@ -2,9 +2,13 @@ package builder
 
 import (
 	"context"
+	sqldriver "database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/carlosnayan/prisma-go-client/internal/dialect"
 	testutil "github.com/carlosnayan/prisma-go-client/internal/testing"
@@ -372,6 +376,78 @@ func TestQuery_ScanFind(t *testing.T) {
 	}
 }
 
+// TestQuery_ScanFind_PointerSlice tests ScanFind into []*DTO as well as []DTO
+func TestQuery_ScanFind_PointerSlice(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+
+			var createTableSQL string
+			switch provider {
+			case "postgresql":
+				createTableSQL = `CREATE TABLE IF NOT EXISTS products (id SERIAL PRIMARY KEY, name VARCHAR(255) NOT NULL)`
+			case "mysql":
+				createTableSQL = `CREATE TABLE IF NOT EXISTS products (id INT AUTO_INCREMENT PRIMARY KEY, name VARCHAR(255) NOT NULL)`
+			case "sqlite":
+				createTableSQL = `CREATE TABLE IF NOT EXISTS products (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)`
+			}
+			if _, err := sqlDB.ExecContext(ctx, createTableSQL); err != nil {
+				t.Fatalf("failed to create table: %v", err)
+			}
+			if _, err := sqlDB.ExecContext(ctx, `INSERT INTO products (name) VALUES ('Product 1'), ('Product 2')`); err != nil {
+				t.Fatalf("failed to insert: %v", err)
+			}
+
+			type ProductDTO struct {
+				ID   int    `db:"id"`
+				Name string `db:"name"`
+			}
+
+			newQuery := func() *Query {
+				query := NewQuery(db, "products", []string{"id", "name"})
+				query.SetDialect(dialect.GetDialect(provider))
+				return query.Order("id ASC")
+			}
+
+			var values []ProductDTO
+			if err := newQuery().ScanFind(ctx, &values, reflect.TypeOf(ProductDTO{})); err != nil {
+				t.Fatalf("ScanFind into []ProductDTO failed: %v", err)
+			}
+			if len(values) != 2 || values[1].Name != "Product 2" {
+				t.Errorf("unexpected []ProductDTO results: %+v", values)
+			}
+
+			// The scan type may be given as the element or as the pointer type
+			for _, scanType := range []reflect.Type{reflect.TypeOf(ProductDTO{}), reflect.TypeOf(&ProductDTO{})} {
+				var pointers []*ProductDTO
+				if err := newQuery().ScanFind(ctx, &pointers, scanType); err != nil {
+					t.Fatalf("ScanFind into []*ProductDTO with %v failed: %v", scanType, err)
+				}
+				if len(pointers) != 2 {
+					t.Fatalf("Expected 2 results, got %d", len(pointers))
+				}
+				if pointers[0] == pointers[1] {
+					t.Error("each row should get its own pointer")
+				}
+				if pointers[0].Name != "Product 1" || pointers[1].Name != "Product 2" {
+					t.Errorf("unexpected []*ProductDTO results: %+v, %+v", *pointers[0], *pointers[1])
+				}
+			}
+		})
+	}
+}
+
 // TestQuery_ScanFirst_WithMissingFields tests ScanFirst with DTO that has missing fields
 func TestQuery_ScanFirst_WithMissingFields(t *testing.T) {
 	providers := []string{"postgresql", "mysql", "sqlite"}
@@ -683,3 +759,491 @@ func TestQuery_ScanFind_EmptyResult(t *testing.T) {
 		})
 	}
 }
+
+// TestQuery_ScanFind_LenientScan tests that LenientScan returns good rows plus a multi-error
+func TestQuery_ScanFind_LenientScan(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+
+			var createTableSQL string
+			switch provider {
+			case "postgresql":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS lenient_products (
+						id SERIAL PRIMARY KEY,
+						name VARCHAR(255) NOT NULL,
+						price DECIMAL(10,2)
+					)
+				`
+			case "mysql":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS lenient_products (
+						id INT AUTO_INCREMENT PRIMARY KEY,
+						name VARCHAR(255) NOT NULL,
+						price DECIMAL(10,2)
+					)
+				`
+			case "sqlite":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS lenient_products (
+						id INTEGER PRIMARY KEY AUTOINCREMENT,
+						name TEXT NOT NULL,
+						price REAL
+					)
+				`
+			}
+
+			_, err := sqlDB.ExecContext(ctx, createTableSQL)
+			if err != nil {
+				t.Fatalf("failed to create table: %v", err)
+			}
+
+			var insertSQL string
+			switch provider {
+			case "postgresql":
+				insertSQL = `INSERT INTO lenient_products (name, price) VALUES ($1, $2)`
+			case "mysql", "sqlite":
+				insertSQL = `INSERT INTO lenient_products (name, price) VALUES (?, ?)`
+			}
+
+			// The second row has a NULL price, which cannot be scanned into float64
+			rowsToInsert := []struct {
+				name  string
+				price interface{}
+			}{
+				{"Good 1", 10.50},
+				{"Bad", nil},
+				{"Good 2", 30.00},
+			}
+			for _, r := range rowsToInsert {
+				if _, err := sqlDB.ExecContext(ctx, insertSQL, r.name, r.price); err != nil {
+					t.Fatalf("failed to insert: %v", err)
+				}
+			}
+
+			type ProductDTO struct {
+				ID    int     `json:"id" db:"id"`
+				Name  string  `json:"name" db:"name"`
+				Price float64 `json:"price" db:"price"`
+			}
+
+			newQuery := func() *Query {
+				query := NewQuery(db, "lenient_products", []string{"id", "name", "price"})
+				query.SetDialect(dialect.GetDialect(provider))
+				query.Order("id ASC")
+				return query
+			}
+
+			// Default: fail-fast
+			var strict []ProductDTO
+			if err := newQuery().ScanFind(ctx, &strict, reflect.TypeOf(ProductDTO{})); err == nil {
+				t.Fatal("Expected ScanFind to fail without LenientScan")
+			}
+
+			// LenientScan: good rows are kept, bad row is reported
+			var results []ProductDTO
+			err = newQuery().LenientScan().ScanFind(ctx, &results, reflect.TypeOf(ProductDTO{}))
+			if err == nil {
+				t.Fatal("Expected ScanErrors from LenientScan")
+			}
+
+			var scanErrs *ScanErrors
+			if !errors.As(err, &scanErrs) {
+				t.Fatalf("Expected *ScanErrors, got %T: %v", err, err)
+			}
+			if len(scanErrs.Rows) != 1 || scanErrs.Rows[0].Row != 1 {
+				t.Errorf("Expected a single error for row 1, got %+v", scanErrs.Rows)
+			}
+
+			if len(results) != 2 {
+				t.Fatalf("Expected 2 scanned rows, got %d", len(results))
+			}
+			if results[0].Name != "Good 1" || results[1].Name != "Good 2" {
+				t.Errorf("Unexpected scanned rows: %+v", results)
+			}
+		})
+	}
+}
+
+// pgxLikeRows behaves like pgx rows: a failed Scan closes them, Next stops and Err
+// reports the scan error
+type pgxLikeRows struct {
+	rows   []valuesRow
+	pos    int
+	closed bool
+	err    error
+}
+
+func (r *pgxLikeRows) Close()     { r.closed = true }
+func (r *pgxLikeRows) Err() error { return r.err }
+func (r *pgxLikeRows) Next() bool {
+	if r.closed || r.pos >= len(r.rows) {
+		return false
+	}
+	r.pos++
+	return true
+}
+func (r *pgxLikeRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		value := r.rows[r.pos-1][i]
+		target := reflect.ValueOf(d).Elem()
+		switch {
+		case value == nil && (target.Kind() == reflect.Interface || target.Kind() == reflect.Ptr):
+			target.Set(reflect.Zero(target.Type()))
+		case value != nil && reflect.TypeOf(value).AssignableTo(target.Type()):
+			target.Set(reflect.ValueOf(value))
+		case value != nil && reflect.TypeOf(value).Kind() == reflect.Int32 && target.Kind() == reflect.Int:
+			target.SetInt(int64(value.(int32)))
+		default:
+			r.err = fmt.Errorf("can't scan %v into dest[%d] (%s)", value, i, target.Type())
+			r.closed = true
+			return r.err
+		}
+	}
+	return nil
+}
+
+type pgxLikeDB struct {
+	DBTX
+	rows []valuesRow
+}
+
+func (d *pgxLikeDB) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	return &pgxLikeRows{rows: d.rows}, nil
+}
+
+// TestQuery_ScanFind_LenientScanPgx tests that LenientScan keeps going on pgx, whose rows
+// close when a Scan fails, and still returns *ScanErrors
+func TestQuery_ScanFind_LenientScanPgx(t *testing.T) {
+	type ProductDTO struct {
+		ID    int     `db:"id"`
+		Name  string  `db:"name"`
+		Price float64 `db:"price"`
+		Stock *int64  `db:"stock"`
+	}
+	db := &pgxLikeDB{rows: []valuesRow{
+		{int32(1), "Good 1", 10.5, int64(3)},
+		{int32(2), "Bad", nil, nil},
+		{int32(3), "Good 2", "30", nil},
+		{int32(4), "Bad 2", "x", nil},
+		{int32(5), "Good 3", float32(2), int64(7)},
+	}}
+	newQuery := func() *Query {
+		q := NewQuery(db, "products", []string{"id", "name", "price", "stock"})
+		q.SetDialect(dialect.GetDialect("postgresql"))
+		return q
+	}
+
+	var strict []ProductDTO
+	if err := newQuery().ScanFind(context.Background(), &strict, reflect.TypeOf(ProductDTO{})); err == nil {
+		t.Fatal("Expected ScanFind to fail without LenientScan")
+	}
+
+	var results []ProductDTO
+	err := newQuery().LenientScan().ScanFind(context.Background(), &results, reflect.TypeOf(ProductDTO{}))
+	var scanErrs *ScanErrors
+	if !errors.As(err, &scanErrs) {
+		t.Fatalf("Expected *ScanErrors, got %T: %v", err, err)
+	}
+	if len(scanErrs.Rows) != 2 || scanErrs.Rows[0].Row != 1 || scanErrs.Rows[1].Row != 3 {
+		t.Errorf("Expected errors for rows 1 and 3, got %+v", scanErrs.Rows)
+	}
+	if len(results) != 3 || results[0].Name != "Good 1" || results[1].Price != 30 || results[2].ID != 5 {
+		t.Fatalf("Unexpected scanned rows: %+v", results)
+	}
+	if results[0].Stock == nil || *results[0].Stock != 3 || results[1].Stock != nil {
+		t.Errorf("Unexpected stock values: %v, %v", results[0].Stock, results[1].Stock)
+	}
+}
+
+// TestQuery_ScanFind_LenientScanPgxUUID tests that a pgx [16]byte UUID scans into a string
+// field in canonical form, and that a driver type with no conversion is a row error
+func TestQuery_ScanFind_LenientScanPgxUUID(t *testing.T) {
+	type AccountDTO struct {
+		ID   string `db:"id"`
+		Name string `db:"name"`
+	}
+	type point struct{ X, Y float64 }
+	db := &pgxLikeDB{rows: []valuesRow{
+		{[16]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}, "Good"},
+		{[16]byte{}, point{X: 1, Y: 2}},
+	}}
+	q := NewQuery(db, "accounts", []string{"id", "name"})
+	q.SetDialect(dialect.GetDialect("postgresql"))
+
+	var results []AccountDTO
+	err := q.LenientScan().ScanFind(context.Background(), &results, reflect.TypeOf(AccountDTO{}))
+	var scanErrs *ScanErrors
+	if !errors.As(err, &scanErrs) {
+		t.Fatalf("Expected *ScanErrors, got %T: %v", err, err)
+	}
+	if len(scanErrs.Rows) != 1 || scanErrs.Rows[0].Row != 1 {
+		t.Errorf("Expected an error for row 1, got %+v", scanErrs.Rows)
+	}
+	if len(results) != 1 || results[0].ID != "12345678-9abc-def0-0123-456789abcdef" {
+		t.Fatalf("Unexpected scanned rows: %+v", results)
+	}
+}
+
+// testDate is a custom date type (like civil.Date) implementing sql.Scanner and driver.Valuer
+type testDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func (d *testDate) Scan(src interface{}) error {
+	var t time.Time
+	switch v := src.(type) {
+	case time.Time:
+		t = v
+	case string:
+		return d.parse(v)
+	case []byte:
+		return d.parse(string(v))
+	case nil:
+		*d = testDate{}
+		return nil
+	default:
+		return fmt.Errorf("testDate: cannot scan %T", src)
+	}
+	*d = testDate{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+	return nil
+}
+
+func (d *testDate) parse(s string) error {
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05", time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			*d = testDate{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+			return nil
+		}
+	}
+	return fmt.Errorf("testDate: cannot parse %q", s)
+}
+
+func (d testDate) Value() (sqldriver.Value, error) {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// TestQuery_Scan_CustomTimeType tests scanning a DateTime column into a custom Scanner/Valuer type
+func TestQuery_Scan_CustomTimeType(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+
+			var createTableSQL string
+			switch provider {
+			case "postgresql":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS custom_time_events (
+						id SERIAL PRIMARY KEY,
+						name VARCHAR(255) NOT NULL,
+						happened_at TIMESTAMP NOT NULL,
+						reviewed_at TIMESTAMP
+					)
+				`
+			case "mysql":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS custom_time_events (
+						id INT AUTO_INCREMENT PRIMARY KEY,
+						name VARCHAR(255) NOT NULL,
+						happened_at DATETIME NOT NULL,
+						reviewed_at DATETIME
+					)
+				`
+			case "sqlite":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS custom_time_events (
+						id INTEGER PRIMARY KEY AUTOINCREMENT,
+						name TEXT NOT NULL,
+						happened_at DATETIME NOT NULL,
+						reviewed_at DATETIME
+					)
+				`
+			}
+
+			if _, err := sqlDB.ExecContext(ctx, createTableSQL); err != nil {
+				t.Fatalf("failed to create table: %v", err)
+			}
+
+			type Event struct {
+				ID         int       `json:"id" db:"id"`
+				Name       string    `json:"name" db:"name"`
+				HappenedAt testDate  `json:"happened_at" db:"happened_at"`
+				ReviewedAt *testDate `json:"reviewed_at" db:"reviewed_at"`
+			}
+
+			columns := []string{"id", "name", "happened_at", "reviewed_at"}
+			want := testDate{Year: 2024, Month: time.March, Day: 9}
+
+			// O Valuer é usado no INSERT
+			query := NewQuery(db, "custom_time_events", columns)
+			query.SetDialect(dialect.GetDialect(provider))
+			query.SetPrimaryKey("id")
+			if err := query.Create(ctx, Event{Name: "launch", HappenedAt: want}); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			// O Scanner é usado no ScanFirst
+			query = NewQuery(db, "custom_time_events", columns)
+			query.SetDialect(dialect.GetDialect(provider))
+			query.Where("name = ?", "launch")
+
+			var event Event
+			if err := query.ScanFirst(ctx, &event, reflect.TypeOf(Event{})); err != nil {
+				t.Fatalf("ScanFirst failed: %v", err)
+			}
+			if event.HappenedAt != want {
+				t.Errorf("Expected HappenedAt %+v, got %+v", want, event.HappenedAt)
+			}
+			if event.ReviewedAt != nil {
+				t.Errorf("Expected nil ReviewedAt, got %+v", event.ReviewedAt)
+			}
+
+			// E também no Find com modelType
+			query = NewQuery(db, "custom_time_events", columns)
+			query.SetDialect(dialect.GetDialect(provider))
+			query.SetModelType(reflect.TypeOf(Event{}))
+			query.Where("name = ?", "launch")
+
+			var events []Event
+			if err := query.Find(ctx, &events); err != nil {
+				t.Fatalf("Find failed: %v", err)
+			}
+			if len(events) != 1 || events[0].HappenedAt != want {
+				t.Errorf("Expected one event on %+v, got %+v", want, events)
+			}
+		})
+	}
+}
+
+// namedRows returns rows in its own column order and reports the column names like a driver would
+type namedRows struct {
+	columns []string
+	rows    []valuesRow
+	pos     int
+}
+
+func (r *namedRows) Close()                     {}
+func (r *namedRows) Err() error                 { return nil }
+func (r *namedRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *namedRows) Next() bool {
+	r.pos++
+	return r.pos <= len(r.rows)
+}
+func (r *namedRows) Scan(dest ...interface{}) error {
+	if len(dest) != len(r.columns) {
+		return fmt.Errorf("expected %d destinations, got %d", len(r.columns), len(dest))
+	}
+	return r.rows[r.pos-1].Scan(dest...)
+}
+
+type namedRowsDB struct {
+	DBTX
+	columns []string
+	rows    []valuesRow
+}
+
+func (d *namedRowsDB) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	return &namedRows{columns: d.columns, rows: d.rows}, nil
+}
+
+// TestQuery_ScanMapsColumnsByName tests that the columns reported by the driver are mapped by name,
+// even in another order than the selected columns and with columns the model doesn't have
+func TestQuery_ScanMapsColumnsByName(t *testing.T) {
+	type Product struct {
+		ID    int    `db:"id"`
+		Name  string `db:"name"`
+		Stock int    `db:"stock"`
+	}
+	db := &namedRowsDB{
+		columns: []string{"stock", "extra", "name", "id"},
+		rows:    []valuesRow{{7, "x", "pen", 1}, {0, "y", "ink", 2}},
+	}
+	want := []Product{{ID: 1, Name: "pen", Stock: 7}, {ID: 2, Name: "ink", Stock: 0}}
+	newQuery := func() *Query {
+		q := NewQuery(db, "products", []string{"id", "name", "stock"})
+		q.SetDialect(dialect.GetDialect("sqlite"))
+		q.SetModelType(reflect.TypeOf(Product{}))
+		return q
+	}
+
+	var products []Product
+	if err := newQuery().Find(context.Background(), &products); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if !reflect.DeepEqual(products, want) {
+		t.Errorf("Find = %+v, want %+v", products, want)
+	}
+
+	var scanned []*Product
+	if err := newQuery().ScanFind(context.Background(), &scanned, reflect.TypeOf(Product{})); err != nil {
+		t.Fatalf("ScanFind failed: %v", err)
+	}
+	if len(scanned) != 2 || *scanned[0] != want[0] || *scanned[1] != want[1] {
+		t.Errorf("ScanFind = %+v, want %+v", scanned, want)
+	}
+}
+
+// namedRow is a single row that reports its column names
+type namedRow struct {
+	columns []string
+	values  valuesRow
+}
+
+func (r namedRow) Columns() ([]string, error)     { return r.columns, nil }
+func (r namedRow) Scan(dest ...interface{}) error { return r.values.Scan(dest...) }
+
+type namedRowDB struct {
+	DBTX
+	row namedRow
+}
+
+func (d *namedRowDB) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	return d.row
+}
+
+// TestQuery_FirstScansByColumnName tests that First maps the columns a row reports by name
+func TestQuery_FirstScansByColumnName(t *testing.T) {
+	type Product struct {
+		ID    int    `db:"id"`
+		Name  string `db:"name"`
+		Stock int    `db:"stock"`
+	}
+	db := &namedRowDB{row: namedRow{columns: []string{"name", "stock", "id"}, values: valuesRow{"pen", 7, 1}}}
+	q := NewQuery(db, "products", []string{"id", "name", "stock"})
+	q.SetDialect(dialect.GetDialect("sqlite"))
+	q.SetModelType(reflect.TypeOf(Product{}))
+
+	var product Product
+	if err := q.First(context.Background(), &product); err != nil || product != (Product{ID: 1, Name: "pen", Stock: 7}) {
+		t.Errorf("First = %+v, %v", product, err)
+	}
+	var scanned Product
+	if err := q.ScanFirst(context.Background(), &scanned, reflect.TypeOf(Product{})); err != nil || scanned != product {
+		t.Errorf("ScanFirst = %+v, %v", scanned, err)
+	}
+}
@@ -2,9 +2,14 @@ package builder
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
+	sqldriver "database/sql/driver"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -26,14 +31,20 @@ var (
 
 // Query represents a query builder with fluent (chainable) API
 type Query struct {
-	db         driver.DB
-	table      string
-	columns    []string
-	primaryKey string
-	modelType  reflect.Type
-	logger     *logger.Logger  // Logger for queries
-	dialect    dialect.Dialect // Database dialect
-	ctx        context.Context // Stored context for operations
+	db           driver.DB
+	table        string
+	columns      []string
+	primaryKey   string
+	modelType    reflect.Type
+	logger       *logger.Logger       // Logger for queries
+	slogLogger   *slog.Logger         // Structured logger set by SetSlogLogger (takes precedence over logger)
+	hooks        []QueryHook          // Hooks added with AddHook, called after the global ones
+	argRedaction *logger.ArgRedaction // Arg redaction set by SetArgRedaction (nil uses the global mode)
+	dialect      dialect.Dialect      // Database dialect
+	ctx          context.Context      // Stored context for operations
+	timeout      *time.Duration       // Per-query timeout set by Timeout (nil uses the package default)
+	readOnly     bool                 // Write methods fail with ErrReadOnly (see SetReadOnly)
+	dryRun       *dryRunConn          // Connection wrapper set by DryRun (nil runs statements)
 
 	// Query state
 	whereConditions []whereCondition
@@ -44,6 +55,17 @@ type Query struct {
 	groupBy         []string
 	having          []whereCondition
 	joins           []join
+	indexHint       string
+	lock            string // Row lock set by ForUpdate or ForShare (UPDATE, SHARE)
+	lockWait        string // SKIP LOCKED or NOWAIT, set by SkipLocked or NoWait
+	lenientScan     bool
+	cascade         []CascadeRelation
+	cursor          *cursor
+	distinct        bool
+	distinctOn      []string
+	returning       []string
+	from            *subquerySource // Derived table set by FromSubquery (nil reads the table)
+	raw             *rawStatement   // Statement set by Raw, run instead of the generated SELECT
 }
 
 // whereCondition represents a WHERE condition
@@ -61,8 +83,29 @@ type join struct {
 	args     []interface{}
 }
 
+// cursor represents a keyset pagination position
+type cursor struct {
+	column string
+	value  interface{}
+}
+
+// CascadeRelation describes a table whose rows reference a parent table.
+// Columns are the foreign key columns on Table and ReferencedColumns the
+// matching columns on the parent. Children lists the tables referencing Table.
+// OnDelete is the relation's referential action: empty or "Cascade" deletes the
+// rows, "SetNull" sets Columns to NULL, and "Restrict" or "NoAction" fail the
+// delete while rows still reference the parent.
+type CascadeRelation struct {
+	Table             string
+	Columns           []string
+	ReferencedColumns []string
+	OnDelete          string
+	Children          []CascadeRelation
+}
+
 // NewQuery creates a new query builder with fluent API
 func NewQuery(db DBTX, table string, columns []string) *Query {
+	_, readOnly := db.(*readOnlyConn)
 	return &Query{
 		db:              db,
 		table:           table,
@@ -75,6 +118,7 @@ func NewQuery(db DBTX, table string, columns []string) *Query {
 		selectFields:    []string{},
 		groupBy:         []string{},
 		having:          []whereCondition{},
+		readOnly:        readOnly,
 	}
 }
 
@@ -102,6 +146,38 @@ func (q *Query) SetModelType(modelType reflect.Type) *Query {
 	return q
 }
 
+// SetReadOnly makes Create, CreateMany, Save, Update(s) and Delete fail with ErrReadOnly
+// instead of writing. Queries built on a ReadOnly DBTX start read-only.
+func (q *Query) SetReadOnly(readOnly bool) *Query {
+	q.readOnly = readOnly
+	return q
+}
+
+// IsReadOnly reports whether the write methods are disabled (see SetReadOnly)
+func (q *Query) IsReadOnly() bool {
+	return q.readOnly
+}
+
+// checkWritable returns ErrReadOnly when the query is read-only
+func (q *Query) checkWritable() error {
+	if q.readOnly {
+		return fmt.Errorf("%w: table %s", errors.ErrReadOnly, q.table)
+	}
+	if q.from != nil {
+		return fmt.Errorf("%w: cannot write through subquery %s", errors.ErrInvalidInput, q.from.alias)
+	}
+	return q.checkUnlocked()
+}
+
+// checkUnlocked returns ErrInvalidInput when a row lock is set, for statements that
+// are not a plain SELECT (writes, counts and aggregates)
+func (q *Query) checkUnlocked() error {
+	if q.lock != "" || q.lockWait != "" {
+		return fmt.Errorf("%w: row locks only apply to SELECT queries on table %s", errors.ErrInvalidInput, q.table)
+	}
+	return nil
+}
+
 // GetDB returns the database connection
 func (q *Query) GetDB() DBTX {
 	return q.db
@@ -151,9 +227,62 @@ func (q *Query) Reset() *Query {
 	q.groupBy = []string{}
 	q.having = []whereCondition{}
 	q.joins = []join{}
+	q.indexHint = ""
+	q.lock = ""
+	q.lockWait = ""
+	q.lenientScan = false
+	q.cascade = nil
+	q.cursor = nil
+	q.distinct = false
+	q.distinctOn = nil
+	q.returning = nil
+	q.raw = nil
 	return q
 }
 
+// Clone returns an independent copy of the query: the same table, columns, dialect, model
+// type, logger and settings, with its own copy of the conditions, ordering and pagination,
+// so changes to one don't affect the other.
+func (q *Query) Clone() *Query {
+	c := *q
+	c.hooks = append(q.hooks[:0:0], q.hooks...)
+	c.whereConditions = append(q.whereConditions[:0:0], q.whereConditions...)
+	c.orderBy = append(q.orderBy[:0:0], q.orderBy...)
+	c.selectFields = append(q.selectFields[:0:0], q.selectFields...)
+	c.groupBy = append(q.groupBy[:0:0], q.groupBy...)
+	c.having = append(q.having[:0:0], q.having...)
+	c.joins = append(q.joins[:0:0], q.joins...)
+	c.cascade = append(q.cascade[:0:0], q.cascade...)
+	c.distinctOn = append(q.distinctOn[:0:0], q.distinctOn...)
+	c.returning = append(q.returning[:0:0], q.returning...)
+	if q.take != nil {
+		take := *q.take
+		c.take = &take
+	}
+	if q.skip != nil {
+		skip := *q.skip
+		c.skip = &skip
+	}
+	if q.dryRun != nil {
+		c.dryRun = &dryRunConn{DBTX: q.dryRun.DBTX}
+		c.db = c.dryRun
+	}
+	return &c
+}
+
+// WithDB returns a Clone of the query that runs against db, e.g. the DB of a
+// Transaction, keeping the table, columns, dialect, model type and settings.
+// Example: err := q.WithDB(tx.DB()).Where("id = ?", id).Updates(ctx, values)
+func (q *Query) WithDB(db DBTX) *Query {
+	c := q.Clone()
+	if c.dryRun != nil {
+		c.dryRun.DBTX = db
+		return c
+	}
+	c.db = db
+	return c
+}
+
 // WithContext sets the context for this query builder.
 // The context will be used automatically when Exec() is called without parameters.
 // If a context is passed explicitly to Exec(ctx), it takes priority over the stored context.
@@ -166,6 +295,29 @@ func (q *Query) WithContext(ctx context.Context) *Query {
 	return q
 }
 
+// Timeout overrides the default query timeout for the operations run by this query builder
+// (First, Find, Count, Create, Updates, Delete...). The context is derived from the caller's
+// context with this duration; zero means no timeout. Like WithContext, it is not cleared by Reset.
+// Example:
+//
+//	err := client.Report.Timeout(2 * time.Minute).Where("year = ?", 2024).Find(ctx, &rows)
+func (q *Query) Timeout(d time.Duration) *Query {
+	q.timeout = &d
+	return q
+}
+
+// withTimeout derives the operation context from ctx, using the Timeout override when set
+// and the default query timeout otherwise
+func (q *Query) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if q.timeout == nil {
+		return contextutil.WithQueryTimeout(ctx)
+	}
+	if *q.timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, *q.timeout)
+}
+
 // GetContext returns the context to use for operations.
 // Priority: explicit context > stored context > context.Background()
 func (q *Query) GetContext(ctx ...context.Context) context.Context {
@@ -193,7 +345,14 @@ func (q *Query) Where(condition interface{}, args ...interface{}) *Query {
 	}
 
 	if whereMap, ok := condition.(Where); ok {
-		for field, value := range whereMap {
+		// Sort the keys so the same map always renders the same SQL
+		fields := make([]string, 0, len(whereMap))
+		for field := range whereMap {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			value := whereMap[field]
 			if op, ok := value.(WhereOperator); ok {
 				q.addPrismaWhereCondition(field, op)
 			} else if value == nil {
@@ -220,6 +379,10 @@ func (q *Query) Where(condition interface{}, args ...interface{}) *Query {
 
 // addPrismaWhereCondition adds a WHERE condition using Prisma operator
 func (q *Query) addPrismaWhereCondition(field string, op WhereOperator) {
+	if op.collation != "" {
+		q.addCollatedCondition(field, op)
+		return
+	}
 	quotedField := q.dialect.QuoteIdentifier(field)
 	switch op.GetOp() {
 	case ">":
@@ -246,6 +409,14 @@ func (q *Query) addPrismaWhereCondition(field string, op WhereOperator) {
 			args:  []interface{}{op.GetValue()},
 			or:    false,
 		})
+	case "BETWEEN":
+		if values, ok := op.GetValue().([]interface{}); ok && len(values) == 2 {
+			q.whereConditions = append(q.whereConditions, whereCondition{
+				query: fmt.Sprintf("%s BETWEEN ? AND ?", quotedField),
+				args:  values,
+				or:    false,
+			})
+		}
 	case "IN":
 		if values, ok := op.GetValue().([]interface{}); ok {
 			placeholders := make([]string, len(values))
@@ -270,18 +441,21 @@ func (q *Query) addPrismaWhereCondition(field string, op WhereOperator) {
 				or:    false,
 			})
 		}
-	case "LIKE":
-		q.whereConditions = append(q.whereConditions, whereCondition{
-			query: fmt.Sprintf("%s LIKE ?", quotedField),
-			args:  []interface{}{op.GetValue()},
-			or:    false,
-		})
-	case "ILIKE":
-		q.whereConditions = append(q.whereConditions, whereCondition{
-			query: fmt.Sprintf("%s ILIKE ?", quotedField),
-			args:  []interface{}{op.GetValue()},
-			or:    false,
-		})
+	case "LIKE", "ILIKE", "NOT LIKE", "NOT ILIKE":
+		if query, arg, ok := likeCondition(q.dialect, field, op); ok {
+			q.whereConditions = append(q.whereConditions, whereCondition{
+				query: query,
+				args:  []interface{}{arg},
+				or:    false,
+			})
+		} else {
+			// Like/ILike: the value is already a pattern
+			q.whereConditions = append(q.whereConditions, whereCondition{
+				query: fmt.Sprintf("%s %s ?", quotedField, op.GetOp()),
+				args:  []interface{}{op.GetValue()},
+				or:    false,
+			})
+		}
 	case "IS NULL":
 		q.whereConditions = append(q.whereConditions, whereCondition{
 			query: fmt.Sprintf("%s IS NULL", quotedField),
@@ -349,7 +523,7 @@ func (q *Query) addPrismaWhereCondition(field string, op WhereOperator) {
 	case "HAS_SOME":
 		if q.dialect.SupportsJSON() {
 			if values, ok := op.GetValue().([]interface{}); ok {
-				if q.dialect.Name() == "postgresql" {
+				if isPostgresFamily(q.dialect) {
 					placeholders := make([]string, len(values))
 					for i := range values {
 						placeholders[i] = "?"
@@ -392,7 +566,7 @@ func (q *Query) addPrismaWhereCondition(field string, op WhereOperator) {
 	case "IS_EMPTY":
 		if q.dialect.SupportsJSON() {
 			quotedField := q.dialect.QuoteIdentifier(field)
-			if q.dialect.Name() == "postgresql" {
+			if isPostgresFamily(q.dialect) {
 				q.whereConditions = append(q.whereConditions, whereCondition{
 					query: fmt.Sprintf("(jsonb_typeof(%s) = 'array' AND jsonb_array_length(%s) = 0) OR %s = '[]'::jsonb", quotedField, quotedField, quotedField),
 					args:  []interface{}{},
@@ -419,11 +593,71 @@ func (q *Query) addPrismaWhereCondition(field string, op WhereOperator) {
 				or:    false,
 			})
 		}
+	case "ARRAY_HAS", "ARRAY_HAS_EVERY", "ARRAY_HAS_SOME", "ARRAY_IS_EMPTY", "ARRAY_EQUALS":
+		q.addScalarListCondition(field, op)
+	case "JSON_ARRAY_LENGTH":
+		length, ok := op.GetValue().(jsonArrayLength)
+		if !ok || !isComparisonOperator(length.op) {
+			q.addUnsatisfiableCondition(fmt.Sprintf("invalid JsonArrayLength operator %q for field %s", length.op, field))
+			return
+		}
+		q.whereConditions = append(q.whereConditions, whereCondition{
+			query: fmt.Sprintf("%s %s ?", q.dialect.GetJSONArrayLengthExpression(field), length.op),
+			args:  []interface{}{length.n},
+			or:    false,
+		})
+	case "JSON_ARRAY_ELEMENT":
+		element, ok := op.GetValue().(jsonArrayElement)
+		if !ok || element.index < 0 {
+			q.addUnsatisfiableCondition(fmt.Sprintf("invalid JsonArrayElement index %d for field %s", element.index, field))
+			return
+		}
+		q.whereConditions = append(q.whereConditions, whereCondition{
+			query: fmt.Sprintf("%s = ?", q.dialect.GetJSONArrayElementExpression(field, element.index)),
+			args:  []interface{}{fmt.Sprint(element.value)},
+			or:    false,
+		})
+	case "JSON_PATH":
+		path, ok := op.GetValue().(jsonPathFilter)
+		if !ok || !isValidJSONPath(path.path) || (path.op != "=" && path.op != "!=" && path.op != "LIKE") {
+			q.addUnsatisfiableCondition(fmt.Sprintf("invalid JsonPath %q %q for field %s", path.path, path.op, field))
+			return
+		}
+		value := fmt.Sprint(path.value)
+		if pattern, ok := path.value.(likePattern); ok && path.op == "LIKE" {
+			value = pattern.prefix + q.dialect.EscapeLikePattern(pattern.value) + pattern.suffix
+		}
+		q.whereConditions = append(q.whereConditions, whereCondition{
+			query: q.dialect.GetJSONPathQuery(field, path.path, path.op),
+			args:  []interface{}{value},
+			or:    false,
+		})
+	case "NOT":
+		inner, _ := op.GetValue().(Where)
+		for _, cond := range q.groupCondition(inner, false) {
+			cond.query = "NOT " + cond.query
+			q.whereConditions = append(q.whereConditions, cond)
+		}
+	case "AND", "OR":
+		groups, _ := op.GetValue().([]Where)
+		q.WhereGroup(func(group *Query) {
+			for _, where := range groups {
+				if op.GetOp() == "OR" {
+					group.Or(where)
+				} else {
+					group.whereConditions = append(group.whereConditions, q.groupCondition(where, false)...)
+				}
+			}
+		})
 	case "FULLTEXT_SEARCH":
 		if q.dialect.SupportsFullTextSearch() {
 			if queryStr, ok := op.GetValue().(string); ok {
-				if q.dialect.Name() == "postgresql" {
+				if isPostgresFamily(q.dialect) {
 					queryStr = NormalizeTSQuery(queryStr)
+					if queryStr == "" {
+						q.addUnsatisfiableCondition("empty full-text search query")
+						return
+					}
 				}
 				query := q.dialect.GetFullTextSearchQuery(field, queryStr)
 				args := []interface{}{}
@@ -447,10 +681,14 @@ func (q *Query) addPrismaWhereCondition(field string, op WhereOperator) {
 			}
 		}
 	case "FULLTEXT_SEARCH_CONFIG":
-		if q.dialect.SupportsFullTextSearch() && q.dialect.Name() == "postgresql" {
+		if q.dialect.SupportsFullTextSearch() && isPostgresFamily(q.dialect) {
 			if configMap, ok := op.GetValue().(map[string]interface{}); ok {
 				if queryStr, ok := configMap["query"].(string); ok {
 					queryStr = NormalizeTSQuery(queryStr)
+					if queryStr == "" {
+						q.addUnsatisfiableCondition("empty full-text search query")
+						return
+					}
 					config := "english"
 					if c, ok := configMap["config"].(string); ok {
 						config = c
@@ -485,16 +723,311 @@ func (q *Query) addPrismaWhereCondition(field string, op WhereOperator) {
 	}
 }
 
-// Or adds an OR condition
-func (q *Query) Or(query string, args ...interface{}) *Query {
+// limitClause returns the dialect's LIMIT/OFFSET syntax with placeholders numbered from
+// argIndex, and the limit/offset args. SQL Server only accepts OFFSET/FETCH after an
+// ORDER BY, so an unordered query gets ORDER BY (SELECT NULL) in front of it.
+func limitClause(d dialect.Dialect, limit, offset int, ordered bool, argIndex *int) (string, []interface{}) {
+	clause, args := d.GetLimitOffsetSyntax(limit, offset)
+	for range args {
+		clause = strings.Replace(clause, "?", d.GetPlaceholder(*argIndex), 1)
+		(*argIndex)++
+	}
+	if clause != "" && !ordered && d.Name() == "sqlserver" {
+		return "ORDER BY (SELECT NULL) " + clause, args
+	}
+	return clause, args
+}
+
+// isPostgresFamily reports whether d speaks PostgreSQL SQL (ON CONFLICT, jsonb operators,
+// tsquery), which CockroachDB inherits
+func isPostgresFamily(d dialect.Dialect) bool {
+	name := d.Name()
+	return name == "postgresql" || name == "cockroachdb"
+}
+
+// likeCondition renders an escaped LIKE operator (likePattern value) through the dialect,
+// returning the condition and its single pattern argument
+func likeCondition(d dialect.Dialect, field string, op WhereOperator) (string, interface{}, bool) {
+	pattern, ok := op.GetValue().(likePattern)
+	if !ok {
+		return "", nil, false
+	}
+	query := d.GetLikeQuery(field, strings.HasSuffix(op.GetOp(), "ILIKE"), strings.HasPrefix(op.GetOp(), "NOT "))
+	return query, pattern.prefix + d.EscapeLikePattern(pattern.value) + pattern.suffix, true
+}
+
+// isComparisonOperator reports whether op is a plain SQL comparison operator
+func isComparisonOperator(op string) bool {
+	switch op {
+	case "=", "!=", "<>", ">", ">=", "<", "<=":
+		return true
+	}
+	return false
+}
+
+// isValidJSONPath reports whether path can be inlined by GetJSONPathQuery: it needs at least
+// one key, and no key may contain ?, which would be expanded as a placeholder
+func isValidJSONPath(path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+	for _, key := range path {
+		if strings.Contains(key, "?") {
+			return false
+		}
+	}
+	return true
+}
+
+// listArg is a slice bound as a single parameter (a native array), where other slice args
+// are expanded into (?, ?)
+type listArg struct {
+	value interface{}
+}
+
+// addScalarListCondition adds a condition on a native array field through the dialect,
+// falling back to the JSON operator (HAS, HAS_EVERY...) on databases without native arrays
+func (q *Query) addScalarListCondition(field string, op WhereOperator) {
+	listOp := strings.TrimPrefix(op.GetOp(), "ARRAY_")
+	args := []interface{}{}
+	switch listOp {
+	case "HAS":
+		args = []interface{}{op.GetValue()}
+	case "EQUALS":
+		args = []interface{}{listArg{value: op.GetValue()}}
+	case "HAS_EVERY", "HAS_SOME":
+		args, _ = op.GetValue().([]interface{})
+		if len(args) == 0 {
+			// Every value of an empty list is contained in any array; none of it can be found
+			if listOp == "HAS_SOME" {
+				q.addUnsatisfiableCondition(fmt.Sprintf("empty HasSome list for field %s", field))
+			}
+			return
+		}
+	}
+	query := ""
+	if (listOp == "HAS" || listOp == "HAS_EVERY" || listOp == "HAS_SOME") && !textValues(args) {
+		// ARRAY[?] resolves an untyped parameter to text[], which only compares with text lists;
+		// ? = ANY(field) takes the element type from the column instead
+		if element := q.dialect.GetScalarListQuery(field, "ANY", 1); element != "" {
+			elements := make([]string, len(args))
+			for i := range elements {
+				elements[i] = element
+			}
+			query = strings.Join(elements, " AND ")
+			if listOp == "HAS_SOME" {
+				query = strings.Join(elements, " OR ")
+			}
+			if len(elements) > 1 {
+				query = "(" + query + ")"
+			}
+		}
+	}
+	if query == "" {
+		query = q.dialect.GetScalarListQuery(field, listOp, len(args))
+	}
+	if query == "" && listOp == "EQUALS" {
+		// Without native arrays the list is stored as JSON, so compare the serialized text
+		data, err := getJSONSerializer().Marshal(op.GetValue())
+		if err != nil {
+			q.addUnsatisfiableCondition(fmt.Sprintf("invalid list for field %s: %v", field, err))
+			return
+		}
+		query = q.dialect.QuoteIdentifier(field) + " = ?"
+		args = []interface{}{string(data)}
+	} else if query == "" {
+		q.addPrismaWhereCondition(field, WhereOperator{op: listOp, value: op.GetValue()})
+		return
+	}
 	q.whereConditions = append(q.whereConditions, whereCondition{
 		query: query,
 		args:  args,
+		or:    false,
+	})
+}
+
+// textValues reports whether every value is a string, the element type ARRAY[?] binds as
+func textValues(values []interface{}) bool {
+	for _, value := range values {
+		if value == nil || reflect.TypeOf(value).Kind() != reflect.String {
+			return false
+		}
+	}
+	return true
+}
+
+// addCollatedCondition adds an equality or IN condition comparing the field with COLLATE
+func (q *Query) addCollatedCondition(field string, op WhereOperator) {
+	collate := q.dialect.GetCollateSyntax(op.collation)
+	if collate == "" {
+		q.addUnsatisfiableCondition(fmt.Sprintf("invalid collation %q for field %s", op.collation, field))
+		return
+	}
+	column := q.dialect.QuoteIdentifier(field) + " " + collate
+	switch op.GetOp() {
+	case "=", "!=":
+		q.whereConditions = append(q.whereConditions, whereCondition{
+			query: fmt.Sprintf("%s %s ?", column, op.GetOp()),
+			args:  []interface{}{op.GetValue()},
+			or:    false,
+		})
+	case "IN", "NOT IN":
+		values, _ := op.GetValue().([]interface{})
+		if len(values) == 0 {
+			if op.GetOp() == "IN" {
+				q.addUnsatisfiableCondition(fmt.Sprintf("empty In list for field %s", field))
+			}
+			return
+		}
+		placeholders := make([]string, len(values))
+		for i := range values {
+			placeholders[i] = "?"
+		}
+		q.whereConditions = append(q.whereConditions, whereCondition{
+			query: fmt.Sprintf("%s %s (%s)", column, op.GetOp(), strings.Join(placeholders, ", ")),
+			args:  values,
+			or:    false,
+		})
+	default:
+		q.addUnsatisfiableCondition(fmt.Sprintf("Collate is not supported with %s for field %s", op.GetOp(), field))
+	}
+}
+
+// addUnsatisfiableCondition adds a condition that matches no rows for an invalid filter,
+// so a bad filter never widens the result (or the rows affected by an update/delete)
+func (q *Query) addUnsatisfiableCondition(reason string) {
+	if logger := q.getLogger(); logger != nil {
+		logger.Warn("%s: filter matches no rows", reason)
+	}
+	q.whereConditions = append(q.whereConditions, whereCondition{
+		query: "1 = 0",
+		args:  []interface{}{},
+		or:    false,
+	})
+}
+
+// Or adds an OR condition
+// Supports the same two syntaxes as Where; a Prisma map is joined with AND in parentheses:
+//  1. Direct SQL: q.Or("name = ?", "jinzhu")
+//  2. Prisma map: q.Or(builder.Where{"name": "jinzhu", "age": builder.Gt(18)})
+func (q *Query) Or(condition interface{}, args ...interface{}) *Query {
+	if whereMap, ok := condition.(Where); ok {
+		q.whereConditions = append(q.whereConditions, q.groupCondition(whereMap, true)...)
+		return q
+	}
+	queryStr, _ := condition.(string)
+	q.whereConditions = append(q.whereConditions, whereCondition{
+		query: queryStr,
+		args:  args,
 		or:    true,
 	})
 	return q
 }
 
+// WhereGroup adds the conditions appended by fn as a single parenthesized group,
+// joined with AND to the other conditions. Inside fn, Where adds AND and Or adds OR.
+// Example: q.Where("active = ?", true).WhereGroup(func(g *Query) { g.Where("role = ?", "admin").Or("role = ?", "owner") })
+// produces: active = ? AND (role = ? OR role = ?)
+func (q *Query) WhereGroup(fn func(*Query)) *Query {
+	group := *q
+	group.whereConditions = nil
+	fn(&group)
+	if cond, ok := joinWhereConditions(group.whereConditions); ok {
+		q.whereConditions = append(q.whereConditions, cond)
+	}
+	return q
+}
+
+// groupCondition renders a Prisma map as one parenthesized condition "(a AND b)".
+// An empty map yields no condition.
+func (q *Query) groupCondition(where Where, or bool) []whereCondition {
+	group := *q
+	group.whereConditions = nil
+	group.Where(where)
+	cond, ok := joinWhereConditions(group.whereConditions)
+	if !ok {
+		return nil
+	}
+	cond.or = or
+	return []whereCondition{cond}
+}
+
+// joinWhereConditions joins conditions with AND/OR into a single parenthesized condition
+func joinWhereConditions(conditions []whereCondition) (whereCondition, bool) {
+	if len(conditions) == 0 {
+		return whereCondition{}, false
+	}
+	var sb strings.Builder
+	var args []interface{}
+	sb.WriteString("(")
+	for i, cond := range conditions {
+		if i > 0 {
+			if cond.or {
+				sb.WriteString(" OR ")
+			} else {
+				sb.WriteString(" AND ")
+			}
+		}
+		sb.WriteString(cond.query)
+		args = append(args, cond.args...)
+	}
+	sb.WriteString(")")
+	return whereCondition{query: sb.String(), args: args}, true
+}
+
+// WhereTupleIn adds a composite IN over several columns, for batch lookups by composite key.
+// Renders (a, b) IN ((?, ?), (?, ?)) when the dialect supports it and ((a = ? AND b = ?) OR
+// (a = ? AND b = ?)) otherwise. With no rows, or a row without one value per column, it matches no rows.
+// Example: q.WhereTupleIn([]string{"author_id", "title"}, [][]interface{}{{1, "Go"}, {2, "SQL"}})
+func (q *Query) WhereTupleIn(columns []string, rows [][]interface{}) *Query {
+	if len(columns) == 0 {
+		return q
+	}
+	if len(rows) == 0 {
+		q.addUnsatisfiableCondition("WhereTupleIn without rows")
+		return q
+	}
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = q.dialect.QuoteIdentifier(column)
+	}
+
+	rowParts := make([]string, len(rows))
+	args := make([]interface{}, 0, len(rows)*len(columns))
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			q.addUnsatisfiableCondition(fmt.Sprintf("WhereTupleIn row %d has %d values for %d columns", i, len(row), len(columns)))
+			return q
+		}
+		parts := make([]string, len(columns))
+		for j := range columns {
+			if q.dialect.SupportsTupleIn() {
+				parts[j] = "?"
+			} else {
+				parts[j] = quoted[j] + " = ?"
+			}
+		}
+		if q.dialect.SupportsTupleIn() {
+			rowParts[i] = "(" + strings.Join(parts, ", ") + ")"
+		} else {
+			rowParts[i] = "(" + strings.Join(parts, " AND ") + ")"
+		}
+		args = append(args, row...)
+	}
+
+	query := "(" + strings.Join(rowParts, " OR ") + ")"
+	if q.dialect.SupportsTupleIn() {
+		query = "(" + strings.Join(quoted, ", ") + ") IN (" + strings.Join(rowParts, ", ") + ")"
+	}
+	q.whereConditions = append(q.whereConditions, whereCondition{
+		query: query,
+		args:  args,
+		or:    false,
+	})
+	return q
+}
+
 // Not adds a NOT condition
 func (q *Query) Not(query string, args ...interface{}) *Query {
 	q.whereConditions = append(q.whereConditions, whereCondition{
@@ -519,6 +1052,31 @@ func (q *Query) Select(fields ...string) *Query {
 	return q
 }
 
+// SelectExcept selects every column of the table except the given ones,
+// replacing any previous Select. Names are database column names (as mapped with @map).
+// Example: q.SelectExcept("avatar_blob")
+func (q *Query) SelectExcept(fields ...string) *Query {
+	excluded := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		excluded[field] = true
+	}
+
+	selected := make([]string, 0, len(q.columns))
+	for _, col := range q.columns {
+		if !excluded[col] {
+			selected = append(selected, col)
+		}
+	}
+	if len(selected) == 0 {
+		if logger := q.getLogger(); logger != nil {
+			logger.Warn("SelectExcept excludes every column of %s; selecting all columns", q.table)
+		}
+	}
+
+	q.selectFields = []string{}
+	return q.Select(selected...)
+}
+
 // SelectAll clears Select and returns all fields
 func (q *Query) SelectAll() *Query {
 	q.selectFields = []string{}
@@ -546,6 +1104,61 @@ func (q *Query) Order(order string) *Query {
 	return q
 }
 
+// OrderByValues orders the results by the position of column's value in values, so rows
+// come back in the same order as the input (e.g. ids of a dataloader batch). Rows whose
+// value is not in the list come last. Rendered as CASE column WHEN ? THEN 0 ... END on every dialect.
+// Example: q.Where(Where{"id": In(ids...)}).OrderByValues("id", ids).Find(ctx, &users)
+func (q *Query) OrderByValues(column string, values []interface{}) *Query {
+	if len(q.orderBy) >= limits.MaxOrderByFields || len(values) == 0 {
+		return q
+	}
+	q.orderBy = append(q.orderBy, OrderBy{
+		Field:  column,
+		Order:  "ASC",
+		values: values,
+	})
+	return q
+}
+
+// OrderInsensitive orders the results case-insensitively by field, rendered as
+// ORDER BY LOWER(field) on every dialect. direction is ASC or DESC; anything other
+// than DESC sorts ascending.
+// Example: q.OrderInsensitive("name", "ASC").Find(ctx, &users)
+func (q *Query) OrderInsensitive(field, direction string) *Query {
+	if len(q.orderBy) >= limits.MaxOrderByFields {
+		return q
+	}
+	order := "ASC"
+	if strings.EqualFold(strings.TrimSpace(direction), "DESC") {
+		order = "DESC"
+	}
+	q.orderBy = append(q.orderBy, OrderBy{
+		Field:       field,
+		Order:       order,
+		insensitive: true,
+	})
+	return q
+}
+
+// Collate sorts the last Order/OrderInsensitive field using the given collation,
+// rendered as ORDER BY field COLLATE collation. Without a previous order it does nothing;
+// a collation name the dialect does not accept is ignored with a warning.
+// Example: q.Order("name ASC").Collate("C").Find(ctx, &users)
+func (q *Query) Collate(collation string) *Query {
+	if len(q.orderBy) == 0 {
+		return q
+	}
+	collate := q.dialect.GetCollateSyntax(collation)
+	if collate == "" {
+		if logger := q.getLogger(); logger != nil {
+			logger.Warn("invalid collation %q ignored in ORDER BY", collation)
+		}
+		return q
+	}
+	q.orderBy[len(q.orderBy)-1].collate = collate
+	return q
+}
+
 // Take sets the LIMIT
 func (q *Query) Take(take int) *Query {
 	q.take = &take
@@ -610,53 +1223,250 @@ func (q *Query) RightJoin(table, on string, args ...interface{}) *Query {
 	return q.Join("RIGHT", table, on, args...)
 }
 
-// First executes the query and returns the first result
-// Example: q.Where("email = ?", "user@example.com").First(ctx, &user)
-func (q *Query) First(ctx context.Context, dest interface{}) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
-	defer cancel()
+// IndexHint passes an index hint to the query planner (SELECT and COUNT only).
+// The syntax is provider-specific and decided by the dialect:
+//   - MySQL: FORCE INDEX (hint) after the table, so the optimizer only falls back to a
+//     table scan when the index cannot be used
+//   - SQLite: INDEXED BY hint after the table
+//   - PostgreSQL: /*+ hint */ comment before SELECT, read by the pg_hint_plan extension
+//     (e.g. "IndexScan(users users_email_idx)")
+//
+// Dialects without hint support ignore it and log a warning.
+func (q *Query) IndexHint(hint string) *Query {
+	q.indexHint = hint
+	return q
+}
 
-	processStart := time.Now()
-	query, args := q.buildSelectQuery(true)
+// ForUpdate locks the selected rows until the transaction ends (SELECT ... FOR UPDATE).
+// Row locks only apply to SELECTs (Find, First, Pluck and the Scan methods) and are
+// meant for queries inside a transaction. Writes, counts and aggregates on a locked
+// query fail with ErrInvalidInput, as do databases without row locks (SQLite, SQL Server).
+// Example: tx.Query("accounts").Where("id = ?", id).ForUpdate().First(ctx, &account)
+func (q *Query) ForUpdate() *Query {
+	q.lock = "UPDATE"
+	return q
+}
 
-	queryStart := time.Now()
-	row := q.db.QueryRow(ctx, query, args...)
-	queryEnd := time.Now()
-	queryDuration := queryEnd.Sub(queryStart)
+// ForShare locks the selected rows against writes from other transactions while still
+// letting them read: FOR SHARE on PostgreSQL, LOCK IN SHARE MODE on MySQL.
+// The same restrictions as ForUpdate apply.
+func (q *Query) ForShare() *Query {
+	q.lock = "SHARE"
+	return q
+}
 
-	var err error
-	if q.modelType != nil {
-		err = q.scanRowIntoModel(row, dest)
-	} else {
-		err = row.Scan(dest)
-	}
+// SkipLocked makes a locking SELECT skip rows already locked by other transactions
+// instead of waiting for them, so concurrent queue consumers each claim different rows.
+// It requires ForUpdate or ForShare (MySQL 8.0+ on MySQL).
+// Example: q.Where("status = ?", "pending").Order("id ASC").Take(10).ForUpdate().SkipLocked().Find(ctx, &jobs)
+func (q *Query) SkipLocked() *Query {
+	q.lockWait = "SKIP LOCKED"
+	return q
+}
 
-	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+// NoWait makes a locking SELECT fail right away when a row is locked by another
+// transaction instead of waiting for it. It requires ForUpdate or ForShare.
+func (q *Query) NoWait() *Query {
+	q.lockWait = "NOWAIT"
+	return q
+}
 
-	if err != nil {
-		if logger := q.getLogger(); logger != nil {
-			logger.Error("SELECT query failed: %v", err)
-		}
-	}
+// LenientScan makes ScanFind keep going when a row fails to scan.
+// Rows that scan successfully are still appended to dest, and the failures are
+// returned together as *ScanErrors. By default ScanFind fails fast on the first bad row.
+func (q *Query) LenientScan() *Query {
+	q.lenientScan = true
+	return q
+}
 
-	return err
+// Cursor starts the results after the row where column equals value (keyset pagination),
+// which stays fast on deep pages and stable when rows are inserted between pages.
+// column must also be passed to Order; the comparison follows that direction:
+// column > value for ASC and column < value for DESC. Use Take for the page size.
+// Example: q.Order("id ASC").Cursor("id", lastID).Take(20).Find(ctx, &users)
+func (q *Query) Cursor(column string, value interface{}) *Query {
+	q.cursor = &cursor{column: column, value: value}
+	return q
 }
 
-// Find executes the query and returns all results
-// Example: q.Where("active = ?", true).Order("created_at DESC").Find(ctx, &users)
-func (q *Query) Find(ctx context.Context, dest interface{}) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
+// applyCursor turns the cursor into a WHERE condition matching its ORDER BY direction.
+// It returns an error if the cursor column has no matching Order.
+func (q *Query) applyCursor() error {
+	if q.cursor == nil {
+		return nil
+	}
+	c := q.cursor
+	q.cursor = nil
+
+	for _, order := range q.orderBy {
+		if order.Field != c.column || order.values != nil || order.insensitive {
+			continue
+		}
+		op := ">"
+		if order.Order == "DESC" {
+			op = "<"
+		}
+		column := q.dialect.QuoteIdentifier(c.column)
+		if order.collate != "" {
+			column += " " + order.collate
+		}
+		q.whereConditions = append(q.whereConditions, whereCondition{
+			query: fmt.Sprintf("%s %s ?", column, op),
+			args:  []interface{}{c.value},
+			or:    false,
+		})
+		return nil
+	}
+	return fmt.Errorf("%w: cursor column %q has no matching Order (add Order(\"%s ASC\") or Order(\"%s DESC\"))",
+		errors.ErrInvalidInput, c.column, c.column, c.column)
+}
+
+// Distinct removes duplicate rows. Without fields it emits SELECT DISTINCT; with fields
+// it keeps one row per distinct combination of them: DISTINCT ON on PostgreSQL (use Order
+// starting with the same fields to choose the row kept) and GROUP BY on SQLite.
+// MySQL has no DISTINCT ON, so passing fields makes the query fail with ErrInvalidInput.
+// Example: q.Distinct("email").Order("email ASC").Find(ctx, &users)
+func (q *Query) Distinct(fields ...string) *Query {
+	q.distinct = true
+	q.distinctOn = fields
+	return q
+}
+
+// distinctSyntax returns the DISTINCT clause of the SELECT and the columns to group
+// by when the dialect emulates DISTINCT ON with GROUP BY
+func (q *Query) distinctSyntax() (string, []string) {
+	if !q.distinct {
+		return "", nil
+	}
+	if len(q.distinctOn) == 0 {
+		return "DISTINCT", nil
+	}
+	clause, groupBy := q.dialect.GetDistinctOnSyntax(q.distinctOn)
+	if groupBy {
+		return "", q.distinctOn
+	}
+	return clause, nil
+}
+
+// lockSyntax returns the row locking clause of the SELECT, or an error when the lock
+// is incomplete or the dialect has no row locks
+func (q *Query) lockSyntax() (string, error) {
+	if q.lock == "" {
+		if q.lockWait != "" {
+			return "", fmt.Errorf("%w: %s requires ForUpdate or ForShare", errors.ErrInvalidInput, q.lockWait)
+		}
+		return "", nil
+	}
+	clause := q.dialect.GetLockSyntax(q.lock, q.lockWait)
+	if clause == "" {
+		return "", fmt.Errorf("%w: row locking is not supported by %s", errors.ErrInvalidInput, q.dialect.Name())
+	}
+	return clause, nil
+}
+
+// prepareSelect validates the query and applies the cursor before a SELECT runs
+func (q *Query) prepareSelect() error {
+	if err := q.checkSubquery(); err != nil {
+		return err
+	}
+	if _, err := q.lockSyntax(); err != nil {
+		return err
+	}
+	if q.distinct && len(q.distinctOn) > 0 {
+		if clause, groupBy := q.dialect.GetDistinctOnSyntax(q.distinctOn); clause == "" && !groupBy {
+			return fmt.Errorf("%w: DISTINCT ON is not supported by %s", errors.ErrInvalidInput, q.dialect.Name())
+		}
+	}
+	return q.applyCursor()
+}
+
+// Cascade makes Delete remove dependent rows before the matched rows, in
+// foreign key dependency order and within a single transaction.
+// This simulates ON DELETE CASCADE, SET NULL and RESTRICT for databases where
+// they are not enforced (e.g. SQLite without PRAGMA foreign_keys). Composite
+// foreign keys need (a, b) IN (SELECT ...), which SQL Server lacks.
+func (q *Query) Cascade(relations ...CascadeRelation) *Query {
+	q.cascade = relations
+	return q
+}
+
+// indexHintSyntax returns the query prefix and FROM suffix for the current index hint
+func (q *Query) indexHintSyntax() (string, string) {
+	if q.indexHint == "" {
+		return "", ""
+	}
+	prefix, suffix := q.dialect.GetIndexHintSyntax(q.indexHint)
+	if prefix == "" && suffix == "" {
+		if logger := q.getLogger(); logger != nil {
+			logger.Warn("index hint %q ignored: %s does not support index hints", q.indexHint, q.dialect.Name())
+		}
+	}
+	return prefix, suffix
+}
+
+// First executes the query and returns the first result
+// Example: q.Where("email = ?", "user@example.com").First(ctx, &user)
+func (q *Query) First(ctx context.Context, dest interface{}) error {
+	if q.raw != nil && q.modelType != nil {
+		return q.firstRaw(dest, func(slice interface{}) error { return q.Find(ctx, slice) })
+	}
+
+	ctx, cancel := q.withTimeout(ctx)
 	defer cancel()
 
+	if err := q.prepareSelect(); err != nil {
+		return err
+	}
+
+	processStart := time.Now()
+	query, args := q.buildSelectQuery(true)
+
+	ctx, afterHooks := q.startHooks(ctx, query, args)
+	queryStart := time.Now()
+	row := q.db.QueryRow(ctx, query, args...)
+	queryEnd := time.Now()
+	queryDuration := queryEnd.Sub(queryStart)
+
+	var err error
+	if q.modelType != nil {
+		err = q.scanRowIntoModel(row, dest)
+	} else {
+		err = row.Scan(dest)
+	}
+
+	afterHooks(err)
+	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+
+	if err != nil {
+		if logger := q.getLogger(); logger != nil {
+			logger.Error("SELECT query failed: %v", err)
+		}
+	}
+
+	return err
+}
+
+// Find executes the query and returns all results
+// Example: q.Where("active = ?", true).Order("created_at DESC").Find(ctx, &users)
+func (q *Query) Find(ctx context.Context, dest interface{}) error {
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	if err := q.prepareSelect(); err != nil {
+		return err
+	}
+
 	processStart := time.Now()
 	query, args := q.buildSelectQuery(false)
 
+	ctx, afterHooks := q.startHooks(ctx, query, args)
 	queryStart := time.Now()
 	rows, err := q.db.Query(ctx, query, args...)
 	queryEnd := time.Now()
 	queryDuration := queryEnd.Sub(queryStart)
 
 	if err != nil {
+		afterHooks(err)
 		if logger := q.getLogger(); logger != nil {
 			logger.Error("SELECT query failed: %v", err)
 		}
@@ -670,15 +1480,18 @@ func (q *Query) Find(ctx context.Context, dest interface{}) error {
 		err = q.scanRowsDirect(rows, dest)
 	}
 
-	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+	afterHooks(err)
+	q.logQueryRows(ctx, query, args, queryStart, processStart, queryDuration, resultRows(dest))
 
 	if err != nil {
 		if logger := q.getLogger(); logger != nil {
 			logger.Error("SELECT query failed during scan: %v", err)
 		}
+		return err
 	}
 
-	return err
+	q.warnOnRowCount(query, dest)
+	return nil
 }
 
 // FindFirst is an alias for First (compatibility)
@@ -691,11 +1504,101 @@ func (q *Query) FindMany(ctx context.Context, dest interface{}) error {
 	return q.Find(ctx, dest)
 }
 
+// Pluck fetches a single column into a slice, respecting the query's WHERE, ORDER BY and Take/Skip.
+// dest must be a pointer to a slice whose element type the column scans into (e.g. *[]string, *[]int).
+// Example: q.Where("active = ?", true).Pluck(ctx, "email", &emails)
+func (q *Query) Pluck(ctx context.Context, column string, dest interface{}) error {
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dest must be a pointer to slice")
+	}
+	if column == "" {
+		return fmt.Errorf("pluck column cannot be empty")
+	}
+
+	if err := q.prepareSelect(); err != nil {
+		return err
+	}
+
+	processStart := time.Now()
+	query, args := q.buildPluckQuery(column)
+
+	queryStart := time.Now()
+	rows, err := q.hooked(q.db).Query(ctx, query, args...)
+	queryDuration := time.Since(queryStart)
+
+	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+
+	if err != nil {
+		if logger := q.getLogger(); logger != nil {
+			logger.Error("SELECT query failed: %v", err)
+		}
+		return errors.SanitizeError(err)
+	}
+	defer rows.Close()
+
+	sliceVal := destVal.Elem()
+	elemType := sliceVal.Type().Elem()
+	sliceVal.Set(reflect.MakeSlice(sliceVal.Type(), 0, 0))
+
+	rowCount := 0
+	for rows.Next() {
+		if rowCount >= limits.MaxScanRows {
+			return fmt.Errorf("result set too large: maximum %d rows allowed", limits.MaxScanRows)
+		}
+
+		value := reflect.New(elemType)
+		if err := rows.Scan(value.Interface()); err != nil {
+			return errors.SanitizeError(err)
+		}
+
+		rowCount++
+		sliceVal.Set(reflect.Append(sliceVal, value.Elem()))
+	}
+	return rows.Err()
+}
+
+// buildPluckQuery builds the SELECT of a single column with the query's current state
+func (q *Query) buildPluckQuery(column string) (string, []interface{}) {
+	pluck := *q
+	pluck.selectFields = []string{column}
+	return pluck.buildSelectQuery(false)
+}
+
 // Count executes COUNT(*)
 func (q *Query) Count(ctx context.Context) (int64, error) {
+	return q.count(ctx, "COUNT(*)")
+}
+
+// CountDistinct counts the distinct non-NULL values of column among the matching rows,
+// rendered as SELECT COUNT(DISTINCT column) with the query's joins and conditions.
+// Example: customers, err := q.Where("status = ?", "paid").CountDistinct(ctx, "customer_id")
+func (q *Query) CountDistinct(ctx context.Context, column string) (int64, error) {
+	if strings.TrimSpace(column) == "" {
+		return 0, fmt.Errorf("%w: CountDistinct requires a column", errors.ErrInvalidInput)
+	}
+	return q.count(ctx, "COUNT(DISTINCT "+q.dialect.QuoteIdentifier(column)+")")
+}
+
+// count runs SELECT selectList with the query's filters and scans the single result
+func (q *Query) count(ctx context.Context, selectList string) (int64, error) {
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	if err := q.checkSubquery(); err != nil {
+		return 0, err
+	}
+	if err := q.checkUnlocked(); err != nil {
+		return 0, err
+	}
+
 	processStart := time.Now()
-	query, args := q.buildCountQuery()
+	query, args := q.buildFilteredQuery(selectList)
 
+	ctx, afterHooks := q.startHooks(ctx, query, args)
 	queryStart := time.Now()
 	row := q.db.QueryRow(ctx, query, args...)
 	var count int64
@@ -703,6 +1606,7 @@ func (q *Query) Count(ctx context.Context) (int64, error) {
 	queryEnd := time.Now()
 	queryDuration := queryEnd.Sub(queryStart)
 
+	afterHooks(err)
 	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
 
 	if err != nil {
@@ -713,32 +1617,355 @@ func (q *Query) Count(ctx context.Context) (int64, error) {
 	return count, err
 }
 
-// Create inserts a new record
+// Exists reports whether any row matches the query. It runs SELECT EXISTS(SELECT 1 ... LIMIT 1),
+// which stops at the first match and is cheaper than Count(ctx) > 0.
+// Example: taken, err := q.Where("email = ?", email).Exists(ctx)
+func (q *Query) Exists(ctx context.Context) (bool, error) {
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	if err := q.checkSubquery(); err != nil {
+		return false, err
+	}
+	if err := q.checkUnlocked(); err != nil {
+		return false, err
+	}
+
+	processStart := time.Now()
+	query, args := q.buildExistsQuery()
+
+	queryStart := time.Now()
+	var exists bool
+	err := q.hooked(q.db).QueryRow(ctx, query, args...).Scan(&exists)
+	queryDuration := time.Since(queryStart)
+
+	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+
+	if err != nil {
+		if logger := q.getLogger(); logger != nil {
+			logger.Error("EXISTS query failed: %v", err)
+		}
+	}
+	return exists, err
+}
+
+// EstimatedCount returns a fast approximate row count of the whole table, for
+// dashboards and diagnostics where exactness isn't required. On PostgreSQL it reads
+// the planner estimate from pg_class.reltuples (as fresh as the last ANALYZE) and
+// ignores WHERE conditions. Other databases, and tables PostgreSQL has never
+// analyzed, fall back to an exact Count.
+func (q *Query) EstimatedCount(ctx context.Context) (int64, error) {
+	if q.dialect.Name() != "postgresql" {
+		return q.Count(ctx)
+	}
+
+	processStart := time.Now()
+	query := "SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass($1)"
+	args := []interface{}{q.dialect.QuoteIdentifier(q.table)}
+
+	queryStart := time.Now()
+	var estimate int64
+	err := q.hooked(q.db).QueryRow(ctx, query, args...).Scan(&estimate)
+	queryDuration := time.Since(queryStart)
+
+	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+
+	if err != nil || estimate < 0 {
+		return q.Count(ctx)
+	}
+	return estimate, nil
+}
+
+// Create inserts a new record. With Returning, value is filled with the returned columns.
 func (q *Query) Create(ctx context.Context, value interface{}) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
+	if err := q.checkWritable(); err != nil {
+		return err
+	}
+
+	if len(q.returning) > 0 {
+		ctx, cancel := q.withTimeout(ctx)
+		defer cancel()
+		return q.createReturning(ctx, value)
+	}
+
+	_, err := q.CreateWithResult(ctx, value)
+	return err
+}
+
+// CreateWithResult inserts a new record like Create and returns the driver's Result, for
+// RowsAffected and, on PostgreSQL, the raw command tag via CommandTagResult. Returning is not read.
+// Example: res, err := q.CreateWithResult(ctx, &user); tagged, ok := res.(CommandTagResult)
+func (q *Query) CreateWithResult(ctx context.Context, value interface{}) (Result, error) {
+	if err := q.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := q.withTimeout(ctx)
 	defer cancel()
 
 	processStart := time.Now()
-	query, args := q.buildInsertQuery(value)
+	query, args, _ := q.buildInsertQuery(value)
 
+	ctx, afterHooks := q.startHooks(ctx, query, args)
 	queryStart := time.Now()
-	_, err := q.db.Exec(ctx, query, args...)
+	result, err := q.db.Exec(ctx, query, args...)
 	queryEnd := time.Now()
 	queryDuration := queryEnd.Sub(queryStart)
 
-	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+	afterHooks(err)
+	q.logQueryRows(ctx, query, args, queryStart, processStart, queryDuration, affectedRows(result, err))
 
 	if err != nil {
 		if logger := q.getLogger(); logger != nil {
 			logger.Error("INSERT query failed: %v", err)
 		}
+		return nil, errors.SanitizeError(err)
 	}
-	return errors.SanitizeError(err)
+	return result, nil
+}
+
+// CreateMany inserts a slice of structs (or of pointers to structs) with multi-row INSERTs
+// and returns the number of rows inserted. The columns are those set in any element, and an
+// element that leaves one of them zero gets the column default there, as with Create; string
+// primary keys left empty get a generated UUID. Slices that would exceed the dialect's
+// placeholder limit are split into several statements, run in one transaction.
+// Example: n, err := q.CreateMany(ctx, users) // users is a []User
+func (q *Query) CreateMany(ctx context.Context, values interface{}) (int64, error) {
+	if err := q.checkWritable(); err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	processStart := time.Now()
+	queries, args, err := q.buildCreateManyQueries(values)
+	if err != nil {
+		return 0, err
+	}
+
+	var inserted int64
+	run := func(db DBTX) error {
+		db = q.hooked(db)
+		inserted = 0
+		for i, query := range queries {
+			queryStart := time.Now()
+			result, err := db.Exec(ctx, query, args[i]...)
+			queryDuration := time.Since(queryStart)
+
+			q.logQueryWithTiming(ctx, query, args[i], queryStart, processStart, queryDuration)
+
+			if err != nil {
+				if logger := q.getLogger(); logger != nil {
+					logger.Error("INSERT query failed: %v", err)
+				}
+				return err
+			}
+			inserted += result.RowsAffected()
+		}
+		return nil
+	}
+
+	if len(queries) > 1 {
+		err = q.RunInTransaction(ctx, run)
+	} else {
+		err = run(q.db)
+	}
+	if err != nil {
+		return 0, errors.SanitizeError(err)
+	}
+	return inserted, nil
+}
+
+// buildCreateManyQueries builds the multi-row INSERTs for CreateMany, one per chunk of rows
+func (q *Query) buildCreateManyQueries(values interface{}) ([]string, [][]interface{}, error) {
+	val := reflect.ValueOf(values)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice {
+		return nil, nil, fmt.Errorf("%w: CreateMany expects a slice of structs, got %T", errors.ErrInvalidInput, values)
+	}
+
+	rows := make([]reflect.Value, val.Len())
+	for i := range rows {
+		row := reflect.Indirect(val.Index(i))
+		if row.Kind() == reflect.Interface {
+			row = reflect.Indirect(row.Elem())
+		}
+		if row.Kind() != reflect.Struct || (i > 0 && row.Type() != rows[0].Type()) {
+			return nil, nil, fmt.Errorf("%w: CreateMany element %d is not a struct of the slice's type", errors.ErrInvalidInput, i)
+		}
+		rows[i] = row
+	}
+	if len(rows) == 0 {
+		return nil, nil, nil
+	}
+
+	// Colunas preenchidas em algum elemento; a PK string vazia recebe um UUID por linha
+	typ := rows[0].Type()
+	var columns []string
+	fieldIndexes := make(map[string]int)
+	rowColumns := make([]map[string]bool, len(rows))
+	for r := range rowColumns {
+		rowColumns[r] = make(map[string]bool)
+	}
+	uuidColumn := ""
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		column, ok := fieldColumn(field)
+		if !ok {
+			continue
+		}
+
+		isUUID := column == q.primaryKey && field.Type.Kind() == reflect.String
+		isSet := false
+		for r, row := range rows {
+			if isUUID || !row.Field(i).IsZero() {
+				rowColumns[r][column] = true
+				isSet = true
+			}
+		}
+		if !isSet {
+			continue
+		}
+		if isUUID {
+			uuidColumn = column
+		}
+
+		columns = append(columns, column)
+		fieldIndexes[column] = i
+	}
+	if len(columns) == 0 {
+		return nil, nil, fmt.Errorf("%w: CreateMany has no columns to insert", errors.ErrInvalidInput)
+	}
+
+	queries, queryArgs := buildInsertRows(q.dialect, q.table, columns, rowColumns, 0, func(r int, column string) interface{} {
+		fieldVal := rows[r].Field(fieldIndexes[column])
+		if column == uuidColumn && fieldVal.IsZero() {
+			return uuid.GenerateUUID()
+		}
+		return bindArg(fieldVal.Interface())
+	}, nil)
+	return queries, queryArgs, nil
+}
+
+// buildInsertRows builds the multi-row INSERTs for rows, where rowColumns holds the columns
+// each row sets and value returns the arg of one of them. The cells a row leaves out are
+// DEFAULT, so the database default applies instead of a zero value; SQLite has no DEFAULT in
+// VALUES, so there the rows are grouped by the columns they set, one INSERT per group. Each
+// INSERT carries at most maxRows rows (0 for no cap) and stays under the placeholder limit,
+// and suffix, when not nil, returns what follows the VALUES for the INSERT's columns.
+func buildInsertRows(d dialect.Dialect, table string, columns []string, rowColumns []map[string]bool, maxRows int, value func(row int, column string) interface{}, suffix func(columns []string) string) ([]string, [][]interface{}) {
+	type rowGroup struct {
+		columns []string
+		rows    []int
+	}
+	var groups []rowGroup
+	if d.Name() == "sqlite" {
+		byColumns := make(map[string]int)
+		for r, set := range rowColumns {
+			var groupColumns []string
+			for _, column := range columns {
+				if set[column] {
+					groupColumns = append(groupColumns, column)
+				}
+			}
+			key := strings.Join(groupColumns, "\x00")
+			g, ok := byColumns[key]
+			if !ok {
+				g = len(groups)
+				byColumns[key] = g
+				groups = append(groups, rowGroup{columns: groupColumns})
+			}
+			groups[g].rows = append(groups[g].rows, r)
+		}
+	} else {
+		all := rowGroup{columns: columns, rows: make([]int, len(rowColumns))}
+		for r := range all.rows {
+			all.rows[r] = r
+		}
+		groups = append(groups, all)
+	}
+
+	quotedTable := d.QuoteIdentifier(table)
+	var queries []string
+	var queryArgs [][]interface{}
+	for _, group := range groups {
+		tail := ""
+		if suffix != nil {
+			tail = suffix(group.columns)
+		}
+		// Sem colunas, cada linha usa só os defaults (o MySQL aceita () VALUES ())
+		if len(group.columns) == 0 && d.Name() != "mysql" {
+			for range group.rows {
+				queries = append(queries, "INSERT INTO "+quotedTable+" DEFAULT VALUES"+tail)
+				queryArgs = append(queryArgs, nil)
+			}
+			continue
+		}
+
+		quotedColumns := make([]string, len(group.columns))
+		for i, column := range group.columns {
+			quotedColumns[i] = d.QuoteIdentifier(column)
+		}
+		prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quotedTable, strings.Join(quotedColumns, ", "))
+
+		rowsPerQuery := len(group.rows)
+		if len(group.columns) > 0 {
+			rowsPerQuery = maxPlaceholders(d) / len(group.columns)
+		}
+		if maxRows > 0 && rowsPerQuery > maxRows {
+			rowsPerQuery = maxRows
+		}
+		for start := 0; start < len(group.rows); start += rowsPerQuery {
+			end := start + rowsPerQuery
+			if end > len(group.rows) {
+				end = len(group.rows)
+			}
+
+			valuesParts := make([]string, 0, end-start)
+			var args []interface{}
+			argIndex := 1
+			for _, r := range group.rows[start:end] {
+				cells := make([]string, len(group.columns))
+				for j, column := range group.columns {
+					if !rowColumns[r][column] {
+						cells[j] = "DEFAULT"
+						continue
+					}
+					args = append(args, value(r, column))
+					cells[j] = d.GetPlaceholder(argIndex)
+					argIndex++
+				}
+				valuesParts = append(valuesParts, "("+strings.Join(cells, ", ")+")")
+			}
+
+			queries = append(queries, prefix+strings.Join(valuesParts, ", ")+tail)
+			queryArgs = append(queryArgs, args)
+		}
+	}
+	return queries, queryArgs
+}
+
+// maxPlaceholders returns how many bind parameters a single statement may carry on the dialect
+func maxPlaceholders(d dialect.Dialect) int {
+	if d.Name() == "sqlite" {
+		return limits.MaxPlaceholdersSQLite
+	}
+	return limits.MaxPlaceholders
 }
 
 // Save updates or creates a record (upsert)
 func (q *Query) Save(ctx context.Context, value interface{}) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
+	if err := q.checkWritable(); err != nil {
+		return err
+	}
+
+	ctx, cancel := q.withTimeout(ctx)
 	defer cancel()
 
 	if q.primaryKey == "" {
@@ -750,7 +1977,7 @@ func (q *Query) Save(ctx context.Context, value interface{}) error {
 	query, args := q.buildUpsertQuery(value)
 
 	queryStart := time.Now()
-	_, err := q.db.Exec(ctx, query, args...)
+	_, err := q.hooked(q.db).Exec(ctx, query, args...)
 	queryEnd := time.Now()
 	queryDuration := queryEnd.Sub(queryStart)
 
@@ -766,14 +1993,18 @@ func (q *Query) Save(ctx context.Context, value interface{}) error {
 
 // Update updates records
 func (q *Query) Update(ctx context.Context, column string, value interface{}) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
+	if err := q.checkWritable(); err != nil {
+		return err
+	}
+
+	ctx, cancel := q.withTimeout(ctx)
 	defer cancel()
 
 	processStart := time.Now()
 	query, args := q.buildUpdateQuery(column, value)
 
 	queryStart := time.Now()
-	_, err := q.db.Exec(ctx, query, args...)
+	_, err := q.hooked(q.db).Exec(ctx, query, args...)
 	queryEnd := time.Now()
 	queryDuration := queryEnd.Sub(queryStart)
 
@@ -784,59 +2015,215 @@ func (q *Query) Update(ctx context.Context, column string, value interface{}) er
 			logger.Error("UPDATE query failed: %v", err)
 		}
 	}
-	return errors.SanitizeError(err)
-}
+	return errors.SanitizeError(err)
+}
+
+// Updates updates multiple columns. With Returning, the updated rows are read into dest
+// (a pointer to struct receives the first row, a pointer to slice all of them).
+func (q *Query) Updates(ctx context.Context, values map[string]interface{}, dest ...interface{}) error {
+	if err := q.checkWritable(); err != nil {
+		return err
+	}
+
+	if len(q.returning) > 0 && len(dest) > 0 {
+		ctx, cancel := q.withTimeout(ctx)
+		defer cancel()
+		return q.updatesReturning(ctx, values, dest[0])
+	}
+
+	_, err := q.UpdatesResult(ctx, values)
+	return err
+}
+
+// UpdatesResult updates multiple columns like Updates and returns the number of rows affected
+// Example: n, err := q.Where("id = ?", id).UpdatesResult(ctx, values); if n == 0 { /* no row matched */ }
+func (q *Query) UpdatesResult(ctx context.Context, values map[string]interface{}) (int64, error) {
+	result, err := q.UpdatesWithResult(ctx, values)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// UpdatesWithResult updates multiple columns like Updates and returns the driver's Result, for
+// RowsAffected and, on PostgreSQL, the raw command tag via CommandTagResult. Returning is not read.
+func (q *Query) UpdatesWithResult(ctx context.Context, values map[string]interface{}) (Result, error) {
+	if err := q.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	processStart := time.Now()
+	query, args := q.buildUpdatesQuery(values)
+
+	ctx, afterHooks := q.startHooks(ctx, query, args)
+	queryStart := time.Now()
+	result, err := q.db.Exec(ctx, query, args...)
+	queryEnd := time.Now()
+	queryDuration := queryEnd.Sub(queryStart)
+
+	afterHooks(err)
+	q.logQueryRows(ctx, query, args, queryStart, processStart, queryDuration, affectedRows(result, err))
+
+	if err != nil {
+		if logger := q.getLogger(); logger != nil {
+			logger.Error("UPDATE query failed: %v", err)
+		}
+		return nil, errors.SanitizeError(err)
+	}
+	return result, nil
+}
+
+// Delete removes records. With Returning (and no Cascade), the deleted rows are read into
+// value (a pointer to struct receives the first row, a pointer to slice all of them).
+func (q *Query) Delete(ctx context.Context, value interface{}) error {
+	if err := q.checkWritable(); err != nil {
+		return err
+	}
+
+	if len(q.cascade) == 0 && len(q.returning) > 0 && value != nil {
+		ctx, cancel := q.withTimeout(ctx)
+		defer cancel()
+		return q.deleteReturning(ctx, value)
+	}
+
+	_, err := q.DeleteResult(ctx, value)
+	return err
+}
+
+// DeleteResult removes records like Delete and returns the number of rows deleted.
+// With Cascade, only the matched rows are counted, not their dependents. Returning is not read.
+func (q *Query) DeleteResult(ctx context.Context, value interface{}) (int64, error) {
+	if err := q.checkWritable(); err != nil {
+		return 0, err
+	}
+
+	if len(q.cascade) > 0 {
+		ctx, cancel := q.withTimeout(ctx)
+		defer cancel()
+		return q.deleteCascade(ctx)
+	}
+
+	result, err := q.DeleteWithResult(ctx, value)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// DeleteWithResult removes records like Delete and returns the driver's Result, for
+// RowsAffected and, on PostgreSQL, the raw command tag via CommandTagResult. Returning is
+// not read, and Cascade runs several statements so it is rejected (use DeleteResult).
+func (q *Query) DeleteWithResult(ctx context.Context, value interface{}) (Result, error) {
+	if err := q.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	if len(q.cascade) > 0 {
+		return nil, fmt.Errorf("%w: DeleteWithResult does not support Cascade, use DeleteResult", errors.ErrInvalidInput)
+	}
 
-// Updates updates multiple columns
-func (q *Query) Updates(ctx context.Context, values map[string]interface{}) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
+	ctx, cancel := q.withTimeout(ctx)
 	defer cancel()
 
 	processStart := time.Now()
-	query, args := q.buildUpdatesQuery(values)
+	query, args := q.buildDeleteQuery()
 
+	ctx, afterHooks := q.startHooks(ctx, query, args)
 	queryStart := time.Now()
-	_, err := q.db.Exec(ctx, query, args...)
+	result, err := q.db.Exec(ctx, query, args...)
 	queryEnd := time.Now()
 	queryDuration := queryEnd.Sub(queryStart)
 
-	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+	afterHooks(err)
+	q.logQueryRows(ctx, query, args, queryStart, processStart, queryDuration, affectedRows(result, err))
 
 	if err != nil {
 		if logger := q.getLogger(); logger != nil {
-			logger.Error("UPDATE query failed: %v", err)
+			logger.Error("DELETE query failed: %v", err)
 		}
+		return nil, errors.SanitizeError(err)
 	}
-	return errors.SanitizeError(err)
+	return result, nil
 }
 
-// Delete removes records
-func (q *Query) Delete(ctx context.Context, value interface{}) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
-	defer cancel()
-
+// deleteCascade deletes dependent rows and then the matched rows, returning how many
+// matched rows were deleted. All statements run in one transaction, or in the current
+// one when the query already belongs to a transaction. Rows of a Restrict relation
+// still referencing the matched rows fail the delete with ErrInvalidInput.
+func (q *Query) deleteCascade(ctx context.Context) (int64, error) {
 	processStart := time.Now()
-	query, args := q.buildDeleteQuery()
-
-	queryStart := time.Now()
-	_, err := q.db.Exec(ctx, query, args...)
-	queryEnd := time.Now()
-	queryDuration := queryEnd.Sub(queryStart)
+	plan, args, err := q.buildCascadeDeleteQueries()
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	run := func(db DBTX) error {
+		db = q.hooked(db)
+		for _, check := range plan.checks {
+			queryStart := time.Now()
+			var count int64
+			err := db.QueryRow(ctx, check.query, args...).Scan(&count)
+			q.logQueryWithTiming(ctx, check.query, args, queryStart, processStart, time.Since(queryStart))
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: %d %s rows still reference the rows to delete", errors.ErrInvalidInput, count, check.table)
+			}
+		}
+		for _, query := range plan.statements {
+			queryStart := time.Now()
+			result, err := db.Exec(ctx, query, args...)
+			queryDuration := time.Since(queryStart)
 
-	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
+			q.logQueryRows(ctx, query, args, queryStart, processStart, queryDuration, affectedRows(result, err))
 
-	if err != nil {
-		if logger := q.getLogger(); logger != nil {
-			logger.Error("DELETE query failed: %v", err)
+			if err != nil {
+				if logger := q.getLogger(); logger != nil {
+					logger.Error("DELETE query failed: %v", err)
+				}
+				return err
+			}
+			// The matched rows are deleted last
+			deleted = result.RowsAffected()
 		}
+		return nil
 	}
-	return errors.SanitizeError(err)
+
+	if err := q.RunInTransaction(ctx, run); err != nil {
+		return 0, errors.SanitizeError(err)
+	}
+	return deleted, nil
+}
+
+// RunInTransaction runs fn in a new transaction on the query's database, or
+// directly when the query already belongs to a transaction. fn receives the
+// connection to run statements on; returning an error rolls the transaction back.
+func (q *Query) RunInTransaction(ctx context.Context, fn func(db DBTX) error) error {
+	if _, inTx := q.db.(*txDBAdapter); inTx {
+		return fn(q.db)
+	}
+	return ExecuteTransaction(ctx, q.db, func(tx *Transaction) error {
+		return fn(tx.DB())
+	})
 }
 
 // buildSelectQuery builds the SELECT query
 func (q *Query) buildSelectQuery(single bool) (string, []interface{}) {
-	var args []interface{}
+	if q.raw != nil {
+		return q.raw.sql, q.raw.args
+	}
 	argIndex := 1
+	return q.buildSelectQueryAt(single, &argIndex)
+}
+
+// buildSelectQueryAt builds the SELECT query with placeholders numbered from argIndex
+func (q *Query) buildSelectQueryAt(single bool, argIndex *int) (string, []interface{}) {
+	var args []interface{}
 
 	// Estimar tamanho inicial do query builder
 	estimatedSize := 256
@@ -846,7 +2233,14 @@ func (q *Query) buildSelectQuery(single bool) (string, []interface{}) {
 	var queryBuilder strings.Builder
 	queryBuilder.Grow(estimatedSize)
 
+	hintPrefix, hintSuffix := q.indexHintSyntax()
+	queryBuilder.WriteString(hintPrefix)
 	queryBuilder.WriteString("SELECT ")
+	distinctClause, distinctGroupBy := q.distinctSyntax()
+	if distinctClause != "" {
+		queryBuilder.WriteString(distinctClause)
+		queryBuilder.WriteString(" ")
+	}
 	if len(q.selectFields) > 0 {
 		for i, field := range q.selectFields {
 			if i > 0 {
@@ -863,8 +2257,14 @@ func (q *Query) buildSelectQuery(single bool) (string, []interface{}) {
 		}
 	}
 
+	fromClause, fromArgs := q.fromClause(argIndex)
 	queryBuilder.WriteString(" FROM ")
-	queryBuilder.WriteString(q.dialect.QuoteIdentifier(q.table))
+	queryBuilder.WriteString(fromClause)
+	args = append(args, fromArgs...)
+	if hintSuffix != "" {
+		queryBuilder.WriteString(" ")
+		queryBuilder.WriteString(hintSuffix)
+	}
 
 	for _, join := range q.joins {
 		queryBuilder.WriteString(" ")
@@ -874,19 +2274,20 @@ func (q *Query) buildSelectQuery(single bool) (string, []interface{}) {
 		queryBuilder.WriteString(" ON ")
 		queryBuilder.WriteString(join.on)
 		args = append(args, join.args...)
-		argIndex += len(join.args)
+		*argIndex += len(join.args)
 	}
 
 	if len(q.whereConditions) > 0 {
-		whereClause, whereArgs := q.buildWhereClause(&argIndex)
+		whereClause, whereArgs := q.buildWhereClause(argIndex)
 		queryBuilder.WriteString(" WHERE ")
 		queryBuilder.WriteString(whereClause)
 		args = append(args, whereArgs...)
 	}
 
-	if len(q.groupBy) > 0 {
+	groupBy := append(append([]string{}, q.groupBy...), distinctGroupBy...)
+	if len(groupBy) > 0 {
 		queryBuilder.WriteString(" GROUP BY ")
-		for i, field := range q.groupBy {
+		for i, field := range groupBy {
 			if i > 0 {
 				queryBuilder.WriteString(", ")
 			}
@@ -895,26 +2296,24 @@ func (q *Query) buildSelectQuery(single bool) (string, []interface{}) {
 	}
 
 	if len(q.having) > 0 {
-		havingClause, havingArgs := q.buildHavingClause(&argIndex)
+		havingClause, havingArgs := q.buildHavingClause(argIndex)
 		queryBuilder.WriteString(" HAVING ")
 		queryBuilder.WriteString(havingClause)
 		args = append(args, havingArgs...)
 	}
 
 	if len(q.orderBy) > 0 {
+		orderClause, orderArgs := q.buildOrderByClause(argIndex)
 		queryBuilder.WriteString(" ORDER BY ")
-		for i, order := range q.orderBy {
-			if i > 0 {
-				queryBuilder.WriteString(", ")
-			}
-			queryBuilder.WriteString(q.dialect.QuoteIdentifier(order.Field))
-			queryBuilder.WriteString(" ")
-			queryBuilder.WriteString(order.Order)
-		}
+		queryBuilder.WriteString(orderClause)
+		args = append(args, orderArgs...)
 	}
 
 	if single {
-		queryBuilder.WriteString(" LIMIT 1")
+		limitOffset, limitArgs := limitClause(q.dialect, 1, 0, len(q.orderBy) > 0, argIndex)
+		queryBuilder.WriteString(" ")
+		queryBuilder.WriteString(limitOffset)
+		args = append(args, limitArgs...)
 	} else if q.take != nil || q.skip != nil {
 		limit := 0
 		offset := 0
@@ -924,13 +2323,17 @@ func (q *Query) buildSelectQuery(single bool) (string, []interface{}) {
 		if q.skip != nil {
 			offset = *q.skip
 		}
-		limitOffset := q.dialect.GetLimitOffsetSyntax(limit, offset)
+		limitOffset, limitArgs := limitClause(q.dialect, limit, offset, len(q.orderBy) > 0, argIndex)
 		if limitOffset != "" {
 			queryBuilder.WriteString(" ")
 			queryBuilder.WriteString(limitOffset)
+			args = append(args, limitArgs...)
 		}
-		// Note: GetLimitOffsetSyntax already includes the values in the SQL string,
-		// so we don't need to add them to args
+	}
+
+	if lockClause, _ := q.lockSyntax(); lockClause != "" {
+		queryBuilder.WriteString(" ")
+		queryBuilder.WriteString(lockClause)
 	}
 
 	return queryBuilder.String(), args
@@ -938,14 +2341,19 @@ func (q *Query) buildSelectQuery(single bool) (string, []interface{}) {
 
 // buildWhereClause builds the WHERE clause
 func (q *Query) buildWhereClause(argIndex *int) (string, []interface{}) {
-	if len(q.whereConditions) == 0 {
+	return q.buildConditions(q.whereConditions, argIndex)
+}
+
+// buildConditions joins conditions with AND/OR, expanding ? into dialect placeholders
+func (q *Query) buildConditions(conditions []whereCondition, argIndex *int) (string, []interface{}) {
+	if len(conditions) == 0 {
 		return "", nil
 	}
 
 	var parts []string
 	var args []interface{}
 
-	for i, cond := range q.whereConditions {
+	for i, cond := range conditions {
 		if i > 0 {
 			if cond.or {
 				parts = append(parts, "OR")
@@ -962,7 +2370,11 @@ func (q *Query) buildWhereClause(argIndex *int) (string, []interface{}) {
 		for i := 0; i < len(query); i++ {
 			if query[i] == '?' && argPos < len(cond.args) {
 				arg := cond.args[argPos]
-				if reflect.TypeOf(arg).Kind() == reflect.Slice {
+				if list, ok := arg.(listArg); ok {
+					queryBuilder.WriteString(q.dialect.GetPlaceholder(*argIndex))
+					args = append(args, list.value)
+					(*argIndex)++
+				} else if arg != nil && reflect.TypeOf(arg).Kind() == reflect.Slice {
 					slice := reflect.ValueOf(arg)
 					placeholders := make([]string, slice.Len())
 					for j := 0; j < slice.Len(); j++ {
@@ -991,16 +2403,72 @@ func (q *Query) buildWhereClause(argIndex *int) (string, []interface{}) {
 
 // buildHavingClause builds the HAVING clause (similar to WHERE)
 func (q *Query) buildHavingClause(argIndex *int) (string, []interface{}) {
-	return q.buildWhereClause(argIndex)
+	return q.buildConditions(q.having, argIndex)
+}
+
+// buildOrderByClause builds the ORDER BY list, expanding OrderByValues into a CASE ladder
+func (q *Query) buildOrderByClause(argIndex *int) (string, []interface{}) {
+	parts := make([]string, len(q.orderBy))
+	var args []interface{}
+	for i, order := range q.orderBy {
+		field := q.dialect.QuoteIdentifier(order.Field)
+		if order.insensitive {
+			field = "LOWER(" + field + ")"
+		}
+		if order.collate != "" {
+			field += " " + order.collate
+		}
+		if len(order.values) == 0 {
+			parts[i] = field + " " + order.Order
+			continue
+		}
+		var sb strings.Builder
+		sb.WriteString("CASE " + field)
+		for pos, value := range order.values {
+			sb.WriteString(fmt.Sprintf(" WHEN %s THEN %d", q.dialect.GetPlaceholder(*argIndex), pos))
+			args = append(args, value)
+			(*argIndex)++
+		}
+		sb.WriteString(fmt.Sprintf(" ELSE %d END %s", len(order.values), order.Order))
+		parts[i] = sb.String()
+	}
+	return strings.Join(parts, ", "), args
 }
 
 // buildCountQuery builds the COUNT query
 func (q *Query) buildCountQuery() (string, []interface{}) {
+	return q.buildFilteredQuery("COUNT(*)")
+}
+
+// buildExistsQuery builds the SELECT EXISTS query, stopping at the first matching row
+func (q *Query) buildExistsQuery() (string, []interface{}) {
+	query, args := q.buildFilteredQuery("1")
+	if q.dialect.Name() == "sqlserver" {
+		// SQL Server does not accept EXISTS as a column value
+		return "SELECT CASE WHEN EXISTS(" + query + ") THEN 1 ELSE 0 END", args
+	}
+	argIndex := len(args) + 1
+	if limit, limitArgs := limitClause(q.dialect, 1, 0, false, &argIndex); limit != "" {
+		query += " " + limit
+		args = append(args, limitArgs...)
+	}
+	return "SELECT EXISTS(" + query + ")", args
+}
+
+// buildFilteredQuery builds SELECT selectList with the query's FROM, JOINs and WHERE,
+// ignoring ordering and pagination
+func (q *Query) buildFilteredQuery(selectList string) (string, []interface{}) {
 	var parts []string
 	var args []interface{}
 	argIndex := 1
 
-	parts = append(parts, "SELECT COUNT(*) FROM", q.dialect.QuoteIdentifier(q.table))
+	hintPrefix, hintSuffix := q.indexHintSyntax()
+	fromClause, fromArgs := q.fromClause(&argIndex)
+	parts = append(parts, hintPrefix+"SELECT "+selectList+" FROM", fromClause)
+	args = append(args, fromArgs...)
+	if hintSuffix != "" {
+		parts = append(parts, hintSuffix)
+	}
 
 	for _, join := range q.joins {
 		parts = append(parts, fmt.Sprintf("%s JOIN %s ON %s", join.joinType, q.dialect.QuoteIdentifier(join.table), join.on))
@@ -1017,14 +2485,15 @@ func (q *Query) buildCountQuery() (string, []interface{}) {
 	return strings.Join(parts, " "), args
 }
 
-// buildInsertQuery builds the INSERT query
-func (q *Query) buildInsertQuery(value interface{}) (string, []interface{}) {
+// buildInsertQuery builds the INSERT query. It also returns the primary key it inserts
+// (the value given or the generated UUID), or nil when the database assigns it.
+func (q *Query) buildInsertQuery(value interface{}) (string, []interface{}, interface{}) {
 	val := reflect.ValueOf(value)
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
 	if val.Kind() != reflect.Struct {
-		return "", nil
+		return "", nil, nil
 	}
 
 	var columns []string
@@ -1037,11 +2506,15 @@ func (q *Query) buildInsertQuery(value interface{}) (string, []interface{}) {
 	var primaryKeyCol string
 	var primaryKeyType reflect.Kind
 	var primaryKeyIsZero bool
+	var insertedKey interface{}
 
 	for i := 0; i < val.NumField(); i++ {
 		field := typ.Field(i)
 		fieldVal := val.Field(i)
-		fieldName := toSnakeCase(field.Name)
+		fieldName, ok := fieldColumn(field)
+		if !ok {
+			continue
+		}
 
 		if fieldName == q.primaryKey {
 			primaryKeyCol = fieldName
@@ -1057,7 +2530,7 @@ func (q *Query) buildInsertQuery(value interface{}) (string, []interface{}) {
 
 		columns = append(columns, fieldName)
 		values = append(values, q.dialect.GetPlaceholder(argIndex))
-		args = append(args, fieldVal.Interface())
+		args = append(args, bindArg(fieldVal.Interface()))
 		argIndex++
 	}
 
@@ -1066,11 +2539,13 @@ func (q *Query) buildInsertQuery(value interface{}) (string, []interface{}) {
 			columns = append(columns, primaryKeyCol)
 			values = append(values, q.dialect.GetPlaceholder(argIndex))
 			args = append(args, primaryKeyValue)
+			insertedKey = primaryKeyValue
 		} else if primaryKeyType == reflect.String {
 			generatedUUID := uuid.GenerateUUID()
 			columns = append(columns, primaryKeyCol)
 			values = append(values, q.dialect.GetPlaceholder(argIndex))
 			args = append(args, generatedUUID)
+			insertedKey = generatedUUID
 		}
 	}
 
@@ -1080,13 +2555,14 @@ func (q *Query) buildInsertQuery(value interface{}) (string, []interface{}) {
 	}
 
 	query := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s)",
+		"INSERT INTO %s (%s) VALUES (%s)%s",
 		q.dialect.QuoteIdentifier(q.table),
 		strings.Join(quotedColumns, ", "),
 		strings.Join(values, ", "),
+		q.returningClause(),
 	)
 
-	return query, args
+	return query, args, insertedKey
 }
 
 // buildUpsertQuery builds an INSERT ... ON CONFLICT (upsert) query
@@ -1113,10 +2589,9 @@ func (q *Query) buildUpsertQuery(value interface{}) (string, []interface{}) {
 		fieldVal := val.Field(i)
 
 		// Use db tag if available, otherwise use snake_case of field name
-		dbTag := field.Tag.Get("db")
-		fieldName := dbTag
-		if fieldName == "" {
-			fieldName = toSnakeCase(field.Name)
+		fieldName, ok := fieldColumn(field)
+		if !ok {
+			continue
 		}
 
 		if fieldName == q.primaryKey {
@@ -1131,7 +2606,7 @@ func (q *Query) buildUpsertQuery(value interface{}) (string, []interface{}) {
 
 		columns = append(columns, fieldName)
 		values = append(values, q.dialect.GetPlaceholder(argIndex))
-		args = append(args, fieldVal.Interface())
+		args = append(args, bindArg(fieldVal.Interface()))
 		argIndex++
 	}
 
@@ -1160,7 +2635,7 @@ func (q *Query) buildUpsertQuery(value interface{}) (string, []interface{}) {
 	dialectName := q.dialect.Name()
 	var conflictPart string
 
-	if dialectName == "postgresql" || dialectName == "postgres" || dialectName == "sqlite" {
+	if isPostgresFamily(q.dialect) || dialectName == "sqlite" {
 		// PostgreSQL e SQLite usam ON CONFLICT
 		if primaryKeyCol != "" {
 			quotedPK := q.dialect.QuoteIdentifier(primaryKeyCol)
@@ -1180,15 +2655,13 @@ func (q *Query) buildUpsertQuery(value interface{}) (string, []interface{}) {
 	} else if dialectName == "mysql" || dialectName == "mariadb" {
 		// MySQL usa ON DUPLICATE KEY UPDATE
 		if primaryKeyCol != "" {
-			var updateParts []string
+			var updateColumns []string
 			for _, col := range columns {
-				if col == primaryKeyCol {
-					continue
+				if col != primaryKeyCol {
+					updateColumns = append(updateColumns, col)
 				}
-				quotedCol := q.dialect.QuoteIdentifier(col)
-				updateParts = append(updateParts, fmt.Sprintf("%s = VALUES(%s)", quotedCol, quotedCol))
 			}
-			conflictPart = fmt.Sprintf("ON DUPLICATE KEY UPDATE %s", strings.Join(updateParts, ", "))
+			return insertPart + mysqlDuplicateKeyUpdate(q.dialect, updateColumns), args
 		} else {
 			// Sem primary key, apenas INSERT
 			return insertPart, args
@@ -1212,7 +2685,7 @@ func (q *Query) buildUpdateQuery(column string, value interface{}) (string, []in
 		q.dialect.QuoteIdentifier(q.table),
 		q.dialect.QuoteIdentifier(column),
 		q.dialect.GetPlaceholder(argIndex)))
-	args = append(args, value)
+	args = append(args, bindArg(value))
 	argIndex++
 
 	// WHERE
@@ -1236,7 +2709,7 @@ func (q *Query) buildUpdatesQuery(values map[string]interface{}) (string, []inte
 		setParts = append(setParts, fmt.Sprintf("%s = %s",
 			q.dialect.QuoteIdentifier(col),
 			q.dialect.GetPlaceholder(argIndex)))
-		args = append(args, val)
+		args = append(args, bindArg(val))
 		argIndex++
 	}
 
@@ -1251,7 +2724,7 @@ func (q *Query) buildUpdatesQuery(values map[string]interface{}) (string, []inte
 		args = append(args, whereArgs...)
 	}
 
-	return strings.Join(parts, " "), args
+	return strings.Join(parts, " ") + q.returningClause(), args
 }
 
 // buildDeleteQuery builds the DELETE query
@@ -1269,7 +2742,85 @@ func (q *Query) buildDeleteQuery() (string, []interface{}) {
 		args = append(args, whereArgs...)
 	}
 
-	return strings.Join(parts, " "), args
+	return strings.Join(parts, " ") + q.returningClause(), args
+}
+
+// cascadePlan holds the statements of a cascading delete. Every check counts the rows
+// of a Restrict relation and must find none; the statements then run in order.
+type cascadePlan struct {
+	checks     []cascadeCheck
+	statements []string
+}
+
+// cascadeCheck counts the rows of table that still reference the rows being deleted
+type cascadeCheck struct {
+	table string
+	query string
+}
+
+// buildCascadeDeleteQueries builds the statements for a cascading delete.
+// Dependent tables are filtered with nested subqueries down to the parent WHERE,
+// so every statement shares the same args. Children come before their parents.
+func (q *Query) buildCascadeDeleteQueries() (cascadePlan, []interface{}, error) {
+	argIndex := 1
+	scope := fmt.Sprintf("FROM %s", q.dialect.QuoteIdentifier(q.table))
+	var args []interface{}
+	if len(q.whereConditions) > 0 {
+		whereClause, whereArgs := q.buildWhereClause(&argIndex)
+		scope += " WHERE " + whereClause
+		args = whereArgs
+	}
+
+	var plan cascadePlan
+	if err := q.appendCascadeDeletes(&plan, q.cascade, scope); err != nil {
+		return cascadePlan{}, nil, err
+	}
+	plan.statements = append(plan.statements, "DELETE "+scope)
+	return plan, args, nil
+}
+
+// appendCascadeDeletes appends the statements for relations (deepest first).
+// parentScope is the FROM ... WHERE ... clause selecting the parent rows.
+func (q *Query) appendCascadeDeletes(plan *cascadePlan, relations []CascadeRelation, parentScope string) error {
+	for _, rel := range relations {
+		fkColumns := q.quoteColumnList(rel.Columns)
+		if len(rel.Columns) > 1 {
+			// (a, b) IN (SELECT ...) não existe no SQL Server
+			if q.dialect.Name() == "sqlserver" {
+				return fmt.Errorf("%w: Cascade through the composite foreign key of %s is not supported by %s", errors.ErrInvalidInput, rel.Table, q.dialect.Name())
+			}
+			fkColumns = "(" + fkColumns + ")"
+		}
+		table := q.dialect.QuoteIdentifier(rel.Table)
+		where := fmt.Sprintf("%s IN (SELECT %s %s)", fkColumns, q.quoteColumnList(rel.ReferencedColumns), parentScope)
+
+		switch rel.OnDelete {
+		case "SetNull":
+			sets := make([]string, len(rel.Columns))
+			for i, col := range rel.Columns {
+				sets[i] = q.dialect.QuoteIdentifier(col) + " = NULL"
+			}
+			plan.statements = append(plan.statements, fmt.Sprintf("UPDATE %s SET %s WHERE %s", table, strings.Join(sets, ", "), where))
+		case "Restrict", "NoAction":
+			plan.checks = append(plan.checks, cascadeCheck{table: rel.Table, query: fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", table, where)})
+		default:
+			scope := fmt.Sprintf("FROM %s WHERE %s", table, where)
+			if err := q.appendCascadeDeletes(plan, rel.Children, scope); err != nil {
+				return err
+			}
+			plan.statements = append(plan.statements, "DELETE "+scope)
+		}
+	}
+	return nil
+}
+
+// quoteColumnList quotes and joins columns with commas
+func (q *Query) quoteColumnList(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = q.dialect.QuoteIdentifier(col)
+	}
+	return strings.Join(quoted, ", ")
 }
 
 // scanRowIntoModel scans a row into a model
@@ -1287,10 +2838,9 @@ func (q *Query) scanRowIntoModel(row interface{}, dest interface{}) error {
 
 		modelValue := reflect.New(q.modelType).Elem()
 
-		// Use selectFields if available (when Select() was called), otherwise use all columns
-		columnsToScan := q.columns
-		if len(q.selectFields) > 0 {
-			columnsToScan = q.selectFields
+		columnsToScan, err := q.scanColumns(driverRow)
+		if err != nil {
+			return err
 		}
 
 		// Build column-to-field map filtering only fields that correspond to actual columns
@@ -1301,7 +2851,7 @@ func (q *Query) scanRowIntoModel(row interface{}, dest interface{}) error {
 		for i, colName := range columnsToScan {
 			if fieldIdx, ok := columnToField[colName]; ok {
 				field := modelValue.Field(fieldIdx)
-				fields[i] = field.Addr().Interface()
+				fields[i] = scanDestination(field)
 				mappedCount++
 			} else {
 				var dummy interface{}
@@ -1346,6 +2896,13 @@ func (q *Query) scanRowsIntoModel(rows interface{}, dest interface{}) error {
 			sliceType = sliceType.Elem()
 		}
 
+		columnsToScan, err := q.scanColumns(driverRows)
+		if err != nil {
+			return err
+		}
+		// Build column-to-field map filtering only fields that correspond to actual columns
+		columnToField := buildColumnToFieldMapForScan(sliceType, columnsToScan)
+
 		rowCount := 0
 
 		for driverRows.Next() {
@@ -1355,20 +2912,11 @@ func (q *Query) scanRowsIntoModel(rows interface{}, dest interface{}) error {
 
 			modelValue := reflect.New(sliceType).Elem()
 
-			// Use selectFields if available (when Select() was called), otherwise use all columns
-			columnsToScan := q.columns
-			if len(q.selectFields) > 0 {
-				columnsToScan = q.selectFields
-			}
-
-			// Build column-to-field map filtering only fields that correspond to actual columns
-			columnToField := buildColumnToFieldMapForScan(sliceType, columnsToScan)
-
 			fields := make([]interface{}, len(columnsToScan))
 			for i, colName := range columnsToScan {
 				if fieldIdx, ok := columnToField[colName]; ok {
 					field := modelValue.Field(fieldIdx)
-					fields[i] = field.Addr().Interface()
+					fields[i] = scanDestination(field)
 				} else {
 					var dummy interface{}
 					fields[i] = &dummy
@@ -1400,6 +2948,35 @@ func (q *Query) scanRowsDirect(rows interface{}, dest interface{}) error {
 	return q.scanRowsIntoModel(rows, dest)
 }
 
+// columnsReporter is implemented by rows and rows sets that report the names of their
+// result columns (see driver.ColumnsRows)
+type columnsReporter interface {
+	Columns() ([]string, error)
+}
+
+// scanColumns returns the column names of the result, in result order, so each column is
+// mapped to a field by name: the names reported by the driver when result implements
+// Columns, otherwise the selected columns (selectFields when Select was called, all columns
+// otherwise). When the reported names match the selected columns those are reused.
+func (q *Query) scanColumns(result interface{}) ([]string, error) {
+	expected := q.columns
+	if len(q.selectFields) > 0 {
+		expected = q.selectFields
+	}
+	named, ok := result.(columnsReporter)
+	if !ok {
+		return expected, nil
+	}
+	columns, err := named.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if slices.Equal(columns, expected) {
+		return expected, nil
+	}
+	return columns, nil
+}
+
 // buildColumnToFieldMapForScan creates a map of column names to field indices
 // Only includes fields that correspond to actual columns being scanned
 // Iterates through columns first to ensure all columns are mapped
@@ -1414,7 +2991,10 @@ func buildColumnToFieldMapForScan(modelType reflect.Type, columns []string) map[
 	for i := 0; i < modelType.NumField(); i++ {
 		field := modelType.Field(i)
 		jsonTag := field.Tag.Get("json")
-		dbTag := field.Tag.Get("db")
+		column, ok := fieldColumn(field)
+		if !ok {
+			continue
+		}
 
 		// Remove options from json tag (e.g., "id,omitempty" -> "id")
 		if jsonTag != "" {
@@ -1424,10 +3004,8 @@ func buildColumnToFieldMapForScan(modelType reflect.Type, columns []string) map[
 		}
 
 		// Map all possible identifiers to this field index
-		// Priority: dbTag > jsonTag > snake_case field name
-		if dbTag != "" {
-			fieldMap[dbTag] = i
-		}
+		// Priority: column > jsonTag > snake_case field name
+		fieldMap[column] = i
 		if jsonTag != "" {
 			fieldMap[jsonTag] = i
 		}
@@ -1451,6 +3029,180 @@ func buildColumnToFieldMapForScan(modelType reflect.Type, columns []string) map[
 	return columnToField
 }
 
+// scanDestination returns the Scan target for a struct field.
+// Fields implementing sql.Scanner (e.g. custom Date wrappers on DateTime columns)
+// are handed to the driver as the Scanner so the type controls the conversion.
+// json.RawMessage fields keep the column's raw JSON, and struct and map fields hold
+// JSON columns decoded with the JSONSerializer.
+func scanDestination(field reflect.Value) interface{} {
+	if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+		return scanner
+	}
+	if field.Type() == rawMessageType {
+		return rawJSONScanner{field: field}
+	}
+	if isJSONType(field.Type()) {
+		return jsonScanner{field: field}
+	}
+	return field.Addr().Interface()
+}
+
+// scanRowLeniently scans the current row into interface{} values, which no driver rejects,
+// and then stores each value in its destination with the conversions database/sql applies.
+// A value that doesn't fit its field is returned as a RowScanError; other errors come from
+// the driver.
+func scanRowLeniently(rows Rows, fields []interface{}) error {
+	values := make([]interface{}, len(fields))
+	targets := make([]interface{}, len(fields))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return err
+	}
+	for i, field := range fields {
+		if err := assignScanned(field, values[i]); err != nil {
+			return RowScanError{Err: fmt.Errorf("column %d: %w", i, err)}
+		}
+	}
+	return nil
+}
+
+// assignScanned stores a value scanned into interface{} in a Scan destination
+func assignScanned(dest, src interface{}) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+	return assignScannedValue(reflect.ValueOf(dest).Elem(), src)
+}
+
+// assignScannedValue sets target from a driver value: NULL only fits pointers, interfaces,
+// slices and maps, and text and numbers are converted like database/sql does
+func assignScannedValue(target reflect.Value, src interface{}) error {
+	if valuer, ok := src.(sqldriver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return err
+		}
+		src = value
+	}
+	if src == nil {
+		switch target.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		return fmt.Errorf("cannot scan NULL into %s", target.Type())
+	}
+	if target.Kind() == reflect.Ptr {
+		elem := reflect.New(target.Type().Elem())
+		if err := assignScanned(scanDestination(elem.Elem()), src); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	}
+
+	if b, ok := src.([]byte); ok {
+		src = append([]byte(nil), b...)
+	}
+	srcVal := reflect.ValueOf(src)
+	if srcVal.Type().AssignableTo(target.Type()) {
+		target.Set(srcVal)
+		return nil
+	}
+
+	text, ok := scannedText(src)
+	if !ok {
+		return fmt.Errorf("unsupported Scan, storing %T into %s", src, target.Type())
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(text)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %T %q to %s: %w", src, text, target.Type(), err)
+		}
+		target.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %T %q to %s: %w", src, text, target.Type(), err)
+		}
+		target.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %T %q to %s: %w", src, text, target.Type(), err)
+		}
+		target.SetFloat(n)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("converting %T %q to %s: %w", src, text, target.Type(), err)
+		}
+		target.SetBool(b)
+		return nil
+	}
+	return fmt.Errorf("unsupported Scan, storing %T into %s", src, target.Type())
+}
+
+// scannedText formats src as text following database/sql's convertAssign: strings, bytes,
+// times, numbers and booleans. pgx returns UUID columns as [16]byte, which become the
+// canonical 8-4-4-4-12 form. Any other type reports false instead of being stringified
+func scannedText(src interface{}) (string, bool) {
+	switch v := src.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16]), true
+	}
+	value := reflect.ValueOf(src)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), true
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), true
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true
+	}
+	return "", false
+}
+
+// MappedColumns returns the table columns that map to a field of scanType (by db tag,
+// json tag or snake_case name, as when scanning), in table order. Select them to fetch
+// only what a DTO needs. scanType may be a struct or a pointer to struct.
+// Example: q.Select(q.MappedColumns(reflect.TypeOf(UserDTO{}))...).ScanFind(ctx, &dtos, reflect.TypeOf(UserDTO{}))
+func (q *Query) MappedColumns(scanType reflect.Type) []string {
+	if scanType.Kind() == reflect.Ptr {
+		scanType = scanType.Elem()
+	}
+	if scanType.Kind() != reflect.Struct {
+		return nil
+	}
+	value := reflect.New(scanType).Elem()
+	var columns []string
+	for _, col := range q.columns {
+		if findFieldByColumn(value, col).IsValid() {
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
+
 // findFieldByColumn finds a struct field by column name
 // Uses caching to avoid repeated reflection operations
 func findFieldByColumn(modelValue reflect.Value, colName string) reflect.Value {
@@ -1471,7 +3223,10 @@ func findFieldByColumn(modelValue reflect.Value, colName string) reflect.Value {
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
 		jsonTag := field.Tag.Get("json")
-		dbTag := field.Tag.Get("db")
+		column, ok := fieldColumn(field)
+		if !ok {
+			continue
+		}
 
 		// Remove options from json tag (e.g., "id,omitempty" -> "id")
 		if jsonTag != "" {
@@ -1481,7 +3236,7 @@ func findFieldByColumn(modelValue reflect.Value, colName string) reflect.Value {
 		}
 
 		// Verificar tags
-		if dbTag == colName || jsonTag == colName {
+		if column == colName || jsonTag == colName {
 			foundIdx = i
 			break
 		}
@@ -1509,14 +3264,22 @@ func findFieldByColumn(modelValue reflect.Value, colName string) reflect.Value {
 
 // ScanFirst scans a single row into a custom type using tags JSON/DB
 func (q *Query) ScanFirst(ctx context.Context, dest interface{}, scanType reflect.Type) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
+	if q.raw != nil {
+		return q.firstRaw(dest, func(slice interface{}) error { return q.ScanFind(ctx, slice, scanType) })
+	}
+
+	ctx, cancel := q.withTimeout(ctx)
 	defer cancel()
 
+	if err := q.prepareSelect(); err != nil {
+		return err
+	}
+
 	processStart := time.Now()
 	query, args := q.buildSelectQuery(true)
 
 	queryStart := time.Now()
-	row := q.db.QueryRow(ctx, query, args...)
+	row := q.hooked(q.db).QueryRow(ctx, query, args...)
 
 	destVal := reflect.ValueOf(dest)
 	if destVal.Kind() != reflect.Ptr {
@@ -1524,29 +3287,19 @@ func (q *Query) ScanFirst(ctx context.Context, dest interface{}, scanType reflec
 	}
 	destVal = destVal.Elem()
 
-	// Use selectFields if available (when Select() was called), otherwise use all columns
-	columnsToScan := q.columns
-	if len(q.selectFields) > 0 {
-		columnsToScan = q.selectFields
+	columnsToScan, err := q.scanColumns(row)
+	if err != nil {
+		return err
 	}
 
 	// Create instance of scanType
 	customValue := reflect.New(scanType).Elem()
 
-	// Track which fields are json.RawMessage for post-processing
-	jsonRawMessageFields := make(map[int]bool)
 	fields := make([]interface{}, len(columnsToScan))
 	for i, colName := range columnsToScan {
 		field := findFieldByColumn(customValue, colName)
 		if field.IsValid() {
-			// Handle json.RawMessage specially - scan to string first, then convert
-			if field.Type() == reflect.TypeOf(json.RawMessage{}) {
-				var rawMsgStr string
-				fields[i] = &rawMsgStr
-				jsonRawMessageFields[i] = true
-			} else {
-				fields[i] = field.Addr().Interface()
-			}
+			fields[i] = scanDestination(field)
 		} else {
 			var dummy interface{}
 			fields[i] = &dummy
@@ -1564,35 +3317,62 @@ func (q *Query) ScanFirst(ctx context.Context, dest interface{}, scanType reflec
 		return err
 	}
 
-	// Copy scanned values back to customValue, handling json.RawMessage conversion
-	for i, colName := range columnsToScan {
-		field := findFieldByColumn(customValue, colName)
-		if field.IsValid() {
-			if jsonRawMessageFields[i] {
-				// Convert string to json.RawMessage
-				if strPtr, ok := fields[i].(*string); ok && strPtr != nil {
-					field.Set(reflect.ValueOf(json.RawMessage(*strPtr)))
-				}
-			}
-			// For other types, the scan already populated the field directly
-		}
-	}
-
 	destVal.Set(customValue)
 	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
 	return nil
 }
 
+// RowScanError describes a row that could not be scanned in LenientScan mode
+type RowScanError struct {
+	// Row is the zero-based position of the row in the result set
+	Row int
+	Err error
+}
+
+func (e RowScanError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e RowScanError) Unwrap() error {
+	return e.Err
+}
+
+// ScanErrors aggregates the per-row scan errors of a LenientScan query.
+// The destination slice still holds every row that was scanned successfully.
+type ScanErrors struct {
+	Rows []RowScanError
+}
+
+func (e *ScanErrors) Error() string {
+	if len(e.Rows) == 1 {
+		return fmt.Sprintf("1 row failed to scan: %v", e.Rows[0])
+	}
+	return fmt.Sprintf("%d rows failed to scan (first: %v)", len(e.Rows), e.Rows[0])
+}
+
+// Unwrap exposes the row errors to errors.Is / errors.As
+func (e *ScanErrors) Unwrap() []error {
+	errs := make([]error, len(e.Rows))
+	for i, rowErr := range e.Rows {
+		errs[i] = rowErr
+	}
+	return errs
+}
+
 // ScanFind scans multiple rows into a slice of custom types using tags JSON/DB
 func (q *Query) ScanFind(ctx context.Context, dest interface{}, scanType reflect.Type) error {
-	ctx, cancel := contextutil.WithQueryTimeout(ctx)
+	ctx, cancel := q.withTimeout(ctx)
 	defer cancel()
 
+	if err := q.prepareSelect(); err != nil {
+		return err
+	}
+
 	processStart := time.Now()
 	query, args := q.buildSelectQuery(false)
 
 	queryStart := time.Now()
-	rows, err := q.db.Query(ctx, query, args...)
+	rows, err := q.hooked(q.db).Query(ctx, query, args...)
 	queryEnd := time.Now()
 	queryDuration := queryEnd.Sub(queryStart)
 
@@ -1615,63 +3395,66 @@ func (q *Query) ScanFind(ctx context.Context, dest interface{}, scanType reflect
 		return errors.SanitizeError(fmt.Errorf("dest must be a pointer to slice"))
 	}
 
-	// Use selectFields if available (when Select() was called), otherwise use all columns
-	columnsToScan := q.columns
-	if len(q.selectFields) > 0 {
-		columnsToScan = q.selectFields
+	// For []*DTO, scan into DTO values and append their addresses
+	appendPointers := sliceVal.Type().Elem().Kind() == reflect.Ptr
+	if scanType.Kind() == reflect.Ptr {
+		scanType = scanType.Elem()
+	}
+
+	columnsToScan, err := q.scanColumns(rows)
+	if err != nil {
+		return err
 	}
 
 	rowCount := 0
+	rowIndex := -1
+	var scanErrs []RowScanError
 	for rows.Next() {
+		rowIndex++
 		if rowCount >= limits.MaxScanRows {
 			return fmt.Errorf("result set too large: maximum %d rows allowed", limits.MaxScanRows)
 		}
 
 		customValue := reflect.New(scanType).Elem()
 
-		// Track which fields are json.RawMessage for post-processing
-		jsonRawMessageFields := make(map[int]bool)
 		fields := make([]interface{}, len(columnsToScan))
 		for i, colName := range columnsToScan {
 			field := findFieldByColumn(customValue, colName)
 			if field.IsValid() {
-				// Handle json.RawMessage specially - scan to string first, then convert
-				if field.Type() == reflect.TypeOf(json.RawMessage{}) {
-					var rawMsgStr string
-					fields[i] = &rawMsgStr
-					jsonRawMessageFields[i] = true
-				} else {
-					fields[i] = field.Addr().Interface()
-				}
+				fields[i] = scanDestination(field)
 			} else {
 				var dummy interface{}
 				fields[i] = &dummy
 			}
 		}
 
-		if err := rows.Scan(fields...); err != nil {
+		if q.lenientScan {
+			// Scan into interface{} values and convert each field afterwards: pgx closes the
+			// rows when Scan fails, so a bad row must never fail the driver scan
+			if err := scanRowLeniently(rows, fields); err != nil {
+				if rowErr, ok := err.(RowScanError); ok {
+					if logger := q.getLogger(); logger != nil {
+						logger.Error("Scan failed: %v (scanning %d fields: %v)", rowErr.Err, len(columnsToScan), columnsToScan)
+					}
+					rowErr.Row = rowIndex
+					scanErrs = append(scanErrs, rowErr)
+					continue
+				}
+				return err
+			}
+		} else if err := rows.Scan(fields...); err != nil {
 			if logger := q.getLogger(); logger != nil {
 				logger.Error("Scan failed: %v (scanning %d fields: %v)", err, len(columnsToScan), columnsToScan)
 			}
 			return err
 		}
 
-		// Copy scanned values back to customValue, handling json.RawMessage conversion
-		for i, colName := range columnsToScan {
-			field := findFieldByColumn(customValue, colName)
-			if field.IsValid() {
-				if jsonRawMessageFields[i] {
-					// Convert string to json.RawMessage
-					if strPtr, ok := fields[i].(*string); ok && strPtr != nil {
-						field.Set(reflect.ValueOf(json.RawMessage(*strPtr)))
-					}
-				}
-				// For other types, the scan already populated the field directly
-			}
-		}
-
 		rowCount++
-		sliceVal.Set(reflect.Append(sliceVal, customValue))
+		if appendPointers {
+			sliceVal.Set(reflect.Append(sliceVal, customValue.Addr()))
+		} else {
+			sliceVal.Set(reflect.Append(sliceVal, customValue))
+		}
 	}
 
 	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, queryDuration)
@@ -1683,5 +3466,11 @@ func (q *Query) ScanFind(ctx context.Context, dest interface{}, scanType reflect
 		return err
 	}
 
+	q.warnOnRowCount(query, dest)
+
+	if len(scanErrs) > 0 {
+		return &ScanErrors{Rows: scanErrs}
+	}
+
 	return nil
 }
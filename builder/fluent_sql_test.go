@@ -0,0 +1,1707 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+	"github.com/carlosnayan/prisma-go-client/internal/logger"
+)
+
+// newSQLTestQuery cria uma Query sem conexão, apenas para inspecionar o SQL gerado
+func newSQLTestQuery(provider string) *Query {
+	q := NewQuery(nil, "users", []string{"id", "email", "name"})
+	q.SetDialect(dialect.GetDialect(provider))
+	q.SetPrimaryKey("id")
+	return q
+}
+
+// TestQuery_IndexHint tests that the index hint renders in the right position per dialect
+func TestQuery_IndexHint(t *testing.T) {
+	tests := []struct {
+		provider string
+		hint     string
+		expected string
+	}{
+		{"postgresql", "IndexScan(users users_email_idx)", `/*+ IndexScan(users users_email_idx) */ SELECT "id", "email", "name" FROM "users" WHERE email = $1`},
+		{"mysql", "users_email_idx", "SELECT `id`, `email`, `name` FROM `users` FORCE INDEX (`users_email_idx`) WHERE email = ?"},
+		{"sqlite", "users_email_idx", `SELECT "id", "email", "name" FROM "users" INDEXED BY "users_email_idx" WHERE email = ?`},
+		{"cockroachdb", "users_email_idx", `SELECT "id", "email", "name" FROM "users" @{FORCE_INDEX="users_email_idx"} WHERE email = $1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).IndexHint(tt.hint).Where("email = ?", "a@example.com")
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("buildSelectQuery() =\n%s\nwant\n%s", query, tt.expected)
+			}
+			if len(args) != 1 {
+				t.Errorf("expected 1 arg, got %d", len(args))
+			}
+
+			countQuery, _ := q.buildCountQuery()
+			if !strings.Contains(countQuery, "COUNT(*)") {
+				t.Errorf("unexpected count query: %s", countQuery)
+			}
+			switch tt.provider {
+			case "postgresql":
+				if !strings.HasPrefix(countQuery, "/*+ ") {
+					t.Errorf("count query should start with the hint comment: %s", countQuery)
+				}
+			case "cockroachdb":
+				if !strings.Contains(countQuery, `"users" @{FORCE_INDEX=`) {
+					t.Errorf("count query should carry the hint after the table: %s", countQuery)
+				}
+			default:
+				if !strings.Contains(countQuery, `"users" INDEXED BY`) && !strings.Contains(countQuery, "`users` FORCE INDEX") {
+					t.Errorf("count query should carry the hint after the table: %s", countQuery)
+				}
+			}
+		})
+	}
+}
+
+// TestQuery_IndexHint_WithJoin tests that the hint stays on the base table, before the JOINs
+func TestQuery_IndexHint_WithJoin(t *testing.T) {
+	q := newSQLTestQuery("mysql").
+		IndexHint("users_email_idx").
+		Join("INNER", "posts", "posts.user_id = users.id").
+		Where("email = ?", "a@example.com")
+	query, _ := q.buildSelectQuery(false)
+	expected := "SELECT `id`, `email`, `name` FROM `users` FORCE INDEX (`users_email_idx`) INNER JOIN `posts` ON posts.user_id = users.id WHERE email = ?"
+	if query != expected {
+		t.Errorf("buildSelectQuery() =\n%s\nwant\n%s", query, expected)
+	}
+}
+
+// TestQuery_IndexHint_ClearedByReset tests that Reset removes the index hint
+func TestQuery_IndexHint_ClearedByReset(t *testing.T) {
+	q := newSQLTestQuery("mysql").IndexHint("users_email_idx")
+	q.Reset()
+	query, _ := q.buildSelectQuery(false)
+	if strings.Contains(query, "FORCE INDEX") {
+		t.Errorf("index hint should be cleared by Reset: %s", query)
+	}
+}
+
+// TestQuery_Lock tests the row locking clause per dialect, after the LIMIT
+func TestQuery_Lock(t *testing.T) {
+	tests := []struct {
+		provider string
+		lock     func(q *Query) *Query
+		expected string
+	}{
+		{"postgresql", (*Query).ForUpdate, `SELECT "id", "email", "name" FROM "users" WHERE email = $1 LIMIT $2 FOR UPDATE`},
+		{"postgresql", func(q *Query) *Query { return q.ForUpdate().SkipLocked() }, `SELECT "id", "email", "name" FROM "users" WHERE email = $1 LIMIT $2 FOR UPDATE SKIP LOCKED`},
+		{"postgresql", func(q *Query) *Query { return q.ForShare().NoWait() }, `SELECT "id", "email", "name" FROM "users" WHERE email = $1 LIMIT $2 FOR SHARE NOWAIT`},
+		{"cockroachdb", (*Query).ForUpdate, `SELECT "id", "email", "name" FROM "users" WHERE email = $1 LIMIT $2 FOR UPDATE`},
+		{"mysql", (*Query).ForUpdate, "SELECT `id`, `email`, `name` FROM `users` WHERE email = ? LIMIT ? FOR UPDATE"},
+		{"mysql", (*Query).ForShare, "SELECT `id`, `email`, `name` FROM `users` WHERE email = ? LIMIT ? LOCK IN SHARE MODE"},
+		// LOCK IN SHARE MODE não aceita modificadores, então usa FOR SHARE
+		{"mysql", func(q *Query) *Query { return q.ForShare().SkipLocked() }, "SELECT `id`, `email`, `name` FROM `users` WHERE email = ? LIMIT ? FOR SHARE SKIP LOCKED"},
+	}
+
+	for _, tt := range tests {
+		q := tt.lock(newSQLTestQuery(tt.provider).Where("email = ?", "a@example.com").Take(10))
+		if err := q.prepareSelect(); err != nil {
+			t.Fatalf("%s: prepareSelect failed: %v", tt.provider, err)
+		}
+		query, _ := q.buildSelectQuery(false)
+		if query != tt.expected {
+			t.Errorf("%s: buildSelectQuery() =\n%s\nwant\n%s", tt.provider, query, tt.expected)
+		}
+	}
+}
+
+// TestQuery_Lock_Invalid tests that locks fail outside plain SELECTs and on databases without row locks
+func TestQuery_Lock_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	for _, provider := range []string{"sqlite", "sqlserver"} {
+		if err := newSQLTestQuery(provider).ForUpdate().prepareSelect(); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: ForUpdate error = %v, want ErrInvalidInput", provider, err)
+		}
+	}
+	if err := newSQLTestQuery("postgresql").SkipLocked().prepareSelect(); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("SkipLocked without a lock error = %v, want ErrInvalidInput", err)
+	}
+
+	// As verificações acontecem antes de usar a conexão (nil aqui)
+	locked := newSQLTestQuery("postgresql").Where("id = ?", 1).ForUpdate()
+	if err := locked.Update(ctx, "email", "b@example.com"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Update error = %v, want ErrInvalidInput", err)
+	}
+	if _, err := locked.Count(ctx); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Count error = %v, want ErrInvalidInput", err)
+	}
+	if _, err := locked.Exists(ctx); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Exists error = %v, want ErrInvalidInput", err)
+	}
+
+	locked.Reset()
+	if query, _ := locked.buildSelectQuery(false); strings.Contains(query, "FOR UPDATE") {
+		t.Errorf("lock should be cleared by Reset: %s", query)
+	}
+}
+
+// TestQuery_Cascade_BuildsDeletesInDependencyOrder tests that dependents are deleted before their parents
+func TestQuery_Cascade_BuildsDeletesInDependencyOrder(t *testing.T) {
+	q := newSQLTestQuery("postgresql").Where("email = ?", "a@example.com").Cascade(CascadeRelation{
+		Table:             "posts",
+		Columns:           []string{"user_id"},
+		ReferencedColumns: []string{"id"},
+		Children: []CascadeRelation{{
+			Table:             "comments",
+			Columns:           []string{"post_id"},
+			ReferencedColumns: []string{"id"},
+		}, {
+			Table:             "likes",
+			Columns:           []string{"post_id"},
+			ReferencedColumns: []string{"id"},
+			OnDelete:          "SetNull",
+		}},
+	}, CascadeRelation{
+		Table:             "invoices",
+		Columns:           []string{"user_id"},
+		ReferencedColumns: []string{"id"},
+		OnDelete:          "Restrict",
+	})
+
+	plan, args, err := q.buildCascadeDeleteQueries()
+	if err != nil {
+		t.Fatalf("buildCascadeDeleteQueries failed: %v", err)
+	}
+	if len(plan.checks) != 1 || plan.checks[0].table != "invoices" ||
+		plan.checks[0].query != `SELECT COUNT(*) FROM "invoices" WHERE "user_id" IN (SELECT "id" FROM "users" WHERE email = $1)` {
+		t.Errorf("unexpected Restrict checks: %+v", plan.checks)
+	}
+	queries := plan.statements
+	expected := []string{
+		`DELETE FROM "comments" WHERE "post_id" IN (SELECT "id" FROM "posts" WHERE "user_id" IN (SELECT "id" FROM "users" WHERE email = $1))`,
+		`UPDATE "likes" SET "post_id" = NULL WHERE "post_id" IN (SELECT "id" FROM "posts" WHERE "user_id" IN (SELECT "id" FROM "users" WHERE email = $1))`,
+		`DELETE FROM "posts" WHERE "user_id" IN (SELECT "id" FROM "users" WHERE email = $1)`,
+		`DELETE FROM "users" WHERE email = $1`,
+	}
+	if len(queries) != len(expected) {
+		t.Fatalf("expected %d queries, got %d: %v", len(expected), len(queries), queries)
+	}
+	for i := range expected {
+		if queries[i] != expected[i] {
+			t.Errorf("query %d =\n%s\nwant\n%s", i, queries[i], expected[i])
+		}
+	}
+	if len(args) != 1 {
+		t.Errorf("expected 1 arg, got %d", len(args))
+	}
+
+	q.Reset()
+	if len(q.cascade) != 0 {
+		t.Error("cascade relations should be cleared by Reset")
+	}
+
+	// SQL Server não aceita (a, b) IN (SELECT ...)
+	composite := CascadeRelation{Table: "items", Columns: []string{"order_id", "shop_id"}, ReferencedColumns: []string{"id", "shop_id"}}
+	if _, _, err := newSQLTestQuery("sqlserver").Cascade(composite).buildCascadeDeleteQueries(); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("composite foreign key on sqlserver error = %v, want ErrInvalidInput", err)
+	}
+	if _, _, err := newSQLTestQuery("mysql").Cascade(composite).buildCascadeDeleteQueries(); err != nil {
+		t.Errorf("composite foreign key on mysql failed: %v", err)
+	}
+}
+
+// TestQuery_JsonArrayLength tests the JSON array length filter per dialect
+func TestQuery_JsonArrayLength(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users" WHERE (CASE WHEN jsonb_typeof("tags") = 'array' THEN jsonb_array_length("tags") END) > $1`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users` WHERE JSON_LENGTH(`tags`) > ?"},
+		{"sqlite", `SELECT "id", "email", "name" FROM "users" WHERE json_array_length("tags") > ?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where(Where{"tags": JsonArrayLength(">", 3)})
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("buildSelectQuery() =\n%s\nwant\n%s", query, tt.expected)
+			}
+			if len(args) != 1 || args[0] != 3 {
+				t.Errorf("expected args [3], got %v", args)
+			}
+		})
+	}
+}
+
+// TestQuery_JsonArrayElement tests the JSON array element filter per dialect
+func TestQuery_JsonArrayElement(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `("tags"->>1) = $1`},
+		{"mysql", "JSON_UNQUOTE(JSON_EXTRACT(`tags`, '$[1]')) = ?"},
+		{"sqlite", `CAST(json_extract("tags", '$[1]') AS TEXT) = ?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where(Where{"tags": JsonArrayElement(1, 42)})
+			query, args := q.buildSelectQuery(false)
+			if !strings.HasSuffix(query, "WHERE "+tt.expected) {
+				t.Errorf("unexpected query: %s", query)
+			}
+			if len(args) != 1 || args[0] != "42" {
+				t.Errorf("expected args [\"42\"], got %v", args)
+			}
+		})
+	}
+}
+
+// TestQuery_JsonPath tests the JSON path filter per dialect
+func TestQuery_JsonPath(t *testing.T) {
+	tests := []struct {
+		provider string
+		path     []string
+		op       string
+		expected string
+	}{
+		{"postgresql", []string{"city"}, "=", `("meta"->>'city') = $1`},
+		{"postgresql", []string{"address", "it's"}, "!=", `("meta" #>> '{"address","it''s"}') != $1`},
+		{"postgresql", []string{"tags", "0"}, "LIKE", `("meta" #>> '{"tags","0"}') LIKE $1 ESCAPE '\'`},
+		{"mysql", []string{"address", "city"}, "=", "JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.\"address\".\"city\"')) = ?"},
+		{"mysql", []string{"tags", "0"}, "=", "JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.\"tags\"[0]')) = ?"},
+		{"sqlite", []string{"address", `a"b`}, "=", `CAST(json_extract("meta", '$."address"."a\"b"') AS TEXT) = ?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where(Where{"meta": JsonPath(tt.path, tt.op, 42)})
+			query, args := q.buildSelectQuery(false)
+			if !strings.HasSuffix(query, "WHERE "+tt.expected) {
+				t.Errorf("unexpected query: %s", query)
+			}
+			if len(args) != 1 || args[0] != "42" {
+				t.Errorf("expected args [\"42\"], got %v", args)
+			}
+		})
+	}
+}
+
+// TestQuery_JsonPathContains tests that JsonPathContains escapes %, _ and \ with an ESCAPE clause
+func TestQuery_JsonPathContains(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `("meta"->>'city') LIKE $1 ESCAPE '\'`},
+		{"mysql", "JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.\"city\"')) LIKE ? ESCAPE '\\\\'"},
+		{"sqlite", `CAST(json_extract("meta", '$."city"') AS TEXT) LIKE ? ESCAPE '\'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where(Where{"meta": JsonPathContains([]string{"city"}, `50%_a\b`)})
+			query, args := q.buildSelectQuery(false)
+			if !strings.HasSuffix(query, "WHERE "+tt.expected) {
+				t.Errorf("unexpected query: %s", query)
+			}
+			if len(args) != 1 || args[0] != `%50\%\_a\\b%` {
+				t.Errorf("expected escaped pattern, got %v", args)
+			}
+		})
+	}
+}
+
+// TestQuery_JsonArrayFilters_Invalid tests that invalid JSON array filters match no rows
+func TestQuery_JsonArrayFilters_Invalid(t *testing.T) {
+	for _, op := range []WhereOperator{
+		JsonArrayLength("; DROP TABLE users", 1),
+		JsonArrayElement(-1, "x"),
+		JsonPath(nil, "=", "x"),
+		JsonPath([]string{"a?"}, "=", "x"),
+		JsonPath([]string{"a"}, "; DROP TABLE users", "x"),
+	} {
+		q := newSQLTestQuery("sqlite").Where(Where{"tags": op})
+		query, _ := q.buildSelectQuery(false)
+		if !strings.HasSuffix(query, "WHERE 1 = 0") || strings.Contains(query, "DROP") {
+			t.Errorf("invalid filter should match no rows: %s", query)
+		}
+	}
+}
+
+// TestQuery_SelectExcept tests that excluded columns are absent from the generated SELECT
+func TestQuery_SelectExcept(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "name" FROM "users"`},
+		{"mysql", "SELECT `id`, `name` FROM `users`"},
+		{"sqlite", `SELECT "id", "name" FROM "users"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Select("email").SelectExcept("email")
+			query, _ := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("buildSelectQuery() = %s, want %s", query, tt.expected)
+			}
+		})
+	}
+}
+
+// TestQuery_Distinct tests SELECT DISTINCT and DISTINCT ON per dialect
+func TestQuery_Distinct(t *testing.T) {
+	tests := []struct {
+		provider   string
+		distinct   string
+		distinctOn string
+	}{
+		{"postgresql", `SELECT DISTINCT "id", "email", "name" FROM "users"`, `SELECT DISTINCT ON ("email") "id", "email", "name" FROM "users" ORDER BY "email" ASC`},
+		{"mysql", "SELECT DISTINCT `id`, `email`, `name` FROM `users`", ""},
+		{"sqlite", `SELECT DISTINCT "id", "email", "name" FROM "users"`, `SELECT "id", "email", "name" FROM "users" GROUP BY "email" ORDER BY "email" ASC`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			query, _ := newSQLTestQuery(tt.provider).Distinct().buildSelectQuery(false)
+			if query != tt.distinct {
+				t.Errorf("Distinct() = %s, want %s", query, tt.distinct)
+			}
+
+			q := newSQLTestQuery(tt.provider).Distinct("email").Order("email ASC")
+			err := q.prepareSelect()
+			if tt.distinctOn == "" {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("expected ErrInvalidInput for unsupported DISTINCT ON, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("prepareSelect failed: %v", err)
+			}
+			if query, _ := q.buildSelectQuery(false); query != tt.distinctOn {
+				t.Errorf("Distinct(\"email\") = %s, want %s", query, tt.distinctOn)
+			}
+		})
+	}
+}
+
+// TestQuery_AggregateQuery tests that aggregates quote the column and reuse the WHERE state
+func TestQuery_AggregateQuery(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT SUM("total") FROM "users" WHERE email = $1`},
+		{"mysql", "SELECT SUM(`total`) FROM `users` WHERE email = ?"},
+		{"sqlite", `SELECT SUM("total") FROM "users" WHERE email = ?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where("email = ?", "a@example.com")
+			query, args, err := q.buildAggregateQuery("total", "sum")
+			if err != nil {
+				t.Fatalf("buildAggregateQuery failed: %v", err)
+			}
+			if query != tt.expected {
+				t.Errorf("buildAggregateQuery() = %s, want %s", query, tt.expected)
+			}
+			if len(args) != 1 {
+				t.Errorf("expected 1 arg, got %v", args)
+			}
+		})
+	}
+
+	if _, _, err := newSQLTestQuery("sqlite").buildAggregateQuery("total", "median"); err == nil {
+		t.Error("expected an error for an unsupported aggregate")
+	}
+}
+
+// TestQuery_GroupAggregateQuery tests the SELECT of grouped columns and aliased aggregates
+func TestQuery_GroupAggregateQuery(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "name", COUNT(*) AS "_count", SUM("id") AS "_sum_id" FROM "users" WHERE email <> $1 GROUP BY "name" HAVING COUNT(*) > $2`},
+		{"mysql", "SELECT `name`, COUNT(*) AS `_count`, SUM(`id`) AS `_sum_id` FROM `users` WHERE email <> ? GROUP BY `name` HAVING COUNT(*) > ?"},
+		{"sqlite", `SELECT "name", COUNT(*) AS "_count", SUM("id") AS "_sum_id" FROM "users" WHERE email <> ? GROUP BY "name" HAVING COUNT(*) > ?`},
+	}
+
+	aggregates := []GroupAggregation{{Func: "count"}, {Func: "SUM", Field: "id"}}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where("email <> ?", "").Having("COUNT(*) > ?", 1)
+			query, args, err := q.buildGroupAggregateQuery([]string{"name"}, aggregates)
+			if err != nil {
+				t.Fatalf("buildGroupAggregateQuery failed: %v", err)
+			}
+			if query != tt.expected {
+				t.Errorf("buildGroupAggregateQuery() = %s, want %s", query, tt.expected)
+			}
+			if len(args) != 2 {
+				t.Errorf("expected 2 args, got %v", args)
+			}
+		})
+	}
+
+	if _, _, err := newSQLTestQuery("sqlite").buildGroupAggregateQuery(nil, aggregates); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without fields, got %v", err)
+	}
+	if _, _, err := newSQLTestQuery("sqlite").buildGroupAggregateQuery([]string{"name"}, []GroupAggregation{{Func: "SUM"}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for SUM without field, got %v", err)
+	}
+}
+
+// TestAggregateFloat tests the conversion of driver aggregate results, with NULL as 0
+func TestAggregateFloat(t *testing.T) {
+	tests := []struct {
+		result   interface{}
+		expected float64
+	}{
+		{nil, 0},
+		{int64(42), 42},
+		{float64(2.5), 2.5},
+		{[]byte("10.75"), 10.75},
+		{"3.5", 3.5},
+	}
+
+	for _, tt := range tests {
+		got, err := aggregateFloat(tt.result, "SUM")
+		if err != nil {
+			t.Errorf("aggregateFloat(%#v) failed: %v", tt.result, err)
+		}
+		if got != tt.expected {
+			t.Errorf("aggregateFloat(%#v) = %v, want %v", tt.result, got, tt.expected)
+		}
+	}
+
+	if _, err := aggregateFloat(struct{}{}, "SUM"); err == nil {
+		t.Error("expected an error for an unexpected result type")
+	}
+}
+
+// TestQuery_WhereNilArg tests that a nil arg is bound as a single placeholder instead of panicking
+func TestQuery_WhereNilArg(t *testing.T) {
+	var name *string
+	query, args := newSQLTestQuery("postgresql").Where("email = ?", nil).Where("name = ? OR id IN ?", name, []int{1, 2}).buildSelectQuery(false)
+	if query != `SELECT "id", "email", "name" FROM "users" WHERE email = $1 AND name = $2 OR id IN ($3, $4)` {
+		t.Errorf("query = %s", query)
+	}
+	if len(args) != 4 || args[0] != nil || args[1] != name {
+		t.Errorf("args = %v", args)
+	}
+}
+
+// TestQuery_WhereNot tests that Not wraps all of its conditions in NOT (...), including nested groups
+func TestQuery_WhereNot(t *testing.T) {
+	tests := []struct {
+		provider string
+		not      string
+		nested   string
+		or       string
+	}{
+		{
+			"postgresql",
+			`SELECT "id", "email", "name" FROM "users" WHERE email <> $1 AND NOT ("name" = $2)`,
+			`SELECT "id", "email", "name" FROM "users" WHERE NOT (NOT ("name" IS NULL))`,
+			`SELECT "id", "email", "name" FROM "users" WHERE email = $1 OR (NOT ("name" IN ($2, $3)))`,
+		},
+		{
+			"mysql",
+			"SELECT `id`, `email`, `name` FROM `users` WHERE email <> ? AND NOT (`name` = ?)",
+			"SELECT `id`, `email`, `name` FROM `users` WHERE NOT (NOT (`name` IS NULL))",
+			"SELECT `id`, `email`, `name` FROM `users` WHERE email = ? OR (NOT (`name` IN (?, ?)))",
+		},
+		{
+			"sqlite",
+			`SELECT "id", "email", "name" FROM "users" WHERE email <> ? AND NOT ("name" = ?)`,
+			`SELECT "id", "email", "name" FROM "users" WHERE NOT (NOT ("name" IS NULL))`,
+			`SELECT "id", "email", "name" FROM "users" WHERE email = ? OR (NOT ("name" IN (?, ?)))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where("email <> ?", "").Where(Where{"$not": Not(Where{"name": "bob"})})
+			query, args := q.buildSelectQuery(false)
+			if query != tt.not {
+				t.Errorf("Not = %s, want %s", query, tt.not)
+			}
+			if len(args) != 2 || args[1] != "bob" {
+				t.Errorf("unexpected args %v", args)
+			}
+
+			q = newSQLTestQuery(tt.provider).Where(Where{"$not": Not(Where{"$not": Not(Where{"name": nil})})})
+			if query, _ := q.buildSelectQuery(false); query != tt.nested {
+				t.Errorf("nested Not = %s, want %s", query, tt.nested)
+			}
+
+			q = newSQLTestQuery(tt.provider).Where("email = ?", "a@example.com").Or(Where{"$not": Not(Where{"name": In("a", "b")})})
+			query, args = q.buildSelectQuery(false)
+			if query != tt.or {
+				t.Errorf("Or(Not) = %s, want %s", query, tt.or)
+			}
+			if len(args) != 3 {
+				t.Errorf("unexpected args %v", args)
+			}
+		})
+	}
+
+	// A Not without conditions adds nothing
+	q := newSQLTestQuery("sqlite").Where(Where{"$not": Not(Where{})})
+	if query, _ := q.buildSelectQuery(false); query != `SELECT "id", "email", "name" FROM "users"` {
+		t.Errorf("empty Not = %s", query)
+	}
+}
+
+func TestQuery_WhereGroup(t *testing.T) {
+	tests := []struct {
+		provider string
+		group    string
+		or       string
+	}{
+		{
+			"postgresql",
+			`SELECT "id", "email", "name" FROM "users" WHERE id > $1 AND (name = $2 OR email = $3)`,
+			`SELECT "id", "email", "name" FROM "users" WHERE id > $1 AND (((("email" = $2) AND ("name" = $3))) OR ("name" IS NULL))`,
+		},
+		{
+			"mysql",
+			"SELECT `id`, `email`, `name` FROM `users` WHERE id > ? AND (name = ? OR email = ?)",
+			"SELECT `id`, `email`, `name` FROM `users` WHERE id > ? AND ((((`email` = ?) AND (`name` = ?))) OR (`name` IS NULL))",
+		},
+		{
+			"sqlite",
+			`SELECT "id", "email", "name" FROM "users" WHERE id > ? AND (name = ? OR email = ?)`,
+			`SELECT "id", "email", "name" FROM "users" WHERE id > ? AND (((("email" = ?) AND ("name" = ?))) OR ("name" IS NULL))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			// Condições AND no nível superior combinadas com um bloco OR
+			q := newSQLTestQuery(tt.provider).Where("id > ?", 1).WhereGroup(func(g *Query) {
+				g.Where("name = ?", "bob").Or("email = ?", "bob@example.com")
+			})
+			query, args := q.buildSelectQuery(false)
+			if query != tt.group {
+				t.Errorf("WhereGroup = %s, want %s", query, tt.group)
+			}
+			if len(args) != 3 || args[1] != "bob" {
+				t.Errorf("unexpected args %v", args)
+			}
+
+			// Elemento do OR com vários campos: (email AND name) OR (name IS NULL)
+			q = newSQLTestQuery(tt.provider).Where("id > ?", 1).Where(Where{"$or": Or(
+				Where{"$and": And(Where{"email": "a@example.com"}, Where{"name": "a"})},
+				Where{"name": nil},
+			)})
+			query, args = q.buildSelectQuery(false)
+			if query != tt.or {
+				t.Errorf("Or = %s, want %s", query, tt.or)
+			}
+			if len(args) != 3 || args[1] != "a@example.com" || args[2] != "a" {
+				t.Errorf("unexpected args %v", args)
+			}
+		})
+	}
+
+	// Um grupo sem condições não adiciona nada
+	q := newSQLTestQuery("sqlite").WhereGroup(func(g *Query) {})
+	if query, _ := q.buildSelectQuery(false); query != `SELECT "id", "email", "name" FROM "users"` {
+		t.Errorf("empty group = %s", query)
+	}
+}
+
+// TestQuery_SQLServerPagination tests that SQL Server gets OFFSET/FETCH with the ORDER BY it
+// requires, and CASE WHEN EXISTS for Exists
+func TestQuery_SQLServerPagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []interface{})
+		expected string
+	}{
+		{"first", func() (string, []interface{}) {
+			return newSQLTestQuery("sqlserver").Where("email = ?", "a").buildSelectQuery(true)
+		}, "SELECT [id], [email], [name] FROM [users] WHERE email = @p1 ORDER BY (SELECT NULL) OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY"},
+		{"ordered page", func() (string, []interface{}) {
+			return newSQLTestQuery("sqlserver").Order("id DESC").Take(10).Skip(20).buildSelectQuery(false)
+		}, "SELECT [id], [email], [name] FROM [users] ORDER BY [id] DESC OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY"},
+		{"unordered skip", func() (string, []interface{}) {
+			return newSQLTestQuery("sqlserver").Skip(5).buildSelectQuery(false)
+		}, "SELECT [id], [email], [name] FROM [users] ORDER BY (SELECT NULL) OFFSET @p1 ROWS"},
+		{"exists", func() (string, []interface{}) {
+			return newSQLTestQuery("sqlserver").Where("email = ?", "a").buildExistsQuery()
+		}, "SELECT CASE WHEN EXISTS(SELECT 1 FROM [users] WHERE email = @p1) THEN 1 ELSE 0 END"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if query, _ := tt.query(); query != tt.expected {
+				t.Errorf("query =\n%s\nwant\n%s", query, tt.expected)
+			}
+		})
+	}
+
+	// TableQueryBuilder.FindFirst também precisa do ORDER BY
+	b := NewTableQueryBuilder(nil, "users", []string{"id"})
+	b.SetDialect(dialect.GetDialect("sqlserver"))
+	if query, _ := b.buildQuery(nil, nil, true); query != "SELECT [id] FROM [users] ORDER BY (SELECT NULL) OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY" {
+		t.Errorf("TableQueryBuilder single = %s", query)
+	}
+
+	// LIMIT/OFFSET continuam a numeração depois do WHERE
+	b.SetDialect(dialect.GetDialect("postgresql"))
+	query, args := b.buildQuery(Where{"email": "a"}, &QueryOptions{Take: Ptr(10), Skip: Ptr(20)}, false)
+	if query != `SELECT "id" FROM "users" WHERE "email" = $1 LIMIT $2 OFFSET $3` || !reflect.DeepEqual(args, []interface{}{"a", 10, 20}) {
+		t.Errorf("TableQueryBuilder page = %s %v", query, args)
+	}
+}
+
+// TestQuery_PluckQuery tests that Pluck selects only the given column and keeps the query state
+func TestQuery_PluckQuery(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+		args     []interface{}
+	}{
+		{"postgresql", `SELECT "email" FROM "users" WHERE name = $1 ORDER BY "id" DESC LIMIT $2 OFFSET $3`, []interface{}{"bob", 10, 20}},
+		{"mysql", "SELECT `email` FROM `users` WHERE name = ? ORDER BY `id` DESC LIMIT ?, ?", []interface{}{"bob", 20, 10}},
+		{"sqlite", `SELECT "email" FROM "users" WHERE name = ? ORDER BY "id" DESC LIMIT ? OFFSET ?`, []interface{}{"bob", 10, 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Select("id", "name").Where("name = ?", "bob").Order("id DESC").Take(10).Skip(20)
+			query, args := q.buildPluckQuery("email")
+			if query != tt.expected {
+				t.Errorf("Pluck query = %s, want %s", query, tt.expected)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("unexpected args %v", args)
+			}
+			// A query original mantém o Select
+			if len(q.selectFields) != 2 {
+				t.Errorf("Pluck should not change the query's Select, got %v", q.selectFields)
+			}
+		})
+	}
+}
+
+// TestQuery_OrderByValuesQuery tests the CASE ordering and its placeholders after the WHERE args
+func TestQuery_OrderByValuesQuery(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users" WHERE name = $1 ORDER BY CASE "id" WHEN $2 THEN 0 WHEN $3 THEN 1 WHEN $4 THEN 2 ELSE 3 END ASC, "email" ASC`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users` WHERE name = ? ORDER BY CASE `id` WHEN ? THEN 0 WHEN ? THEN 1 WHEN ? THEN 2 ELSE 3 END ASC, `email` ASC"},
+		{"sqlite", `SELECT "id", "email", "name" FROM "users" WHERE name = ? ORDER BY CASE "id" WHEN ? THEN 0 WHEN ? THEN 1 WHEN ? THEN 2 ELSE 3 END ASC, "email" ASC`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where("name = ?", "bob").OrderByValues("id", []interface{}{3, 1, 2}).Order("email")
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("OrderByValues = %s, want %s", query, tt.expected)
+			}
+			if len(args) != 4 || args[0] != "bob" || args[1] != 3 || args[3] != 2 {
+				t.Errorf("unexpected args %v", args)
+			}
+		})
+	}
+
+	// Uma lista vazia não adiciona ordenação
+	q := newSQLTestQuery("sqlite").OrderByValues("id", nil)
+	if query, _ := q.buildSelectQuery(false); query != `SELECT "id", "email", "name" FROM "users"` {
+		t.Errorf("empty OrderByValues = %s", query)
+	}
+}
+
+// TestQuery_OrderInsensitive tests that the column is quoted inside LOWER and the direction normalized
+func TestQuery_OrderInsensitive(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users" ORDER BY LOWER("name") DESC, LOWER("email") ASC, "id" ASC`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users` ORDER BY LOWER(`name`) DESC, LOWER(`email`) ASC, `id` ASC"},
+		{"sqlite", `SELECT "id", "email", "name" FROM "users" ORDER BY LOWER("name") DESC, LOWER("email") ASC, "id" ASC`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			// Direção inválida vira ASC
+			q := newSQLTestQuery(tt.provider).OrderInsensitive("name", "desc").OrderInsensitive("email", "sideways; DROP").Order("id")
+			if query, _ := q.buildSelectQuery(false); query != tt.expected {
+				t.Errorf("OrderInsensitive = %s, want %s", query, tt.expected)
+			}
+		})
+	}
+
+	// O cursor não usa uma ordenação case-insensitive
+	q := newSQLTestQuery("sqlite").OrderInsensitive("name", "ASC").Cursor("name", "bob")
+	if err := q.prepareSelect(); err == nil {
+		t.Error("cursor on an OrderInsensitive column should require a plain Order")
+	}
+}
+
+// TestQuery_Collate tests COLLATE on ORDER BY and on equality conditions
+func TestQuery_Collate(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users" WHERE "name" COLLATE "und-x-icu" = $1 AND "email" COLLATE "und-x-icu" IN ($2, $3) ORDER BY "name" COLLATE "und-x-icu" ASC, "id" ASC`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users` WHERE `name` COLLATE utf8mb4_bin = ? AND `email` COLLATE utf8mb4_bin IN (?, ?) ORDER BY `name` COLLATE utf8mb4_bin ASC, `id` ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			collation := "und-x-icu"
+			if tt.provider == "mysql" {
+				collation = "utf8mb4_bin"
+			}
+			q := newSQLTestQuery(tt.provider).
+				Where(Where{"name": Equals("José").Collate(collation)}).
+				Where(Where{"email": In("a@x.com", "b@x.com").Collate(collation)}).
+				Order("name ASC").Collate(collation).Order("id")
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("buildSelectQuery() =\n%s\nwant\n%s", query, tt.expected)
+			}
+			if len(args) != 3 {
+				t.Errorf("args = %v, want 3 values", args)
+			}
+		})
+	}
+
+	// Nome inválido não filtra nada no WHERE e é ignorado no ORDER BY
+	q := newSQLTestQuery("mysql").
+		Where(Where{"name": Equals("x").Collate("utf8mb4_bin; DROP")}).
+		Order("name").Collate("bad name")
+	expected := "SELECT `id`, `email`, `name` FROM `users` WHERE 1 = 0 ORDER BY `name` ASC"
+	if query, _ := q.buildSelectQuery(false); query != expected {
+		t.Errorf("invalid collation = %s, want %s", query, expected)
+	}
+
+	// Collate sem Order não faz nada
+	if query, _ := newSQLTestQuery("sqlite").Collate("NOCASE").buildSelectQuery(false); query != `SELECT "id", "email", "name" FROM "users"` {
+		t.Errorf("Collate without Order = %s", query)
+	}
+}
+
+// TestQuery_WhereTupleIn tests the tuple IN syntax and the OR-of-ANDs fallback, keeping
+// placeholder numbering in order with the other WHERE conditions
+func TestQuery_WhereTupleIn(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users" WHERE id > $1 AND ("email", "name") IN (($2, $3), ($4, $5)) AND name <> $6`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users` WHERE id > ? AND ((`email` = ? AND `name` = ?) OR (`email` = ? AND `name` = ?)) AND name <> ?"},
+		{"sqlite", `SELECT "id", "email", "name" FROM "users" WHERE id > ? AND ("email", "name") IN ((?, ?), (?, ?)) AND name <> ?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).
+				Where("id > ?", 0).
+				WhereTupleIn([]string{"email", "name"}, [][]interface{}{{"a@example.com", "a"}, {"b@example.com", "b"}}).
+				Where("name <> ?", "")
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("WhereTupleIn = %s, want %s", query, tt.expected)
+			}
+			if len(args) != 6 || args[1] != "a@example.com" || args[4] != "b" || args[5] != "" {
+				t.Errorf("unexpected args %v", args)
+			}
+		})
+	}
+
+	// Sem linhas ou com uma linha incompleta a condição não casa nada
+	for _, rows := range [][][]interface{}{nil, {{"a@example.com"}}} {
+		q := newSQLTestQuery("sqlite").WhereTupleIn([]string{"email", "name"}, rows)
+		if query, _ := q.buildSelectQuery(false); query != `SELECT "id", "email", "name" FROM "users" WHERE 1 = 0` {
+			t.Errorf("invalid WhereTupleIn = %s", query)
+		}
+	}
+}
+
+// TestQuery_WhereBetween tests that Between renders an inclusive range with two
+// placeholders, numbered in order with the other WHERE conditions
+func TestQuery_WhereBetween(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users" WHERE email <> $1 AND "id" BETWEEN $2 AND $3 AND name <> $4`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users` WHERE email <> ? AND `id` BETWEEN ? AND ? AND name <> ?"},
+		{"sqlite", `SELECT "id", "email", "name" FROM "users" WHERE email <> ? AND "id" BETWEEN ? AND ? AND name <> ?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where("email <> ?", "").Where(Where{"id": Between(10, 20)}).Where("name <> ?", "bob")
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("Between = %s, want %s", query, tt.expected)
+			}
+			if !reflect.DeepEqual(args, []interface{}{"", 10, 20, "bob"}) {
+				t.Errorf("Between args = %v", args)
+			}
+		})
+	}
+
+	// Os mapas de UpdateMany/DeleteMany (TableQueryBuilder) também usam dois placeholders
+	argIndex := 2
+	clause, args := NewTableQueryBuilder(nil, "users", []string{"id"}).buildWhereFromMap(Where{"id": Between(10, 20)}, &argIndex)
+	if clause != `"id" BETWEEN $2 AND $3` || argIndex != 4 || !reflect.DeepEqual(args, []interface{}{10, 20}) {
+		t.Errorf("TableQueryBuilder Between = %s %v (next %d)", clause, args, argIndex)
+	}
+}
+
+// TestQuery_ExistsQuery tests that Exists wraps the filtered query in SELECT EXISTS with
+// LIMIT 1, ignoring ordering and pagination
+func TestQuery_ExistsQuery(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT EXISTS(SELECT 1 FROM "users" WHERE email = $1 LIMIT $2)`},
+		{"mysql", "SELECT EXISTS(SELECT 1 FROM `users` WHERE email = ? LIMIT ?)"},
+		{"sqlite", `SELECT EXISTS(SELECT 1 FROM "users" WHERE email = ? LIMIT ?)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where("email = ?", "a@example.com").Order("name").Skip(20).Take(10)
+			query, args := q.buildExistsQuery()
+			if query != tt.expected {
+				t.Errorf("buildExistsQuery() = %s, want %s", query, tt.expected)
+			}
+			if !reflect.DeepEqual(args, []interface{}{"a@example.com", 1}) {
+				t.Errorf("buildExistsQuery() args = %v", args)
+			}
+		})
+	}
+
+	// Exists roda uma única query pela conexão
+	db := &routingDB{}
+	q := NewQuery(db, "users", []string{"id"})
+	q.SetDialect(dialect.GetDialect("postgresql"))
+	if _, err := q.Exists(context.Background()); err == nil {
+		t.Error("Exists should return the scan error")
+	}
+	if len(db.log) != 1 || db.log[0] != `SELECT EXISTS(SELECT 1 FROM "users" LIMIT $1)` {
+		t.Errorf("Exists ran %v", db.log)
+	}
+}
+
+// TestQuery_CountDistinct tests that CountDistinct quotes the column and keeps joins and conditions
+func TestQuery_CountDistinct(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT COUNT(DISTINCT "customer_id") FROM "orders" INNER JOIN "items" ON items.order_id = orders.id WHERE status = $1`},
+		{"mysql", "SELECT COUNT(DISTINCT `customer_id`) FROM `orders` INNER JOIN `items` ON items.order_id = orders.id WHERE status = ?"},
+		{"sqlite", `SELECT COUNT(DISTINCT "customer_id") FROM "orders" INNER JOIN "items" ON items.order_id = orders.id WHERE status = ?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			db := &routingDB{}
+			q := NewQuery(db, "orders", []string{"id"})
+			q.SetDialect(dialect.GetDialect(tt.provider))
+			q.Join("INNER", "items", "items.order_id = orders.id").Where("status = ?", "paid").Order("id").Take(10)
+			q.CountDistinct(context.Background(), "customer_id")
+			if len(db.log) != 1 || db.log[0] != tt.expected {
+				t.Errorf("CountDistinct ran %v, want %s", db.log, tt.expected)
+			}
+		})
+	}
+
+	// Sem coluna a query não é executada
+	db := &routingDB{}
+	q := NewQuery(db, "orders", []string{"id"})
+	q.SetDialect(dialect.GetDialect("postgresql"))
+	if _, err := q.CountDistinct(context.Background(), " "); !errors.Is(err, ErrInvalidInput) || len(db.log) != 0 {
+		t.Errorf("CountDistinct without column err = %v, ran %v", err, db.log)
+	}
+}
+
+// TestQuery_WhereContainsEscaped tests that Contains/StartsWith/EndsWith match %, _ and \
+// literally, while Like keeps its value as a pattern
+func TestQuery_WhereContainsEscaped(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users" WHERE "name" LIKE $1 ESCAPE '\' AND "email" ILIKE $2 ESCAPE '\' AND "name" LIKE $3`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users` WHERE `name` LIKE ? ESCAPE '\\\\' AND LOWER(`email`) LIKE LOWER(?) ESCAPE '\\\\' AND `name` LIKE ?"},
+		{"sqlite", `SELECT "id", "email", "name" FROM "users" WHERE "name" LIKE ? ESCAPE '\' AND LOWER("email") LIKE LOWER(?) ESCAPE '\' AND "name" LIKE ?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).
+				Where(Where{"name": Contains("50%")}).
+				Where(Where{"email": StartsWithInsensitive(`a_b\`)}).
+				Where(Where{"name": Like("J%n")})
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("LIKE = %s, want %s", query, tt.expected)
+			}
+			if !reflect.DeepEqual(args, []interface{}{`%50\%%`, `a\_b\\%`, "J%n"}) {
+				t.Errorf("LIKE args = %v", args)
+			}
+		})
+	}
+
+	// Nos mapas do TableQueryBuilder o padrão também é escapado
+	argIndex := 1
+	clause, args := NewTableQueryBuilder(nil, "users", []string{"email"}).buildWhereFromMap(Where{"email": EndsWith("_test")}, &argIndex)
+	if clause != `"email" LIKE $1 ESCAPE '\'` || !reflect.DeepEqual(args, []interface{}{`%\_test`}) {
+		t.Errorf("TableQueryBuilder LIKE = %s %v", clause, args)
+	}
+}
+
+// TestQuery_WhereNotLike tests that the NOT LIKE operators escape % and _ in the value
+// and render the insensitive variant through the dialect
+func TestQuery_WhereNotLike(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users" WHERE email <> $1 AND "name" NOT LIKE $2 ESCAPE '\' AND "email" NOT ILIKE $3 ESCAPE '\'`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users` WHERE email <> ? AND `name` NOT LIKE ? ESCAPE '\\\\' AND LOWER(`email`) NOT LIKE LOWER(?) ESCAPE '\\\\'"},
+		{"sqlite", `SELECT "id", "email", "name" FROM "users" WHERE email <> ? AND "name" NOT LIKE ? ESCAPE '\' AND LOWER("email") NOT LIKE LOWER(?) ESCAPE '\'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Where("email <> ?", "").
+				Where(Where{"name": NotStartsWith("50%_off")}).
+				Where(Where{"email": NotContainsInsensitive("Admin")})
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("NOT LIKE = %s, want %s", query, tt.expected)
+			}
+			if !reflect.DeepEqual(args, []interface{}{"", `50\%\_off%`, "%Admin%"}) {
+				t.Errorf("NOT LIKE args = %v", args)
+			}
+		})
+	}
+
+	// Contém e termina com: curingas dos dois lados e só no início
+	for pattern, op := range map[string]WhereOperator{`%a\\b%`: NotContains(`a\b`), "%.com": NotEndsWith(".com")} {
+		q := newSQLTestQuery("postgresql").Where(Where{"email": op})
+		if _, args := q.buildSelectQuery(false); !reflect.DeepEqual(args, []interface{}{pattern}) {
+			t.Errorf("NOT LIKE pattern = %v, want %s", args, pattern)
+		}
+	}
+
+	// Os mapas de UpdateMany/DeleteMany (TableQueryBuilder) numeram o placeholder do padrão
+	argIndex := 3
+	clause, args := NewTableQueryBuilder(nil, "users", []string{"email"}).buildWhereFromMap(Where{"email": NotEndsWith("_test")}, &argIndex)
+	if clause != `"email" NOT LIKE $3 ESCAPE '\'` || argIndex != 4 || !reflect.DeepEqual(args, []interface{}{`%\_test`}) {
+		t.Errorf("TableQueryBuilder NOT LIKE = %s %v (next %d)", clause, args, argIndex)
+	}
+}
+
+// TestQuery_ReturningQuery tests that RETURNING is appended only where the dialect supports it
+func TestQuery_ReturningQuery(t *testing.T) {
+	type user struct {
+		ID    int
+		Email string
+	}
+
+	tests := []struct {
+		provider string
+		insert   string
+		updates  string
+		delete   string
+	}{
+		{
+			"postgresql",
+			`INSERT INTO "users" ("email") VALUES ($1) RETURNING "id", "email"`,
+			`UPDATE "users" SET "email" = $1 WHERE id = $2 RETURNING "id", "email"`,
+			`DELETE FROM "users" WHERE id = $1 RETURNING "id", "email"`,
+		},
+		{
+			"mysql",
+			"INSERT INTO `users` (`email`) VALUES (?)",
+			"UPDATE `users` SET `email` = ? WHERE id = ?",
+			"DELETE FROM `users` WHERE id = ?",
+		},
+		{
+			"sqlite",
+			`INSERT INTO "users" ("email") VALUES (?)`,
+			`UPDATE "users" SET "email" = ? WHERE id = ?`,
+			`DELETE FROM "users" WHERE id = ?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider).Returning("id", "email")
+			if query, _, _ := q.buildInsertQuery(&user{Email: "a@example.com"}); query != tt.insert {
+				t.Errorf("insert = %s, want %s", query, tt.insert)
+			}
+
+			q.Where("id = ?", 1)
+			if query, _ := q.buildUpdatesQuery(map[string]interface{}{"email": "b@example.com"}); query != tt.updates {
+				t.Errorf("updates = %s, want %s", query, tt.updates)
+			}
+			if query, _ := q.buildDeleteQuery(); query != tt.delete {
+				t.Errorf("delete = %s, want %s", query, tt.delete)
+			}
+		})
+	}
+
+	// Sem colunas, Returning devolve todas as colunas da tabela
+	q := newSQLTestQuery("postgresql").Returning()
+	if query, _ := q.buildDeleteQuery(); query != `DELETE FROM "users" RETURNING "id", "email", "name"` {
+		t.Errorf("Returning() = %s", query)
+	}
+}
+
+// reselectDB records the reads of Returning on dialects without RETURNING and answers
+// them with no rows
+type reselectDB struct {
+	DBTX
+	insertArgs []interface{}
+	queries    [][]interface{}
+}
+
+func (d *reselectDB) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	d.insertArgs = args
+	return taggedResult{rows: 1}, nil
+}
+
+func (d *reselectDB) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	d.queries = append(d.queries, args)
+	return &pgxLikeRows{}, nil
+}
+
+// TestQuery_ReturningReselect tests that Returning without RETURNING reads the row back by the
+// inserted key of a mapped primary key, and many rows in chunks within the placeholder limit
+func TestQuery_ReturningReselect(t *testing.T) {
+	type user struct {
+		Key   string `db:"user_key"`
+		Email string
+	}
+	ctx := context.Background()
+
+	// Chave com @map e UUID gerado: a releitura usa o UUID, não o LastInsertId
+	db := &reselectDB{}
+	q := NewQuery(db, "users", []string{"user_key", "email"})
+	q.SetDialect(dialect.GetDialect("mysql"))
+	q.SetPrimaryKey("user_key")
+	q.Returning("email")
+	if err := q.createReturning(ctx, &user{Email: "a@example.com"}); err != nil {
+		t.Fatalf("createReturning failed: %v", err)
+	}
+	if len(db.insertArgs) != 2 || len(db.queries) != 1 || !reflect.DeepEqual(db.queries[0], db.insertArgs[1:]) {
+		t.Errorf("reselect args = %v, insert args = %v", db.queries, db.insertArgs)
+	}
+
+	// Mais chaves que o limite de parâmetros: uma leitura por lote
+	db = &reselectDB{}
+	q = newSQLTestQuery("sqlite").Returning("id")
+	size := maxPlaceholders(q.dialect)
+	var dest []struct{ ID int }
+	if err := q.reselectReturning(ctx, db, make([]interface{}, size+1), &dest); err != nil {
+		t.Fatalf("reselectReturning failed: %v", err)
+	}
+	if len(db.queries) != 2 || len(db.queries[0]) != size || len(db.queries[1]) != 1 {
+		t.Errorf("expected chunks of %d and 1 keys, got %d queries", size, len(db.queries))
+	}
+}
+
+// TestQuery_MappedColumns tests that the DTO columns follow the table order and skip unmapped fields
+func TestQuery_MappedColumns(t *testing.T) {
+	type userDTO struct {
+		Name     string `db:"name"`
+		Mail     string `json:"email"`
+		ID       int
+		Computed string `db:"computed"`
+	}
+
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "email", "name" FROM "users"`},
+		{"mysql", "SELECT `id`, `email`, `name` FROM `users`"},
+		{"sqlite", `SELECT "id", "email", "name" FROM "users"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider)
+			columns := q.MappedColumns(reflect.TypeOf(&userDTO{}))
+			if !reflect.DeepEqual(columns, []string{"id", "email", "name"}) {
+				t.Fatalf("MappedColumns() = %v", columns)
+			}
+			query, _ := q.Select(columns...).buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("buildSelectQuery() = %s, want %s", query, tt.expected)
+			}
+
+			// Um DTO sem campos mapeados não seleciona nada
+			type emptyDTO struct {
+				Other string `db:"other"`
+			}
+			if columns := q.MappedColumns(reflect.TypeOf(emptyDTO{})); len(columns) != 0 {
+				t.Errorf("expected no mapped columns, got %v", columns)
+			}
+		})
+	}
+}
+
+// TestQuery_CreateManyQueries tests the multi-row INSERT, its placeholder numbering and the chunking
+func TestQuery_CreateManyQueries(t *testing.T) {
+	type user struct {
+		ID    int
+		Email string
+		Name  string `db:"name"`
+	}
+
+	tests := []struct {
+		provider string
+		expected []string
+		args     string
+	}{
+		{"postgresql", []string{`INSERT INTO "users" ("email", "name") VALUES ($1, DEFAULT), ($2, $3), ($4, DEFAULT)`}, "[[a@example.com b@example.com Bob c@example.com]]"},
+		{"mysql", []string{"INSERT INTO `users` (`email`, `name`) VALUES (?, DEFAULT), (?, ?), (?, DEFAULT)"}, "[[a@example.com b@example.com Bob c@example.com]]"},
+		// O SQLite não aceita DEFAULT em VALUES: um INSERT por conjunto de colunas
+		{"sqlite", []string{`INSERT INTO "users" ("email") VALUES (?), (?)`, `INSERT INTO "users" ("email", "name") VALUES (?, ?)`}, "[[a@example.com c@example.com] [b@example.com Bob]]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			q := newSQLTestQuery(tt.provider)
+			// O nome só aparece no segundo elemento; nas outras linhas fica o default da coluna
+			users := []*user{{Email: "a@example.com"}, {Email: "b@example.com", Name: "Bob"}, {Email: "c@example.com"}}
+			queries, args, err := q.buildCreateManyQueries(users)
+			if err != nil {
+				t.Fatalf("buildCreateManyQueries() error = %v", err)
+			}
+			if !reflect.DeepEqual(queries, tt.expected) {
+				t.Errorf("queries = %v, want %v", queries, tt.expected)
+			}
+			if fmt.Sprint(args) != tt.args {
+				t.Errorf("args = %v, want %s", args, tt.args)
+			}
+
+			// Acima do limite de placeholders, o INSERT é dividido e a numeração recomeça
+			rowsPerQuery := maxPlaceholders(q.dialect) / 2
+			many := make([]user, rowsPerQuery+1)
+			for i := range many {
+				many[i].Email = "x@example.com"
+				many[i].Name = "x"
+			}
+			queries, args, err = q.buildCreateManyQueries(many)
+			if err != nil {
+				t.Fatalf("buildCreateManyQueries() error = %v", err)
+			}
+			if len(queries) != 2 || len(args[0]) != rowsPerQuery*2 || len(args[1]) != 2 {
+				t.Fatalf("expected 2 chunks with %d and 2 args, got %d queries", rowsPerQuery*2, len(queries))
+			}
+			if !strings.HasSuffix(queries[1], "VALUES ("+q.dialect.GetPlaceholder(1)+", "+q.dialect.GetPlaceholder(2)+")") {
+				t.Errorf("second chunk should restart placeholders: %s", queries[1])
+			}
+		})
+	}
+
+	// No SQLite, a linha sem nenhuma coluna preenchida usa DEFAULT VALUES
+	queries, _, err := newSQLTestQuery("sqlite").buildCreateManyQueries([]user{{}, {Email: "a@example.com"}})
+	if err != nil {
+		t.Fatalf("buildCreateManyQueries() error = %v", err)
+	}
+	if want := []string{`INSERT INTO "users" DEFAULT VALUES`, `INSERT INTO "users" ("email") VALUES (?)`}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %v, want %v", queries, want)
+	}
+}
+
+// TestQuery_CreateManyQueries_Invalid tests the values that CreateMany rejects
+func TestQuery_CreateManyQueries_Invalid(t *testing.T) {
+	q := newSQLTestQuery("postgresql")
+
+	type empty struct{ ID int }
+	for _, values := range []interface{}{"users", []int{1, 2}, []empty{{}, {}}, []interface{}{struct{ Email string }{"a"}, 1}} {
+		if _, _, err := q.buildCreateManyQueries(values); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("buildCreateManyQueries(%v) error = %v, want ErrInvalidInput", values, err)
+		}
+	}
+
+	queries, _, err := q.buildCreateManyQueries([]empty{})
+	if err != nil || len(queries) != 0 {
+		t.Errorf("empty slice should build no query, got %v, %v", queries, err)
+	}
+}
+
+// TestQuery_RowCountWarning tests that the soft row limit logs a warning only past the threshold
+func TestQuery_RowCountWarning(t *testing.T) {
+	var buf bytes.Buffer
+	previous := logger.GetDefaultLogger()
+	logger.SetDefaultLogger(logger.NewLogger([]string{"warn"}, &buf))
+	defer func() {
+		logger.SetDefaultLogger(previous)
+		SetRowCountWarning(0)
+	}()
+
+	q := newSQLTestQuery("postgresql")
+	query := `/* report */ SELECT "id" FROM "users"`
+	rows := []int{1, 2, 3}
+
+	// Desativado por padrão
+	q.warnOnRowCount(query, &rows)
+	if buf.Len() != 0 {
+		t.Fatalf("no warning expected while disabled, got %q", buf.String())
+	}
+
+	SetRowCountWarning(3)
+	q.warnOnRowCount(query, &rows)
+	if buf.Len() != 0 {
+		t.Fatalf("no warning expected at the threshold, got %q", buf.String())
+	}
+
+	SetRowCountWarning(2)
+	q.warnOnRowCount(query, &rows)
+	out := buf.String()
+	if !strings.Contains(out, "3 rows (warning threshold 2)") || !strings.Contains(out, query) {
+		t.Errorf("expected a warning with the row count and the query, got %q", out)
+	}
+}
+
+// TestTableQueryBuilder_UpsertQuery testa o INSERT ... ON CONFLICT gerado por dialeto,
+// com apenas os campos de update no SET
+func TestTableQueryBuilder_UpsertQuery(t *testing.T) {
+	type user struct {
+		ID    int    `db:"id"`
+		Email string `db:"email"`
+		Name  string `db:"name"`
+	}
+
+	tests := []struct {
+		provider string
+		updates  map[string]interface{}
+		expected string
+	}{
+		{"postgresql", map[string]interface{}{"name": "B"}, `INSERT INTO "users" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "name" = $3`},
+		{"cockroachdb", map[string]interface{}{"name": "B"}, `INSERT INTO "users" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "name" = $3`},
+		{"sqlite", map[string]interface{}{"name": "B"}, `INSERT INTO "users" ("email", "name") VALUES (?, ?) ON CONFLICT ("email") DO UPDATE SET "name" = ?`},
+		{"mysql", map[string]interface{}{"name": "B"}, "INSERT INTO `users` (`email`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `name` = ?"},
+		{"postgresql", nil, `INSERT INTO "users" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "email" = EXCLUDED."email"`},
+		{"mysql", nil, "INSERT INTO `users` (`email`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `email` = `email`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			b := NewTableQueryBuilder(nil, "users", []string{"id", "email", "name"})
+			b.SetDialect(dialect.GetDialect(tt.provider))
+			b.SetPrimaryKey("id")
+
+			query, args, conflictArgs, err := b.buildUpsertQuery(user{Email: "a@example.com", Name: "A"}, []string{"email"}, tt.updates)
+			if err != nil {
+				t.Fatalf("buildUpsertQuery() error = %v", err)
+			}
+			if query != tt.expected {
+				t.Errorf("buildUpsertQuery() =\n%s\nwant\n%s", query, tt.expected)
+			}
+			if len(args) != 2+len(tt.updates) {
+				t.Errorf("expected %d args, got %v", 2+len(tt.updates), args)
+			}
+			if len(conflictArgs) != 1 || conflictArgs[0] != "a@example.com" {
+				t.Errorf("unexpected conflict args: %v", conflictArgs)
+			}
+		})
+	}
+
+	b := NewTableQueryBuilder(nil, "users", []string{"id", "email", "name"})
+	b.SetDialect(dialect.GetDialect("postgresql"))
+	if _, _, _, err := b.buildUpsertQuery(user{Email: "a@example.com"}, []string{"name"}, nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("conflict column missing from data should return ErrInvalidInput, got %v", err)
+	}
+	if _, _, _, err := b.buildUpsertQuery(user{Email: "a@example.com"}, []string{"email"}, map[string]interface{}{"missing": 1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("unknown update column should return ErrInvalidInput, got %v", err)
+	}
+
+	// Índice único parcial: o predicado entra no alvo do conflito
+	b.SetConflictWhere("deleted_at IS NULL")
+	query, _, _, err := b.buildUpsertQuery(user{Email: "a@example.com"}, []string{"email"}, map[string]interface{}{"name": "B"})
+	if err != nil || query != `INSERT INTO "users" ("email") VALUES ($1) ON CONFLICT ("email") WHERE deleted_at IS NULL DO UPDATE SET "name" = $2` {
+		t.Errorf("partial unique upsert = %s, %v", query, err)
+	}
+	b.SetConflictWhere("")
+
+	// O SQL Server não tem sufixo de upsert
+	b.SetDialect(dialect.GetDialect("sqlserver"))
+	if _, _, _, err := b.buildUpsertQuery(user{Email: "a@example.com"}, []string{"email"}, nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("upsert on SQL Server should return ErrInvalidInput, got %v", err)
+	}
+}
+
+// TestTableQueryBuilder_ProvidedColumns tests that provided columns are inserted even when
+// zero, while the other zero-valued columns are left to the database default
+func TestTableQueryBuilder_ProvidedColumns(t *testing.T) {
+	type item struct {
+		ID     int    `db:"id"`
+		Name   string `db:"name"`
+		Count  int    `db:"count"`
+		Active bool   `db:"active"`
+	}
+	ctx := context.Background()
+	db := &routingDB{}
+	b := NewTableQueryBuilder(db, "items", []string{"id", "name", "count", "active"})
+	b.SetPrimaryKey("id")
+
+	b.Create(ctx, item{Name: "a"})
+	b.SetProvidedColumns("name", "count", "active")
+	b.Create(ctx, item{Name: "a"})
+	b.CreateMany(ctx, []interface{}{item{Name: "a"}, item{Name: "b", Count: 2}}, false)
+	want := []string{
+		`INSERT INTO "items" ("name") VALUES ($1) RETURNING "id", "name", "count", "active"`,
+		`INSERT INTO "items" ("name", "count", "active") VALUES ($1, $2, $3) RETURNING "id", "name", "count", "active"`,
+		`INSERT INTO "items" ("name", "count", "active") VALUES ($1, $2, $3), ($4, $5, $6)`,
+	}
+	if !reflect.DeepEqual(db.log, want) {
+		t.Errorf("inserts =\n%s\nwant\n%s", strings.Join(db.log, "\n"), strings.Join(want, "\n"))
+	}
+
+	query, args, _, err := b.buildUpsertQuery(item{Name: "a"}, []string{"name"}, nil)
+	if err != nil || query != `INSERT INTO "items" ("name", "count", "active") VALUES ($1, $2, $3) ON CONFLICT ("name") DO UPDATE SET "name" = EXCLUDED."name"` || len(args) != 3 {
+		t.Errorf("upsert = %s %v (%v)", query, args, err)
+	}
+}
+
+// TestInsert_SkipsRelationFields testa que campos db:"-" (relações carregadas por Include)
+// ficam fora de Create, CreateMany, Update, Upsert e Save
+func TestInsert_SkipsRelationFields(t *testing.T) {
+	type author struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	type comment struct {
+		ID   int    `db:"id"`
+		Body string `db:"body"`
+	}
+	type post struct {
+		ID       int       `db:"id"`
+		Title    string    `db:"title"`
+		AuthorID int       `db:"author_id"`
+		Author   *author   `json:"author,omitempty" db:"-"`
+		Comments []comment `json:"comments,omitempty" db:"-"`
+	}
+	ctx := context.Background()
+	loaded := post{ID: 1, Title: "Hello", AuthorID: 2, Author: &author{ID: 2, Name: "Ana"}, Comments: []comment{{ID: 3, Body: "Hi"}}}
+
+	db := &routingDB{}
+	b := NewTableQueryBuilder(db, "posts", []string{"id", "title", "author_id"})
+	b.SetPrimaryKey("id")
+	b.Create(ctx, loaded)
+	b.CreateMany(ctx, []interface{}{loaded}, false)
+	b.Update(ctx, 1, loaded)
+	want := []string{
+		`INSERT INTO "posts" ("title", "author_id", "id") VALUES ($1, $2, $3) RETURNING "id", "title", "author_id"`,
+		`INSERT INTO "posts" ("id", "title", "author_id") VALUES ($1, $2, $3)`,
+		`UPDATE "posts" SET "title" = $1, "author_id" = $2 WHERE "id" = $3 RETURNING "id", "title", "author_id"`,
+	}
+	if !reflect.DeepEqual(db.log, want) {
+		t.Errorf("inserts =\n%s\nwant\n%s", strings.Join(db.log, "\n"), strings.Join(want, "\n"))
+	}
+	if query, _, _, err := b.buildUpsertQuery(loaded, []string{"id"}, nil); err != nil || strings.Contains(query, "-") {
+		t.Errorf("upsert = %s (%v)", query, err)
+	}
+
+	q := newSQLTestQuery("postgresql")
+	q.SetPrimaryKey("id")
+	if query, args, _ := q.buildInsertQuery(loaded); query != `INSERT INTO "users" ("title", "author_id", "id") VALUES ($1, $2, $3)` || len(args) != 3 {
+		t.Errorf("Query.Create = %s %v", query, args)
+	}
+	if queries, _, err := q.buildCreateManyQueries([]post{loaded}); err != nil || len(queries) != 1 || queries[0] != `INSERT INTO "users" ("id", "title", "author_id") VALUES ($1, $2, $3)` {
+		t.Errorf("Query.CreateMany = %v (%v)", queries, err)
+	}
+	if query, args := q.buildUpsertQuery(loaded); !strings.HasPrefix(query, `INSERT INTO "users" ("title", "author_id", "id") VALUES ($1, $2, $3) ON CONFLICT`) || len(args) != 3 {
+		t.Errorf("Query.Save = %s %v", query, args)
+	}
+}
+
+// TestMySQLVersion_UpsertSyntax testa que o upsert do MySQL só usa o alias de linha a partir do 8.0.20
+func TestMySQLVersion_UpsertSyntax(t *testing.T) {
+	defer SetMySQLVersion("")
+	type user struct {
+		ID    int    `db:"id"`
+		Email string `db:"email"`
+		Name  string `db:"name"`
+	}
+
+	tests := []struct {
+		version string
+		query   string
+		create  string
+	}{
+		{"", "INSERT INTO `users` (`email`, `name`, `id`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `email` = VALUES(`email`), `name` = VALUES(`name`)",
+			" ON DUPLICATE KEY UPDATE `email` = VALUES(`email`), `name` = VALUES(`name`)"},
+		{"8.0.19", "INSERT INTO `users` (`email`, `name`, `id`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `email` = VALUES(`email`), `name` = VALUES(`name`)",
+			" ON DUPLICATE KEY UPDATE `email` = VALUES(`email`), `name` = VALUES(`name`)"},
+		{"10.11.6-MariaDB", "INSERT INTO `users` (`email`, `name`, `id`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `email` = VALUES(`email`), `name` = VALUES(`name`)",
+			" ON DUPLICATE KEY UPDATE `email` = VALUES(`email`), `name` = VALUES(`name`)"},
+		{"8.0.20", "INSERT INTO `users` (`email`, `name`, `id`) VALUES (?, ?, ?) AS new ON DUPLICATE KEY UPDATE `email` = new.`email`, `name` = new.`name`",
+			" AS new ON DUPLICATE KEY UPDATE `email` = new.`email`, `name` = new.`name`"},
+		{"8.4.0-0ubuntu0.24.04.1", "INSERT INTO `users` (`email`, `name`, `id`) VALUES (?, ?, ?) AS new ON DUPLICATE KEY UPDATE `email` = new.`email`, `name` = new.`name`",
+			" AS new ON DUPLICATE KEY UPDATE `email` = new.`email`, `name` = new.`name`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if err := SetMySQLVersion(tt.version); err != nil {
+				t.Fatalf("SetMySQLVersion(%q) error = %v", tt.version, err)
+			}
+			query, _ := newSQLTestQuery("mysql").buildUpsertQuery(user{ID: 1, Email: "a@example.com", Name: "A"})
+			if query != tt.query {
+				t.Errorf("buildUpsertQuery() =\n%s\nwant\n%s", query, tt.query)
+			}
+
+			b := NewTableQueryBuilder(nil, "users", []string{"id", "email", "name"})
+			b.SetDialect(dialect.GetDialect("mysql"))
+			if clause := b.buildPKConflictClause([]string{"id", "email", "name"}, "id"); clause != tt.create {
+				t.Errorf("buildPKConflictClause() = %s, want %s", clause, tt.create)
+			}
+		})
+	}
+
+	// Versões inválidas são rejeitadas e mantêm a configuração anterior
+	if err := SetMySQLVersion("latest"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("invalid version should return ErrInvalidInput, got %v", err)
+	}
+	if !mysqlRowAliasEnabled.Load() {
+		t.Error("invalid version should keep the previous setting")
+	}
+}
+
+// TestQuery_WhereMapDeterministic testa que um mapa Where gera sempre o mesmo SQL, com as chaves ordenadas
+func TestQuery_WhereMapDeterministic(t *testing.T) {
+	where := Where{"name": "A", "email": "a@example.com", "id": Gt(1)}
+	expected := `SELECT "id", "email", "name" FROM "users" WHERE "email" = $1 AND "id" > $2 AND "name" = $3`
+	for i := 0; i < 20; i++ {
+		query, args := newSQLTestQuery("postgresql").Where(where).buildSelectQuery(false)
+		if query != expected {
+			t.Fatalf("buildSelectQuery() =\n%s\nwant\n%s", query, expected)
+		}
+		if !reflect.DeepEqual(args, []interface{}{"a@example.com", 1, "A"}) {
+			t.Fatalf("unexpected args: %v", args)
+		}
+	}
+}
+
+// TestNormalizeTSQuery tests that tsquery operators in user input never reach to_tsquery
+func TestNormalizeTSQuery(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"golang prisma", "golang:* & prisma:*"},
+		{"  golang  ", "golang:*"},
+		{"go&lang | (prisma)", "go:* & lang:* & prisma:*"},
+		{"!rust <-> 'c++':*", "rust:* & -:* & c++:*"},
+		{`a\b`, "a:* & b:*"},
+		{"", ""},
+		{"   \t", ""},
+		{"&|!():*<>", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTSQuery(tt.input); got != tt.expected {
+			t.Errorf("NormalizeTSQuery(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestQuery_SearchAdversarialInput tests that operator-only or empty searches match no rows
+func TestQuery_SearchAdversarialInput(t *testing.T) {
+	for _, input := range []string{"", "   ", "&|!()", "(((", "':*"} {
+		t.Run(input, func(t *testing.T) {
+			// Search direto
+			query, args := newSQLTestQuery("postgresql").Search("name", input).buildSelectQuery(false)
+			if !strings.HasSuffix(query, "WHERE 1 = 0") || len(args) != 0 {
+				t.Errorf("Search(%q) = %s %v, want a no-match condition", input, query, args)
+			}
+
+			// operador no mapa Where
+			query, args = newSQLTestQuery("postgresql").Where(Where{"name": SearchOp(input)}).buildSelectQuery(false)
+			if !strings.HasSuffix(query, "WHERE 1 = 0") || len(args) != 0 {
+				t.Errorf("SearchOp(%q) = %s %v, want a no-match condition", input, query, args)
+			}
+
+			query, _ = newSQLTestQuery("postgresql").Where(Where{"name": SearchOpWithConfig(input, "english")}).buildSelectQuery(false)
+			if !strings.HasSuffix(query, "WHERE 1 = 0") {
+				t.Errorf("SearchOpWithConfig(%q) = %s, want a no-match condition", input, query)
+			}
+		})
+	}
+
+	query, args := newSQLTestQuery("postgresql").Search("name", "go & (lang)").buildSelectQuery(false)
+	if !strings.Contains(query, "name @@ to_tsquery($1)") || len(args) != 1 || args[0] != "go:* & lang:*" {
+		t.Errorf("unexpected search query: %s %v", query, args)
+	}
+
+	query, _ = newSQLTestQuery("postgresql").Where(Where{"name": SearchOp("it's (fine)")}).buildSelectQuery(false)
+	if !strings.Contains(query, `to_tsquery('it:* & s:* & fine:*')`) {
+		t.Errorf("unexpected SearchOp query: %s", query)
+	}
+}
+
+// TestQuery_FromSubquery tests that FromSubquery reads from the aliased subquery, numbering
+// its placeholders before the outer ones, and rejects writes and an invalid source
+func TestQuery_FromSubquery(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+		count    string
+	}{
+		{
+			"postgresql",
+			`SELECT "id", "email", "name" FROM (SELECT "id", "email", "name" FROM "users" WHERE name <> $1 ORDER BY "id" DESC LIMIT $2) AS "u" WHERE email LIKE $3 ORDER BY "name" ASC`,
+			`SELECT COUNT(*) FROM (SELECT "id", "email", "name" FROM "users" WHERE name <> $1 ORDER BY "id" DESC LIMIT $2) AS "u" WHERE email LIKE $3`,
+		},
+		{
+			"mysql",
+			"SELECT `id`, `email`, `name` FROM (SELECT `id`, `email`, `name` FROM `users` WHERE name <> ? ORDER BY `id` DESC LIMIT ?) AS `u` WHERE email LIKE ? ORDER BY `name` ASC",
+			"SELECT COUNT(*) FROM (SELECT `id`, `email`, `name` FROM `users` WHERE name <> ? ORDER BY `id` DESC LIMIT ?) AS `u` WHERE email LIKE ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			sub := newSQLTestQuery(tt.provider).Where("name <> ?", "x").Order("id DESC").Take(10)
+			q := newSQLTestQuery(tt.provider).FromSubquery(sub, "u").Where("email LIKE ?", "%a").Order("name")
+			query, args := q.buildSelectQuery(false)
+			if query != tt.expected {
+				t.Errorf("FromSubquery = %s, want %s", query, tt.expected)
+			}
+			if !reflect.DeepEqual(args, []interface{}{"x", 10, "%a"}) {
+				t.Errorf("FromSubquery args = %v", args)
+			}
+			if query, _ := q.buildCountQuery(); query != tt.count {
+				t.Errorf("FromSubquery count = %s, want %s", query, tt.count)
+			}
+		})
+	}
+
+	// Escritas não passam pela subquery
+	q := newSQLTestQuery("postgresql").FromSubquery(newSQLTestQuery("postgresql"), "u")
+	if err := q.Updates(context.Background(), map[string]interface{}{"name": "y"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Updates through a subquery = %v, want ErrInvalidInput", err)
+	}
+
+	// Subquery nula ou alias vazio
+	for _, q := range []*Query{
+		newSQLTestQuery("postgresql").FromSubquery(nil, "u"),
+		newSQLTestQuery("postgresql").FromSubquery(newSQLTestQuery("postgresql"), " "),
+	} {
+		if err := q.prepareSelect(); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("prepareSelect = %v, want ErrInvalidInput", err)
+		}
+	}
+}
+
+// TestQuery_ArrayOperators tests the native array operators on PostgreSQL and the JSON fallback elsewhere
+func TestQuery_ArrayOperators(t *testing.T) {
+	tests := []struct {
+		provider string
+		op       WhereOperator
+		where    string
+		args     string
+	}{
+		{"postgresql", ArrayHas("go"), `WHERE "name" @> ARRAY[$1]`, "[go]"},
+		{"postgresql", ArrayHasEvery("go", "sql"), `WHERE "name" @> ARRAY[$1, $2]`, "[go sql]"},
+		{"postgresql", ArrayHasSome("go", "sql"), `WHERE "name" && ARRAY[$1, $2]`, "[go sql]"},
+		{"postgresql", ArrayIsEmpty(), `WHERE COALESCE(array_length("name", 1), 0) = 0`, "[]"},
+		{"cockroachdb", ArrayHas("go"), `WHERE "name" @> ARRAY[$1]`, "[go]"},
+		// Int[]: ARRAY[$1] seria text[], então o elemento é comparado com = ANY
+		{"postgresql", ArrayHas(7), `WHERE $1 = ANY("name")`, "[7]"},
+		{"postgresql", ArrayHasEvery(7, 8), `WHERE ($1 = ANY("name") AND $2 = ANY("name"))`, "[7 8]"},
+		{"postgresql", ArrayHasSome(int64(7), int64(8)), `WHERE ($1 = ANY("name") OR $2 = ANY("name"))`, "[7 8]"},
+		{"postgresql", ArrayHasSome(), "WHERE 1 = 0", "[]"},
+		// A lista do Equals é um único parâmetro, não uma tupla ($1, $2)
+		{"postgresql", ArrayEquals([]string{"go", "sql"}), `WHERE "name" = $1`, "[[go sql]]"},
+		{"sqlite", ArrayEquals([]string{"go", "sql"}), `WHERE "name" = ?`, `[["go","sql"]]`},
+		// Sem arrays nativos cai no operador JSON equivalente
+		{"mysql", ArrayHas("go"), "WHERE JSON_CONTAINS(`name`, '[\"go\"]')", "[]"},
+	}
+
+	for _, tt := range tests {
+		q := newSQLTestQuery(tt.provider).Where(Where{"name": tt.op})
+		query, args := q.buildSelectQuery(false)
+		if !strings.HasSuffix(query, tt.where) || fmt.Sprint(args) != tt.args {
+			t.Errorf("%s %s = %s %v, want %s %s", tt.provider, tt.op.GetOp(), query, args, tt.where, tt.args)
+		}
+	}
+
+	// HasEvery sem valores não restringe nada
+	if query, _ := newSQLTestQuery("postgresql").Where(Where{"name": ArrayHasEvery()}).buildSelectQuery(false); strings.Contains(query, "WHERE") {
+		t.Errorf("empty ArrayHasEvery = %s", query)
+	}
+}
+
+// TestQuery_SchemaQualifiedTable tests that a schema-qualified table (@@schema) has each part quoted
+func TestQuery_SchemaQualifiedTable(t *testing.T) {
+	q := NewQuery(nil, "auth.users", []string{"id", "email"})
+	q.SetDialect(dialect.GetDialect("postgresql"))
+	q.LeftJoin("public.posts", `"posts"."author_id" = "users"."id"`).Where("email = ?", "a")
+
+	query, _ := q.buildSelectQuery(false)
+	expected := `SELECT "id", "email" FROM "auth"."users" LEFT JOIN "public"."posts" ON "posts"."author_id" = "users"."id" WHERE email = $1`
+	if query != expected {
+		t.Errorf("buildSelectQuery() =\n%s\nwant\n%s", query, expected)
+	}
+
+	b := NewTableQueryBuilder(nil, "auth.users", []string{"id", "email"})
+	b.SetDialect(dialect.GetDialect("postgresql"))
+	if query, _ := b.buildQuery(Where{"email": "a"}, nil, false); query != `SELECT "id", "email" FROM "auth"."users" WHERE "email" = $1` {
+		t.Errorf("TableQueryBuilder = %s", query)
+	}
+}
+
+// TestQuery_QuotedIdentifierEscaping tests that quote characters in table and column names
+// are escaped by the dialect instead of closing the identifier
+func TestQuery_QuotedIdentifierEscaping(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `SELECT "id", "na""me" FROM "us""ers"`},
+		{"mysql", "SELECT `id`, `na\"me` FROM `us\"ers`"},
+		{"sqlserver", `SELECT [id], [na"me] FROM [us"ers]`},
+	}
+	for _, tt := range tests {
+		q := NewQuery(nil, `us"ers`, []string{"id", `na"me`})
+		q.SetDialect(dialect.GetDialect(tt.provider))
+		if query, _ := q.buildSelectQuery(false); query != tt.expected {
+			t.Errorf("%s buildSelectQuery() = %s, want %s", tt.provider, query, tt.expected)
+		}
+	}
+
+	q := NewQuery(nil, "users", []string{"id", "na`me"})
+	q.SetDialect(dialect.GetDialect("mysql"))
+	if query, _ := q.buildSelectQuery(false); query != "SELECT `id`, `na``me` FROM `users`" {
+		t.Errorf("mysql buildSelectQuery() = %s", query)
+	}
+}
@@ -11,10 +11,15 @@ import (
 // Search creates a full-text search operator
 // Example: q.Search("content", "golang prisma")
 func (q *Query) Search(field string, query string) *Query {
-	searchQuery := fmt.Sprintf("%s @@ to_tsquery($1)", field)
+	normalized := NormalizeTSQuery(query)
+	if normalized == "" {
+		q.addUnsatisfiableCondition("empty full-text search query")
+		return q
+	}
+	searchQuery := fmt.Sprintf("%s @@ to_tsquery(?)", field)
 	q.whereConditions = append(q.whereConditions, whereCondition{
 		query: searchQuery,
-		args:  []interface{}{NormalizeTSQuery(query)},
+		args:  []interface{}{normalized},
 		or:    false,
 	})
 	return q
@@ -22,10 +27,15 @@ func (q *Query) Search(field string, query string) *Query {
 
 // SearchInsensitive creates a case-insensitive full-text search
 func (q *Query) SearchInsensitive(field string, query string) *Query {
-	searchQuery := fmt.Sprintf("to_tsvector('simple', lower(%s)) @@ to_tsquery('simple', lower($1))", field)
+	normalized := NormalizeTSQuery(query)
+	if normalized == "" {
+		q.addUnsatisfiableCondition("empty full-text search query")
+		return q
+	}
+	searchQuery := fmt.Sprintf("to_tsvector('simple', lower(%s)) @@ to_tsquery('simple', lower(?))", field)
 	q.whereConditions = append(q.whereConditions, whereCondition{
 		query: searchQuery,
-		args:  []interface{}{NormalizeTSQuery(query)},
+		args:  []interface{}{normalized},
 		or:    false,
 	})
 	return q
@@ -61,15 +71,15 @@ func (q *Query) Rank(field string, query string, order string) *Query {
 }
 
 // NormalizeTSQuery normalizes a query for PostgreSQL to_tsquery
-// Converts spaces to & (AND) and adds :* for prefix matching
+// Converts spaces to & (AND) and adds :* for prefix matching. tsquery operator
+// characters (& | ! ( ) : * < > ' \) are stripped from the input so user text can
+// never produce invalid to_tsquery syntax; returns "" when no word is left
 func NormalizeTSQuery(query string) string {
-	query = strings.TrimSpace(query)
-	if query == "" {
+	words := strings.Fields(tsqueryOperatorReplacer.Replace(query))
+	if len(words) == 0 {
 		return ""
 	}
 
-	words := strings.Fields(query)
-
 	wordsWithPrefix := make([]string, len(words))
 	for i, word := range words {
 		wordsWithPrefix[i] = word + ":*"
@@ -78,6 +88,12 @@ func NormalizeTSQuery(query string) string {
 	return strings.Join(wordsWithPrefix, " & ")
 }
 
+// tsqueryOperatorReplacer turns tsquery operator characters into spaces
+var tsqueryOperatorReplacer = strings.NewReplacer(
+	"&", " ", "|", " ", "!", " ", "(", " ", ")", " ",
+	":", " ", "*", " ", "<", " ", ">", " ", "'", " ", "\\", " ",
+)
+
 // SearchOp creates a full-text search operator for use in Where
 // Example: q.Where(builder.Where{"content": builder.SearchOp("golang prisma")})
 func SearchOp(query string) WhereOperator {
@@ -0,0 +1,146 @@
+package builder
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// QueryHook is called around every statement a Query runs (First, Find, Count, Create,
+// Updates and Delete, including their Returning, Cascade and Scan variants) and around the
+// statements of TableQueryBuilder (the generated client's Create, Upsert, CreateMany,
+// UpdateMany and DeleteMany), for tracing and metrics. Before receives the args after
+// redaction (see SetArgRedaction) and may return a derived context (e.g. holding a span) that is passed to the database call and to the matching After.
+type QueryHook interface {
+	Before(ctx context.Context, sql string, args []interface{}) context.Context
+	After(ctx context.Context, sql string, err error, duration time.Duration)
+}
+
+var (
+	queryHooks   []QueryHook
+	queryHooksMu sync.RWMutex
+)
+
+// SetQueryHooks sets the hooks called by every query, before the hooks added to the query
+// itself with AddHook. Calling it without hooks removes them.
+// Example: builder.SetQueryHooks(tracingHook{})
+func SetQueryHooks(hooks ...QueryHook) {
+	queryHooksMu.Lock()
+	defer queryHooksMu.Unlock()
+	queryHooks = append([]QueryHook(nil), hooks...)
+}
+
+// AddHook adds a hook called only by this query, after the global hooks
+func (q *Query) AddHook(hook QueryHook) *Query {
+	q.hooks = append(q.hooks, hook)
+	return q
+}
+
+// startHooks calls Before on the global and query hooks in order and returns the resulting
+// context and a func that calls their After in reverse order, each with its own context
+func (q *Query) startHooks(ctx context.Context, query string, args []interface{}) (context.Context, func(error)) {
+	queryHooksMu.RLock()
+	hooks := queryHooks
+	queryHooksMu.RUnlock()
+	if len(hooks) == 0 && len(q.hooks) == 0 {
+		return ctx, func(error) {}
+	}
+
+	hooks = append(append([]QueryHook(nil), hooks...), q.hooks...)
+	hookArgs := q.redactArgs(args)
+	contexts := make([]context.Context, len(hooks))
+	for i, hook := range hooks {
+		ctx = hook.Before(ctx, query, hookArgs)
+		contexts[i] = ctx
+	}
+	start := time.Now()
+	return ctx, func(err error) {
+		duration := time.Since(start)
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i].After(contexts[i], query, err, duration)
+		}
+	}
+}
+
+// CopyHooks runs the builder's statements through the hooks and arg redaction of from, the
+// query the generated client derived it from; the global hooks run even without it
+func (b *TableQueryBuilder) CopyHooks(from *Query) *TableQueryBuilder {
+	b.hookQuery = (&Query{}).CopyLogging(from)
+	return b
+}
+
+// conn returns the builder's DBTX, wrapped so its statements call the hooks when there are any
+func (b *TableQueryBuilder) conn() DBTX {
+	query := b.hookQuery
+	if query == nil {
+		query = &Query{}
+	}
+	return query.hooked(b.db)
+}
+
+// hooked wraps db so every statement run on it calls the query's hooks, for the paths that
+// don't call startHooks themselves; without hooks db is returned as is
+func (q *Query) hooked(db DBTX) DBTX {
+	queryHooksMu.RLock()
+	global := len(queryHooks)
+	queryHooksMu.RUnlock()
+	if global == 0 && len(q.hooks) == 0 {
+		return db
+	}
+	return &hookedConn{DBTX: db, query: q}
+}
+
+// hookedConn calls the hooks of query around every statement
+type hookedConn struct {
+	DBTX
+	query *Query
+}
+
+func (c *hookedConn) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	ctx, afterHooks := c.query.startHooks(ctx, sql, args)
+	result, err := c.DBTX.Exec(ctx, sql, args...)
+	afterHooks(err)
+	return result, err
+}
+
+func (c *hookedConn) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	ctx, afterHooks := c.query.startHooks(ctx, sql, args)
+	rows, err := c.DBTX.Query(ctx, sql, args...)
+	if err != nil {
+		afterHooks(err)
+		return nil, err
+	}
+	return &hookedRows{Rows: rows, afterHooks: afterHooks}, nil
+}
+
+func (c *hookedConn) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	ctx, afterHooks := c.query.startHooks(ctx, sql, args)
+	return &hookedRow{Row: c.DBTX.QueryRow(ctx, sql, args...), afterHooks: afterHooks}
+}
+
+// hookedRows calls After when the rows are closed, with the iteration error
+type hookedRows struct {
+	Rows
+	afterHooks func(error)
+	closed     bool
+}
+
+func (r *hookedRows) Close() {
+	r.Rows.Close()
+	if !r.closed {
+		r.closed = true
+		r.afterHooks(r.Rows.Err())
+	}
+}
+
+// hookedRow calls After when the row is scanned, which is when QueryRow reports its error
+type hookedRow struct {
+	Row
+	afterHooks func(error)
+}
+
+func (r *hookedRow) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	r.afterHooks(err)
+	return err
+}
@@ -0,0 +1,123 @@
+package builder
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+)
+
+type hookKey struct{}
+
+// recordingHook anota as chamadas em calls e marca o contexto com seu nome
+type recordingHook struct {
+	name  string
+	calls *[]string
+}
+
+func (h recordingHook) Before(ctx context.Context, sql string, args []interface{}) context.Context {
+	*h.calls = append(*h.calls, h.name+" before "+strings.Fields(sql)[0])
+	return context.WithValue(ctx, hookKey{}, h.name)
+}
+
+func (h recordingHook) After(ctx context.Context, sql string, err error, duration time.Duration) {
+	*h.calls = append(*h.calls, h.name+" after "+ctx.Value(hookKey{}).(string))
+}
+
+func TestQuery_Hooks(t *testing.T) {
+	var calls []string
+	SetQueryHooks(recordingHook{name: "global", calls: &calls})
+	defer SetQueryHooks()
+
+	db := &jsonRowDB{row: []interface{}{int64(3)}}
+	q := NewQuery(db, "users", []string{"id", "name"}).AddHook(recordingHook{name: "query", calls: &calls})
+
+	if n, err := q.Count(context.Background()); err != nil || n != 3 {
+		t.Fatalf("Count = %d, %v", n, err)
+	}
+	if _, err := q.UpdatesResult(context.Background(), map[string]interface{}{"name": "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Before na ordem de registro, After na ordem inversa e com o contexto do próprio hook
+	want := "global before SELECT,query before SELECT,query after query,global after global," +
+		"global before UPDATE,query before UPDATE,query after query,global after global"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("calls = %s\nwant  %s", got, want)
+	}
+
+	// Sem hooks nada é chamado
+	calls = nil
+	SetQueryHooks()
+	NewQuery(db, "users", []string{"id", "name"}).Count(context.Background())
+	if len(calls) != 0 {
+		t.Errorf("calls without hooks = %v", calls)
+	}
+}
+
+// TestQuery_HooksOnEveryPath tests that Returning, Cascade, ScanFirst and ScanFind call the
+// hooks around each of their statements
+func TestQuery_HooksOnEveryPath(t *testing.T) {
+	var calls []string
+	type user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	ctx := context.Background()
+	// Numa transação o Cascade roda direto nela
+	db := &txDBAdapter{tx: &routingDB{}}
+	newQuery := func() *Query {
+		q := NewQuery(db, "users", []string{"id", "name"}).AddHook(recordingHook{name: "query", calls: &calls})
+		q.SetDialect(dialect.GetDialect("postgresql"))
+		return q
+	}
+
+	newQuery().Returning("id").Create(ctx, &user{Name: "a"})
+	newQuery().Where("id = ?", 1).Cascade(CascadeRelation{Table: "posts", Columns: []string{"user_id"}, ReferencedColumns: []string{"id"}}).Delete(ctx, nil)
+	var first user
+	newQuery().ScanFirst(ctx, &first, reflect.TypeOf(user{}))
+	var all []user
+	newQuery().ScanFind(ctx, &all, reflect.TypeOf(user{}))
+
+	want := "query before INSERT,query after query," +
+		"query before DELETE,query after query,query before DELETE,query after query," +
+		"query before SELECT,query after query,query before SELECT,query after query"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("calls = %s\nwant  %s", got, want)
+	}
+}
+
+func TestTableQueryBuilder_Hooks(t *testing.T) {
+	var calls []string
+	SetQueryHooks(recordingHook{name: "global", calls: &calls})
+	defer SetQueryHooks()
+
+	type user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	db := &routingDB{}
+	q := NewQuery(db, "users", []string{"id", "name"}).AddHook(recordingHook{name: "query", calls: &calls})
+	b := NewTableQueryBuilder(db, "users", []string{"id", "name"}).CopyHooks(q)
+	b.SetPrimaryKey("id")
+	b.SetModelType(reflect.TypeOf(user{}))
+
+	// O RETURNING do Create só termina os hooks no Scan da linha
+	b.Create(context.Background(), user{Name: "a"})
+	b.Delete(context.Background(), 1)
+	want := "global before INSERT,query before INSERT,query after query,global after global," +
+		"global before DELETE,query before DELETE,query after query,global after global"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("calls = %s\nwant  %s", got, want)
+	}
+
+	// Sem CopyHooks só os hooks globais são chamados
+	calls = nil
+	NewTableQueryBuilder(db, "users", []string{"id", "name"}).SetPrimaryKey("id").Delete(context.Background(), 1)
+	if got := strings.Join(calls, ","); got != "global before DELETE,global after global" {
+		t.Errorf("calls without CopyHooks = %s", got)
+	}
+}
@@ -2,7 +2,6 @@ package builder
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -30,7 +29,7 @@ func (j *JSONField) Get(ctx context.Context, key string) (interface{}, error) {
 	quotedField := j.query.dialect.QuoteIdentifier(j.field)
 	quotedTable := j.query.dialect.QuoteIdentifier(j.query.table)
 	query := fmt.Sprintf("SELECT %s->>$1 FROM %s", quotedField, quotedTable)
-	row := j.query.db.QueryRow(ctx, query, key)
+	row := j.query.hooked(j.query.db).QueryRow(ctx, query, key)
 
 	var result interface{}
 	err := row.Scan(&result)
@@ -40,7 +39,11 @@ func (j *JSONField) Get(ctx context.Context, key string) (interface{}, error) {
 // Set atualiza um valor em um campo JSON
 // Exemplo: q.JSON("metadata").Set("key", "value")
 func (j *JSONField) Set(ctx context.Context, key string, value interface{}) error {
-	valueJSON, err := json.Marshal(value)
+	if err := j.query.checkWritable(); err != nil {
+		return err
+	}
+
+	valueJSON, err := getJSONSerializer().Marshal(value)
 	if err != nil {
 		return fmt.Errorf("erro ao serializar valor JSON: %w", err)
 	}
@@ -63,12 +66,12 @@ func (j *JSONField) Set(ctx context.Context, key string, value interface{}) erro
 			query += " AND " + whereClause
 			allArgs := []interface{}{key, string(valueJSON), nil}
 			allArgs = append(allArgs, whereArgs...)
-			_, err = j.query.db.Exec(ctx, query, allArgs...)
+			_, err = j.query.hooked(j.query.db).Exec(ctx, query, allArgs...)
 			return err
 		}
 	}
 
-	_, err = j.query.db.Exec(ctx, query, key, string(valueJSON), nil)
+	_, err = j.query.hooked(j.query.db).Exec(ctx, query, key, string(valueJSON), nil)
 	return err
 }
 
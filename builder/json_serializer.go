@@ -0,0 +1,160 @@
+package builder
+
+import (
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// JSONSerializer encodes and decodes the struct and map values of JSON columns.
+// The default uses encoding/json; SetJSONSerializer plugs in a faster implementation.
+type JSONSerializer interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// stdJSONSerializer is the default JSONSerializer, backed by encoding/json
+type stdJSONSerializer struct{}
+
+func (stdJSONSerializer) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdJSONSerializer) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// jsonSerializerHolder lets atomic.Value store different JSONSerializer implementations
+type jsonSerializerHolder struct {
+	serializer JSONSerializer
+}
+
+var jsonSerializer atomic.Value
+
+// SetJSONSerializer sets the serializer used to bind struct and map values to JSON columns
+// and to scan JSON columns into struct and map fields. Like the log levels, it applies to
+// every client; nil restores encoding/json.
+// Example: builder.SetJSONSerializer(sonicSerializer{})
+func SetJSONSerializer(serializer JSONSerializer) {
+	if serializer == nil {
+		serializer = stdJSONSerializer{}
+	}
+	jsonSerializer.Store(jsonSerializerHolder{serializer: serializer})
+}
+
+// getJSONSerializer returns the configured JSONSerializer
+func getJSONSerializer() JSONSerializer {
+	if holder, ok := jsonSerializer.Load().(jsonSerializerHolder); ok {
+		return holder.serializer
+	}
+	return stdJSONSerializer{}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// isJSONType reports whether values of t are stored as JSON: maps and structs that don't
+// convert themselves (time.Time, driver.Valuer or sql.Scanner types such as sql.NullString)
+func isJSONType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Map && t.Kind() != reflect.Struct {
+		return false
+	}
+	if t == timeType {
+		return false
+	}
+	valuerType := reflect.TypeOf((*sqldriver.Valuer)(nil)).Elem()
+	scannerType := reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	return !t.Implements(valuerType) && !reflect.PointerTo(t).Implements(valuerType) &&
+		!reflect.PointerTo(t).Implements(scannerType)
+}
+
+// bindArg returns the query argument for value, marshaling struct and map values
+// with the configured JSONSerializer so they can be bound to JSON columns
+func bindArg(value interface{}) interface{} {
+	if value == nil || !isJSONType(reflect.TypeOf(value)) {
+		return value
+	}
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		return nil
+	}
+	data, err := getJSONSerializer().Marshal(value)
+	if err != nil {
+		return jsonMarshalError{err: err}
+	}
+	return string(data)
+}
+
+// jsonMarshalError is bound in place of a value that failed to marshal, so the
+// driver reports the error when it converts the arguments
+type jsonMarshalError struct {
+	err error
+}
+
+func (e jsonMarshalError) Value() (sqldriver.Value, error) {
+	return nil, fmt.Errorf("failed to marshal JSON value: %w", e.err)
+}
+
+// jsonScanner scans a JSON column into a struct or map field with the configured JSONSerializer
+type jsonScanner struct {
+	field reflect.Value
+}
+
+func (s jsonScanner) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		s.field.Set(reflect.Zero(s.field.Type()))
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		// drivers that decode JSON themselves (e.g. pgx into map[string]interface{}) hand over the value
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return fmt.Errorf("failed to scan JSON column: %w", err)
+		}
+	}
+	target := reflect.New(s.field.Type())
+	if err := getJSONSerializer().Unmarshal(data, target.Interface()); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON column: %w", err)
+	}
+	s.field.Set(target.Elem())
+	return nil
+}
+
+// rawJSONScanner scans a JSON column into a json.RawMessage field, copying the bytes
+// since drivers may reuse their buffers; NULL leaves the field nil
+type rawJSONScanner struct {
+	field reflect.Value
+}
+
+func (s rawJSONScanner) Scan(src interface{}) error {
+	var data json.RawMessage
+	switch v := src.(type) {
+	case nil:
+	case []byte:
+		data = append(json.RawMessage{}, v...)
+	case string:
+		data = json.RawMessage(v)
+	default:
+		// drivers that decode JSON themselves hand over the decoded value
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return fmt.Errorf("failed to scan JSON column: %w", err)
+		}
+	}
+	s.field.SetBytes(data)
+	return nil
+}
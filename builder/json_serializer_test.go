@@ -0,0 +1,176 @@
+package builder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+)
+
+// countingSerializer wraps encoding/json and counts its calls
+type countingSerializer struct {
+	marshals   int
+	unmarshals int
+}
+
+func (s *countingSerializer) Marshal(v interface{}) ([]byte, error) {
+	s.marshals++
+	return json.Marshal(v)
+}
+
+func (s *countingSerializer) Unmarshal(data []byte, v interface{}) error {
+	s.unmarshals++
+	return json.Unmarshal(data, v)
+}
+
+// jsonRowDB records the args of Exec and answers QueryRow with a fixed row
+type jsonRowDB struct {
+	DBTX
+	args []interface{}
+	row  []interface{}
+}
+
+func (d *jsonRowDB) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	d.args = args
+	return taggedResult{rows: 1}, nil
+}
+
+func (d *jsonRowDB) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	return valuesRow(d.row)
+}
+
+// valuesRow hands its values to the Scan destinations the way database/sql does for []byte columns
+type valuesRow []interface{}
+
+func (r valuesRow) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		if scanner, ok := d.(sql.Scanner); ok {
+			if err := scanner.Scan(r[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r[i]))
+	}
+	return nil
+}
+
+type jsonSettings struct {
+	Theme string `json:"theme"`
+}
+
+type jsonAccount struct {
+	ID       int                    `db:"id"`
+	Settings jsonSettings           `db:"settings"`
+	Tags     map[string]interface{} `db:"tags"`
+	Extra    *jsonSettings          `db:"extra"`
+}
+
+// TestJSONSerializer_RoundTrip tests that the configured serializer binds and scans JSON fields
+func TestJSONSerializer_RoundTrip(t *testing.T) {
+	serializer := &countingSerializer{}
+	SetJSONSerializer(serializer)
+	defer SetJSONSerializer(nil)
+
+	ctx := context.Background()
+	columns := []string{"id", "settings", "tags", "extra"}
+	db := &jsonRowDB{}
+	newQuery := func() *Query {
+		q := NewQuery(db, "accounts", columns)
+		q.SetDialect(dialect.GetDialect("postgresql"))
+		return q
+	}
+
+	// Structs e maps viram JSON no INSERT
+	account := jsonAccount{ID: 1, Settings: jsonSettings{Theme: "dark"}, Tags: map[string]interface{}{"vip": true}}
+	if err := newQuery().Create(ctx, account); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if fmt.Sprint(db.args) != `[1 {"theme":"dark"} {"vip":true}]` {
+		t.Errorf("Create args = %v", db.args)
+	}
+	if serializer.marshals != 2 {
+		t.Errorf("expected 2 marshals on Create, got %d", serializer.marshals)
+	}
+
+	// E no UPDATE
+	if err := newQuery().Where("id = ?", 1).Updates(ctx, map[string]interface{}{"settings": jsonSettings{Theme: "light"}}); err != nil {
+		t.Fatalf("Updates failed: %v", err)
+	}
+	if fmt.Sprint(db.args) != `[{"theme":"light"} 1]` {
+		t.Errorf("Updates args = %v", db.args)
+	}
+
+	// O scan decodifica as colunas JSON nos campos
+	db.row = []interface{}{int(1), []byte(`{"theme":"dark"}`), `{"vip":true}`, nil}
+	var scanned jsonAccount
+	if err := newQuery().Where("id = ?", 1).ScanFirst(ctx, &scanned, reflect.TypeOf(jsonAccount{})); err != nil {
+		t.Fatalf("ScanFirst failed: %v", err)
+	}
+	if scanned.Settings.Theme != "dark" || scanned.Tags["vip"] != true || scanned.Extra != nil {
+		t.Errorf("ScanFirst = %+v", scanned)
+	}
+	if serializer.unmarshals != 2 {
+		t.Errorf("expected 2 unmarshals on scan, got %d", serializer.unmarshals)
+	}
+}
+
+// TestJSONSerializer_SkipsScalarTypes tests that time, Valuer and scalar values are bound unchanged
+func TestJSONSerializer_SkipsScalarTypes(t *testing.T) {
+	date := testDate{Year: 2024}
+	for _, value := range []interface{}{"x", 1, date, &date, sql.NullString{String: "x", Valid: true}} {
+		if got := bindArg(value); !reflect.DeepEqual(got, value) {
+			t.Errorf("bindArg(%#v) = %#v, want it unchanged", value, got)
+		}
+	}
+	var nilSettings *jsonSettings
+	if got := bindArg(nilSettings); got != nil {
+		t.Errorf("bindArg(nil pointer) = %#v, want nil", got)
+	}
+}
+
+type rawDocument struct {
+	ID   int             `db:"id"`
+	Body json.RawMessage `db:"body"`
+	Meta json.RawMessage `db:"meta"`
+	Note sql.NullString  `db:"note"`
+}
+
+// TestQuery_ScanModelRawJSON tests that First and Find scan json.RawMessage, sql.Null* and
+// NULL JSON columns into the model like ScanFirst/ScanFind do
+func TestQuery_ScanModelRawJSON(t *testing.T) {
+	ctx := context.Background()
+	columns := []string{"id", "body", "meta", "note"}
+
+	// First lê a linha pelo QueryRow; o buffer do driver é copiado
+	body := []byte(`{"title":"a"}`)
+	rowDB := &jsonRowDB{row: []interface{}{int(1), body, nil, "draft"}}
+	q := NewQuery(rowDB, "documents", columns)
+	q.SetDialect(dialect.GetDialect("postgresql"))
+	q.SetModelType(reflect.TypeOf(rawDocument{}))
+	var doc rawDocument
+	if err := q.First(ctx, &doc); err != nil {
+		t.Fatalf("First failed: %v", err)
+	}
+	body[2] = 'X'
+	if string(doc.Body) != `{"title":"a"}` || doc.Meta != nil || doc.Note != (sql.NullString{String: "draft", Valid: true}) {
+		t.Errorf("First = %+v", doc)
+	}
+
+	// Find aceita JSON como string e NULL no sql.NullString
+	rowsDB := &namedRowsDB{columns: columns, rows: []valuesRow{{int(2), `[1,2]`, []byte(`{}`), nil}}}
+	q = NewQuery(rowsDB, "documents", columns)
+	q.SetDialect(dialect.GetDialect("postgresql"))
+	q.SetModelType(reflect.TypeOf(rawDocument{}))
+	var docs []rawDocument
+	if err := q.Find(ctx, &docs); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(docs) != 1 || string(docs[0].Body) != `[1,2]` || string(docs[0].Meta) != `{}` || docs[0].Note.Valid {
+		t.Errorf("Find = %+v", docs)
+	}
+}
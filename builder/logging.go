@@ -2,7 +2,10 @@ package builder
 
 import (
 	"context"
+	"log/slog"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/carlosnayan/prisma-go-client/internal/logger"
@@ -56,6 +59,18 @@ func (q *Query) logQuery(ctx context.Context, query string, args []interface{},
 // processStart: início de todo o processamento (incluindo construção da query)
 // queryDuration: tempo de execução no banco (já calculado, pode ser diferente de time.Since(queryStart) para QueryRow)
 func (q *Query) logQueryWithTiming(ctx context.Context, query string, args []interface{}, queryStart, processStart time.Time, queryDuration time.Duration) {
+	q.logQueryRows(ctx, query, args, queryStart, processStart, queryDuration, -1)
+}
+
+// logQueryRows é logQueryWithTiming com o número de linhas lidas ou afetadas (-1 se desconhecido).
+// Com um slog.Logger definido (SetSlogLogger) emite um registro debug por query no lugar do logger padrão.
+func (q *Query) logQueryRows(ctx context.Context, query string, args []interface{}, queryStart, processStart time.Time, queryDuration time.Duration, rows int64) {
+	args = q.redactArgs(args)
+	if q.slogLogger != nil {
+		q.logQuerySlog(ctx, query, args, processStart, queryDuration, rows)
+		return
+	}
+
 	logger := q.getLogger()
 	if logger == nil {
 		return
@@ -84,12 +99,130 @@ func (q *Query) logQueryWithTiming(ctx context.Context, query string, args []int
 	}
 }
 
+// logQuerySlog emite a query como um registro estruturado de nível debug
+func (q *Query) logQuerySlog(ctx context.Context, query string, args []interface{}, processStart time.Time, queryDuration time.Duration, rows int64) {
+	if !q.slogLogger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("type", detectQueryType(query)),
+		slog.String("sql", query),
+		slog.Int("args", len(args)),
+		slog.Duration("duration", queryDuration),
+		slog.Duration("total", time.Since(processStart)),
+	}
+	if rows >= 0 {
+		attrs = append(attrs, slog.Int64("rows", rows))
+	}
+	q.slogLogger.LogAttrs(ctx, slog.LevelDebug, "query", attrs...)
+}
+
+// affectedRows retorna as linhas afetadas de result, ou -1 quando a query falhou
+func affectedRows(result Result, err error) int64 {
+	if err != nil || result == nil {
+		return -1
+	}
+	return result.RowsAffected()
+}
+
+// resultRows retorna o tamanho de dest (ponteiro para slice), ou -1 para outros destinos
+func resultRows(dest interface{}) int64 {
+	val := reflect.Indirect(reflect.ValueOf(dest))
+	if val.Kind() != reflect.Slice {
+		return -1
+	}
+	return int64(val.Len())
+}
+
+// rowCountWarning é o limite suave de linhas de Find/ScanFind (0 desativa o aviso)
+var rowCountWarning atomic.Int64
+
+// SetRowCountWarning configura o número de linhas acima do qual Find e ScanFind logam um
+// aviso com a query, sem falhá-la, para revelar queries sem limite em produção.
+// Fica abaixo do limite rígido limits.MaxScanRows; 0 desativa o aviso.
+func SetRowCountWarning(rows int) {
+	rowCountWarning.Store(int64(rows))
+}
+
+// warnOnRowCount loga um aviso quando dest (ponteiro para slice) passou do limite suave
+func (q *Query) warnOnRowCount(query string, dest interface{}) {
+	threshold := rowCountWarning.Load()
+	if threshold <= 0 {
+		return
+	}
+	val := reflect.Indirect(reflect.ValueOf(dest))
+	if val.Kind() != reflect.Slice || int64(val.Len()) <= threshold {
+		return
+	}
+	if logger := q.getLogger(); logger != nil {
+		logger.Warn("Large result: %d rows (warning threshold %d) from %s", val.Len(), threshold, query)
+	}
+}
+
 // setLogger define o logger para a query
 func (q *Query) SetLogger(l *logger.Logger) *Query {
 	q.logger = l
 	return q
 }
 
+// SetSlogLogger faz a query emitir um registro slog de nível debug por execução, com o SQL,
+// o número de args, a duração e as linhas, no lugar do logger padrão. nil volta ao logger padrão.
+func (q *Query) SetSlogLogger(l *slog.Logger) *Query {
+	q.slogLogger = l
+	return q
+}
+
+// CopyLogging copia de from o logger, o logger slog, os hooks e a redação dos args, para que
+// queries derivadas (transações, conexões dedicadas) sejam logadas como a original
+func (q *Query) CopyLogging(from *Query) *Query {
+	if from == nil {
+		return q
+	}
+	q.logger = from.logger
+	q.slogLogger = from.slogLogger
+	q.hooks = append(from.hooks[:0:0], from.hooks...)
+	q.argRedaction = from.argRedaction
+	return q
+}
+
+// ArgRedaction define como os args das queries chegam aos logs e hooks
+type ArgRedaction = logger.ArgRedaction
+
+const (
+	// RedactOff repassa os args como estão, truncando strings e bytes longos
+	RedactOff = logger.RedactOff
+	// RedactFull troca cada arg por ***REDACTED***
+	RedactFull = logger.RedactFull
+	// RedactTypes troca cada arg pelo seu tipo e tamanho (ex.: string(12))
+	RedactTypes = logger.RedactTypes
+)
+
+// SetArgRedaction define o modo de redação dos args de todas as queries sem modo próprio
+func SetArgRedaction(mode ArgRedaction) {
+	logger.SetArgRedaction(mode)
+}
+
+// SetMaxArgLength define o tamanho acima do qual args string e []byte são truncados nos
+// logs e hooks (padrão 100); 0 desativa o truncamento
+func SetMaxArgLength(n int) {
+	logger.SetMaxArgLength(n)
+}
+
+// SetArgRedaction define o modo de redação dos args desta query, no lugar do global
+func (q *Query) SetArgRedaction(mode ArgRedaction) *Query {
+	q.argRedaction = &mode
+	return q
+}
+
+// redactArgs aplica aos args o modo da query, ou o global, antes de logs e hooks
+func (q *Query) redactArgs(args []interface{}) []interface{} {
+	mode := logger.GetArgRedaction()
+	if q.argRedaction != nil {
+		mode = *q.argRedaction
+	}
+	return logger.RedactArgs(args, mode)
+}
+
 // SetLogLevels configura os níveis de log do logger padrão
 // Esta é uma função pública que pode ser usada no código gerado
 func SetLogLevels(levels []string) {
@@ -0,0 +1,122 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuery_SlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	db := &jsonRowDB{}
+	q := NewQuery(db, "users", []string{"id", "name"}).
+		SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if _, err := q.Where("id = ?", 1).UpdatesResult(context.Background(), map[string]interface{}{"name": "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("slog output %q: %v", buf.String(), err)
+	}
+	if record["level"] != "DEBUG" || record["msg"] != "query" || record["type"] != "UPDATE" {
+		t.Errorf("record = %v", record)
+	}
+	if record["sql"] != `UPDATE "users" SET "name" = $1 WHERE id = $2` || record["args"] != float64(2) || record["rows"] != float64(1) {
+		t.Errorf("record = %v", record)
+	}
+	if _, ok := record["duration"]; !ok {
+		t.Errorf("record without duration: %v", record)
+	}
+
+	// Acima do nível debug nada é emitido
+	buf.Reset()
+	q.SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	q.UpdatesResult(context.Background(), map[string]interface{}{"name": "b"})
+	if buf.Len() != 0 {
+		t.Errorf("info-level logger emitted %q", buf.String())
+	}
+}
+
+// argsHook guarda os args recebidos em Before
+type argsHook struct{ args *[]interface{} }
+
+func (h argsHook) Before(ctx context.Context, sql string, args []interface{}) context.Context {
+	*h.args = args
+	return ctx
+}
+
+func (h argsHook) After(ctx context.Context, sql string, err error, duration time.Duration) {}
+
+func TestQuery_ArgRedaction(t *testing.T) {
+	var seen []interface{}
+	db := &jsonRowDB{}
+	q := NewQuery(db, "users", []string{"id", "name", "data"}).AddHook(argsHook{args: &seen})
+	// Um só valor por mapa: a ordem das colunas de Updates segue a iteração do mapa
+	values := map[string]interface{}{"name": strings.Repeat("a", 150)}
+	blob := map[string]interface{}{"data": []byte("xyz")}
+
+	// Padrão: args repassados, strings longas truncadas; o banco recebe os originais
+	q.UpdatesResult(context.Background(), values)
+	if fmt.Sprint(seen) != "["+strings.Repeat("a", 100)+"...(truncated)]" {
+		t.Errorf("default args = %v", seen)
+	}
+	if db.args[0] != strings.Repeat("a", 150) {
+		t.Errorf("database args = %v", db.args)
+	}
+
+	SetArgRedaction(RedactFull)
+	defer SetArgRedaction(RedactOff)
+	q.UpdatesResult(context.Background(), values)
+	if fmt.Sprint(seen) != "[***REDACTED***]" {
+		t.Errorf("global full args = %v", seen)
+	}
+
+	// O modo da query tem precedência sobre o global
+	q.SetArgRedaction(RedactTypes).Where("id = ?", 7)
+	q.UpdatesResult(context.Background(), blob)
+	if fmt.Sprint(seen) != "[[]uint8(3) int]" {
+		t.Errorf("query types args = %v", seen)
+	}
+
+	SetMaxArgLength(0)
+	defer SetMaxArgLength(100)
+	q.SetArgRedaction(RedactOff)
+	q.UpdatesResult(context.Background(), values)
+	if seen[0] != strings.Repeat("a", 150) {
+		t.Errorf("untruncated args = %v", seen)
+	}
+}
+
+func TestQuery_CopyLogging(t *testing.T) {
+	var buf bytes.Buffer
+	var calls []string
+	parent := NewQuery(&jsonRowDB{}, "users", []string{"id", "name"}).
+		SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))).
+		AddHook(recordingHook{name: "parent", calls: &calls})
+
+	// A query derivada, numa outra conexão, loga e chama os hooks como a original
+	db := &jsonRowDB{}
+	derived := NewQuery(db, "users", []string{"id", "name"}).CopyLogging(parent)
+	if _, err := derived.Where("id = ?", 1).UpdatesResult(context.Background(), map[string]interface{}{"name": "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"msg":"query"`) {
+		t.Errorf("derived query not logged: %q", buf.String())
+	}
+	if fmt.Sprint(calls) != "[parent before UPDATE parent after parent]" {
+		t.Errorf("calls = %v", calls)
+	}
+
+	// Hooks adicionados depois à derivada não chegam à original
+	derived.AddHook(recordingHook{name: "derived", calls: &calls})
+	if len(parent.hooks) != 1 {
+		t.Errorf("parent hooks = %d", len(parent.hooks))
+	}
+}
@@ -0,0 +1,78 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// mysqlRowAlias is the alias given to the inserted row when ON DUPLICATE KEY UPDATE
+// references it as alias.col instead of the deprecated VALUES(col)
+const mysqlRowAlias = "new"
+
+// mysqlRowAliasEnabled is set when the configured MySQL server supports row aliases (8.0.19+)
+var mysqlRowAliasEnabled atomic.Bool
+
+// SetMySQLVersion tells the builder which MySQL server version it talks to. From 8.0.20,
+// where VALUES() in ON DUPLICATE KEY UPDATE is deprecated, upserts alias the inserted row
+// (INSERT ... AS new ON DUPLICATE KEY UPDATE col = new.col) and avoid the deprecation warning.
+// Older servers, MariaDB and an empty version keep the VALUES(col) form. Like the log levels,
+// it applies to every client.
+// Example: builder.SetMySQLVersion("8.0.35")
+func SetMySQLVersion(version string) error {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		mysqlRowAliasEnabled.Store(false)
+		return nil
+	}
+	major, minor, patch, ok := parseMySQLVersion(version)
+	if !ok {
+		return fmt.Errorf("%w: invalid MySQL version %q", errors.ErrInvalidInput, version)
+	}
+	mariaDB := strings.Contains(strings.ToLower(version), "mariadb")
+	mysqlRowAliasEnabled.Store(!mariaDB && (major > 8 || major == 8 && (minor > 0 || patch >= 20)))
+	return nil
+}
+
+// parseMySQLVersion parses the numeric part of a server version such as "8.0.35" or
+// "8.0.35-0ubuntu0.22.04.1"; missing minor and patch numbers are 0
+func parseMySQLVersion(version string) (major, minor, patch int, ok bool) {
+	if i := strings.IndexFunc(version, func(r rune) bool { return r != '.' && (r < '0' || r > '9') }); i >= 0 {
+		version = version[:i]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		numbers[i] = n
+	}
+	return numbers[0], numbers[1], numbers[2], true
+}
+
+// mysqlDuplicateKeyUpdate builds the ON DUPLICATE KEY UPDATE suffix that sets columns to
+// the inserted values, with the row alias when the configured server supports it
+func mysqlDuplicateKeyUpdate(d dialect.Dialect, columns []string) string {
+	parts := make([]string, len(columns))
+	if mysqlRowAliasEnabled.Load() {
+		for i, col := range columns {
+			quotedCol := d.QuoteIdentifier(col)
+			parts[i] = fmt.Sprintf("%s = %s.%s", quotedCol, mysqlRowAlias, quotedCol)
+		}
+		return fmt.Sprintf(" AS %s ON DUPLICATE KEY UPDATE %s", mysqlRowAlias, strings.Join(parts, ", "))
+	}
+	for i, col := range columns {
+		quotedCol := d.QuoteIdentifier(col)
+		parts[i] = fmt.Sprintf("%s = VALUES(%s)", quotedCol, quotedCol)
+	}
+	return " ON DUPLICATE KEY UPDATE " + strings.Join(parts, ", ")
+}
@@ -22,6 +22,15 @@ type OrderBy struct {
 
 	// Order direction: "ASC" or "DESC"
 	Order string
+
+	// values orders by the position of Field's value in the list (see Query.OrderByValues)
+	values []interface{}
+
+	// insensitive orders by LOWER(Field) (see Query.OrderInsensitive)
+	insensitive bool
+
+	// collate is the dialect's COLLATE clause appended to Field (see Query.Collate)
+	collate string
 }
 
 // Ptr is a helper function to create a pointer to an int
@@ -33,4 +42,24 @@ func Ptr(i int) *int {
 type BatchPayload struct {
 	// Count is the number of records affected
 	Count int
+	// Skipped is the number of records CreateMany left out because they already exist
+	// (IgnoreExisting); 0 for other operations
+	Skipped int
 }
+
+// PKConflictMode controls how Create behaves when the data carries a pre-set primary key
+type PKConflictMode int
+
+const (
+	// PKConflictInsert issues a plain INSERT (default). A colliding primary key
+	// surfaces as the raw driver error.
+	PKConflictInsert PKConflictMode = iota
+
+	// PKConflictUpsert turns the INSERT into an upsert on the primary key, updating
+	// the existing row with the provided values.
+	PKConflictUpsert
+
+	// PKConflictError maps unique violations to ErrUniqueConstraint so callers can
+	// detect collisions with errors.Is.
+	PKConflictError
+)
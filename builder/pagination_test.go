@@ -2,6 +2,7 @@ package builder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -408,3 +409,72 @@ func TestQueryOptions_TakeAndSkip(t *testing.T) {
 		})
 	}
 }
+
+// TestQuery_Cursor tests keyset pagination with Cursor() in both directions
+func TestQuery_Cursor(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			statements := []string{
+				"DROP TABLE IF EXISTS cursor_pagination_test",
+				"CREATE TABLE cursor_pagination_test (id INT PRIMARY KEY, name VARCHAR(255) NOT NULL)",
+			}
+			for i := 1; i <= 10; i++ {
+				statements = append(statements, fmt.Sprintf("INSERT INTO cursor_pagination_test (id, name) VALUES (%d, 'User %d')", i, i))
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			type TestRecord struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			}
+
+			newQuery := func() *Query {
+				query := NewQuery(db, "cursor_pagination_test", []string{"id", "name"})
+				query.SetDialect(dialect.GetDialect(provider))
+				query.SetModelType(reflect.TypeOf(TestRecord{}))
+				return query
+			}
+
+			// Página seguinte em ordem crescente
+			var page []TestRecord
+			if err := newQuery().Order("id ASC").Cursor("id", 3).Take(3).Find(ctx, &page); err != nil {
+				t.Fatalf("Find with Cursor failed: %v", err)
+			}
+			if len(page) != 3 || page[0].ID != 4 || page[2].ID != 6 {
+				t.Errorf("Expected ids 4..6, got %+v", page)
+			}
+
+			// Página seguinte em ordem decrescente
+			page = nil
+			if err := newQuery().Cursor("id", 8).Order("id DESC").Take(3).Find(ctx, &page); err != nil {
+				t.Fatalf("Find with Cursor DESC failed: %v", err)
+			}
+			if len(page) != 3 || page[0].ID != 7 || page[2].ID != 5 {
+				t.Errorf("Expected ids 7..5, got %+v", page)
+			}
+
+			// Cursor sem Order correspondente
+			page = nil
+			err := newQuery().Order("name ASC").Cursor("id", 3).Find(ctx, &page)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("Expected ErrInvalidInput for cursor without order, got %v", err)
+			}
+		})
+	}
+}
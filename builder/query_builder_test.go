@@ -1,12 +1,16 @@
 package builder
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+	"github.com/carlosnayan/prisma-go-client/internal/logger"
 	testutil "github.com/carlosnayan/prisma-go-client/internal/testing"
 )
 
@@ -875,3 +879,1098 @@ func TestTableQueryBuilder_Create_WithTransaction_DBTags(t *testing.T) {
 		})
 	}
 }
+
+// TestTableQueryBuilder_Create_WithProvidedPK testa Create com primary key pré-definida
+// nos modos padrão (INSERT), PKConflictError e PKConflictUpsert
+func TestTableQueryBuilder_Create_WithProvidedPK(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+
+			var createTableSQL string
+			switch provider {
+			case "postgresql":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS books (
+						id SERIAL PRIMARY KEY,
+						title VARCHAR(255) NOT NULL,
+						author VARCHAR(255) NOT NULL,
+						isbn VARCHAR(50),
+						created_at TIMESTAMP DEFAULT NOW()
+					)
+				`
+			case "mysql":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS books (
+						id INT AUTO_INCREMENT PRIMARY KEY,
+						title VARCHAR(255) NOT NULL,
+						author VARCHAR(255) NOT NULL,
+						isbn VARCHAR(50),
+						created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+					)
+				`
+			case "sqlite":
+				createTableSQL = `
+					CREATE TABLE IF NOT EXISTS books (
+						id INTEGER PRIMARY KEY AUTOINCREMENT,
+						title TEXT NOT NULL,
+						author TEXT NOT NULL,
+						isbn TEXT,
+						created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+					)
+				`
+			}
+
+			_, err := sqlDB.ExecContext(ctx, createTableSQL)
+			if err != nil {
+				t.Fatalf("failed to create table: %v", err)
+			}
+
+			columns := []string{"id", "title", "author", "isbn", "created_at"}
+			newBuilder := func(mode PKConflictMode) *TableQueryBuilder {
+				builder := NewTableQueryBuilder(db, "books", columns)
+				builder.SetDialect(dialect.GetDialect(provider))
+				builder.SetPrimaryKey("id")
+				builder.SetModelType(reflect.TypeOf(Book{}))
+				builder.SetPKConflictMode(mode)
+				return builder
+			}
+
+			// Insert com PK informada deve funcionar no modo padrão
+			_, err = newBuilder(PKConflictInsert).Create(ctx, Book{ID: 42, Title: "Original", Author: "Author A", ISBN: "978-0"})
+			if err != nil {
+				t.Fatalf("Create with provided PK failed: %v", err)
+			}
+
+			// Modo padrão: colisão retorna o erro do driver
+			_, err = newBuilder(PKConflictInsert).Create(ctx, Book{ID: 42, Title: "Duplicate", Author: "Author B", ISBN: "978-0"})
+			if err == nil {
+				t.Fatal("Expected error on duplicate primary key")
+			}
+
+			// PKConflictError: colisão retorna ErrUniqueConstraint
+			_, err = newBuilder(PKConflictError).Create(ctx, Book{ID: 42, Title: "Duplicate", Author: "Author B", ISBN: "978-0"})
+			if !errors.Is(err, ErrUniqueConstraint) {
+				t.Fatalf("Expected ErrUniqueConstraint, got %v", err)
+			}
+			if !errors.Is(err, ErrDuplicateKey) {
+				t.Errorf("Expected ErrDuplicateKey to match ErrUniqueConstraint, got %v", err)
+			}
+
+			// PKConflictUpsert: colisão atualiza o registro existente
+			_, err = newBuilder(PKConflictUpsert).Create(ctx, Book{ID: 42, Title: "Updated", Author: "Author C", ISBN: "978-0"})
+			if err != nil {
+				t.Fatalf("Create with PKConflictUpsert failed: %v", err)
+			}
+
+			found, err := newBuilder(PKConflictInsert).FindFirst(ctx, Where{"id": 42})
+			if err != nil {
+				t.Fatalf("FindFirst failed: %v", err)
+			}
+			book, ok := found.(Book)
+			if !ok {
+				t.Fatal("FindFirst returned wrong type")
+			}
+			if book.Title != "Updated" || book.Author != "Author C" {
+				t.Errorf("Expected upserted book 'Updated'/'Author C', got '%s'/'%s'", book.Title, book.Author)
+			}
+
+			count, err := newBuilder(PKConflictInsert).Count(ctx, Where{})
+			if err != nil {
+				t.Fatalf("Count failed: %v", err)
+			}
+			if count != 1 {
+				t.Errorf("Expected 1 book, got %d", count)
+			}
+		})
+	}
+}
+
+// TestQuery_Delete_Cascade tests deleting a parent together with children across two tables
+func TestQuery_Delete_Cascade(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			// Tabelas sem FOREIGN KEY: o cascade é feito pela aplicação
+			statements := []string{
+				"DROP TABLE IF EXISTS cascade_reviews",
+				"DROP TABLE IF EXISTS cascade_books",
+				"DROP TABLE IF EXISTS cascade_authors",
+				"CREATE TABLE cascade_authors (id INT PRIMARY KEY, name VARCHAR(255) NOT NULL)",
+				"CREATE TABLE cascade_books (id INT PRIMARY KEY, author_id INT NOT NULL, title VARCHAR(255) NOT NULL)",
+				"CREATE TABLE cascade_reviews (id INT PRIMARY KEY, book_id INT NOT NULL, body VARCHAR(255) NOT NULL)",
+				"INSERT INTO cascade_authors (id, name) VALUES (1, 'Machado'), (2, 'Clarice')",
+				"INSERT INTO cascade_books (id, author_id, title) VALUES (10, 1, 'Dom Casmurro'), (11, 1, 'Helena'), (20, 2, 'A Hora da Estrela')",
+				"INSERT INTO cascade_reviews (id, book_id, body) VALUES (100, 10, 'a'), (101, 11, 'b'), (200, 20, 'c')",
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			q := NewQuery(db, "cascade_authors", []string{"id", "name"})
+			q.SetDialect(dialect.GetDialect(provider))
+			q.SetPrimaryKey("id")
+			q.Where("id = ?", 1).Cascade(CascadeRelation{
+				Table:             "cascade_books",
+				Columns:           []string{"author_id"},
+				ReferencedColumns: []string{"id"},
+				Children: []CascadeRelation{{
+					Table:             "cascade_reviews",
+					Columns:           []string{"book_id"},
+					ReferencedColumns: []string{"id"},
+				}},
+			})
+
+			if err := q.Delete(ctx, nil); err != nil {
+				t.Fatalf("Delete with cascade failed: %v", err)
+			}
+
+			// Apenas as linhas do autor 2 devem permanecer
+			for table, want := range map[string]int{"cascade_authors": 1, "cascade_books": 1, "cascade_reviews": 1} {
+				var count int
+				if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&count); err != nil {
+					t.Fatalf("failed to count %s: %v", table, err)
+				}
+				if count != want {
+					t.Errorf("expected %d rows in %s, got %d", want, table, count)
+				}
+			}
+
+			var remaining int
+			if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM cascade_reviews WHERE book_id = 20").Scan(&remaining); err != nil {
+				t.Fatalf("failed to count reviews: %v", err)
+			}
+			if remaining != 1 {
+				t.Errorf("review of the other author should be kept, got %d", remaining)
+			}
+		})
+	}
+}
+
+// TestQuery_Delete_CascadeActions tests that SetNull children are detached and that Restrict
+// children fail the delete and roll back what ran before
+func TestQuery_Delete_CascadeActions(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			statements := []string{
+				"DROP TABLE IF EXISTS action_loans",
+				"DROP TABLE IF EXISTS action_notes",
+				"DROP TABLE IF EXISTS action_members",
+				"CREATE TABLE action_members (id INT PRIMARY KEY, name VARCHAR(255) NOT NULL)",
+				"CREATE TABLE action_notes (id INT PRIMARY KEY, member_id INT NULL)",
+				"CREATE TABLE action_loans (id INT PRIMARY KEY, member_id INT NOT NULL)",
+				"INSERT INTO action_members (id, name) VALUES (1, 'Ana'), (2, 'Bia')",
+				"INSERT INTO action_notes (id, member_id) VALUES (10, 1), (20, 2)",
+				"INSERT INTO action_loans (id, member_id) VALUES (100, 2)",
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			relations := []CascadeRelation{
+				{Table: "action_notes", Columns: []string{"member_id"}, ReferencedColumns: []string{"id"}, OnDelete: "SetNull"},
+				{Table: "action_loans", Columns: []string{"member_id"}, ReferencedColumns: []string{"id"}, OnDelete: "Restrict"},
+			}
+			newQuery := func() *Query {
+				q := NewQuery(db, "action_members", []string{"id", "name"})
+				q.SetDialect(dialect.GetDialect(provider))
+				q.SetPrimaryKey("id")
+				return q
+			}
+
+			// O membro 1 não tem empréstimos: a nota fica sem dono
+			if n, err := newQuery().Where("id = ?", 1).Cascade(relations...).DeleteResult(ctx, nil); err != nil || n != 1 {
+				t.Fatalf("DeleteResult = %d, %v, want 1", n, err)
+			}
+			var orphaned int
+			if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM action_notes WHERE id = 10 AND member_id IS NULL").Scan(&orphaned); err != nil {
+				t.Fatalf("failed to count notes: %v", err)
+			}
+			if orphaned != 1 {
+				t.Errorf("note of member 1 should have member_id NULL, got %d matching rows", orphaned)
+			}
+
+			// O membro 2 tem um empréstimo: nada é removido nem alterado
+			if _, err := newQuery().Where("id = ?", 2).Cascade(relations...).DeleteResult(ctx, nil); !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("Delete with a Restrict child error = %v, want ErrInvalidInput", err)
+			}
+			var members, notes int
+			if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM action_members WHERE id = 2").Scan(&members); err != nil {
+				t.Fatalf("failed to count members: %v", err)
+			}
+			if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM action_notes WHERE member_id = 2").Scan(&notes); err != nil {
+				t.Fatalf("failed to count notes: %v", err)
+			}
+			if members != 1 || notes != 1 {
+				t.Errorf("restricted delete should change nothing, got %d members and %d notes", members, notes)
+			}
+		})
+	}
+}
+
+// TestQuery_JsonArrayFilters tests filtering rows by JSON array length and element
+func TestQuery_JsonArrayFilters(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			jsonType := map[string]string{"postgresql": "JSONB", "mysql": "JSON", "sqlite": "TEXT"}[provider]
+			statements := []string{
+				"DROP TABLE IF EXISTS json_array_posts",
+				"CREATE TABLE json_array_posts (id INT PRIMARY KEY, tags " + jsonType + " NOT NULL)",
+				`INSERT INTO json_array_posts (id, tags) VALUES (1, '["go"]'), (2, '["go", "sql", "json", "orm"]'), (3, '["sql", "go"]')`,
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			type Post struct {
+				ID int `json:"id" db:"id"`
+			}
+
+			find := func(where Where) []Post {
+				q := NewQuery(db, "json_array_posts", []string{"id"})
+				q.SetDialect(dialect.GetDialect(provider))
+				q.SetModelType(reflect.TypeOf(Post{}))
+				q.Where(where).Order("id ASC")
+				var posts []Post
+				if err := q.Find(ctx, &posts); err != nil {
+					t.Fatalf("Find failed: %v", err)
+				}
+				return posts
+			}
+
+			if posts := find(Where{"tags": JsonArrayLength(">", 3)}); len(posts) != 1 || posts[0].ID != 2 {
+				t.Errorf("expected post 2 for length > 3, got %+v", posts)
+			}
+			if posts := find(Where{"tags": JsonArrayLength("<=", 2)}); len(posts) != 2 {
+				t.Errorf("expected 2 posts for length <= 2, got %+v", posts)
+			}
+			if posts := find(Where{"tags": JsonArrayElement(0, "sql")}); len(posts) != 1 || posts[0].ID != 3 {
+				t.Errorf("expected post 3 for tags[0] = sql, got %+v", posts)
+			}
+			if posts := find(Where{"tags": JsonPath([]string{"1"}, "=", "sql")}); len(posts) != 1 || posts[0].ID != 2 {
+				t.Errorf("expected post 2 for path [1] = sql, got %+v", posts)
+			}
+		})
+	}
+}
+
+// TestQuery_Count_ExactAndEstimated tests exact counting and the estimated count fallback
+func TestQuery_Count_ExactAndEstimated(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			statements := []string{
+				"DROP TABLE IF EXISTS count_stats_items",
+				"CREATE TABLE count_stats_items (id INT PRIMARY KEY, kind VARCHAR(20) NOT NULL)",
+				"INSERT INTO count_stats_items (id, kind) VALUES (1, 'a'), (2, 'b'), (3, 'b')",
+			}
+			if provider == "postgresql" {
+				// Atualiza pg_class.reltuples para a estimativa
+				statements = append(statements, "ANALYZE count_stats_items")
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			q := NewQuery(db, "count_stats_items", []string{"id", "kind"})
+			q.SetDialect(dialect.GetDialect(provider))
+
+			count, err := q.Where("kind = ?", "b").Count(ctx)
+			if err != nil {
+				t.Fatalf("Count failed: %v", err)
+			}
+			if count != 2 {
+				t.Errorf("expected 2 rows of kind b, got %d", count)
+			}
+
+			count, err = q.Reset().Count(ctx)
+			if err != nil {
+				t.Fatalf("Count failed: %v", err)
+			}
+			if count != 3 {
+				t.Errorf("expected 3 rows, got %d", count)
+			}
+
+			estimate, err := q.Reset().EstimatedCount(ctx)
+			if err != nil {
+				t.Fatalf("EstimatedCount failed: %v", err)
+			}
+			if estimate != 3 {
+				t.Errorf("expected estimate of 3 rows, got %d", estimate)
+			}
+		})
+	}
+}
+
+// TestQuery_RunInTransaction_CreateWithChildren tests creating a user with several posts atomically
+func TestQuery_RunInTransaction_CreateWithChildren(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	type User struct {
+		ID    int    `json:"id" db:"id"`
+		Email string `json:"email" db:"email"`
+	}
+	type Post struct {
+		ID       int    `json:"id" db:"id"`
+		AuthorID int    `json:"author_id" db:"author_id"`
+		Title    string `json:"title" db:"title"`
+	}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			idColumn := map[string]string{
+				"postgresql": "id SERIAL PRIMARY KEY",
+				"mysql":      "id INT AUTO_INCREMENT PRIMARY KEY",
+				"sqlite":     "id INTEGER PRIMARY KEY AUTOINCREMENT",
+			}[provider]
+			statements := []string{
+				"DROP TABLE IF EXISTS nested_posts",
+				"DROP TABLE IF EXISTS nested_users",
+				"CREATE TABLE nested_users (" + idColumn + ", email VARCHAR(255) NOT NULL)",
+				"CREATE TABLE nested_posts (" + idColumn + ", author_id INT NOT NULL, title VARCHAR(255) NOT NULL)",
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			q := NewQuery(db, "nested_users", []string{"id", "email"})
+			q.SetDialect(dialect.GetDialect(provider))
+			q.SetPrimaryKey("id")
+
+			createWithPosts := func(email string, titles ...string) (*User, error) {
+				var created User
+				err := q.RunInTransaction(ctx, func(tx DBTX) error {
+					users := NewTableQueryBuilder(tx, "nested_users", []string{"id", "email"})
+					users.SetDialect(dialect.GetDialect(provider))
+					users.SetPrimaryKey("id")
+					users.SetModelType(reflect.TypeOf(User{}))
+					users.SetFetchCreated(true)
+					result, err := users.Create(ctx, User{Email: email})
+					if err != nil {
+						return err
+					}
+					created = result.(User)
+
+					posts := make([]interface{}, len(titles))
+					for i, title := range titles {
+						if title == "" {
+							return errors.New("title is required")
+						}
+						posts[i] = Post{AuthorID: created.ID, Title: title}
+					}
+					postsBuilder := NewTableQueryBuilder(tx, "nested_posts", []string{"id", "author_id", "title"})
+					postsBuilder.SetDialect(dialect.GetDialect(provider))
+					postsBuilder.SetPrimaryKey("id")
+					_, err = postsBuilder.CreateMany(ctx, posts, false)
+					return err
+				})
+				return &created, err
+			}
+
+			user, err := createWithPosts("ana@example.com", "first", "second", "third")
+			if err != nil {
+				t.Fatalf("create with posts failed: %v", err)
+			}
+			if user.ID == 0 {
+				t.Fatal("expected the created user to have its ID")
+			}
+
+			var count int
+			if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM nested_posts").Scan(&count); err != nil {
+				t.Fatalf("failed to count posts: %v", err)
+			}
+			if count != 3 {
+				t.Errorf("expected 3 posts, got %d", count)
+			}
+
+			// Um post inválido deve desfazer também a criação do usuário
+			if _, err := createWithPosts("bia@example.com", "ok", ""); err == nil {
+				t.Fatal("expected an error for a post without title")
+			}
+			if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM nested_users").Scan(&count); err != nil {
+				t.Fatalf("failed to count users: %v", err)
+			}
+			if count != 1 {
+				t.Errorf("expected the failed create to be rolled back, got %d users", count)
+			}
+		})
+	}
+}
+
+// TestQuery_Aggregates tests SUM, AVG, MIN and MAX, including the empty (NULL) case
+func TestQuery_Aggregates(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			statements := []string{
+				"DROP TABLE IF EXISTS aggregate_orders",
+				"CREATE TABLE aggregate_orders (id INT PRIMARY KEY, status VARCHAR(20) NOT NULL, total INT NOT NULL)",
+				"INSERT INTO aggregate_orders (id, status, total) VALUES (1, 'paid', 10), (2, 'paid', 30), (3, 'open', 5)",
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			newQuery := func(status string) *Query {
+				q := NewQuery(db, "aggregate_orders", []string{"id", "status", "total"})
+				q.SetDialect(dialect.GetDialect(provider))
+				return q.Where("status = ?", status)
+			}
+
+			sum, err := newQuery("paid").Sum(ctx, "total")
+			if err != nil || sum != 40 {
+				t.Errorf("Sum = %v, %v; want 40", sum, err)
+			}
+			avg, err := newQuery("paid").Avg(ctx, "total")
+			if err != nil || avg != 20 {
+				t.Errorf("Avg = %v, %v; want 20", avg, err)
+			}
+			minValue, err := newQuery("paid").Min(ctx, "total")
+			if minTotal, _ := aggregateFloat(minValue, "MIN"); err != nil || minTotal != 10 {
+				t.Errorf("Min = %v, %v; want 10", minValue, err)
+			}
+			maxValue, err := newQuery("paid").Max(ctx, "total")
+			if maxTotal, _ := aggregateFloat(maxValue, "MAX"); err != nil || maxTotal != 30 {
+				t.Errorf("Max = %v, %v; want 30", maxValue, err)
+			}
+
+			// Sem linhas o resultado é NULL, que deve virar 0 sem erro
+			sum, err = newQuery("cancelled").Sum(ctx, "total")
+			if err != nil || sum != 0 {
+				t.Errorf("Sum over no rows = %v, %v; want 0", sum, err)
+			}
+			avg, err = newQuery("cancelled").Avg(ctx, "total")
+			if err != nil || avg != 0 {
+				t.Errorf("Avg over no rows = %v, %v; want 0", avg, err)
+			}
+			if minValue, err := newQuery("cancelled").Min(ctx, "total"); err != nil || minValue != nil {
+				t.Errorf("Min over no rows = %v, %v; want nil", minValue, err)
+			}
+		})
+	}
+}
+
+// TestQuery_GroupAggregate tests grouping with aggregates scanned into a struct by alias
+func TestQuery_GroupAggregate(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	type orderGroup struct {
+		Status string   `db:"status"`
+		Count  int64    `db:"_count"`
+		Sum    *float64 `db:"_sum_total"`
+	}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			statements := []string{
+				"DROP TABLE IF EXISTS group_orders",
+				"CREATE TABLE group_orders (id INT PRIMARY KEY, status VARCHAR(20) NOT NULL, total INT NOT NULL)",
+				"INSERT INTO group_orders (id, status, total) VALUES (1, 'paid', 10), (2, 'paid', 30), (3, 'open', 5)",
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			q := NewQuery(db, "group_orders", []string{"id", "status", "total"})
+			q.SetDialect(dialect.GetDialect(provider))
+			q.Order("status")
+
+			var groups []orderGroup
+			aggregates := []GroupAggregation{{Func: "COUNT"}, {Func: "SUM", Field: "total"}}
+			if err := q.GroupAggregate(ctx, []string{"status"}, aggregates, &groups); err != nil {
+				t.Fatalf("GroupAggregate failed: %v", err)
+			}
+			if len(groups) != 2 {
+				t.Fatalf("expected 2 groups, got %+v", groups)
+			}
+			if groups[0].Status != "open" || groups[0].Count != 1 || groups[0].Sum == nil || *groups[0].Sum != 5 {
+				t.Errorf("unexpected open group: %+v", groups[0])
+			}
+			if groups[1].Status != "paid" || groups[1].Count != 2 || groups[1].Sum == nil || *groups[1].Sum != 40 {
+				t.Errorf("unexpected paid group: %+v", groups[1])
+			}
+
+			// HAVING filtra os grupos pelo resultado da agregação
+			q = NewQuery(db, "group_orders", []string{"id", "status", "total"})
+			q.SetDialect(dialect.GetDialect(provider))
+			q.Having("COUNT(*) > ?", 1)
+			groups = nil
+			if err := q.GroupAggregate(ctx, []string{"status"}, aggregates, &groups); err != nil {
+				t.Fatalf("GroupAggregate with HAVING failed: %v", err)
+			}
+			if len(groups) != 1 || groups[0].Status != "paid" {
+				t.Errorf("expected only the paid group, got %+v", groups)
+			}
+		})
+	}
+}
+
+func TestQuery_Pluck(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			statements := []string{
+				"DROP TABLE IF EXISTS pluck_users",
+				"CREATE TABLE pluck_users (id INT PRIMARY KEY, email VARCHAR(100) NOT NULL, active INT NOT NULL)",
+				"INSERT INTO pluck_users (id, email, active) VALUES (1, 'a@example.com', 1), (2, 'b@example.com', 0), (3, 'c@example.com', 1)",
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			newQuery := func() *Query {
+				q := NewQuery(db, "pluck_users", []string{"id", "email", "active"})
+				q.SetDialect(dialect.GetDialect(provider))
+				return q
+			}
+
+			// Respeita WHERE e ORDER BY
+			var emails []string
+			if err := newQuery().Where(Where{"active": 1}).Order("id DESC").Pluck(ctx, "email", &emails); err != nil {
+				t.Fatalf("Pluck failed: %v", err)
+			}
+			if len(emails) != 2 || emails[0] != "c@example.com" || emails[1] != "a@example.com" {
+				t.Errorf("unexpected emails: %v", emails)
+			}
+
+			// Respeita Take/Skip e converte para o tipo do elemento
+			var ids []int
+			if err := newQuery().Order("id ASC").Skip(1).Take(1).Pluck(ctx, "id", &ids); err != nil {
+				t.Fatalf("Pluck with Take/Skip failed: %v", err)
+			}
+			if len(ids) != 1 || ids[0] != 2 {
+				t.Errorf("expected [2], got %v", ids)
+			}
+
+			// dest precisa ser ponteiro para slice
+			var single string
+			if err := newQuery().Pluck(ctx, "email", &single); err == nil {
+				t.Error("expected an error when dest is not a pointer to slice")
+			}
+		})
+	}
+}
+
+func TestQuery_OrderByValues(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	type user struct {
+		ID    int    `db:"id"`
+		Email string `db:"email"`
+	}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			statements := []string{
+				"DROP TABLE IF EXISTS ordered_users",
+				"CREATE TABLE ordered_users (id INT PRIMARY KEY, email VARCHAR(100) NOT NULL)",
+				"INSERT INTO ordered_users (id, email) VALUES (1, 'a@example.com'), (2, 'b@example.com'), (3, 'c@example.com'), (4, 'd@example.com')",
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			// Os resultados voltam na ordem dos ids de entrada
+			ids := []interface{}{3, 1, 4, 2}
+			q := NewQuery(db, "ordered_users", []string{"id", "email"})
+			q.SetDialect(dialect.GetDialect(provider))
+			q.SetModelType(reflect.TypeOf(user{}))
+
+			var users []user
+			if err := q.Where(Where{"id": In(ids...)}).OrderByValues("id", ids).Find(ctx, &users); err != nil {
+				t.Fatalf("Find with OrderByValues failed: %v", err)
+			}
+			if len(users) != len(ids) {
+				t.Fatalf("expected %d users, got %+v", len(ids), users)
+			}
+			for i, id := range ids {
+				if users[i].ID != id {
+					t.Errorf("position %d: expected id %v, got %d", i, id, users[i].ID)
+				}
+			}
+
+			// Valores fora da lista ficam no final
+			var plucked []int
+			q = NewQuery(db, "ordered_users", []string{"id", "email"})
+			q.SetDialect(dialect.GetDialect(provider))
+			if err := q.OrderByValues("id", []interface{}{2}).Order("id DESC").Pluck(ctx, "id", &plucked); err != nil {
+				t.Fatalf("Pluck with OrderByValues failed: %v", err)
+			}
+			if len(plucked) != 4 || plucked[0] != 2 || plucked[1] != 4 || plucked[3] != 1 {
+				t.Errorf("expected [2 4 3 1], got %v", plucked)
+			}
+		})
+	}
+}
+
+func TestQuery_WhereTupleInExec(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			statements := []string{
+				"DROP TABLE IF EXISTS tuple_posts",
+				"CREATE TABLE tuple_posts (id INT PRIMARY KEY, author_id INT NOT NULL, title VARCHAR(100) NOT NULL)",
+				"INSERT INTO tuple_posts (id, author_id, title) VALUES (1, 1, 'Go'), (2, 1, 'SQL'), (3, 2, 'Go'), (4, 2, 'SQL')",
+			}
+			for _, stmt := range statements {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			// Busca em lote pela chave composta (author_id, title)
+			q := NewQuery(db, "tuple_posts", []string{"id", "author_id", "title"})
+			q.SetDialect(dialect.GetDialect(provider))
+
+			var ids []int
+			rows := [][]interface{}{{1, "SQL"}, {2, "Go"}, {3, "Go"}}
+			if err := q.Where("id > ?", 0).WhereTupleIn([]string{"author_id", "title"}, rows).Order("id").Pluck(ctx, "id", &ids); err != nil {
+				t.Fatalf("WhereTupleIn failed: %v", err)
+			}
+			if len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+				t.Errorf("expected [2 3], got %v", ids)
+			}
+		})
+	}
+}
+
+func TestQuery_Returning(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	type account struct {
+		ID      int    `db:"id"`
+		Email   string `db:"email"`
+		Credits int    `db:"credits"`
+	}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			createTableSQL := "CREATE TABLE returning_accounts (id SERIAL PRIMARY KEY, email VARCHAR(100) NOT NULL, credits INT NOT NULL DEFAULT 10)"
+			switch provider {
+			case "mysql":
+				createTableSQL = "CREATE TABLE returning_accounts (id INT AUTO_INCREMENT PRIMARY KEY, email VARCHAR(100) NOT NULL, credits INT NOT NULL DEFAULT 10)"
+			case "sqlite":
+				createTableSQL = "CREATE TABLE returning_accounts (id INTEGER PRIMARY KEY AUTOINCREMENT, email TEXT NOT NULL, credits INT NOT NULL DEFAULT 10)"
+			}
+
+			ctx := context.Background()
+			for _, stmt := range []string{"DROP TABLE IF EXISTS returning_accounts", createTableSQL} {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			newQuery := func() *Query {
+				q := NewQuery(db, "returning_accounts", []string{"id", "email", "credits"})
+				q.SetDialect(dialect.GetDialect(provider))
+				q.SetPrimaryKey("id")
+				return q
+			}
+
+			// Create preenche o id gerado e o default do banco
+			created := account{Email: "a@example.com"}
+			if err := newQuery().Returning().Create(ctx, &created); err != nil {
+				t.Fatalf("Create with Returning failed: %v", err)
+			}
+			if created.ID == 0 || created.Credits != 10 || created.Email != "a@example.com" {
+				t.Errorf("unexpected created account: %+v", created)
+			}
+			second := account{Email: "b@example.com"}
+			if err := newQuery().Returning("id").Create(ctx, &second); err != nil {
+				t.Fatalf("Create with Returning failed: %v", err)
+			}
+
+			// Updates devolve as linhas atualizadas, mesmo quando o WHERE deixa de casar
+			var updated []account
+			err := newQuery().Where("credits = ?", 10).Returning("id", "credits").
+				Updates(ctx, map[string]interface{}{"credits": 20}, &updated)
+			if err != nil {
+				t.Fatalf("Updates with Returning failed: %v", err)
+			}
+			if len(updated) != 2 || updated[0].Credits != 20 || updated[1].Credits != 20 {
+				t.Errorf("unexpected updated accounts: %+v", updated)
+			}
+
+			// Delete devolve a linha removida
+			var deleted account
+			if err := newQuery().Where("id = ?", created.ID).Returning().Delete(ctx, &deleted); err != nil {
+				t.Fatalf("Delete with Returning failed: %v", err)
+			}
+			if deleted.ID != created.ID || deleted.Email != "a@example.com" || deleted.Credits != 20 {
+				t.Errorf("unexpected deleted account: %+v", deleted)
+			}
+			count, err := newQuery().Count(ctx)
+			if err != nil || count != 1 {
+				t.Errorf("expected 1 remaining account, got %d (%v)", count, err)
+			}
+		})
+	}
+}
+
+// TestQuery_RowsAffected testa que UpdatesResult e DeleteResult devolvem o número de linhas afetadas
+func TestQuery_RowsAffected(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			for _, stmt := range []string{
+				"DROP TABLE IF EXISTS affected_items",
+				"CREATE TABLE affected_items (id INT PRIMARY KEY, status VARCHAR(20) NOT NULL)",
+				"INSERT INTO affected_items (id, status) VALUES (1, 'open'), (2, 'open'), (3, 'closed')",
+			} {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			newQuery := func() *Query {
+				q := NewQuery(db, "affected_items", []string{"id", "status"})
+				q.SetDialect(dialect.GetDialect(provider))
+				q.SetPrimaryKey("id")
+				return q
+			}
+
+			n, err := newQuery().Where("status = ?", "open").UpdatesResult(ctx, map[string]interface{}{"status": "pending"})
+			if err != nil {
+				t.Fatalf("UpdatesResult failed: %v", err)
+			}
+			if n != 2 {
+				t.Errorf("UpdatesResult = %d, want 2", n)
+			}
+
+			// Nenhuma linha casa com o WHERE
+			n, err = newQuery().Where("id = ?", 42).UpdatesResult(ctx, map[string]interface{}{"status": "pending"})
+			if err != nil {
+				t.Fatalf("UpdatesResult failed: %v", err)
+			}
+			if n != 0 {
+				t.Errorf("UpdatesResult without matches = %d, want 0", n)
+			}
+
+			n, err = newQuery().Where("status = ?", "closed").DeleteResult(ctx, nil)
+			if err != nil {
+				t.Fatalf("DeleteResult failed: %v", err)
+			}
+			if n != 1 {
+				t.Errorf("DeleteResult = %d, want 1", n)
+			}
+
+			var count int
+			if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM affected_items").Scan(&count); err != nil {
+				t.Fatalf("failed to count rows: %v", err)
+			}
+			if count != 2 {
+				t.Errorf("expected 2 rows left, got %d", count)
+			}
+		})
+	}
+}
+
+// TestQuery_CreateMany testa o INSERT de várias linhas com PK gerada pelo banco e PK UUID
+func TestQuery_CreateMany(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	type tag struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	type label struct {
+		ID   string `db:"id"`
+		Name string `db:"name"`
+	}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			createTagsSQL := "CREATE TABLE many_tags (id SERIAL PRIMARY KEY, name VARCHAR(50) NOT NULL)"
+			switch provider {
+			case "mysql":
+				createTagsSQL = "CREATE TABLE many_tags (id INT AUTO_INCREMENT PRIMARY KEY, name VARCHAR(50) NOT NULL)"
+			case "sqlite":
+				createTagsSQL = "CREATE TABLE many_tags (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)"
+			}
+
+			ctx := context.Background()
+			for _, stmt := range []string{
+				"DROP TABLE IF EXISTS many_tags",
+				"DROP TABLE IF EXISTS many_labels",
+				createTagsSQL,
+				"CREATE TABLE many_labels (id VARCHAR(36) PRIMARY KEY, name VARCHAR(50) NOT NULL)",
+			} {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			tags := NewQuery(db, "many_tags", []string{"id", "name"})
+			tags.SetDialect(dialect.GetDialect(provider))
+			tags.SetPrimaryKey("id")
+
+			n, err := tags.CreateMany(ctx, []tag{{Name: "go"}, {Name: "sql"}, {Name: "orm"}})
+			if err != nil {
+				t.Fatalf("CreateMany failed: %v", err)
+			}
+			if n != 3 {
+				t.Errorf("CreateMany = %d, want 3", n)
+			}
+
+			var names []string
+			if err := tags.Order("id ASC").Pluck(ctx, "name", &names); err != nil {
+				t.Fatalf("Pluck failed: %v", err)
+			}
+			if len(names) != 3 || names[0] != "go" || names[1] != "sql" || names[2] != "orm" {
+				t.Errorf("unexpected names %v", names)
+			}
+
+			labels := NewQuery(db, "many_labels", []string{"id", "name"})
+			labels.SetDialect(dialect.GetDialect(provider))
+			labels.SetPrimaryKey("id")
+
+			n, err = labels.CreateMany(ctx, []*label{{ID: "fixed", Name: "a"}, {Name: "b"}})
+			if err != nil {
+				t.Fatalf("CreateMany with UUID PK failed: %v", err)
+			}
+			if n != 2 {
+				t.Errorf("CreateMany = %d, want 2", n)
+			}
+
+			var ids []string
+			if err := labels.Order("name ASC").Pluck(ctx, "id", &ids); err != nil {
+				t.Fatalf("Pluck failed: %v", err)
+			}
+			if len(ids) != 2 || ids[0] != "fixed" || len(ids[1]) != 36 {
+				t.Errorf("expected the given id and a generated UUID, got %v", ids)
+			}
+		})
+	}
+}
+
+// TestQuery_FindRowCountWarning testa que Find avisa, sem falhar, quando passa do limite suave
+func TestQuery_FindRowCountWarning(t *testing.T) {
+	providers := []string{"postgresql", "mysql", "sqlite"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+
+			ctx := context.Background()
+			for _, stmt := range []string{
+				"DROP TABLE IF EXISTS warned_rows",
+				"CREATE TABLE warned_rows (id INT PRIMARY KEY)",
+				"INSERT INTO warned_rows (id) VALUES (1), (2), (3)",
+			} {
+				if _, err := sqlDB.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("failed to execute %q: %v", stmt, err)
+				}
+			}
+
+			var buf bytes.Buffer
+			previous := logger.GetDefaultLogger()
+			logger.SetDefaultLogger(logger.NewLogger([]string{"warn"}, &buf))
+			SetRowCountWarning(2)
+			defer func() {
+				logger.SetDefaultLogger(previous)
+				SetRowCountWarning(0)
+			}()
+
+			type row struct {
+				ID int `db:"id"`
+			}
+			q := NewQuery(db, "warned_rows", []string{"id"})
+			q.SetDialect(dialect.GetDialect(provider))
+
+			var all []row
+			if err := q.ScanFind(ctx, &all, reflect.TypeOf(row{})); err != nil {
+				t.Fatalf("ScanFind failed: %v", err)
+			}
+			if len(all) != 3 {
+				t.Fatalf("expected 3 rows, got %d", len(all))
+			}
+			if !strings.Contains(buf.String(), "3 rows (warning threshold 2)") {
+				t.Errorf("expected a row count warning, got %q", buf.String())
+			}
+
+			buf.Reset()
+			var some []row
+			if err := q.Where("id <= ?", 2).ScanFind(ctx, &some, reflect.TypeOf(row{})); err != nil {
+				t.Fatalf("ScanFind failed: %v", err)
+			}
+			// Só o aviso de contagem importa; o de overhead depende do tempo da máquina
+			if strings.Contains(buf.String(), "warning threshold") {
+				t.Errorf("no row count warning expected at the threshold, got %q", buf.String())
+			}
+		})
+	}
+}
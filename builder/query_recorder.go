@@ -0,0 +1,155 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// RecordedQuery is a statement executed through a RecordQueries connection
+type RecordedQuery struct {
+	SQL      string
+	Args     []interface{}
+	Duration time.Duration
+}
+
+// QueryRecorder keeps the last executed statements in a fixed-size ring buffer.
+// It is safe for concurrent use.
+type QueryRecorder struct {
+	mu      sync.Mutex
+	queries []RecordedQuery
+	next    int
+	full    bool
+}
+
+// NewQueryRecorder returns a recorder that keeps the last size statements
+func NewQueryRecorder(size int) (*QueryRecorder, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("%w: query recorder size must be at least 1, got %d", errors.ErrInvalidInput, size)
+	}
+	return &QueryRecorder{queries: make([]RecordedQuery, size)}, nil
+}
+
+// Recent returns the recorded statements, oldest first
+func (r *QueryRecorder) Recent() []RecordedQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]RecordedQuery{}, r.queries[:r.next]...)
+	}
+	return append(append([]RecordedQuery{}, r.queries[r.next:]...), r.queries[:r.next]...)
+}
+
+// Reset discards the recorded statements
+func (r *QueryRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.queries {
+		r.queries[i] = RecordedQuery{}
+	}
+	r.next = 0
+	r.full = false
+}
+
+// record adds a statement, overwriting the oldest one when the buffer is full
+func (r *QueryRecorder) record(sql string, args []interface{}, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queries[r.next] = RecordedQuery{SQL: sql, Args: append([]interface{}{}, args...), Duration: duration}
+	r.next++
+	if r.next == len(r.queries) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// RecordQueries returns a DBTX that records every statement it runs (SQL, args and
+// duration) in recorder, including the statements of its transactions. It is meant for
+// tests and debugging: assert on recorder.Recent() without setting up a logger.
+// Example: db := builder.RecordQueries(pool, recorder)
+func RecordQueries(db DBTX, recorder *QueryRecorder) DBTX {
+	return &recordingConn{DBTX: db, recorder: recorder}
+}
+
+// recordingConn records the statements it runs
+type recordingConn struct {
+	DBTX
+	recorder *QueryRecorder
+}
+
+func (c *recordingConn) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	start := time.Now()
+	result, err := c.DBTX.Exec(ctx, sql, args...)
+	c.recorder.record(sql, args, time.Since(start))
+	return result, err
+}
+
+func (c *recordingConn) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	start := time.Now()
+	rows, err := c.DBTX.Query(ctx, sql, args...)
+	c.recorder.record(sql, args, time.Since(start))
+	return rows, err
+}
+
+func (c *recordingConn) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	return &recordingRow{Row: c.DBTX.QueryRow(ctx, sql, args...), recorder: c.recorder, sql: sql, args: args, start: time.Now()}
+}
+
+func (c *recordingConn) Begin(ctx context.Context) (Tx, error) {
+	tx, err := c.DBTX.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingTx{Tx: tx, recorder: c.recorder}, nil
+}
+
+// Acquire reserves a dedicated connection from the wrapped DBTX, recorded in the same recorder
+func (c *recordingConn) Acquire(ctx context.Context) (DBTX, error) {
+	conn, err := acquireConn(ctx, c.DBTX)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingConn{DBTX: conn, recorder: c.recorder}, nil
+}
+
+// recordingTx records the statements of a transaction
+type recordingTx struct {
+	Tx
+	recorder *QueryRecorder
+}
+
+func (t *recordingTx) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	start := time.Now()
+	result, err := t.Tx.Exec(ctx, sql, args...)
+	t.recorder.record(sql, args, time.Since(start))
+	return result, err
+}
+
+func (t *recordingTx) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	start := time.Now()
+	rows, err := t.Tx.Query(ctx, sql, args...)
+	t.recorder.record(sql, args, time.Since(start))
+	return rows, err
+}
+
+func (t *recordingTx) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	return &recordingRow{Row: t.Tx.QueryRow(ctx, sql, args...), recorder: t.recorder, sql: sql, args: args, start: time.Now()}
+}
+
+// recordingRow records its statement on Scan, since drivers like pgx only run it then
+type recordingRow struct {
+	Row
+	recorder *QueryRecorder
+	sql      string
+	args     []interface{}
+	start    time.Time
+}
+
+func (r *recordingRow) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	r.recorder.record(r.sql, r.args, time.Since(r.start))
+	return err
+}
@@ -0,0 +1,104 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+)
+
+// TestRecordQueries tests that executed statements are recorded in order, with their args,
+// and that the ring buffer keeps only the last ones
+func TestRecordQueries(t *testing.T) {
+	ctx := context.Background()
+	if _, err := NewQueryRecorder(0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("NewQueryRecorder(0) error = %v, want ErrInvalidInput", err)
+	}
+
+	recorder, err := NewQueryRecorder(3)
+	if err != nil {
+		t.Fatalf("NewQueryRecorder failed: %v", err)
+	}
+	db := RecordQueries(&routingDB{}, recorder)
+	newQuery := func() *Query {
+		q := NewQuery(db, "users", []string{"id", "email"})
+		q.SetDialect(dialect.GetDialect("postgresql"))
+		return q
+	}
+
+	var users []struct{ ID int }
+	newQuery().Where("id = ?", 1).Find(ctx, &users)
+	newQuery().Where("id = ?", 2).Updates(ctx, map[string]interface{}{"email": "x"})
+	newQuery().Count(ctx)
+
+	recent := recorder.Recent()
+	want := []string{
+		`SELECT "id", "email" FROM "users" WHERE id = $1`,
+		`UPDATE "users" SET "email" = $1 WHERE id = $2`,
+		`SELECT COUNT(*) FROM "users"`,
+	}
+	if len(recent) != len(want) {
+		t.Fatalf("Recent() = %v, want %d queries", recent, len(want))
+	}
+	for i, sql := range want {
+		if recent[i].SQL != sql {
+			t.Errorf("query %d = %s, want %s", i, recent[i].SQL, sql)
+		}
+	}
+	if fmt.Sprint(recent[0].Args) != "[1]" || fmt.Sprint(recent[1].Args) != "[x 2]" {
+		t.Errorf("recorded args = %v, %v", recent[0].Args, recent[1].Args)
+	}
+
+	// O buffer descarta as mais antigas, inclusive as de transações
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	tx.Exec(ctx, "DELETE FROM users")
+	recent = recorder.Recent()
+	if len(recent) != 3 || recent[0].SQL != want[1] || recent[2].SQL != "DELETE FROM users" {
+		t.Errorf("Recent() after wrap-around = %v", recent)
+	}
+
+	recorder.Reset()
+	if recent := recorder.Recent(); len(recent) != 0 {
+		t.Errorf("Recent() after Reset = %v", recent)
+	}
+}
+
+// TestRecordQueries_Concurrent tests that the recorder can be used from several goroutines
+func TestRecordQueries_Concurrent(t *testing.T) {
+	recorder, err := NewQueryRecorder(10)
+	if err != nil {
+		t.Fatalf("NewQueryRecorder failed: %v", err)
+	}
+	db := RecordQueries(&execDB{}, recorder)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				db.Exec(context.Background(), "SELECT 1")
+				recorder.Recent()
+			}
+		}()
+	}
+	wg.Wait()
+	if recent := recorder.Recent(); len(recent) != 10 {
+		t.Errorf("Recent() = %d queries, want 10", len(recent))
+	}
+}
+
+// execDB accepts every Exec, from any goroutine
+type execDB struct {
+	DBTX
+}
+
+func (d *execDB) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	return taggedResult{rows: 1}, nil
+}
@@ -0,0 +1,45 @@
+package builder
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// rawStatement is the SQL set by Raw, run instead of the generated SELECT
+type rawStatement struct {
+	sql  string
+	args []interface{}
+}
+
+// Raw returns a Clone of the query that runs sql (with the dialect's placeholders) instead of
+// the generated SELECT in First, Find, ScanFirst and ScanFind. The result columns are mapped to
+// the model by the names the driver reports, so the statement may select them in any order,
+// join other tables or leave columns out. Conditions, ordering and pagination set on the query
+// are not added to sql.
+// Example: err := client.User.Raw(`SELECT * FROM users WHERE email LIKE $1`, "%@example.com").Find(ctx, &users)
+func (q *Query) Raw(sql string, args ...interface{}) *Query {
+	c := q.Clone()
+	c.raw = &rawStatement{sql: sql, args: args}
+	return c
+}
+
+// firstRaw runs the Raw statement through find and keeps its first row, since the column
+// names needed to map a raw result are only reported by Rows
+func (q *Query) firstRaw(dest interface{}, find func(slice interface{}) error) error {
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr {
+		return errors.SanitizeError(fmt.Errorf("dest must be a pointer"))
+	}
+	slice := reflect.New(reflect.SliceOf(destVal.Elem().Type()))
+	if err := find(slice.Interface()); err != nil {
+		return err
+	}
+	if slice.Elem().Len() == 0 {
+		return fmt.Errorf("%w: %w", errors.ErrNotFound, sql.ErrNoRows)
+	}
+	destVal.Elem().Set(slice.Elem().Index(0))
+	return nil
+}
@@ -0,0 +1,79 @@
+package builder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+)
+
+// rawRowsDB grava o SQL recebido e devolve as linhas de namedRowsDB
+type rawRowsDB struct {
+	namedRowsDB
+	sql  string
+	args []interface{}
+}
+
+func (d *rawRowsDB) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	d.sql, d.args = sql, args
+	return d.namedRowsDB.Query(ctx, sql, args...)
+}
+
+// TestQuery_Raw tests that Raw runs the user's SQL and maps the reported columns to the model
+func TestQuery_Raw(t *testing.T) {
+	type Product struct {
+		ID    int    `db:"id"`
+		Name  string `db:"name"`
+		Stock int    `db:"stock"`
+	}
+	ctx := context.Background()
+	db := &rawRowsDB{namedRowsDB: namedRowsDB{
+		columns: []string{"name", "id"},
+		rows:    []valuesRow{{"pen", 1}, {"ink", 2}},
+	}}
+	q := NewQuery(db, "products", []string{"id", "name", "stock"})
+	q.SetDialect(dialect.GetDialect("postgresql"))
+	q.SetModelType(reflect.TypeOf(Product{}))
+	q.Where("id = ?", 9)
+
+	const rawSQL = `SELECT name, id FROM products WHERE stock > $1`
+	var products []Product
+	if err := q.Raw(rawSQL, 0).Find(ctx, &products); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if want := []Product{{ID: 1, Name: "pen"}, {ID: 2, Name: "ink"}}; !reflect.DeepEqual(products, want) {
+		t.Errorf("Find = %+v, want %+v", products, want)
+	}
+	if db.sql != rawSQL || fmt.Sprint(db.args) != "[0]" {
+		t.Errorf("Find ran %s %v", db.sql, db.args)
+	}
+
+	// A query original continua gerando o próprio SELECT
+	if query, _ := q.ToSelectSQL(); query == rawSQL {
+		t.Errorf("Raw changed the original query")
+	}
+
+	var product Product
+	if err := q.Raw(rawSQL, 0).First(ctx, &product); err != nil || product != (Product{ID: 1, Name: "pen"}) {
+		t.Errorf("First = %+v, %v", product, err)
+	}
+
+	type productName struct {
+		Name string `db:"name"`
+	}
+	var name productName
+	if err := q.Raw(rawSQL, 0).ScanFirst(ctx, &name, reflect.TypeOf(productName{})); err != nil || name.Name != "pen" {
+		t.Errorf("ScanFirst = %+v, %v", name, err)
+	}
+
+	// Sem linhas, First retorna ErrNotFound (e sql.ErrNoRows)
+	db.rows = nil
+	err := q.Raw(rawSQL, 0).First(ctx, &product)
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("First without rows err = %v", err)
+	}
+}
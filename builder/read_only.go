@@ -0,0 +1,128 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// ReadOnly returns a DBTX that refuses writes, for replica connections: Exec and any
+// statement other than a plain SELECT (or WITH ... SELECT) fail with ErrReadOnly before
+// reaching the database. Queries built on it with NewQuery are read-only as well.
+// Example: client := NewClient(builder.ReadOnly(replicaPool))
+func ReadOnly(db DBTX) DBTX {
+	if _, ok := db.(*readOnlyConn); ok {
+		return db
+	}
+	return &readOnlyConn{DBTX: db}
+}
+
+// readOnlyConn rejects the statements that are not reads
+type readOnlyConn struct {
+	DBTX
+}
+
+// Exec only runs the search_path changes WithSearchPath makes on a dedicated connection
+func (c *readOnlyConn) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	if !isSearchPathStatement(sql) {
+		return nil, readOnlyError(sql)
+	}
+	return c.DBTX.Exec(ctx, sql, args...)
+}
+
+func (c *readOnlyConn) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	if !isReadStatement(sql) {
+		return nil, readOnlyError(sql)
+	}
+	return c.DBTX.Query(ctx, sql, args...)
+}
+
+func (c *readOnlyConn) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	if !isReadStatement(sql) {
+		return readOnlyRow{err: readOnlyError(sql)}
+	}
+	return c.DBTX.QueryRow(ctx, sql, args...)
+}
+
+func (c *readOnlyConn) Begin(ctx context.Context) (Tx, error) {
+	tx, err := c.DBTX.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &readOnlyTx{Tx: tx}, nil
+}
+
+// Acquire reserves a dedicated connection from the wrapped DBTX, read-only as well
+func (c *readOnlyConn) Acquire(ctx context.Context) (DBTX, error) {
+	conn, err := acquireConn(ctx, c.DBTX)
+	if err != nil {
+		return nil, err
+	}
+	return &readOnlyConn{DBTX: conn}, nil
+}
+
+// readOnlyTx rejects the statements of a transaction that are not reads
+type readOnlyTx struct {
+	Tx
+}
+
+func (t *readOnlyTx) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	return nil, readOnlyError(sql)
+}
+
+func (t *readOnlyTx) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	if !isReadStatement(sql) {
+		return nil, readOnlyError(sql)
+	}
+	return t.Tx.Query(ctx, sql, args...)
+}
+
+func (t *readOnlyTx) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	if !isReadStatement(sql) {
+		return readOnlyRow{err: readOnlyError(sql)}
+	}
+	return t.Tx.QueryRow(ctx, sql, args...)
+}
+
+// readOnlyRow is the Row of a rejected QueryRow; Scan returns the error
+type readOnlyRow struct {
+	err error
+}
+
+func (r readOnlyRow) Scan(dest ...interface{}) error {
+	return r.err
+}
+
+// readOnlyError wraps ErrReadOnly with the statement's first keyword
+func readOnlyError(sql string) error {
+	return fmt.Errorf("%w: %s statement rejected", errors.ErrReadOnly, statementKeyword(sql))
+}
+
+// isSearchPathStatement reports whether sql is a SET or RESET of search_path, a session setting
+func isSearchPathStatement(sql string) bool {
+	keyword := statementKeyword(sql)
+	if keyword != "SET" && keyword != "RESET" {
+		return false
+	}
+	upper := strings.ToUpper(sql)
+	fields := strings.Fields(upper[strings.Index(upper, keyword):])
+	return len(fields) > 1 && fields[1] == "SEARCH_PATH"
+}
+
+// statementKeyword returns the first keyword of sql, skipping leading comments
+func statementKeyword(sql string) string {
+	sql = strings.TrimSpace(sql)
+	for strings.HasPrefix(sql, "/*") {
+		end := strings.Index(sql, "*/")
+		if end == -1 {
+			break
+		}
+		sql = strings.TrimSpace(sql[end+2:])
+	}
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "empty"
+}
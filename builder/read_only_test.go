@@ -0,0 +1,86 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+)
+
+// TestReadOnly tests that writes fail with ErrReadOnly without reaching the database,
+// while reads still go through
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	recorder := &routingDB{}
+	db := ReadOnly(recorder)
+	if ReadOnly(db) != db {
+		t.Error("ReadOnly should not wrap a read-only DBTX twice")
+	}
+	newQuery := func() *Query {
+		q := NewQuery(db, "users", []string{"id", "email"})
+		q.SetDialect(dialect.GetDialect("postgresql"))
+		q.SetPrimaryKey("id")
+		return q
+	}
+	if !newQuery().IsReadOnly() {
+		t.Fatal("queries built on a ReadOnly DBTX should be read-only")
+	}
+
+	user := struct {
+		ID    int    `db:"id"`
+		Email string `db:"email"`
+	}{ID: 1, Email: "a@example.com"}
+	writes := map[string]error{
+		"Create":     newQuery().Create(ctx, &user),
+		"Save":       newQuery().Save(ctx, &user),
+		"Update":     newQuery().Where("id = ?", 1).Update(ctx, "email", "x"),
+		"Updates":    newQuery().Where("id = ?", 1).Updates(ctx, map[string]interface{}{"email": "x"}),
+		"Delete":     newQuery().Where("id = ?", 1).Delete(ctx, nil),
+		"Returning":  newQuery().Returning("id").Create(ctx, &user),
+		"CreateMany": func() error { _, err := newQuery().CreateMany(ctx, []interface{}{user}); return err }(),
+	}
+	for name, err := range writes {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s error = %v, want ErrReadOnly", name, err)
+		}
+	}
+
+	// O wrapper também barra escritas feitas fora do Query (TableQueryBuilder, SQL direto, transações)
+	tb := NewTableQueryBuilder(db, "users", []string{"id", "email"})
+	tb.SetDialect(dialect.GetDialect("postgresql"))
+	tb.SetModelType(reflect.TypeOf(user))
+	if _, err := tb.Create(ctx, &user); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("TableQueryBuilder.Create error = %v, want ErrReadOnly", err)
+	}
+	if err := db.QueryRow(ctx, "INSERT INTO users (email) VALUES ($1) RETURNING id", "x").Scan(new(int)); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("QueryRow INSERT error = %v, want ErrReadOnly", err)
+	}
+	if _, err := db.Query(ctx, "/* c */ SELECT id FROM users FOR UPDATE"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("locking read error = %v, want ErrReadOnly", err)
+	}
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM users"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("tx Exec error = %v, want ErrReadOnly", err)
+	}
+	if len(recorder.log) != 0 {
+		t.Fatalf("writes should not reach the database, got %v", recorder.log)
+	}
+
+	var users []struct{ ID int }
+	newQuery().Find(ctx, &users)
+	tx.Query(ctx, "SELECT id FROM users")
+	if len(recorder.log) != 2 {
+		t.Errorf("reads should reach the database, got %v", recorder.log)
+	}
+
+	// SetReadOnly também funciona sem o wrapper
+	q := NewQuery(recorder, "users", []string{"id", "email"}).SetReadOnly(true)
+	if err := q.Where("id = ?", 1).Delete(ctx, nil); !errors.Is(err, ErrReadOnly) || len(recorder.log) != 2 {
+		t.Errorf("SetReadOnly Delete error = %v, log = %v", err, recorder.log)
+	}
+}
@@ -0,0 +1,96 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+)
+
+// taggedResult is a Result carrying a command tag, like the pgx driver's
+type taggedResult struct {
+	tag  string
+	rows int64
+}
+
+func (r taggedResult) RowsAffected() int64          { return r.rows }
+func (r taggedResult) LastInsertId() (int64, error) { return 0, errors.New("not supported") }
+func (r taggedResult) CommandTag() string           { return r.tag }
+
+// taggedDB answers every Exec with a command tag built from the statement's verb
+type taggedDB struct {
+	DBTX
+	rows int64
+}
+
+func (d *taggedDB) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	verb := strings.Fields(sql)[0]
+	if verb == "INSERT" {
+		return taggedResult{tag: "INSERT 0 1", rows: 1}, nil
+	}
+	return taggedResult{tag: verb + " 3", rows: d.rows}, nil
+}
+
+// TestQuery_WithResult tests that the WithResult variants return the driver's Result and its command tag
+func TestQuery_WithResult(t *testing.T) {
+	ctx := context.Background()
+	newQuery := func() *Query {
+		q := NewQuery(&taggedDB{rows: 3}, "users", []string{"id", "email"})
+		q.SetDialect(dialect.GetDialect("postgresql"))
+		return q
+	}
+
+	tests := []struct {
+		name string
+		run  func() (Result, error)
+		tag  string
+		rows int64
+	}{
+		{"create", func() (Result, error) {
+			return newQuery().CreateWithResult(ctx, &struct {
+				Email string `db:"email"`
+			}{Email: "a@example.com"})
+		}, "INSERT 0 1", 1},
+		{"updates", func() (Result, error) {
+			return newQuery().Where("id > ?", 1).UpdatesWithResult(ctx, map[string]interface{}{"email": "x"})
+		}, "UPDATE 3", 3},
+		{"delete", func() (Result, error) {
+			return newQuery().Where("id > ?", 1).DeleteWithResult(ctx, nil)
+		}, "DELETE 3", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.run()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.RowsAffected() != tt.rows {
+				t.Errorf("RowsAffected() = %d, want %d", result.RowsAffected(), tt.rows)
+			}
+			tagged, ok := result.(CommandTagResult)
+			if !ok {
+				t.Fatalf("result %T should implement CommandTagResult", result)
+			}
+			if tagged.CommandTag() != tt.tag {
+				t.Errorf("CommandTag() = %q, want %q", tagged.CommandTag(), tt.tag)
+			}
+		})
+	}
+
+	// as variantes com contagem continuam usando RowsAffected
+	if n, err := newQuery().Where("id > ?", 1).UpdatesResult(ctx, map[string]interface{}{"email": "x"}); err != nil || n != 3 {
+		t.Errorf("UpdatesResult() = %d, %v, want 3", n, err)
+	}
+	if n, err := newQuery().Where("id > ?", 1).DeleteResult(ctx, nil); err != nil || n != 3 {
+		t.Errorf("DeleteResult() = %d, %v, want 3", n, err)
+	}
+
+	// Cascade executa vários comandos, então não há um único Result
+	_, err := newQuery().Cascade(CascadeRelation{Table: "posts", Columns: []string{"user_id"}, ReferencedColumns: []string{"id"}}).DeleteWithResult(ctx, nil)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("DeleteWithResult with Cascade error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,303 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/carlosnayan/prisma-go-client/internal/driver"
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+	"github.com/carlosnayan/prisma-go-client/internal/limits"
+)
+
+// Returning faz Create, Updates e Delete devolverem as colunas das linhas afetadas; sem
+// colunas, devolve todas as colunas da tabela. Create preenche o próprio value, Delete
+// preenche value e Updates preenche o dest opcional (ponteiro para struct ou para slice).
+// Nos bancos com RETURNING a consulta é única; nos demais as linhas são relidas pela chave
+// primária (LastInsertId no Create), dentro de uma transação no Updates e no Delete.
+// Exemplo: q.Where("id = ?", 1).Returning("id", "updated_at").Updates(ctx, values, &user)
+func (q *Query) Returning(columns ...string) *Query {
+	if len(columns) == 0 {
+		columns = q.columns
+	}
+	q.returning = append([]string{}, columns...)
+	return q
+}
+
+// returningClause retorna o sufixo RETURNING quando há colunas e o dialeto o suporta
+func (q *Query) returningClause() string {
+	if len(q.returning) == 0 || !q.dialect.SupportsReturning() {
+		return ""
+	}
+	return " RETURNING " + q.quoteColumnList(q.returning)
+}
+
+// createReturning insere value e o preenche com as colunas de Returning
+func (q *Query) createReturning(ctx context.Context, value interface{}) error {
+	processStart := time.Now()
+	query, args, key := q.buildInsertQuery(value)
+	db := q.hooked(q.db)
+
+	if q.dialect.SupportsReturning() {
+		return errors.SanitizeError(q.queryReturning(ctx, db, query, args, processStart, value))
+	}
+
+	queryStart := time.Now()
+	result, err := db.Exec(ctx, query, args...)
+	q.logQueryRows(ctx, query, args, queryStart, processStart, time.Since(queryStart), affectedRows(result, err))
+	if err != nil {
+		if logger := q.getLogger(); logger != nil {
+			logger.Error("INSERT query failed: %v", err)
+		}
+		return errors.SanitizeError(err)
+	}
+
+	pk, err := q.insertedPrimaryKey(key, result)
+	if err != nil {
+		return err
+	}
+	return errors.SanitizeError(q.reselectReturning(ctx, db, []interface{}{pk}, value))
+}
+
+// updatesReturning atualiza as linhas e lê as colunas de Returning para dest
+func (q *Query) updatesReturning(ctx context.Context, values map[string]interface{}, dest interface{}) error {
+	processStart := time.Now()
+	query, args := q.buildUpdatesQuery(values)
+
+	if q.dialect.SupportsReturning() {
+		return errors.SanitizeError(q.queryReturning(ctx, q.hooked(q.db), query, args, processStart, dest))
+	}
+
+	return errors.SanitizeError(q.RunInTransaction(ctx, func(db DBTX) error {
+		db = q.hooked(db)
+		pks, err := q.selectPrimaryKeys(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		queryStart := time.Now()
+		_, err = db.Exec(ctx, query, args...)
+		q.logQueryWithTiming(ctx, query, args, queryStart, processStart, time.Since(queryStart))
+		if err != nil {
+			if logger := q.getLogger(); logger != nil {
+				logger.Error("UPDATE query failed: %v", err)
+			}
+			return err
+		}
+
+		// Se a própria chave primária mudou, as linhas são relidas pelo novo valor
+		if pk, ok := values[q.primaryKey]; ok && len(pks) > 0 {
+			pks = []interface{}{pk}
+		}
+		return q.reselectReturning(ctx, db, pks, dest)
+	}))
+}
+
+// deleteReturning lê as colunas de Returning das linhas para dest e as remove
+func (q *Query) deleteReturning(ctx context.Context, dest interface{}) error {
+	processStart := time.Now()
+	query, args := q.buildDeleteQuery()
+
+	if q.dialect.SupportsReturning() {
+		return errors.SanitizeError(q.queryReturning(ctx, q.hooked(q.db), query, args, processStart, dest))
+	}
+
+	return errors.SanitizeError(q.RunInTransaction(ctx, func(db DBTX) error {
+		db = q.hooked(db)
+		pks, err := q.selectPrimaryKeys(ctx, db)
+		if err != nil {
+			return err
+		}
+		if err := q.reselectReturning(ctx, db, pks, dest); err != nil {
+			return err
+		}
+
+		queryStart := time.Now()
+		_, err = db.Exec(ctx, query, args...)
+		q.logQueryWithTiming(ctx, query, args, queryStart, processStart, time.Since(queryStart))
+		if err != nil {
+			if logger := q.getLogger(); logger != nil {
+				logger.Error("DELETE query failed: %v", err)
+			}
+		}
+		return err
+	}))
+}
+
+// queryReturning executa uma escrita com RETURNING e lê as linhas para dest
+func (q *Query) queryReturning(ctx context.Context, db DBTX, query string, args []interface{}, processStart time.Time, dest interface{}) error {
+	queryStart := time.Now()
+	rows, err := db.Query(ctx, query, args...)
+	q.logQueryWithTiming(ctx, query, args, queryStart, processStart, time.Since(queryStart))
+	if err != nil {
+		if logger := q.getLogger(); logger != nil {
+			logger.Error("RETURNING query failed: %v", err)
+		}
+		return err
+	}
+	return q.scanReturning(rows, dest)
+}
+
+// selectPrimaryKeys retorna as chaves primárias das linhas que casam com o WHERE
+func (q *Query) selectPrimaryKeys(ctx context.Context, db DBTX) ([]interface{}, error) {
+	if q.primaryKey == "" {
+		return nil, fmt.Errorf("%w: Returning on %s needs a primary key to read the rows back", errors.ErrInvalidInput, q.dialect.Name())
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", q.dialect.QuoteIdentifier(q.primaryKey), q.dialect.QuoteIdentifier(q.table))
+	var args []interface{}
+	if len(q.whereConditions) > 0 {
+		argIndex := 1
+		whereClause, whereArgs := q.buildWhereClause(&argIndex)
+		query += " WHERE " + whereClause
+		args = whereArgs
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pks []interface{}
+	for rows.Next() {
+		if len(pks) >= limits.MaxScanRows {
+			return nil, fmt.Errorf("result set too large: maximum %d rows allowed", limits.MaxScanRows)
+		}
+		var pk interface{}
+		if err := rows.Scan(&pk); err != nil {
+			return nil, err
+		}
+		pks = append(pks, pk)
+	}
+	return pks, rows.Err()
+}
+
+// reselectReturning lê as colunas de Returning das linhas com as chaves primárias pks,
+// em lotes que respeitam o limite de parâmetros do dialeto
+func (q *Query) reselectReturning(ctx context.Context, db DBTX, pks []interface{}, dest interface{}) error {
+	if q.primaryKey == "" {
+		return fmt.Errorf("%w: Returning on %s needs a primary key to read the rows back", errors.ErrInvalidInput, q.dialect.Name())
+	}
+
+	size := maxPlaceholders(q.dialect)
+	if len(pks) <= size {
+		return q.reselectChunk(ctx, db, pks, dest)
+	}
+
+	// Vários lotes só ocorrem com dest slice: cada lote é lido à parte e concatenado
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dest must be a pointer to slice of struct")
+	}
+	all := reflect.MakeSlice(target.Elem().Type(), 0, len(pks))
+	for start := 0; start < len(pks); start += size {
+		end := start + size
+		if end > len(pks) {
+			end = len(pks)
+		}
+		chunk := reflect.New(target.Elem().Type())
+		if err := q.reselectChunk(ctx, db, pks[start:end], chunk.Interface()); err != nil {
+			return err
+		}
+		all = reflect.AppendSlice(all, chunk.Elem())
+	}
+	target.Elem().Set(all)
+	return nil
+}
+
+// reselectChunk lê as colunas de Returning de um lote de chaves com um único IN (...)
+func (q *Query) reselectChunk(ctx context.Context, db DBTX, pks []interface{}, dest interface{}) error {
+	placeholders := make([]string, len(pks))
+	for i := range pks {
+		placeholders[i] = q.dialect.GetPlaceholder(i + 1)
+	}
+	if len(pks) == 0 {
+		placeholders = []string{"NULL"}
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (%s)",
+		q.quoteColumnList(q.returning),
+		q.dialect.QuoteIdentifier(q.table),
+		q.dialect.QuoteIdentifier(q.primaryKey),
+		strings.Join(placeholders, ", "))
+
+	rows, err := db.Query(ctx, query, pks...)
+	if err != nil {
+		return err
+	}
+	return q.scanReturning(rows, dest)
+}
+
+// insertedPrimaryKey retorna a chave primária da linha inserida: a chave devolvida por
+// buildInsertQuery (valor informado ou UUID gerado) ou o LastInsertId do banco
+func (q *Query) insertedPrimaryKey(key interface{}, result driver.Result) (interface{}, error) {
+	if q.primaryKey == "" {
+		return nil, fmt.Errorf("%w: Returning on %s needs a primary key to read the rows back", errors.ErrInvalidInput, q.dialect.Name())
+	}
+	if key != nil {
+		return key, nil
+	}
+	return result.LastInsertId()
+}
+
+// scanReturning lê as colunas de Returning para dest. Um ponteiro para struct recebe a
+// primeira linha nos próprios campos; um ponteiro para slice recebe todas as linhas.
+func (q *Query) scanReturning(rows driver.Rows, dest interface{}) error {
+	defer rows.Close()
+
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return fmt.Errorf("dest must be a pointer")
+	}
+	target := destVal.Elem()
+	isSlice := target.Kind() == reflect.Slice
+	elemType := target.Type()
+	if isSlice {
+		elemType = elemType.Elem()
+		target.Set(reflect.MakeSlice(target.Type(), 0, 0))
+	}
+	isPtr := isSlice && elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("dest must be a pointer to struct or to slice of struct")
+	}
+
+	rowCount := 0
+	for rows.Next() {
+		if rowCount >= limits.MaxScanRows {
+			return fmt.Errorf("result set too large: maximum %d rows allowed", limits.MaxScanRows)
+		}
+
+		row := destVal
+		if isSlice {
+			row = reflect.New(elemType)
+		}
+		targets := make([]interface{}, len(q.returning))
+		for i, colName := range q.returning {
+			if field := findFieldByColumn(row.Elem(), colName); field.IsValid() {
+				targets[i] = scanDestination(field)
+			} else {
+				var dummy interface{}
+				targets[i] = &dummy
+			}
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return err
+		}
+
+		rowCount++
+		if !isSlice {
+			break
+		}
+		if isPtr {
+			target.Set(reflect.Append(target, row))
+		} else {
+			target.Set(reflect.Append(target, row.Elem()))
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,139 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// WithRoutingComments returns a DBTX that prefixes every statement with a routing comment,
+// for proxies that route on query comments: read is used for SELECT statements and write
+// for everything else. An empty comment leaves that kind of statement untouched. Statements
+// in a transaction always get the write comment, so the whole transaction stays on the primary.
+// Example: db, err := builder.WithRoutingComments(pool, "route:replica", "route:primary")
+func WithRoutingComments(db DBTX, read, write string) (DBTX, error) {
+	for _, comment := range []string{read, write} {
+		if strings.Contains(comment, "*/") || strings.Contains(comment, "/*") {
+			return nil, fmt.Errorf("%w: routing comment %q must not contain /* or */", errors.ErrInvalidInput, comment)
+		}
+	}
+	return &routingConn{DBTX: db, read: read, write: write}, nil
+}
+
+// routingConn prefixes statements with a read or write routing comment
+type routingConn struct {
+	DBTX
+	read  string
+	write string
+}
+
+func (c *routingConn) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	return c.DBTX.Exec(ctx, c.route(sql), args...)
+}
+
+func (c *routingConn) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	return c.DBTX.Query(ctx, c.route(sql), args...)
+}
+
+func (c *routingConn) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	return c.DBTX.QueryRow(ctx, c.route(sql), args...)
+}
+
+func (c *routingConn) Begin(ctx context.Context) (Tx, error) {
+	tx, err := c.DBTX.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &routingTx{Tx: tx, write: c.write}, nil
+}
+
+// Acquire reserves a dedicated connection from the wrapped DBTX, with the same comments
+func (c *routingConn) Acquire(ctx context.Context) (DBTX, error) {
+	conn, err := acquireConn(ctx, c.DBTX)
+	if err != nil {
+		return nil, err
+	}
+	return &routingConn{DBTX: conn, read: c.read, write: c.write}, nil
+}
+
+// route prefixes sql with the comment for its kind of statement
+func (c *routingConn) route(sql string) string {
+	if isReadStatement(sql) {
+		return withRoutingComment(sql, c.read)
+	}
+	return withRoutingComment(sql, c.write)
+}
+
+// routingTx prefixes every statement of a transaction with the write comment
+type routingTx struct {
+	Tx
+	write string
+}
+
+func (t *routingTx) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	return t.Tx.Exec(ctx, withRoutingComment(sql, t.write), args...)
+}
+
+func (t *routingTx) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	return t.Tx.Query(ctx, withRoutingComment(sql, t.write), args...)
+}
+
+func (t *routingTx) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	return t.Tx.QueryRow(ctx, withRoutingComment(sql, t.write), args...)
+}
+
+// withRoutingComment prefixes sql with /* comment */. A leading optimizer hint (/*+ ... */)
+// is kept first, since pg_hint_plan and MySQL only read hints at the start of the statement.
+func withRoutingComment(sql, comment string) string {
+	if comment == "" {
+		return sql
+	}
+	prefix := "/* " + comment + " */ "
+	trimmed := strings.TrimLeft(sql, " \t\r\n")
+	if strings.HasPrefix(trimmed, "/*+") {
+		if end := strings.Index(trimmed, "*/"); end != -1 {
+			return trimmed[:end+2] + " " + prefix + strings.TrimLeft(trimmed[end+2:], " ")
+		}
+	}
+	return prefix + sql
+}
+
+// isReadStatement reports whether sql only reads: a SELECT (or WITH ... SELECT) without a
+// locking clause. Locking reads and data-modifying CTEs must run on the primary.
+func isReadStatement(sql string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(sql))
+	for strings.HasPrefix(upper, "/*") {
+		end := strings.Index(upper, "*/")
+		if end == -1 {
+			return false
+		}
+		upper = strings.TrimSpace(upper[end+2:])
+	}
+	fields := strings.Fields(upper)
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "SELECT":
+	case "WITH":
+		words := strings.FieldsFunc(upper, func(r rune) bool {
+			return !(r >= 'A' && r <= 'Z' || r == '_')
+		})
+		for _, word := range words {
+			switch word {
+			case "INSERT", "UPDATE", "DELETE", "MERGE":
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	for _, lock := range []string{" FOR UPDATE", " FOR SHARE", " FOR NO KEY UPDATE", " FOR KEY SHARE", " LOCK IN SHARE MODE"} {
+		if strings.Contains(upper, lock) {
+			return false
+		}
+	}
+	return true
+}
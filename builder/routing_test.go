@@ -0,0 +1,105 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+	prismaerrors "github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// routingDB records the statements it receives, inside and outside transactions
+type routingDB struct {
+	DBTX
+	Tx
+	log []string
+}
+
+func (d *routingDB) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	d.log = append(d.log, sql)
+	return taggedResult{rows: 1}, nil
+}
+
+func (d *routingDB) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	d.log = append(d.log, sql)
+	return nil, errors.New("recorded")
+}
+
+func (d *routingDB) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	d.log = append(d.log, sql)
+	return errRow{}
+}
+
+func (d *routingDB) Begin(ctx context.Context) (Tx, error) {
+	return d, nil
+}
+
+// TestWithRoutingComments tests that reads and writes get their configured comments
+func TestWithRoutingComments(t *testing.T) {
+	ctx := context.Background()
+	recorder := &routingDB{}
+	db, err := WithRoutingComments(recorder, "route:replica", "route:primary")
+	if err != nil {
+		t.Fatalf("WithRoutingComments failed: %v", err)
+	}
+	newQuery := func() *Query {
+		q := NewQuery(db, "users", []string{"id", "email"})
+		q.SetDialect(dialect.GetDialect("postgresql"))
+		return q
+	}
+
+	var users []struct{ ID int }
+	newQuery().Find(ctx, &users)
+	newQuery().Where("id = ?", 1).Updates(ctx, map[string]interface{}{"email": "x"})
+	newQuery().Where("id = ?", 1).Delete(ctx, nil)
+	newQuery().IndexHint("SeqScan(users)").Find(ctx, &users)
+	db.QueryRow(ctx, "SELECT id FROM users FOR UPDATE")
+	db.Query(ctx, "WITH gone AS (DELETE FROM users RETURNING id) SELECT id FROM gone")
+
+	want := []string{
+		`/* route:replica */ SELECT "id", "email" FROM "users"`,
+		`/* route:primary */ UPDATE "users" SET "email" = $1 WHERE id = $2`,
+		`/* route:primary */ DELETE FROM "users" WHERE id = $1`,
+		`/*+ SeqScan(users) */ /* route:replica */ SELECT "id", "email" FROM "users"`,
+		`/* route:primary */ SELECT id FROM users FOR UPDATE`,
+		`/* route:primary */ WITH gone AS (DELETE FROM users RETURNING id) SELECT id FROM gone`,
+	}
+	if len(recorder.log) != len(want) {
+		t.Fatalf("expected %d statements, got %q", len(want), recorder.log)
+	}
+	for i := range want {
+		if recorder.log[i] != want[i] {
+			t.Errorf("statement %d =\n%s\nwant\n%s", i, recorder.log[i], want[i])
+		}
+	}
+
+	// Dentro de transação tudo vai para o primário
+	recorder.log = nil
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	tx.Query(ctx, "SELECT 1")
+	if len(recorder.log) != 1 || recorder.log[0] != "/* route:primary */ SELECT 1" {
+		t.Errorf("transaction read should use the write comment, got %q", recorder.log)
+	}
+}
+
+// TestWithRoutingComments_EmptyAndInvalid tests that an empty comment is skipped and that
+// comments able to close the SQL comment are rejected
+func TestWithRoutingComments_EmptyAndInvalid(t *testing.T) {
+	recorder := &routingDB{}
+	db, err := WithRoutingComments(recorder, "", "route:primary")
+	if err != nil {
+		t.Fatalf("WithRoutingComments failed: %v", err)
+	}
+	db.Query(context.Background(), "SELECT 1")
+	if recorder.log[0] != "SELECT 1" {
+		t.Errorf("empty read comment should leave reads untouched, got %q", recorder.log[0])
+	}
+
+	if _, err := WithRoutingComments(recorder, "x */ DROP TABLE users; /*", ""); !errors.Is(err, prismaerrors.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// WithSearchPath scopes db to a search_path of schemas (PostgreSQL), without leaking the setting
+// to other pool users. A pooled driver reserves a dedicated connection and runs SET search_path on
+// it; Close resets the search_path and releases the connection back to the pool. Inside a
+// transaction, SET LOCAL search_path applies until that transaction ends and db is returned as
+// is. A DBTX that can't reserve a connection is rejected with ErrInvalidInput.
+func WithSearchPath(ctx context.Context, db DBTX, schemas ...string) (DBTX, error) {
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("%w: search_path requires at least one schema", errors.ErrInvalidInput)
+	}
+
+	d := dialect.GetDialect("postgresql")
+	quoted := make([]string, len(schemas))
+	for i, schema := range schemas {
+		quoted[i] = d.QuoteIdentifier(schema)
+	}
+	searchPath := strings.Join(quoted, ", ")
+
+	// Numa transação, o SET LOCAL vale até o fim dela, que fica por conta de quem a abriu
+	if _, inTx := db.(*txDBAdapter); inTx {
+		if _, err := db.Exec(ctx, "SET LOCAL search_path TO "+searchPath); err != nil {
+			return nil, errors.WrapError(err, "failed to set search_path")
+		}
+		return db, nil
+	}
+
+	acquirer, ok := db.(ConnAcquirer)
+	if !ok {
+		return nil, errNoDedicatedConn(db)
+	}
+	conn, err := acquirer.Acquire(ctx)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to acquire connection")
+	}
+	if _, err := conn.Exec(ctx, "SET search_path TO "+searchPath); err != nil {
+		conn.Close()
+		return nil, errors.WrapError(err, "failed to set search_path")
+	}
+	return &searchPathConn{DBTX: conn}, nil
+}
+
+// acquireConn reserves a dedicated connection from db, for the DBTX wrappers' Acquire
+func acquireConn(ctx context.Context, db DBTX) (DBTX, error) {
+	acquirer, ok := db.(ConnAcquirer)
+	if !ok {
+		return nil, errNoDedicatedConn(db)
+	}
+	return acquirer.Acquire(ctx)
+}
+
+// errNoDedicatedConn reports a DBTX that can't reserve a dedicated connection
+func errNoDedicatedConn(db DBTX) error {
+	return fmt.Errorf("%w: %T cannot reserve a dedicated connection", errors.ErrInvalidInput, db)
+}
+
+// searchPathConn is a dedicated connection with a custom search_path
+type searchPathConn struct {
+	DBTX
+}
+
+// Close resets the search_path before releasing the connection, so the
+// pooled connection is handed back with its default settings
+func (c *searchPathConn) Close() {
+	_, _ = c.DBTX.Exec(context.Background(), "RESET search_path")
+	c.DBTX.Close()
+}
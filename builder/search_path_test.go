@@ -0,0 +1,178 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+	prismaerrors "github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// mockConn records every statement it receives
+type mockConn struct {
+	DBTX
+	name     string
+	log      *[]string
+	released bool
+}
+
+func (c *mockConn) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	*c.log = append(*c.log, c.name+": "+sql)
+	return nil, nil
+}
+
+func (c *mockConn) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	*c.log = append(*c.log, c.name+": "+sql)
+	return nil, errors.New("recorded")
+}
+
+func (c *mockConn) Close() {
+	*c.log = append(*c.log, c.name+": release")
+	c.released = true
+}
+
+// mockTx records its statements
+type mockTx struct {
+	Tx
+	log *[]string
+}
+
+func (tx *mockTx) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	*tx.log = append(*tx.log, "tx: "+sql)
+	return nil, nil
+}
+
+// mockPool hands out a dedicated mockConn on Acquire
+type mockPool struct {
+	mockConn
+	conn *mockConn
+}
+
+func (p *mockPool) Acquire(ctx context.Context) (DBTX, error) {
+	p.conn = &mockConn{name: "conn", log: p.log}
+	return p.conn, nil
+}
+
+// TestWithSearchPath_ScopedToDedicatedConnection tests that SET search_path runs on the acquired
+// connection only, that queries use that connection, and that Close resets it before releasing
+func TestWithSearchPath_ScopedToDedicatedConnection(t *testing.T) {
+	var log []string
+	pool := &mockPool{mockConn: mockConn{name: "pool", log: &log}}
+	ctx := context.Background()
+
+	conn, err := WithSearchPath(ctx, pool, "tenant_42", "public")
+	if err != nil {
+		t.Fatalf("WithSearchPath failed: %v", err)
+	}
+
+	query := NewQuery(conn, "users", []string{"id", "email"})
+	query.SetDialect(dialect.GetDialect("postgresql"))
+	var users []map[string]interface{}
+	_ = query.Find(ctx, &users)
+
+	// Operações fora do escopo continuam no pool, sem search_path
+	_, _ = pool.Exec(ctx, "SELECT 1")
+
+	conn.Close()
+
+	want := []string{
+		`conn: SET search_path TO "tenant_42", "public"`,
+		`conn: SELECT "id", "email" FROM "users"`,
+		`pool: SELECT 1`,
+		`conn: RESET search_path`,
+		`conn: release`,
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("statements =\n%q\nwant\n%q", log, want)
+	}
+	if !pool.conn.released {
+		t.Error("dedicated connection should be released on Close")
+	}
+	if pool.released {
+		t.Error("Close on the scoped connection must not close the pool")
+	}
+}
+
+// TestWithSearchPath_Errors tests the invalid inputs
+func TestWithSearchPath_Errors(t *testing.T) {
+	var log []string
+	ctx := context.Background()
+
+	if _, err := WithSearchPath(ctx, &mockPool{mockConn: mockConn{name: "pool", log: &log}}); !errors.Is(err, prismaerrors.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without schemas, got %v", err)
+	}
+	if _, err := WithSearchPath(ctx, &mockConn{name: "db", log: &log}, "tenant"); !errors.Is(err, prismaerrors.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a driver without Acquire, got %v", err)
+	}
+	if len(log) != 0 {
+		t.Errorf("no statement should run on error, got %q", log)
+	}
+}
+
+// TestWithSearchPath_WrappedDriver tests that the DBTX wrappers forward Acquire, so the
+// search_path runs on a dedicated connection that keeps every wrapper's behavior
+func TestWithSearchPath_WrappedDriver(t *testing.T) {
+	var log []string
+	pool := &mockPool{mockConn: mockConn{name: "pool", log: &log}}
+	ctx := context.Background()
+
+	recorder, _ := NewQueryRecorder(10)
+	routed, _ := WithRoutingComments(RecordQueries(ReadOnly(pool), recorder), "route:replica", "route:primary")
+	conn, err := WithSearchPath(ctx, routed, "tenant_42")
+	if err != nil {
+		t.Fatalf("WithSearchPath failed: %v", err)
+	}
+	query := NewQuery(conn, "users", []string{"id"})
+	query.SetDialect(dialect.GetDialect("postgresql"))
+	var users []map[string]interface{}
+	_ = query.Find(ctx, &users)
+	// A conexão dedicada continua somente leitura
+	if _, err := conn.Exec(ctx, "DELETE FROM users"); !errors.Is(err, prismaerrors.ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly on the dedicated connection, got %v", err)
+	}
+	conn.Close()
+
+	want := []string{
+		`conn: /* route:primary */ SET search_path TO "tenant_42"`,
+		`conn: /* route:replica */ SELECT "id" FROM "users"`,
+		`conn: /* route:primary */ RESET search_path`,
+		`conn: release`,
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("statements =\n%q\nwant\n%q", log, want)
+	}
+	if recorded := recorder.Recent(); len(recorded) != 4 {
+		t.Errorf("expected 4 recorded statements, got %d", len(recorded))
+	}
+}
+
+// TestWithSearchPath_NoDedicatedConnection tests that a wrapper around a DBTX that can't
+// reserve a connection is rejected without running anything, and that inside a transaction
+// the SET LOCAL runs in it and the transaction stays open
+func TestWithSearchPath_NoDedicatedConnection(t *testing.T) {
+	var log []string
+	ctx := context.Background()
+	conn := &mockConn{name: "conn", log: &log}
+
+	routed, _ := WithRoutingComments(conn, "", "")
+	for _, db := range []DBTX{routed, ReadOnly(conn)} {
+		if _, err := WithSearchPath(ctx, db, "tenant_42"); !errors.Is(err, prismaerrors.ErrInvalidInput) {
+			t.Errorf("%T: expected ErrInvalidInput, got %v", db, err)
+		}
+	}
+	if len(log) != 0 {
+		t.Errorf("no statement should run without a dedicated connection, got %q", log)
+	}
+
+	txConn := &txDBAdapter{tx: &mockTx{log: &log}}
+	scoped, err := WithSearchPath(ctx, txConn, "tenant_42")
+	if err != nil || scoped != DBTX(txConn) {
+		t.Fatalf("WithSearchPath in a transaction = %v, %v", scoped, err)
+	}
+	scoped.Close()
+	if want := []string{`tx: SET LOCAL search_path TO "tenant_42"`}; !reflect.DeepEqual(log, want) {
+		t.Errorf("statements = %q, want %q", log, want)
+	}
+}
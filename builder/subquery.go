@@ -0,0 +1,65 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/carlosnayan/prisma-go-client/internal/errors"
+)
+
+// subquerySource is a derived table used as the FROM of a query (see FromSubquery)
+type subquerySource struct {
+	query *Query
+	alias string
+}
+
+// FromSubquery returns a copy of this query builder that reads from sub, aliased as alias,
+// instead of the base table: First, Find, Count and the aggregates render
+// FROM (SELECT ...) AS alias, and unqualified column references resolve against the alias.
+// The copy keeps the connection, dialect, model type and context but none of the query state.
+// Writes through it fail with ErrInvalidInput, as do reads when sub is nil or alias is empty.
+// Example: client.User.FromSubquery(client.User.Distinct("email").Order("email ASC"), "u").Find(ctx, &users)
+func (q *Query) FromSubquery(sub *Query, alias string) *Query {
+	derived := NewQuery(q.db, q.table, q.columns)
+	derived.primaryKey = q.primaryKey
+	derived.modelType = q.modelType
+	derived.logger = q.logger
+	derived.slogLogger = q.slogLogger
+	derived.hooks = q.hooks
+	derived.argRedaction = q.argRedaction
+	derived.dialect = q.dialect
+	derived.ctx = q.ctx
+	derived.timeout = q.timeout
+	derived.readOnly = q.readOnly
+	derived.from = &subquerySource{query: sub, alias: strings.TrimSpace(alias)}
+	return derived
+}
+
+// IsSubquery reports whether the query reads from a FromSubquery source
+func (q *Query) IsSubquery() bool {
+	return q.from != nil
+}
+
+// checkSubquery validates the FromSubquery source and prepares its SELECT
+func (q *Query) checkSubquery() error {
+	if q.from == nil {
+		return nil
+	}
+	if q.from.query == nil {
+		return fmt.Errorf("%w: FromSubquery requires a subquery", errors.ErrInvalidInput)
+	}
+	if q.from.alias == "" {
+		return fmt.Errorf("%w: FromSubquery requires an alias", errors.ErrInvalidInput)
+	}
+	return q.from.query.prepareSelect()
+}
+
+// fromClause returns the FROM target: the quoted table, or the subquery set by FromSubquery
+// with its placeholders numbered from argIndex
+func (q *Query) fromClause(argIndex *int) (string, []interface{}) {
+	if q.from == nil || q.from.query == nil {
+		return q.dialect.QuoteIdentifier(q.table), nil
+	}
+	subSQL, subArgs := q.from.query.buildSelectQueryAt(false, argIndex)
+	return "(" + subSQL + ") AS " + q.dialect.QuoteIdentifier(q.from.alias), subArgs
+}
@@ -0,0 +1,93 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+)
+
+// deadlineDB records the deadline of the context each statement runs with
+type deadlineDB struct {
+	DBTX
+	deadline    time.Time
+	hasDeadline bool
+}
+
+func (d *deadlineDB) record(ctx context.Context) {
+	d.deadline, d.hasDeadline = ctx.Deadline()
+}
+
+func (d *deadlineDB) Exec(ctx context.Context, sql string, args ...interface{}) (Result, error) {
+	d.record(ctx)
+	return nil, errors.New("recorded")
+}
+
+func (d *deadlineDB) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	d.record(ctx)
+	return nil, errors.New("recorded")
+}
+
+func (d *deadlineDB) QueryRow(ctx context.Context, sql string, args ...interface{}) Row {
+	d.record(ctx)
+	return errRow{}
+}
+
+type errRow struct{}
+
+func (errRow) Scan(dest ...interface{}) error { return errors.New("recorded") }
+
+// TestQuery_Timeout tests that Timeout overrides the default query timeout, and zero disables it
+func TestQuery_Timeout(t *testing.T) {
+	operations := map[string]func(q *Query, ctx context.Context){
+		"First": func(q *Query, ctx context.Context) { _ = q.First(ctx, &map[string]interface{}{}) },
+		"Find":  func(q *Query, ctx context.Context) { _ = q.Find(ctx, &[]map[string]interface{}{}) },
+		"Count": func(q *Query, ctx context.Context) { _, _ = q.Count(ctx) },
+		"Updates": func(q *Query, ctx context.Context) {
+			_ = q.Where("id = ?", 1).Updates(ctx, map[string]interface{}{"email": "x"})
+		},
+		"Delete": func(q *Query, ctx context.Context) { _ = q.Where("id = ?", 1).Delete(ctx, nil) },
+	}
+
+	for name, run := range operations {
+		t.Run(name, func(t *testing.T) {
+			newQuery := func(db *deadlineDB) *Query {
+				q := NewQuery(db, "users", []string{"id", "email"})
+				q.SetDialect(dialect.GetDialect("postgresql"))
+				return q
+			}
+
+			// sem override usa o timeout padrão de 5 segundos
+			db := &deadlineDB{}
+			run(newQuery(db), context.Background())
+			if !db.hasDeadline || time.Until(db.deadline) > 5*time.Second {
+				t.Errorf("default deadline = %v (set %v), want within 5s", time.Until(db.deadline), db.hasDeadline)
+			}
+
+			// override mais longo
+			db = &deadlineDB{}
+			run(newQuery(db).Timeout(2*time.Minute), context.Background())
+			if remaining := time.Until(db.deadline); !db.hasDeadline || remaining <= time.Minute || remaining > 2*time.Minute {
+				t.Errorf("override deadline = %v (set %v), want about 2m", remaining, db.hasDeadline)
+			}
+
+			// zero significa sem timeout
+			db = &deadlineDB{}
+			run(newQuery(db).Timeout(0), context.Background())
+			if db.hasDeadline {
+				t.Errorf("Timeout(0) should not set a deadline, got %v", time.Until(db.deadline))
+			}
+
+			// o prazo do contexto do chamador continua valendo
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			db = &deadlineDB{}
+			run(newQuery(db).Timeout(0), ctx)
+			if !db.hasDeadline || time.Until(db.deadline) > time.Second {
+				t.Errorf("caller deadline should be kept, got %v (set %v)", time.Until(db.deadline), db.hasDeadline)
+			}
+		})
+	}
+}
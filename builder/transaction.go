@@ -75,6 +75,12 @@ func (a *txDBAdapter) Begin(ctx context.Context) (driver.Tx, error) {
 	return nil, fmt.Errorf("cannot begin a transaction within a transaction")
 }
 
+// Ping checks the transaction's connection with a trivial statement
+func (a *txDBAdapter) Ping(ctx context.Context) error {
+	_, err := a.tx.Exec(ctx, "SELECT 1")
+	return err
+}
+
 func (a *txDBAdapter) SQLDB() *sql.DB {
 	return nil
 }
@@ -753,3 +753,45 @@ func TestTransactionDBAdapter(t *testing.T) {
 		})
 	}
 }
+
+// TestQuery_WithDB tests that WithDB binds a copy of the query to the transaction and that
+// Clone keeps the conditions independent
+func TestQuery_WithDB(t *testing.T) {
+	ctx := context.Background()
+	primary := &routingDB{}
+	txDB := &routingDB{}
+	q := NewQuery(primary, "users", []string{"id", "email", "age"})
+	q.SetDialect(dialect.GetDialect("sqlite"))
+	q.Where("active = ?", true).Order("id").Take(5)
+
+	tx, err := BeginTransaction(ctx, txDB)
+	if err != nil {
+		t.Fatalf("BeginTransaction failed: %v", err)
+	}
+	// Ping na transação roda um SELECT 1 na própria transação
+	if err := tx.DB().Ping(ctx); err != nil || len(txDB.log) != 1 || txDB.log[0] != "SELECT 1" {
+		t.Errorf("transaction Ping = %v, ran %v", err, txDB.log)
+	}
+	txDB.log = nil
+
+	bound := q.WithDB(tx.DB())
+	bound.Where("age > ?", 18).Count(ctx)
+	if len(txDB.log) != 1 || txDB.log[0] != `SELECT COUNT(*) FROM "users" WHERE active = ? AND age > ?` {
+		t.Errorf("transaction ran %v", txDB.log)
+	}
+	if len(primary.log) != 0 {
+		t.Errorf("primary ran %v", primary.log)
+	}
+
+	// A query original não recebe as condições da cópia
+	q.Count(ctx)
+	if len(primary.log) != 1 || primary.log[0] != `SELECT COUNT(*) FROM "users" WHERE active = ?` {
+		t.Errorf("primary ran %v", primary.log)
+	}
+
+	before, _ := q.ToSelectSQL()
+	q.Clone().Where("email = ?", "x").Order("email").Take(1).Skip(2)
+	if after, _ := q.ToSelectSQL(); after != before {
+		t.Errorf("Clone changed the original query: %s, want %s", after, before)
+	}
+}
@@ -17,8 +17,9 @@ type Where map[string]interface{}
 
 // WhereOperator represents a conditional operator with its value
 type WhereOperator struct {
-	op    string
-	value interface{}
+	op        string
+	value     interface{}
+	collation string
 }
 
 // Comparison operators for building WHERE clauses
@@ -53,6 +54,12 @@ func Lte(value interface{}) WhereOperator {
 	return WhereOperator{op: "<=", value: value}
 }
 
+// Between creates an inclusive range operator (BETWEEN lo AND hi)
+// Example: builder.Where{"created_at": builder.Between(from, to)}
+func Between(lo, hi interface{}) WhereOperator {
+	return WhereOperator{op: "BETWEEN", value: []interface{}{lo, hi}}
+}
+
 // Like creates a LIKE operator (case-sensitive pattern matching)
 func Like(value string) WhereOperator {
 	return WhereOperator{op: "LIKE", value: value}
@@ -83,34 +90,57 @@ func IsNotNull() WhereOperator {
 	return WhereOperator{op: "IS NOT NULL", value: nil}
 }
 
-// Contains creates a LIKE operator with %value% pattern (case-sensitive)
+// Contains creates a LIKE operator with %value% pattern (case-sensitive).
+// The %, _ and \ in value are escaped, so they match literally.
 func Contains(value string) WhereOperator {
-	return WhereOperator{op: "LIKE", value: "%" + value + "%"}
+	return WhereOperator{op: "LIKE", value: likePattern{prefix: "%", value: value, suffix: "%"}}
 }
 
 // StartsWith creates a LIKE operator with value% pattern (case-sensitive)
 func StartsWith(value string) WhereOperator {
-	return WhereOperator{op: "LIKE", value: value + "%"}
+	return WhereOperator{op: "LIKE", value: likePattern{value: value, suffix: "%"}}
 }
 
 // EndsWith creates a LIKE operator with %value pattern (case-sensitive)
 func EndsWith(value string) WhereOperator {
-	return WhereOperator{op: "LIKE", value: "%" + value}
+	return WhereOperator{op: "LIKE", value: likePattern{prefix: "%", value: value}}
 }
 
-// ContainsInsensitive creates an ILIKE operator with %value% pattern (case-insensitive)
+// ContainsInsensitive creates an ILIKE operator with %value% pattern (case-insensitive):
+// ILIKE on PostgreSQL, LOWER(field) LIKE LOWER(?) elsewhere
 func ContainsInsensitive(value string) WhereOperator {
-	return WhereOperator{op: "ILIKE", value: "%" + value + "%"}
+	return WhereOperator{op: "ILIKE", value: likePattern{prefix: "%", value: value, suffix: "%"}}
 }
 
 // StartsWithInsensitive creates an ILIKE operator with value% pattern (case-insensitive)
 func StartsWithInsensitive(value string) WhereOperator {
-	return WhereOperator{op: "ILIKE", value: value + "%"}
+	return WhereOperator{op: "ILIKE", value: likePattern{value: value, suffix: "%"}}
 }
 
 // EndsWithInsensitive creates an ILIKE operator with %value pattern (case-insensitive)
 func EndsWithInsensitive(value string) WhereOperator {
-	return WhereOperator{op: "ILIKE", value: "%" + value}
+	return WhereOperator{op: "ILIKE", value: likePattern{prefix: "%", value: value}}
+}
+
+// NotContains creates a NOT LIKE operator excluding values that contain value
+func NotContains(value string) WhereOperator {
+	return WhereOperator{op: "NOT LIKE", value: likePattern{prefix: "%", value: value, suffix: "%"}}
+}
+
+// NotStartsWith creates a NOT LIKE operator excluding values that start with value
+func NotStartsWith(value string) WhereOperator {
+	return WhereOperator{op: "NOT LIKE", value: likePattern{value: value, suffix: "%"}}
+}
+
+// NotEndsWith creates a NOT LIKE operator excluding values that end with value
+func NotEndsWith(value string) WhereOperator {
+	return WhereOperator{op: "NOT LIKE", value: likePattern{prefix: "%", value: value}}
+}
+
+// NotContainsInsensitive creates a case-insensitive NOT LIKE operator excluding values that
+// contain value: NOT ILIKE on PostgreSQL, LOWER(field) NOT LIKE LOWER(?) elsewhere
+func NotContainsInsensitive(value string) WhereOperator {
+	return WhereOperator{op: "NOT ILIKE", value: likePattern{prefix: "%", value: value, suffix: "%"}}
 }
 
 // Has checks if an array/JSON field contains a value
@@ -133,6 +163,111 @@ func IsEmpty() WhereOperator {
 	return WhereOperator{op: "IS_EMPTY", value: nil}
 }
 
+// ArrayHas checks if a native array field (String[], Int[]...) contains a value.
+// PostgreSQL renders field @> ARRAY[?]; databases without native arrays fall back to Has.
+// Example: builder.Where{"tags": builder.ArrayHas("go")}
+func ArrayHas(value interface{}) WhereOperator {
+	return WhereOperator{op: "ARRAY_HAS", value: value}
+}
+
+// ArrayHasEvery checks if a native array field contains all values (field @> ARRAY[...] on PostgreSQL)
+func ArrayHasEvery(values ...interface{}) WhereOperator {
+	return WhereOperator{op: "ARRAY_HAS_EVERY", value: values}
+}
+
+// ArrayHasSome checks if a native array field contains any value (field && ARRAY[...] on PostgreSQL)
+func ArrayHasSome(values ...interface{}) WhereOperator {
+	return WhereOperator{op: "ARRAY_HAS_SOME", value: values}
+}
+
+// ArrayEquals checks if a native array field equals values, a slice bound as a single
+// parameter (field = ? on PostgreSQL). Databases without native arrays compare the JSON text.
+// Example: builder.Where{"tags": builder.ArrayEquals([]string{"go", "sql"})}
+func ArrayEquals(values interface{}) WhereOperator {
+	return WhereOperator{op: "ARRAY_EQUALS", value: values}
+}
+
+// ArrayIsEmpty checks if a native array field is empty
+func ArrayIsEmpty() WhereOperator {
+	return WhereOperator{op: "ARRAY_IS_EMPTY", value: nil}
+}
+
+// JsonArrayLength compares the number of elements of a JSON array field.
+// op is one of =, !=, >, >=, <, <=
+// Example: builder.Where{"tags": builder.JsonArrayLength(">", 3)}
+func JsonArrayLength(op string, n int) WhereOperator {
+	return WhereOperator{op: "JSON_ARRAY_LENGTH", value: jsonArrayLength{op: op, n: n}}
+}
+
+// JsonArrayElement checks the element at index (0-based) of a JSON array field.
+// Elements are compared as text, so JsonArrayElement(0, 42) matches [42, ...] and ["42", ...].
+// Example: builder.Where{"tags": builder.JsonArrayElement(0, "go")}
+func JsonArrayElement(index int, value interface{}) WhereOperator {
+	return WhereOperator{op: "JSON_ARRAY_ELEMENT", value: jsonArrayElement{index: index, value: value}}
+}
+
+// JsonPath compares the value at path inside a JSON field, as text.
+// op is one of =, != or LIKE; numeric keys index arrays.
+// Example: builder.Where{"meta": builder.JsonPath([]string{"address", "city"}, "=", "Lisbon")}
+func JsonPath(path []string, op string, value interface{}) WhereOperator {
+	return WhereOperator{op: "JSON_PATH", value: jsonPathFilter{path: path, op: op, value: value}}
+}
+
+// JsonPathContains matches when the value at path inside a JSON field contains value, as text.
+// Like Contains, the %, _ and \ in value are escaped, so they match literally.
+// Example: builder.Where{"meta": builder.JsonPathContains([]string{"address", "city"}, "bon")}
+func JsonPathContains(path []string, value string) WhereOperator {
+	return WhereOperator{op: "JSON_PATH", value: jsonPathFilter{path: path, op: "LIKE", value: likePattern{prefix: "%", value: value, suffix: "%"}}}
+}
+
+// Not negates a group of conditions, rendered as NOT (a AND b).
+// The key it is stored under is not a column and is ignored; by convention it starts with $.
+// Example: builder.Where{"status": "active", "$not": builder.Not(builder.Where{"role": "admin"})}
+func Not(where Where) WhereOperator {
+	return WhereOperator{op: "NOT", value: where}
+}
+
+// Or matches when any of the groups matches, rendered as ((a AND b) OR (c)).
+// Like Not, the key it is stored under is ignored.
+// Example: builder.Where{"$or": builder.Or(builder.Where{"role": "admin"}, builder.Where{"owner": true})}
+func Or(wheres ...Where) WhereOperator {
+	return WhereOperator{op: "OR", value: wheres}
+}
+
+// And matches when all of the groups match, rendered as ((a) AND (b)).
+// Unlike merging the maps, conditions on the same column in different groups are all kept.
+// Example: builder.Where{"$and": builder.And(builder.Where{"age": builder.Gt(18)}, builder.Where{"age": builder.Lt(65)})}
+func And(wheres ...Where) WhereOperator {
+	return WhereOperator{op: "AND", value: wheres}
+}
+
+// likePattern is the value of an escaped LIKE operator: value matches literally,
+// between the prefix and suffix wildcards
+type likePattern struct {
+	prefix string
+	value  string
+	suffix string
+}
+
+// jsonArrayLength is the value of a JSON_ARRAY_LENGTH operator
+type jsonArrayLength struct {
+	op string
+	n  int
+}
+
+// jsonArrayElement is the value of a JSON_ARRAY_ELEMENT operator
+type jsonArrayElement struct {
+	index int
+	value interface{}
+}
+
+// jsonPathFilter is the value of a JSON_PATH operator
+type jsonPathFilter struct {
+	path  []string
+	op    string
+	value interface{}
+}
+
 // GetOp returns the operator string (exported for internal use)
 func (wo WhereOperator) GetOp() string {
 	return wo.op
@@ -142,3 +277,12 @@ func (wo WhereOperator) GetOp() string {
 func (wo WhereOperator) GetValue() interface{} {
 	return wo.value
 }
+
+// Collate compares the field using the given collation (COLLATE).
+// Supported with Equals, NotEquals, In and NotIn; the name is validated by the dialect:
+//
+//	builder.Where{"name": builder.Equals("josé").Collate("und-x-icu")}
+func (wo WhereOperator) Collate(collation string) WhereOperator {
+	wo.collation = collation
+	return wo
+}
@@ -151,7 +151,7 @@ func runDbPush(args []string) error {
 
 	// Introspect database
 	fmt.Println("Introspecting database...")
-	dbSchema, err := migrations.IntrospectDatabase(db, provider)
+	dbSchema, err := migrations.IntrospectDatabaseSchemas(db, provider, migrations.GetSchemasFromSchema(schema))
 	if err != nil {
 		return fmt.Errorf("error introspecting database: %w", err)
 	}
@@ -168,7 +168,9 @@ func runDbPush(args []string) error {
 		len(diff.TablesToAlter) > 0 ||
 		len(diff.TablesToDrop) > 0 ||
 		len(diff.IndexesToCreate) > 0 ||
-		len(diff.IndexesToDrop) > 0
+		len(diff.IndexesToDrop) > 0 ||
+		len(diff.ChecksToCreate) > 0 ||
+		len(diff.ChecksToDrop) > 0
 
 	if !hasChanges {
 		fmt.Println("No changes detected. Database is synchronized with schema.")
@@ -258,9 +260,9 @@ func runDbPull(args []string) error {
 	// Detect provider
 	provider := migrations.DetectProvider(dbURL)
 
-	// Introspect database
+	// Introspect database, including the schemas listed in the existing schema.prisma
 	fmt.Println("Introspecting database...")
-	dbSchema, err := migrations.IntrospectDatabase(db, provider)
+	dbSchema, err := migrations.IntrospectDatabaseSchemas(db, provider, schemasFromFiles(getSchemaPath()))
 	if err != nil {
 		return fmt.Errorf("error introspecting database: %w", err)
 	}
@@ -591,3 +593,19 @@ func formatModelsAndEnums(schema *parser.Schema) string {
 
 	return strings.TrimSpace(result.String())
 }
+
+// schemasFromFiles returns the datasource schemas of the first of paths that parses,
+// or nil (public only) when none does
+func schemasFromFiles(paths ...string) []string {
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		schema, _, err := parser.ParseFile(path)
+		if err != nil || schema == nil {
+			continue
+		}
+		return migrations.GetSchemasFromSchema(schema)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/carlosnayan/prisma-go-client/cli"
+	"github.com/carlosnayan/prisma-go-client/internal/migrations"
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+var describeCmd = &cli.Command{
+	Name:  "describe",
+	Short: "Print the SQL mapping of a model",
+	Long: `Prints how a model of schema.prisma maps to SQL, without generating a migration:
+  - Resolved table name (@@map)
+  - Columns with types, nullability and defaults (@map)
+  - Indexes and unique constraints
+  - Foreign keys from and to the table
+
+Usage: prisma describe <Model>`,
+	Flags: []*cli.Flag{
+		{
+			Name:  "schema",
+			Short: "s",
+			Usage: "Custom path to your Prisma schema",
+			Value: &schemaPath,
+		},
+	},
+	Run: runDescribe,
+}
+
+func runDescribe(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: prisma describe <Model>")
+	}
+
+	if err := checkProjectRoot(); err != nil {
+		return err
+	}
+
+	schemaPath := getSchemaPath()
+
+	relPath, err := filepath.Rel(".", schemaPath)
+	if err != nil {
+		relPath = schemaPath
+	}
+	fmt.Printf("Prisma schema loaded from %s\n", relPath)
+	fmt.Println()
+
+	schema, errors, err := parser.ParseFile(schemaPath)
+	if err != nil || len(errors) > 0 {
+		if len(errors) > 0 {
+			fmt.Println("Errors in schema:")
+			for _, e := range errors {
+				fmt.Printf("  %s\n", e)
+			}
+		}
+		return fmt.Errorf("error parsing schema: %w", err)
+	}
+
+	description, err := migrations.DescribeModel(schema, args[0], migrations.GetProviderFromSchema(schema))
+	if err != nil {
+		return err
+	}
+	fmt.Print(description)
+
+	return nil
+}
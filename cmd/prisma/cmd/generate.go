@@ -123,6 +123,15 @@ func runGenerateOnce(schemaPath string) error {
 		return fmt.Errorf("error parsing schema: %w", err)
 	}
 
+	// Performance warnings don't block generation
+	if warnings := parser.Lint(schema); len(warnings) > 0 {
+		fmt.Println()
+		fmt.Println(Warning("Warnings found in schema:"))
+		for i, w := range warnings {
+			fmt.Printf("  %d. %s\n", i+1, w)
+		}
+	}
+
 	// Check if models are required
 	if requireModelsFlag && len(schema.Models) == 0 {
 		return fmt.Errorf("no models found in schema. Use --require-models=false to allow generating without models")
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 	"unicode"
 
@@ -312,7 +315,7 @@ func runMigrateDev(args []string) error {
 	// Step 4: Check for pending changes (schema.prisma vs current database)
 	// This is equivalent to evaluateDataLoss + createMigration
 	// Introspect database to detect incremental changes
-	dbSchema, err := migrations.IntrospectDatabase(db, provider)
+	dbSchema, err := migrations.IntrospectDatabaseSchemas(db, provider, migrations.GetSchemasFromSchema(schema))
 	if err != nil {
 		// If introspection fails, we can't proceed safely
 		return fmt.Errorf("error introspecting database: %w", err)
@@ -331,7 +334,9 @@ func runMigrateDev(args []string) error {
 		len(diff.TablesToAlter) > 0 ||
 		len(diff.TablesToDrop) > 0 ||
 		len(diff.IndexesToCreate) > 0 ||
-		len(diff.IndexesToDrop) > 0
+		len(diff.IndexesToDrop) > 0 ||
+		len(diff.ChecksToCreate) > 0 ||
+		len(diff.ChecksToDrop) > 0
 
 	// Step 5: If no changes, show sync message and return
 	if !hasChanges {
@@ -360,6 +365,13 @@ func runMigrateDev(args []string) error {
 		return nil
 	}
 
+	// Surface warnings embedded in the SQL (e.g. columns becoming NOT NULL)
+	for _, line := range strings.Split(sql, "\n") {
+		if strings.HasPrefix(line, "-- WARNING: ") {
+			fmt.Println(Warning("Warning: " + strings.TrimPrefix(line, "-- WARNING: ")))
+		}
+	}
+
 	// Get migration name
 	migrationName := ""
 	if len(args) > 0 {
@@ -382,9 +394,8 @@ func runMigrateDev(args []string) error {
 	}
 
 	// Create migration directory
-	timestamp := time.Now().Format("20060102150405")
-	migrationDirName := fmt.Sprintf("%s_%s", timestamp, migrationName)
 	migrationsPath := cfg.GetMigrationsPath()
+	migrationDirName := migrations.NewMigrationName(migrationsPath, migrationName, time.Now())
 	migrationPath := filepath.Join(migrationsPath, migrationDirName)
 
 	if err := os.MkdirAll(migrationPath, 0755); err != nil {
@@ -397,6 +408,15 @@ func runMigrateDev(args []string) error {
 		return fmt.Errorf("error writing migration.sql: %w", err)
 	}
 
+	// Write down.sql with the SQL that reverts the migration; it is never applied automatically
+	downSQL, err := migrations.GenerateRollbackSQL(diff, provider)
+	if err != nil {
+		return fmt.Errorf("error generating rollback SQL: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(migrationPath, "down.sql"), []byte(downSQL), 0644); err != nil {
+		return fmt.Errorf("error writing down.sql: %w", err)
+	}
+
 	// Normal text (no color) for migration created message
 	fmt.Printf("Migration created: %s\n", migrationDirName)
 
@@ -426,7 +446,8 @@ func runMigrateDev(args []string) error {
 	fmt.Println()
 	fmt.Println("migrations/")
 	fmt.Printf("  └─ %s/\n", MigrationName(migrationDirName+"/"))
-	fmt.Printf("    └─ migration.sql\n")
+	fmt.Printf("    ├─ migration.sql\n")
+	fmt.Printf("    └─ down.sql\n")
 	fmt.Println()
 	fmt.Println(Success("Your database is now in sync with your schema."))
 
@@ -495,12 +516,16 @@ func runMigrateDeploy(args []string) error {
 
 	fmt.Printf("\n%d migration(s) found in prisma/migrations\n\n", len(pending))
 
-	// Apply each migration
-	for _, migration := range pending {
-		fmt.Printf("Applying migration `%s`\n", MigrationName(migration.Name))
-		if err := manager.ApplyMigration(migration); err != nil {
-			return fmt.Errorf("error applying migration %s: %w", migration.Name, err)
-		}
+	// Ctrl+C stops the deploy; the running migration is rolled back where DDL is transactional
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	applied, err := migrations.Apply(ctx, db, migrations.DetectProvider(dbURL), cfg.GetMigrationsPath())
+	for _, name := range applied {
+		fmt.Printf("Applied migration `%s`\n", MigrationName(name))
+	}
+	if err != nil {
+		return err
 	}
 
 	// Show success message and migrations tree
@@ -508,8 +533,8 @@ func runMigrateDeploy(args []string) error {
 	fmt.Println("The following migration(s) have been applied:")
 	fmt.Println()
 	fmt.Println("migrations/")
-	for _, migration := range pending {
-		fmt.Printf("  └─ %s/\n", MigrationName(migration.Name+"/"))
+	for _, name := range applied {
+		fmt.Printf("  └─ %s/\n", MigrationName(name+"/"))
 		fmt.Printf("    └─ migration.sql\n")
 	}
 	fmt.Println()
@@ -723,7 +748,7 @@ func runMigrateStatus(args []string) error {
 	schema, _, err := parser.ParseFile(schemaPath)
 	if err == nil {
 		provider := migrations.GetProviderFromSchema(schema)
-		dbSchema, err := migrations.IntrospectDatabase(db, provider)
+		dbSchema, err := migrations.IntrospectDatabaseSchemas(db, provider, migrations.GetSchemasFromSchema(schema))
 		if err == nil {
 			diff, err := migrations.CompareSchema(schema, dbSchema, provider)
 			if err == nil {
@@ -731,7 +756,9 @@ func runMigrateStatus(args []string) error {
 					len(diff.TablesToAlter) > 0 ||
 					len(diff.TablesToDrop) > 0 ||
 					len(diff.IndexesToCreate) > 0 ||
-					len(diff.IndexesToDrop) > 0
+					len(diff.IndexesToDrop) > 0 ||
+					len(diff.ChecksToCreate) > 0 ||
+					len(diff.ChecksToDrop) > 0
 
 				if hasDivergences {
 					fmt.Println(Warning("Warning: Divergences detected between schema.prisma and database:"))
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -83,6 +86,18 @@ model Post {
 	if fileExists("prisma/migrations") {
 		t.Log("Migration directory created successfully")
 	}
+
+	// down.sql reverts the migration: the created table is dropped
+	if err == nil {
+		downFiles, _ := filepath.Glob("prisma/migrations/*_test_migration/down.sql")
+		if len(downFiles) != 1 {
+			t.Fatalf("expected down.sql beside migration.sql, got %v", downFiles)
+		}
+		down, _ := os.ReadFile(downFiles[0])
+		if !strings.Contains(string(down), `DROP TABLE "Post"`) {
+			t.Errorf("down.sql should drop the created table, got:\n%s", down)
+		}
+	}
 }
 
 func TestMigrateDev_CreatesMigrationDirectory(t *testing.T) {
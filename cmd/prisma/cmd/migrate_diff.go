@@ -75,7 +75,8 @@ func runMigrateDiff(args []string) error {
 		provider := migrations.DetectProvider(dbURL)
 		fmt.Printf("Introspecting database (%s)...\n", provider)
 
-		dbSchema, err := migrations.IntrospectDatabase(db, provider)
+		// The schemas come from the other side of the diff, or from the project's schema.prisma
+		dbSchema, err := migrations.IntrospectDatabaseSchemas(db, provider, schemasFromFiles(diffTo, getSchemaPath()))
 		if err != nil {
 			return fmt.Errorf("error introspecting database: %w", err)
 		}
@@ -121,7 +122,8 @@ func runMigrateDiff(args []string) error {
 		provider := migrations.DetectProvider(dbURL)
 		fmt.Printf("Introspecting database (%s)...\n", provider)
 
-		dbSchema, err := migrations.IntrospectDatabase(db, provider)
+		// The schemas come from the other side of the diff, or from the project's schema.prisma
+		dbSchema, err := migrations.IntrospectDatabaseSchemas(db, provider, schemasFromFiles(diffFrom, getSchemaPath()))
 		if err != nil {
 			return fmt.Errorf("error introspecting database: %w", err)
 		}
@@ -176,7 +178,9 @@ func runMigrateDiff(args []string) error {
 		len(diff.TablesToAlter) > 0 ||
 		len(diff.TablesToDrop) > 0 ||
 		len(diff.IndexesToCreate) > 0 ||
-		len(diff.IndexesToDrop) > 0
+		len(diff.IndexesToDrop) > 0 ||
+		len(diff.ChecksToCreate) > 0 ||
+		len(diff.ChecksToDrop) > 0
 
 	if !hasChanges {
 		fmt.Println("No differences found between schemas.")
@@ -207,105 +211,5 @@ func runMigrateDiff(args []string) error {
 // schemaToDatabaseSchema converte um parser.Schema para DatabaseSchema
 // Isso é necessário para usar CompareSchema que espera DatabaseSchema
 func schemaToDatabaseSchema(schema *parser.Schema, provider string) (*migrations.DatabaseSchema, error) {
-	// Criar um DatabaseSchema vazio
-	dbSchema := &migrations.DatabaseSchema{
-		Tables: make(map[string]*migrations.TableInfo),
-	}
-
-	// Converter models para tabelas
-	for _, model := range schema.Models {
-		tableInfo := &migrations.TableInfo{
-			Name:    model.Name,
-			Columns: make(map[string]*migrations.ColumnInfo),
-			Indexes: []*migrations.IndexInfo{},
-		}
-
-		// Converter campos para colunas
-		for _, field := range model.Fields {
-			defaultVal := ""
-			hasDefault := false
-
-			// Verificar atributos
-			isPrimaryKey := false
-			isUnique := false
-			for _, attr := range field.Attributes {
-				switch attr.Name {
-				case "id":
-					isPrimaryKey = true
-				case "unique":
-					isUnique = true
-				case "default":
-					if len(attr.Arguments) > 0 {
-						if str, ok := attr.Arguments[0].Value.(string); ok {
-							defaultVal = str
-							hasDefault = true
-						}
-					}
-				}
-			}
-
-			var defaultPtr *string
-			if hasDefault {
-				defaultPtr = &defaultVal
-			}
-
-			colInfo := &migrations.ColumnInfo{
-				Name:         field.Name,
-				Type:         mapPrismaTypeToSQLType(field.Type.Name, provider),
-				IsNullable:   field.Type.IsOptional,
-				IsPrimaryKey: isPrimaryKey,
-				IsUnique:     isUnique,
-				DefaultValue: defaultPtr,
-			}
-
-			tableInfo.Columns[field.Name] = colInfo
-		}
-
-		dbSchema.Tables[model.Name] = tableInfo
-	}
-
-	return dbSchema, nil
-}
-
-// mapPrismaTypeToSQLType mapeia tipo Prisma para tipo SQL
-func mapPrismaTypeToSQLType(prismaType, provider string) string {
-	// Usar a função existente de migrations
-	// Por enquanto, mapeamento simples (mesmo usado em diff.go)
-	switch prismaType {
-	case "String":
-		if provider == "mysql" {
-			return "VARCHAR(255)"
-		}
-		return "TEXT"
-	case "Int":
-		return "INTEGER"
-	case "BigInt":
-		return "BIGINT"
-	case "Boolean":
-		if provider == "sqlite" {
-			return "INTEGER"
-		}
-		return "BOOLEAN"
-	case "DateTime":
-		if provider == "sqlite" {
-			return "TEXT"
-		}
-		return "TIMESTAMP"
-	case "Float":
-		return "DOUBLE PRECISION"
-	case "Decimal":
-		return "DECIMAL"
-	case "Json":
-		if provider == "sqlite" {
-			return "TEXT"
-		}
-		return "JSONB"
-	case "Bytes":
-		if provider == "mysql" {
-			return "BLOB"
-		}
-		return "BYTEA"
-	default:
-		return "TEXT"
-	}
+	return migrations.SchemaToDatabaseSchema(schema, provider)
 }
@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/migrations"
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
 )
 
 func TestMigrateDiff_RequiresConfigFile(t *testing.T) {
@@ -140,3 +144,229 @@ model users { id String @id }`
 	// Should succeed with "No differences found"
 	_ = err // May fail for other reasons, but should handle no differences gracefully
 }
+
+func TestSchemaToDatabaseSchema_Checks(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model reviews {
+  id     Int @id
+  rating Int
+
+  @@check("rating BETWEEN 1 AND 5", map: "reviews_rating_check")
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	dbSchema, err := schemaToDatabaseSchema(schema, "postgresql")
+	if err != nil {
+		t.Fatalf("schemaToDatabaseSchema failed: %v", err)
+	}
+	checks := dbSchema.Tables["reviews"].Checks
+	if len(checks) != 1 || checks[0].Name != "reviews_rating_check" {
+		t.Fatalf("Checks = %+v", checks)
+	}
+
+	// Comparar o schema com ele mesmo não deve recriar a constraint
+	diff, err := migrations.CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.ChecksToCreate) != 0 || len(diff.ChecksToDrop) != 0 {
+		t.Errorf("unchanged checks were diffed: create %+v, drop %+v", diff.ChecksToCreate, diff.ChecksToDrop)
+	}
+}
+
+func TestSchemaToDatabaseSchema_DefaultChange(t *testing.T) {
+	from, _, err := parser.Parse(`
+model posts {
+  id        Int      @id @default(autoincrement())
+  status    String   @default("draft")
+  createdAt DateTime @default(now())
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	to, _, err := parser.Parse(`
+model posts {
+  id        Int      @id @default(autoincrement())
+  status    String   @default("published")
+  createdAt DateTime @default(now())
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	dbSchema, err := schemaToDatabaseSchema(from, "postgresql")
+	if err != nil {
+		t.Fatalf("schemaToDatabaseSchema failed: %v", err)
+	}
+
+	// O schema comparado com ele mesmo não tem diferenças, nem no default de now()
+	diff, err := migrations.CompareSchema(from, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	for _, alter := range diff.TablesToAlter {
+		for _, col := range alter.AlterColumns {
+			if col.DefaultChanged {
+				t.Errorf("unchanged default of %s.%s was diffed", alter.TableName, col.ColumnName)
+			}
+		}
+	}
+
+	diff, err = migrations.CompareSchema(to, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	sql, err := migrations.GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	want := `ALTER TABLE "posts" ALTER COLUMN "status" SET DEFAULT 'published';`
+	if strings.TrimSpace(sql) != "-- AlterTable\n"+want {
+		t.Errorf("SQL = %q, want only %q", sql, want)
+	}
+}
+
+func TestSchemaToDatabaseSchema_MapRename(t *testing.T) {
+	from, _, err := parser.Parse(`
+model User {
+  id    Int    @id @default(autoincrement())
+  email String @unique @map("mail")
+  posts Post[]
+
+  @@map("users")
+}
+
+model Post {
+  id       Int  @id
+  authorId Int  @map("author_id")
+  author   User @relation(fields: [authorId], references: [id])
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	to, _, err := parser.Parse(`
+model User {
+  id    Int    @id @default(autoincrement())
+  email String @unique @map("email_address") @renamedFrom("mail")
+  posts Post[]
+
+  @@map("users")
+}
+
+model Post {
+  id       Int  @id
+  authorId Int  @map("author_id")
+  author   User @relation(fields: [authorId], references: [id])
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	dbSchema, err := schemaToDatabaseSchema(from, "postgresql")
+	if err != nil {
+		t.Fatalf("schemaToDatabaseSchema failed: %v", err)
+	}
+
+	// O schema comparado com ele mesmo, com @map, @@map e relações, não gera SQL
+	diff, err := migrations.CompareSchema(from, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	sql, err := migrations.GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	if strings.TrimSpace(sql) != "" {
+		t.Errorf("unchanged schema generated SQL:\n%s", sql)
+	}
+
+	// Mudar o @map renomeia a coluna em vez de recriá-la
+	diff, err = migrations.CompareSchema(to, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	sql, err = migrations.GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	if !strings.Contains(sql, `ALTER TABLE "users" RENAME COLUMN "mail" TO "email_address";`) {
+		t.Errorf("missing RENAME COLUMN in:\n%s", sql)
+	}
+	if strings.Contains(sql, "DROP COLUMN") || strings.Contains(sql, "ADD COLUMN") {
+		t.Errorf("a @map change should not drop and add the column:\n%s", sql)
+	}
+}
+
+func TestSchemaToDatabaseSchema_TypeChange(t *testing.T) {
+	from, _, err := parser.Parse(`
+model posts {
+  id    Int    @id @default(autoincrement())
+  views Int    @default(0)
+  title String @db.VarChar(100)
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	to, _, err := parser.Parse(`
+model posts {
+  id    Int    @id @default(autoincrement())
+  views BigInt @default(0)
+  title String @db.VarChar(255)
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	for _, provider := range []string{"postgresql", "mysql"} {
+		dbSchema, err := schemaToDatabaseSchema(from, provider)
+		if err != nil {
+			t.Fatalf("schemaToDatabaseSchema failed: %v", err)
+		}
+
+		// O schema comparado com ele mesmo não muda o tipo de nenhuma coluna
+		diff, err := migrations.CompareSchema(from, dbSchema, provider)
+		if err != nil {
+			t.Fatalf("CompareSchema failed: %v", err)
+		}
+		for _, alter := range diff.TablesToAlter {
+			for _, col := range alter.AlterColumns {
+				if col.TypeChanged {
+					t.Errorf("%s: unchanged type of %s.%s was diffed", provider, alter.TableName, col.ColumnName)
+				}
+			}
+		}
+
+		diff, err = migrations.CompareSchema(to, dbSchema, provider)
+		if err != nil {
+			t.Fatalf("CompareSchema failed: %v", err)
+		}
+		sql, err := migrations.GenerateMigrationSQL(diff, provider)
+		if err != nil {
+			t.Fatalf("GenerateMigrationSQL failed: %v", err)
+		}
+		want := []string{
+			`ALTER TABLE "posts" ALTER COLUMN "title" TYPE VARCHAR(255);`,
+			`ALTER TABLE "posts" ALTER COLUMN "views" TYPE BIGINT;`,
+		}
+		if provider == "mysql" {
+			want = []string{
+				"ALTER TABLE `posts` MODIFY COLUMN `title` VARCHAR(255) NOT NULL;",
+				"ALTER TABLE `posts` MODIFY COLUMN `views` BIGINT NOT NULL DEFAULT '0';",
+			}
+		}
+		for _, w := range want {
+			if !strings.Contains(sql, w) {
+				t.Errorf("%s: missing %q in:\n%s", provider, w, sql)
+			}
+		}
+	}
+}
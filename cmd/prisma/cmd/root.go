@@ -50,6 +50,7 @@ func Execute() error {
 	app.AddCommand(generateCmd)
 	app.AddCommand(validateCmd)
 	app.AddCommand(formatCmd)
+	app.AddCommand(describeCmd)
 	app.AddCommand(migrateCmd)
 	app.AddCommand(dbCmd)
 
@@ -0,0 +1,47 @@
+package dialect
+
+import "strings"
+
+// CockroachDBDialect implements the CockroachDB dialect. CockroachDB speaks the PostgreSQL
+// wire protocol and SQL ($n placeholders, ON CONFLICT, RETURNING, jsonb), so only the
+// column types that differ are overridden.
+type CockroachDBDialect struct {
+	PostgreSQLDialect
+}
+
+func (d *CockroachDBDialect) Name() string {
+	return "cockroachdb"
+}
+
+func (d *CockroachDBDialect) MapType(prismaType string, isNullable bool) string {
+	switch strings.ToLower(prismaType) {
+	case "string":
+		return "STRING"
+	case "int":
+		return "INT4" // INTEGER é INT8 no CockroachDB
+	case "bigint":
+		return "INT8"
+	case "float":
+		return "FLOAT8"
+	case "bytes":
+		return "BYTES"
+	default:
+		return d.PostgreSQLDialect.MapType(prismaType, isNullable)
+	}
+}
+
+func (d *CockroachDBDialect) MapDefaultValue(value string) string {
+	switch strings.ToLower(value) {
+	case "autoincrement()", "autoincrement":
+		return "unique_rowid()" // Sem sequências; o id é gerado pelo nó
+	case "now()", "now":
+		return "CURRENT_TIMESTAMP"
+	default:
+		return d.PostgreSQLDialect.MapDefaultValue(value)
+	}
+}
+
+func (d *CockroachDBDialect) GetIndexHintSyntax(hint string) (string, string) {
+	// O CockroachDB não lê os comentários do pg_hint_plan; força o índice com tabela@{...}
+	return "", "@{FORCE_INDEX=" + d.QuoteIdentifier(hint) + "}"
+}
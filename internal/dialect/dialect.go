@@ -7,11 +7,13 @@ import (
 // Dialect representa um dialeto de banco de dados
 // Abstrai as diferenças entre PostgreSQL, MySQL, SQLite, etc.
 type Dialect interface {
-	// Name retorna o nome do dialeto (ex: "postgresql", "mysql", "sqlite")
+	// Name retorna o nome do dialeto (ex: "postgresql", "mysql", "sqlite", "sqlserver")
 	Name() string
 
 	// QuoteIdentifier cita um identificador (tabela, coluna, etc.)
 	// PostgreSQL: "table_name", MySQL: `table_name`, SQLite: "table_name"
+	// Nomes qualificados têm cada parte citada: auth.users vira "auth"."users"
+	// As aspas de fechamento dentro do nome são duplicadas: a"b vira "a""b", a`b vira `a``b`
 	QuoteIdentifier(name string) string
 
 	// QuoteString cita uma string literal
@@ -24,6 +26,7 @@ type Dialect interface {
 
 	// MapDefaultValue mapeia um valor default do Prisma para SQL
 	// Exemplo: "autoincrement()" -> "AUTO_INCREMENT" (MySQL) ou "SERIAL" (PostgreSQL)
+	// Booleanos: "true" -> TRUE (PostgreSQL) ou 1 (MySQL, SQLite)
 	MapDefaultValue(value string) string
 
 	// GetPlaceholder retorna o placeholder para parâmetros
@@ -58,13 +61,72 @@ type Dialect interface {
 	// MySQL: JSON_CONTAINS(field, 'value')
 	GetJSONContainsQuery(field string, value string) string
 
-	// GetLimitOffsetSyntax retorna a sintaxe LIMIT/OFFSET
-	// PostgreSQL: LIMIT n OFFSET m, MySQL: LIMIT m, n (ou LIMIT n OFFSET m)
-	GetLimitOffsetSyntax(limit, offset int) string
+	// GetJSONArrayLengthExpression retorna a expressão com o tamanho de um array JSON
+	// PostgreSQL: jsonb_array_length(field), MySQL: JSON_LENGTH(field), SQLite: json_array_length(field)
+	GetJSONArrayLengthExpression(field string) string
+
+	// GetJSONArrayElementExpression retorna o elemento de índice index (base 0) de um array JSON, como texto
+	// PostgreSQL: field->>index, MySQL: JSON_UNQUOTE(JSON_EXTRACT(field, '$[index]')), SQLite: json_extract(field, '$[index]')
+	GetJSONArrayElementExpression(field string, index int) string
+
+	// GetJSONPathQuery retorna a condição que compara, como texto, o valor no caminho path de um campo JSON
+	// com um placeholder ?; chaves numéricas indexam arrays. op é =, != ou LIKE (com o mesmo ESCAPE de GetLikeQuery)
+	// PostgreSQL: field->>'a' ou field #>> '{a,b}', MySQL: JSON_UNQUOTE(JSON_EXTRACT(field, '$."a"."b"')), SQLite: json_extract(field, '$."a"."b"')
+	GetJSONPathQuery(field string, path []string, op string) string
+
+	// GetScalarListQuery retorna a condição sobre um array nativo (String[], Int[]...) com n placeholders ?,
+	// ou vazio quando o banco não tem arrays nativos. op é HAS, HAS_EVERY, HAS_SOME, IS_EMPTY, EQUALS
+	// (a lista inteira num único placeholder) ou ANY (um elemento, para listas que não são de texto)
+	// PostgreSQL: field @> ARRAY[?], field @> ARRAY[?, ?], field && ARRAY[?, ?], COALESCE(array_length(field, 1), 0) = 0, field = ?, ? = ANY(field)
+	GetScalarListQuery(field, op string, n int) string
+
+	// GetLimitOffsetSyntax retorna a sintaxe LIMIT/OFFSET com um placeholder ? por valor e os
+	// argumentos na ordem em que aparecem, para que os valores não sejam embutidos no SQL
+	// PostgreSQL: LIMIT ? OFFSET ?, MySQL: LIMIT ?, ? (offset, limit)
+	GetLimitOffsetSyntax(limit, offset int) (string, []interface{})
 
 	// SupportsReturning indica se o banco suporta RETURNING em INSERT/UPDATE
 	// PostgreSQL: true, MySQL: false, SQLite: false
 	SupportsReturning() bool
+
+	// GetIndexHintSyntax retorna o hint de índice para o planner, como prefixo da query
+	// e/ou sufixo após a tabela no FROM. Ambos vazios indicam que o banco não suporta hints.
+	// PostgreSQL: /*+ hint */ (pg_hint_plan), MySQL: FORCE INDEX (name), SQLite: INDEXED BY name
+	GetIndexHintSyntax(hint string) (prefix string, fromSuffix string)
+
+	// GetCollateSyntax retorna a cláusula COLLATE para a collation, ou vazio quando o nome
+	// não é válido no banco. PostgreSQL: COLLATE "C", MySQL: COLLATE utf8mb4_bin, SQLite: COLLATE NOCASE
+	GetCollateSyntax(collation string) string
+
+	// GetDistinctOnSyntax retorna a cláusula DISTINCT ON para as colunas, ou groupBy = true
+	// quando o banco deve agrupar por elas. Vazio e false indicam que não há suporte.
+	// PostgreSQL: DISTINCT ON (a, b), MySQL: sem suporte, SQLite: GROUP BY a, b
+	GetDistinctOnSyntax(fields []string) (clause string, groupBy bool)
+
+	// GetLockSyntax retorna a cláusula de bloqueio de linhas do SELECT para mode (UPDATE ou SHARE)
+	// e wait (vazio, SKIP LOCKED ou NOWAIT). Vazio indica que o banco não bloqueia linhas.
+	// PostgreSQL: FOR SHARE NOWAIT, MySQL: LOCK IN SHARE MODE, SQLite: sem suporte
+	GetLockSyntax(mode, wait string) string
+
+	// SupportsTupleIn indica se o banco deve usar (a, b) IN ((?, ?), ...) em vez de OR de ANDs
+	// PostgreSQL: true, MySQL: false, SQLite: true
+	SupportsTupleIn() bool
+
+	// EscapeLikePattern escapa os curingas % e _ (e o caractere de escape) de value, para que
+	// um padrão montado com ele compare o texto literalmente na condição de GetLikeQuery
+	EscapeLikePattern(value string) string
+
+	// GetLikeQuery retorna a condição LIKE de field com um placeholder ?, declarando o escape de
+	// EscapeLikePattern. insensitive ignora maiúsculas e minúsculas e not nega a condição
+	// PostgreSQL: "f" NOT ILIKE ? ESCAPE '\', MySQL: LOWER(`f`) NOT LIKE LOWER(?) ESCAPE '\\', SQLite: LOWER("f") NOT LIKE LOWER(?) ESCAPE '\'
+	GetLikeQuery(field string, insensitive, not bool) string
+
+	// GetUpsertClause retorna o sufixo de upsert para um INSERT. assignments já vêm no formato
+	// "coluna" = placeholder; se vazio, a primeira coluna de conflito é atribuída a si mesma.
+	// conflictWhere é o predicado de um índice único parcial nas colunas, ou "" para um índice total.
+	// PostgreSQL/SQLite: ON CONFLICT (cols) [WHERE pred] DO UPDATE SET ..., MySQL: ON DUPLICATE KEY UPDATE ...,
+	// SQL Server: vazio (upsert não suportado)
+	GetUpsertClause(conflictColumns []string, conflictWhere string, assignments []string) string
 }
 
 // GetDialect retorna o dialeto apropriado para o provider
@@ -78,6 +140,10 @@ func GetDialect(provider string) Dialect {
 		return &MySQLDialect{}
 	case "sqlite":
 		return &SQLiteDialect{}
+	case "sqlserver", "mssql":
+		return &SQLServerDialect{}
+	case "cockroachdb", "cockroach":
+		return &CockroachDBDialect{}
 	default:
 		// Default para PostgreSQL
 		return &PostgreSQLDialect{}
@@ -1,6 +1,8 @@
 package dialect
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -118,3 +120,245 @@ func TestDialect_SQLite(t *testing.T) {
 		t.Errorf("GetPlaceholder(1) = %s, want ?", placeholder)
 	}
 }
+
+// TestDialect_SQLServer tests SQL Server-specific features
+func TestDialect_SQLServer(t *testing.T) {
+	for _, provider := range []string{"sqlserver", "mssql", "SQLServer"} {
+		if name := GetDialect(provider).Name(); name != "sqlserver" {
+			t.Errorf("GetDialect(%s).Name() = %s, want sqlserver", provider, name)
+		}
+	}
+	d := GetDialect("sqlserver")
+
+	// Test type mapping
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"String", "NVARCHAR(1000)"},
+		{"Int", "INT"},
+		{"Boolean", "BIT"},
+		{"DateTime", "DATETIME2"},
+		{"Json", "NVARCHAR(MAX)"},
+	}
+
+	for _, tt := range tests {
+		result := d.MapType(tt.input, false)
+		if result != tt.expected {
+			t.Errorf("MapType(%s, false) = %s, want %s", tt.input, result, tt.expected)
+		}
+	}
+
+	if quoted := d.QuoteIdentifier("user"); quoted != "[user]" {
+		t.Errorf("QuoteIdentifier('user') = %s, want [user]", quoted)
+	}
+	if quoted := d.QuoteString(`it's C:\`); quoted != `N'it''s C:\'` {
+		t.Errorf("QuoteString = %s", quoted)
+	}
+	if placeholder := d.GetPlaceholder(3); placeholder != "@p3" {
+		t.Errorf("GetPlaceholder(3) = %s, want @p3", placeholder)
+	}
+
+	// O [ abre uma classe de caracteres no LIKE do SQL Server
+	if pattern := d.EscapeLikePattern("[a]_"); pattern != `\[a]\_` {
+		t.Errorf("EscapeLikePattern = %s", pattern)
+	}
+	if d.SupportsReturning() || d.SupportsFullTextSearch() || !d.SupportsJSON() || d.GetUpsertClause([]string{"id"}, "", nil) != "" {
+		t.Error("unexpected SQL Server capability flags")
+	}
+}
+
+// TestDialect_CockroachDB tests that CockroachDB keeps the PostgreSQL SQL and overrides the column types
+func TestDialect_CockroachDB(t *testing.T) {
+	for _, provider := range []string{"cockroachdb", "cockroach", "CockroachDB"} {
+		if name := GetDialect(provider).Name(); name != "cockroachdb" {
+			t.Errorf("GetDialect(%s).Name() = %s, want cockroachdb", provider, name)
+		}
+	}
+	d := GetDialect("cockroachdb")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"String", "STRING"},
+		{"Int", "INT4"},
+		{"BigInt", "INT8"},
+		{"Bytes", "BYTES"},
+		{"Json", "JSONB"},
+		{"VARCHAR(255)", "VARCHAR(255)"},
+	}
+	for _, tt := range tests {
+		if result := d.MapType(tt.input, false); result != tt.expected {
+			t.Errorf("MapType(%s, false) = %s, want %s", tt.input, result, tt.expected)
+		}
+	}
+	if value := d.MapDefaultValue("autoincrement()"); value != "unique_rowid()" {
+		t.Errorf("MapDefaultValue(autoincrement()) = %s, want unique_rowid()", value)
+	}
+	if value := d.MapDefaultValue("true"); value != "TRUE" {
+		t.Errorf("MapDefaultValue(true) = %s, want TRUE", value)
+	}
+
+	// Placeholders e upsert iguais aos do PostgreSQL
+	if placeholder := d.GetPlaceholder(2); placeholder != "$2" {
+		t.Errorf("GetPlaceholder(2) = %s, want $2", placeholder)
+	}
+	upsert := d.GetUpsertClause([]string{"email"}, "", []string{`"name" = $3`})
+	if upsert != `ON CONFLICT ("email") DO UPDATE SET "name" = $3` {
+		t.Errorf("GetUpsertClause = %s", upsert)
+	}
+	// Índice único parcial: o predicado entra no alvo do conflito
+	upsert = d.GetUpsertClause([]string{"tenant_id", "email"}, "deleted_at IS NULL", []string{`"name" = $3`})
+	if upsert != `ON CONFLICT ("tenant_id", "email") WHERE deleted_at IS NULL DO UPDATE SET "name" = $3` {
+		t.Errorf("GetUpsertClause with predicate = %s", upsert)
+	}
+	if !d.SupportsReturning() || d.GetDriverName() != "pgx" {
+		t.Error("CockroachDB should support RETURNING through the pgx driver")
+	}
+}
+
+// TestDialect_QuoteQualifiedIdentifier tests that each part of a schema-qualified name is quoted
+func TestDialect_QuoteQualifiedIdentifier(t *testing.T) {
+	tests := []struct {
+		provider string
+		expected string
+	}{
+		{"postgresql", `"auth"."users"`},
+		{"cockroachdb", `"auth"."users"`},
+		{"mysql", "`auth`.`users`"},
+		{"sqlite", `"auth"."users"`},
+		{"sqlserver", "[auth].[users]"},
+	}
+	for _, tt := range tests {
+		if quoted := GetDialect(tt.provider).QuoteIdentifier("auth.users"); quoted != tt.expected {
+			t.Errorf("%s QuoteIdentifier(auth.users) = %s, want %s", tt.provider, quoted, tt.expected)
+		}
+	}
+}
+
+// TestDialect_QuoteIdentifierEscaping tests that quote characters inside an identifier are
+// doubled, so a hostile name stays a single quoted identifier
+func TestDialect_QuoteIdentifierEscaping(t *testing.T) {
+	tests := []struct {
+		provider string
+		name     string
+		expected string
+	}{
+		{"postgresql", `a"b`, `"a""b"`},
+		{"postgresql", `x"; DROP TABLE users; --`, `"x""; DROP TABLE users; --"`},
+		{"postgresql", `""`, `""""""`},
+		{"postgresql", "a`b", "\"a`b\""},
+		{"cockroachdb", `a"b`, `"a""b"`},
+		{"sqlite", `a"b`, `"a""b"`},
+		{"sqlite", `x" OR 1=1 --`, `"x"" OR 1=1 --"`},
+		{"mysql", "a`b", "`a``b`"},
+		{"mysql", "x`; DROP TABLE users; --", "`x``; DROP TABLE users; --`"},
+		{"mysql", `a"b`, "`a\"b`"},
+		{"sqlserver", "a]b", "[a]]b]"},
+		{"sqlserver", "x]; DROP TABLE users; --", "[x]]; DROP TABLE users; --]"},
+		{"sqlserver", "a[b", "[a[b]"},
+		// Cada parte de um nome qualificado é escapada separadamente
+		{"postgresql", `au"th.us"ers`, `"au""th"."us""ers"`},
+		{"mysql", "d`b.t`able", "`d``b`.`t``able`"},
+	}
+	for _, tt := range tests {
+		if quoted := GetDialect(tt.provider).QuoteIdentifier(tt.name); quoted != tt.expected {
+			t.Errorf("%s QuoteIdentifier(%q) = %s, want %s", tt.provider, tt.name, quoted, tt.expected)
+		}
+	}
+
+	// O escape vale para todo SQL que cita identificadores, como o LIKE e o upsert
+	if query := GetDialect("postgresql").GetLikeQuery(`na"me`, false, false); query != `"na""me" LIKE ? ESCAPE '\'` {
+		t.Errorf("GetLikeQuery = %s", query)
+	}
+	if upsert := GetDialect("sqlite").GetUpsertClause([]string{`e"mail`}, "", nil); !strings.Contains(upsert, `ON CONFLICT ("e""mail")`) {
+		t.Errorf("GetUpsertClause = %s", upsert)
+	}
+}
+
+// TestDialect_LimitOffsetSyntax tests that LIMIT/OFFSET use placeholders, with the args in
+// the order of the placeholders
+func TestDialect_LimitOffsetSyntax(t *testing.T) {
+	tests := []struct {
+		provider      string
+		limit, offset int
+		expected      string
+		args          string
+	}{
+		{"postgresql", 10, 20, "LIMIT ? OFFSET ?", "[10 20]"},
+		{"postgresql", 10, 0, "LIMIT ?", "[10]"},
+		{"postgresql", 0, 20, "OFFSET ?", "[20]"},
+		{"postgresql", 0, 0, "", "[]"},
+		{"mysql", 10, 20, "LIMIT ?, ?", "[20 10]"},
+		{"mysql", 0, 20, "LIMIT 18446744073709551615 OFFSET ?", "[20]"},
+		{"sqlite", 10, 20, "LIMIT ? OFFSET ?", "[10 20]"},
+		{"sqlite", 0, 20, "LIMIT -1 OFFSET ?", "[20]"},
+		// OFFSET/FETCH no lugar de LIMIT, com OFFSET 0 quando só há limite
+		{"sqlserver", 10, 20, "OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", "[20 10]"},
+		{"sqlserver", 10, 0, "OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", "[0 10]"},
+		{"sqlserver", 0, 20, "OFFSET ? ROWS", "[20]"},
+		{"sqlserver", 0, 0, "", "[]"},
+	}
+	for _, tt := range tests {
+		syntax, args := GetDialect(tt.provider).GetLimitOffsetSyntax(tt.limit, tt.offset)
+		if syntax != tt.expected || fmt.Sprint(args) != tt.args {
+			t.Errorf("%s GetLimitOffsetSyntax(%d, %d) = %q %v, want %q %s", tt.provider, tt.limit, tt.offset, syntax, args, tt.expected, tt.args)
+		}
+	}
+}
+
+// TestDialect_LikeQuery tests the escaped LIKE condition and pattern of each dialect
+func TestDialect_LikeQuery(t *testing.T) {
+	tests := []struct {
+		provider    string
+		insensitive bool
+		not         bool
+		expected    string
+	}{
+		{"postgresql", false, true, `"name" NOT LIKE ? ESCAPE '\'`},
+		{"postgresql", true, true, `"name" NOT ILIKE ? ESCAPE '\'`},
+		{"postgresql", true, false, `"name" ILIKE ? ESCAPE '\'`},
+		{"mysql", false, true, "`name` NOT LIKE ? ESCAPE '\\\\'"},
+		{"mysql", true, true, "LOWER(`name`) NOT LIKE LOWER(?) ESCAPE '\\\\'"},
+		{"sqlite", false, false, `"name" LIKE ? ESCAPE '\'`},
+		{"sqlite", true, true, `LOWER("name") NOT LIKE LOWER(?) ESCAPE '\'`},
+		{"sqlserver", true, false, `LOWER([name]) LIKE LOWER(?) ESCAPE '\'`},
+	}
+
+	for _, tt := range tests {
+		if query := GetDialect(tt.provider).GetLikeQuery("name", tt.insensitive, tt.not); query != tt.expected {
+			t.Errorf("%s GetLikeQuery(%v, %v) = %s, want %s", tt.provider, tt.insensitive, tt.not, query, tt.expected)
+		}
+		if pattern := GetDialect(tt.provider).EscapeLikePattern(`50%_a\b`); pattern != `50\%\_a\\b` {
+			t.Errorf("%s EscapeLikePattern = %s", tt.provider, pattern)
+		}
+	}
+}
+
+// TestDialect_CollateSyntax tests the COLLATE clause of each dialect and the rejected names
+func TestDialect_CollateSyntax(t *testing.T) {
+	tests := []struct {
+		provider  string
+		collation string
+		expected  string
+	}{
+		{"postgresql", "C", `COLLATE "C"`},
+		{"postgresql", "und-x-icu", `COLLATE "und-x-icu"`},
+		{"postgresql", "en_US.utf8", `COLLATE "en_US.utf8"`},
+		{"postgresql", `C" OR 1=1 --`, ""},
+		{"cockroachdb", "de", `COLLATE "de"`},
+		{"mysql", "utf8mb4_bin", "COLLATE utf8mb4_bin"},
+		{"mysql", "utf8mb4-bin", ""},
+		{"sqlite", "NOCASE", "COLLATE NOCASE"},
+		{"sqlite", "", ""},
+		{"sqlserver", "Latin1_General_CS_AS", "COLLATE Latin1_General_CS_AS"},
+		{"sqlserver", "Latin1_General; DROP", ""},
+	}
+
+	for _, tt := range tests {
+		if collate := GetDialect(tt.provider).GetCollateSyntax(tt.collation); collate != tt.expected {
+			t.Errorf("%s GetCollateSyntax(%q) = %q, want %q", tt.provider, tt.collation, collate, tt.expected)
+		}
+	}
+}
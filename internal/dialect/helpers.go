@@ -1,6 +1,9 @@
 package dialect
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // isSQLType checks if a type is already a SQL type (from @db.* attributes)
 func isSQLType(typ string) bool {
@@ -18,3 +21,77 @@ func isSQLType(typ string) bool {
 	}
 	return false
 }
+
+// quoteName cita name como um único identificador, duplicando o caractere de fechamento
+func quoteName(name, open, close string) string {
+	return open + strings.ReplaceAll(name, close, close+close) + close
+}
+
+// quoteQualified cita cada parte de um nome qualificado por schema: auth.users vira "auth"."users".
+// O caractere de fechamento dentro de uma parte é duplicado (a"b vira "a""b"), então o
+// identificador não consegue sair das aspas
+func quoteQualified(name, open, close string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quoteName(part, open, close)
+	}
+	return strings.Join(parts, ".")
+}
+
+// jsonPathKeyReplacer escapa barras e aspas de uma chave de caminho JSON entre aspas duplas
+var jsonPathKeyReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// likePatternReplacer escapa com \ os curingas de LIKE e a própria barra
+var likePatternReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// lowerLikeQuery monta quoted [NOT] LIKE ?, comparando em minúsculas quando insensitive (bancos sem ILIKE)
+func lowerLikeQuery(quoted string, insensitive, not bool) string {
+	operator := "LIKE"
+	if not {
+		operator = "NOT LIKE"
+	}
+	if insensitive {
+		return fmt.Sprintf("LOWER(%s) %s LOWER(?)", quoted, operator)
+	}
+	return fmt.Sprintf("%s %s ?", quoted, operator)
+}
+
+// isCollationName indica se name só tem letras, dígitos, _ e os caracteres de extra
+func isCollationName(name, extra string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || strings.ContainsRune(extra, r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isJSONArrayIndex indica se uma chave de caminho JSON é um índice de array (só dígitos)
+func isJSONArrayIndex(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonPathLiteral monta o caminho JSON do MySQL, SQLite e SQL Server: $."a"[0]."b"
+func jsonPathLiteral(path []string) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, key := range path {
+		if isJSONArrayIndex(key) {
+			sb.WriteString("[" + key + "]")
+		} else {
+			sb.WriteString(`."` + jsonPathKeyReplacer.Replace(key) + `"`)
+		}
+	}
+	return sb.String()
+}
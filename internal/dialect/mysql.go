@@ -13,7 +13,7 @@ func (d *MySQLDialect) Name() string {
 }
 
 func (d *MySQLDialect) QuoteIdentifier(name string) string {
-	return fmt.Sprintf("`%s`", name)
+	return quoteQualified(name, "`", "`")
 }
 
 func (d *MySQLDialect) QuoteString(value string) string {
@@ -101,6 +101,10 @@ func (d *MySQLDialect) MapDefaultValue(value string) string {
 		return "UUID()"
 	case strings.HasPrefix(value, "cuid()") || strings.HasPrefix(value, "cuid"):
 		return "UUID()" // Fallback para UUID
+	case value == "true":
+		return "1" // BOOLEAN é TINYINT(1)
+	case value == "false":
+		return "0"
 	default:
 		return value
 	}
@@ -122,6 +126,55 @@ func (d *MySQLDialect) SupportsReturning() bool {
 	return false
 }
 
+func (d *MySQLDialect) GetIndexHintSyntax(hint string) (string, string) {
+	return "", fmt.Sprintf("FORCE INDEX (%s)", d.QuoteIdentifier(hint))
+}
+
+func (d *MySQLDialect) GetCollateSyntax(collation string) string {
+	if !isCollationName(collation, "") {
+		return ""
+	}
+	return "COLLATE " + collation
+}
+
+func (d *MySQLDialect) GetDistinctOnSyntax(fields []string) (string, bool) {
+	// MySQL não tem DISTINCT ON e, com ONLY_FULL_GROUP_BY (padrão), rejeita
+	// colunas fora do GROUP BY
+	return "", false
+}
+
+func (d *MySQLDialect) GetLockSyntax(mode, wait string) string {
+	// LOCK IN SHARE MODE não aceita SKIP LOCKED nem NOWAIT; com eles usa FOR SHARE (MySQL 8.0+)
+	if mode == "SHARE" && wait == "" {
+		return "LOCK IN SHARE MODE"
+	}
+	return strings.TrimSpace("FOR " + mode + " " + wait)
+}
+
+func (d *MySQLDialect) SupportsTupleIn() bool {
+	// O MySQL aceita a sintaxe, mas antes da 5.7 não usa índices com ela
+	return false
+}
+
+func (d *MySQLDialect) EscapeLikePattern(value string) string {
+	return likePatternReplacer.Replace(value)
+}
+
+func (d *MySQLDialect) GetLikeQuery(field string, insensitive, not bool) string {
+	// A barra também é escape em literais do MySQL, então a cláusula precisa de duas
+	return lowerLikeQuery(d.QuoteIdentifier(field), insensitive, not) + ` ESCAPE '\\'`
+}
+
+func (d *MySQLDialect) GetUpsertClause(conflictColumns []string, conflictWhere string, assignments []string) string {
+	// O MySQL não recebe o alvo do conflito (nem tem índices parciais): qualquer chave única
+	// conflitante dispara o UPDATE
+	if len(assignments) == 0 && len(conflictColumns) > 0 {
+		quoted := d.QuoteIdentifier(conflictColumns[0])
+		assignments = []string{fmt.Sprintf("%s = %s", quoted, quoted)}
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ", ")
+}
+
 func (d *MySQLDialect) GetDriverName() string {
 	return "mysql"
 }
@@ -142,15 +195,36 @@ func (d *MySQLDialect) GetJSONContainsQuery(field string, value string) string {
 	return fmt.Sprintf("JSON_CONTAINS(%s, %s)", d.QuoteIdentifier(field), d.QuoteString(value))
 }
 
-func (d *MySQLDialect) GetLimitOffsetSyntax(limit, offset int) string {
+func (d *MySQLDialect) GetJSONArrayLengthExpression(field string) string {
+	return fmt.Sprintf("JSON_LENGTH(%s)", d.QuoteIdentifier(field))
+}
+
+func (d *MySQLDialect) GetJSONArrayElementExpression(field string, index int) string {
+	return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, '$[%d]'))", d.QuoteIdentifier(field), index)
+}
+
+func (d *MySQLDialect) GetJSONPathQuery(field string, path []string, op string) string {
+	query := fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s)) %s ?", d.QuoteIdentifier(field), d.QuoteString(jsonPathLiteral(path)), op)
+	if op == "LIKE" {
+		// O mesmo escape de GetLikeQuery, para padrões escapados com EscapeLikePattern
+		query += ` ESCAPE '\\'`
+	}
+	return query
+}
+
+func (d *MySQLDialect) GetScalarListQuery(field, op string, n int) string {
+	return ""
+}
+
+func (d *MySQLDialect) GetLimitOffsetSyntax(limit, offset int) (string, []interface{}) {
 	if limit > 0 && offset > 0 {
 		// MySQL suporta LIMIT offset, limit
-		return fmt.Sprintf("LIMIT %d, %d", offset, limit)
+		return "LIMIT ?, ?", []interface{}{offset, limit}
 	} else if limit > 0 {
-		return fmt.Sprintf("LIMIT %d", limit)
+		return "LIMIT ?", []interface{}{limit}
 	} else if offset > 0 {
 		// MySQL não suporta OFFSET sem LIMIT, usar LIMIT grande
-		return fmt.Sprintf("LIMIT 18446744073709551615 OFFSET %d", offset)
+		return "LIMIT 18446744073709551615 OFFSET ?", []interface{}{offset}
 	}
-	return ""
+	return "", nil
 }
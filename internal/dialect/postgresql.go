@@ -13,7 +13,7 @@ func (d *PostgreSQLDialect) Name() string {
 }
 
 func (d *PostgreSQLDialect) QuoteIdentifier(name string) string {
-	return fmt.Sprintf(`"%s"`, name)
+	return quoteQualified(name, `"`, `"`)
 }
 
 func (d *PostgreSQLDialect) QuoteString(value string) string {
@@ -80,6 +80,10 @@ func (d *PostgreSQLDialect) MapDefaultValue(value string) string {
 		return "" // Default to empty (client-side generation)
 	case strings.HasPrefix(value, "cuid()") || strings.HasPrefix(value, "cuid"):
 		return "" // Default to empty
+	case value == "true":
+		return "TRUE"
+	case value == "false":
+		return "FALSE"
 	default:
 		return value
 	}
@@ -101,6 +105,68 @@ func (d *PostgreSQLDialect) SupportsReturning() bool {
 	return true
 }
 
+func (d *PostgreSQLDialect) GetIndexHintSyntax(hint string) (string, string) {
+	// PostgreSQL não tem hints nativos; o comentário é lido pela extensão pg_hint_plan
+	// e ignorado quando ela não está instalada
+	return fmt.Sprintf("/*+ %s */ ", strings.ReplaceAll(hint, "*/", "")), ""
+}
+
+func (d *PostgreSQLDialect) GetCollateSyntax(collation string) string {
+	// Collations ICU têm hífens (ex.: und-x-icu), por isso o nome vai entre aspas; o ponto
+	// de en_US.utf8 faz parte do nome, então ele não é citado como nome qualificado
+	if !isCollationName(collation, "-.") {
+		return ""
+	}
+	return "COLLATE " + quoteName(collation, `"`, `"`)
+}
+
+func (d *PostgreSQLDialect) GetDistinctOnSyntax(fields []string) (string, bool) {
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = d.QuoteIdentifier(field)
+	}
+	return fmt.Sprintf("DISTINCT ON (%s)", strings.Join(quoted, ", ")), false
+}
+
+func (d *PostgreSQLDialect) GetLockSyntax(mode, wait string) string {
+	return strings.TrimSpace("FOR " + mode + " " + wait)
+}
+
+func (d *PostgreSQLDialect) SupportsTupleIn() bool {
+	return true
+}
+
+func (d *PostgreSQLDialect) EscapeLikePattern(value string) string {
+	return likePatternReplacer.Replace(value)
+}
+
+func (d *PostgreSQLDialect) GetLikeQuery(field string, insensitive, not bool) string {
+	operator := "LIKE"
+	if insensitive {
+		operator = "ILIKE"
+	}
+	if not {
+		operator = "NOT " + operator
+	}
+	return fmt.Sprintf(`%s %s ? ESCAPE '\'`, d.QuoteIdentifier(field), operator)
+}
+
+func (d *PostgreSQLDialect) GetUpsertClause(conflictColumns []string, conflictWhere string, assignments []string) string {
+	quoted := make([]string, len(conflictColumns))
+	for i, col := range conflictColumns {
+		quoted[i] = d.QuoteIdentifier(col)
+	}
+	if len(assignments) == 0 && len(quoted) > 0 {
+		// DO UPDATE em vez de DO NOTHING para que RETURNING sempre traga a linha
+		assignments = []string{fmt.Sprintf("%s = EXCLUDED.%s", quoted[0], quoted[0])}
+	}
+	target := "(" + strings.Join(quoted, ", ") + ")"
+	if conflictWhere != "" {
+		target += " WHERE " + conflictWhere
+	}
+	return fmt.Sprintf("ON CONFLICT %s DO UPDATE SET %s", target, strings.Join(assignments, ", "))
+}
+
 func (d *PostgreSQLDialect) GetDriverName() string {
 	return "pgx"
 }
@@ -121,13 +187,65 @@ func (d *PostgreSQLDialect) GetJSONContainsQuery(field string, value string) str
 	return fmt.Sprintf("%s @> %s::jsonb", d.QuoteIdentifier(field), d.QuoteString(value))
 }
 
-func (d *PostgreSQLDialect) GetLimitOffsetSyntax(limit, offset int) string {
+func (d *PostgreSQLDialect) GetJSONArrayLengthExpression(field string) string {
+	// jsonb_array_length falha em escalares e objetos, então só é avaliado para arrays
+	quoted := d.QuoteIdentifier(field)
+	return fmt.Sprintf("(CASE WHEN jsonb_typeof(%s) = 'array' THEN jsonb_array_length(%s) END)", quoted, quoted)
+}
+
+func (d *PostgreSQLDialect) GetJSONArrayElementExpression(field string, index int) string {
+	return fmt.Sprintf("(%s->>%d)", d.QuoteIdentifier(field), index)
+}
+
+func (d *PostgreSQLDialect) GetJSONPathQuery(field string, path []string, op string) string {
+	comparison := op + " ?"
+	if op == "LIKE" {
+		// O mesmo escape de GetLikeQuery, para padrões escapados com EscapeLikePattern
+		comparison += ` ESCAPE '\'`
+	}
+	quoted := d.QuoteIdentifier(field)
+	if len(path) == 1 && !isJSONArrayIndex(path[0]) {
+		return fmt.Sprintf("(%s->>%s) %s", quoted, d.QuoteString(path[0]), comparison)
+	}
+	// #>> recebe um text[]; cada chave vai entre aspas para aceitar vírgulas e chaves
+	elements := make([]string, len(path))
+	for i, key := range path {
+		elements[i] = `"` + jsonPathKeyReplacer.Replace(key) + `"`
+	}
+	return fmt.Sprintf("(%s #>> %s) %s", quoted, d.QuoteString("{"+strings.Join(elements, ",")+"}"), comparison)
+}
+
+func (d *PostgreSQLDialect) GetScalarListQuery(field, op string, n int) string {
+	quoted := d.QuoteIdentifier(field)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+	switch op {
+	case "HAS":
+		// field @> ARRAY[?] equivale a ? = ANY(field), mas pode usar um índice GIN
+		return fmt.Sprintf("%s @> ARRAY[?]", quoted)
+	case "HAS_EVERY":
+		return fmt.Sprintf("%s @> ARRAY[%s]", quoted, placeholders)
+	case "HAS_SOME":
+		return fmt.Sprintf("%s && ARRAY[%s]", quoted, placeholders)
+	case "IS_EMPTY":
+		// array_length de um array vazio é NULL
+		return fmt.Sprintf("COALESCE(array_length(%s, 1), 0) = 0", quoted)
+	case "ANY":
+		// Para listas que não são de texto: o parâmetro assume o tipo do elemento do campo
+		return fmt.Sprintf("? = ANY(%s)", quoted)
+	case "EQUALS":
+		// A lista inteira vai num único parâmetro, comparado como array
+		return fmt.Sprintf("%s = ?", quoted)
+	}
+	return ""
+}
+
+func (d *PostgreSQLDialect) GetLimitOffsetSyntax(limit, offset int) (string, []interface{}) {
 	if limit > 0 && offset > 0 {
-		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+		return "LIMIT ? OFFSET ?", []interface{}{limit, offset}
 	} else if limit > 0 {
-		return fmt.Sprintf("LIMIT %d", limit)
+		return "LIMIT ?", []interface{}{limit}
 	} else if offset > 0 {
-		return fmt.Sprintf("OFFSET %d", offset)
+		return "OFFSET ?", []interface{}{offset}
 	}
-	return ""
+	return "", nil
 }
@@ -13,7 +13,7 @@ func (d *SQLiteDialect) Name() string {
 }
 
 func (d *SQLiteDialect) QuoteIdentifier(name string) string {
-	return fmt.Sprintf(`"%s"`, name)
+	return quoteQualified(name, `"`, `"`)
 }
 
 func (d *SQLiteDialect) QuoteString(value string) string {
@@ -104,6 +104,10 @@ func (d *SQLiteDialect) MapDefaultValue(value string) string {
 		return "(lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' || lower(hex(randomblob(6))))"
 	case strings.HasPrefix(value, "cuid()") || strings.HasPrefix(value, "cuid"):
 		return "(lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' || lower(hex(randomblob(6))))"
+	case value == "true":
+		return "1" // SQLite não tem tipo booleano
+	case value == "false":
+		return "0"
 	default:
 		return value
 	}
@@ -121,6 +125,22 @@ func (d *SQLiteDialect) GetNowFunction() string {
 	return "datetime('now')"
 }
 
+func (d *SQLiteDialect) GetUpsertClause(conflictColumns []string, conflictWhere string, assignments []string) string {
+	quoted := make([]string, len(conflictColumns))
+	for i, col := range conflictColumns {
+		quoted[i] = d.QuoteIdentifier(col)
+	}
+	if len(assignments) == 0 && len(quoted) > 0 {
+		// DO UPDATE em vez de DO NOTHING para que RETURNING sempre traga a linha
+		assignments = []string{fmt.Sprintf("%s = EXCLUDED.%s", quoted[0], quoted[0])}
+	}
+	target := "(" + strings.Join(quoted, ", ") + ")"
+	if conflictWhere != "" {
+		target += " WHERE " + conflictWhere
+	}
+	return fmt.Sprintf("ON CONFLICT %s DO UPDATE SET %s", target, strings.Join(assignments, ", "))
+}
+
 func (d *SQLiteDialect) GetDriverName() string {
 	return "sqlite3"
 }
@@ -143,18 +163,75 @@ func (d *SQLiteDialect) GetJSONContainsQuery(field string, value string) string
 	return fmt.Sprintf("json_extract(%s, '$') = %s", d.QuoteIdentifier(field), d.QuoteString(value))
 }
 
-func (d *SQLiteDialect) GetLimitOffsetSyntax(limit, offset int) string {
+func (d *SQLiteDialect) GetJSONArrayLengthExpression(field string) string {
+	return fmt.Sprintf("json_array_length(%s)", d.QuoteIdentifier(field))
+}
+
+func (d *SQLiteDialect) GetJSONArrayElementExpression(field string, index int) string {
+	// json_extract devolve números como INTEGER/REAL; o CAST mantém a comparação textual como nos outros bancos
+	return fmt.Sprintf("CAST(json_extract(%s, '$[%d]') AS TEXT)", d.QuoteIdentifier(field), index)
+}
+
+func (d *SQLiteDialect) GetJSONPathQuery(field string, path []string, op string) string {
+	query := fmt.Sprintf("CAST(json_extract(%s, %s) AS TEXT) %s ?", d.QuoteIdentifier(field), d.QuoteString(jsonPathLiteral(path)), op)
+	if op == "LIKE" {
+		// O mesmo escape de GetLikeQuery, para padrões escapados com EscapeLikePattern
+		query += ` ESCAPE '\'`
+	}
+	return query
+}
+
+func (d *SQLiteDialect) GetScalarListQuery(field, op string, n int) string {
+	return ""
+}
+
+func (d *SQLiteDialect) GetLimitOffsetSyntax(limit, offset int) (string, []interface{}) {
 	if limit > 0 && offset > 0 {
-		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+		return "LIMIT ? OFFSET ?", []interface{}{limit, offset}
 	} else if limit > 0 {
-		return fmt.Sprintf("LIMIT %d", limit)
+		return "LIMIT ?", []interface{}{limit}
 	} else if offset > 0 {
 		// SQLite requires LIMIT when using OFFSET, use a large number
-		return fmt.Sprintf("LIMIT -1 OFFSET %d", offset)
+		return "LIMIT -1 OFFSET ?", []interface{}{offset}
 	}
-	return ""
+	return "", nil
 }
 
 func (d *SQLiteDialect) SupportsReturning() bool {
 	return false
 }
+
+func (d *SQLiteDialect) GetIndexHintSyntax(hint string) (string, string) {
+	return "", fmt.Sprintf("INDEXED BY %s", d.QuoteIdentifier(hint))
+}
+
+func (d *SQLiteDialect) GetCollateSyntax(collation string) string {
+	// Nativas: BINARY, NOCASE e RTRIM; outras precisam ser registradas na conexão
+	if !isCollationName(collation, "") {
+		return ""
+	}
+	return "COLLATE " + collation
+}
+
+func (d *SQLiteDialect) GetDistinctOnSyntax(fields []string) (string, bool) {
+	// SQLite aceita colunas fora do GROUP BY e retorna uma linha por grupo
+	return "", true
+}
+
+func (d *SQLiteDialect) GetLockSyntax(mode, wait string) string {
+	// O SQLite bloqueia o banco inteiro na transação (BEGIN IMMEDIATE), não linhas
+	return ""
+}
+
+func (d *SQLiteDialect) SupportsTupleIn() bool {
+	// Row values são suportados desde o SQLite 3.15
+	return true
+}
+
+func (d *SQLiteDialect) EscapeLikePattern(value string) string {
+	return likePatternReplacer.Replace(value)
+}
+
+func (d *SQLiteDialect) GetLikeQuery(field string, insensitive, not bool) string {
+	return lowerLikeQuery(d.QuoteIdentifier(field), insensitive, not) + ` ESCAPE '\'`
+}
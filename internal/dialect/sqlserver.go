@@ -0,0 +1,179 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SQLServerDialect implements the SQL Server (and Azure SQL) dialect
+type SQLServerDialect struct{}
+
+// sqlServerLikeReplacer escapa também o [, que abre uma classe de caracteres no LIKE do SQL Server
+var sqlServerLikeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`, `[`, `\[`)
+
+func (d *SQLServerDialect) Name() string {
+	return "sqlserver"
+}
+
+func (d *SQLServerDialect) QuoteIdentifier(name string) string {
+	return quoteQualified(name, "[", "]")
+}
+
+func (d *SQLServerDialect) QuoteString(value string) string {
+	// No SQL Server a barra não é escape, só as aspas simples são duplicadas
+	return fmt.Sprintf("N'%s'", strings.ReplaceAll(value, "'", "''"))
+}
+
+func (d *SQLServerDialect) MapType(prismaType string, isNullable bool) string {
+	switch strings.ToLower(prismaType) {
+	case "string":
+		return "NVARCHAR(1000)"
+	case "int":
+		return "INT"
+	case "bigint":
+		return "BIGINT"
+	case "boolean", "bool":
+		return "BIT"
+	case "datetime":
+		return "DATETIME2"
+	case "float":
+		return "FLOAT"
+	case "decimal":
+		return "DECIMAL(32, 16)"
+	case "json":
+		return "NVARCHAR(MAX)" // O SQL Server guarda JSON como texto
+	case "bytes":
+		return "VARBINARY(MAX)"
+	case "uuid":
+		return "UNIQUEIDENTIFIER"
+	default:
+		return prismaType
+	}
+}
+
+func (d *SQLServerDialect) MapDefaultValue(value string) string {
+	value = strings.ToLower(value)
+	switch {
+	case value == "autoincrement()" || value == "autoincrement":
+		return "" // Será tratado como IDENTITY
+	case value == "now()" || value == "now":
+		return "CURRENT_TIMESTAMP"
+	case strings.HasPrefix(value, "uuid()") || strings.HasPrefix(value, "uuid"):
+		return "NEWID()"
+	case strings.HasPrefix(value, "cuid()") || strings.HasPrefix(value, "cuid"):
+		return "NEWID()" // Fallback para UUID
+	case value == "true":
+		return "1" // BOOLEAN é BIT
+	case value == "false":
+		return "0"
+	default:
+		return value
+	}
+}
+
+func (d *SQLServerDialect) GetPlaceholder(index int) string {
+	return fmt.Sprintf("@p%d", index)
+}
+
+func (d *SQLServerDialect) GetAutoIncrementKeyword() string {
+	return "IDENTITY(1,1)"
+}
+
+func (d *SQLServerDialect) GetNowFunction() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+func (d *SQLServerDialect) GetUpsertClause(conflictColumns []string, conflictWhere string, assignments []string) string {
+	// O SQL Server só faz upsert com MERGE, que não é um sufixo de INSERT
+	return ""
+}
+
+func (d *SQLServerDialect) GetDriverName() string {
+	return "sqlserver"
+}
+
+func (d *SQLServerDialect) SupportsFullTextSearch() bool {
+	return false // CONTAINS exige um catálogo full-text criado na tabela
+}
+
+func (d *SQLServerDialect) GetFullTextSearchQuery(field string, query string) string {
+	return fmt.Sprintf("CONTAINS(%s, %s)", d.QuoteIdentifier(field), d.QuoteString(query))
+}
+
+func (d *SQLServerDialect) SupportsJSON() bool {
+	return true // Funções JSON desde o SQL Server 2016
+}
+
+func (d *SQLServerDialect) GetJSONContainsQuery(field string, value string) string {
+	// Contém todos os elementos de value quando nenhum deles falta no array do campo
+	return fmt.Sprintf("NOT EXISTS (SELECT [value] FROM OPENJSON(%s) EXCEPT SELECT [value] FROM OPENJSON(%s))", d.QuoteString(value), d.QuoteIdentifier(field))
+}
+
+func (d *SQLServerDialect) GetJSONArrayLengthExpression(field string) string {
+	return fmt.Sprintf("(SELECT COUNT(*) FROM OPENJSON(%s))", d.QuoteIdentifier(field))
+}
+
+func (d *SQLServerDialect) GetJSONArrayElementExpression(field string, index int) string {
+	return fmt.Sprintf("JSON_VALUE(%s, '$[%d]')", d.QuoteIdentifier(field), index)
+}
+
+func (d *SQLServerDialect) GetJSONPathQuery(field string, path []string, op string) string {
+	query := fmt.Sprintf("JSON_VALUE(%s, %s) %s ?", d.QuoteIdentifier(field), d.QuoteString(jsonPathLiteral(path)), op)
+	if op == "LIKE" {
+		// O mesmo escape de GetLikeQuery, para padrões escapados com EscapeLikePattern
+		query += ` ESCAPE '\'`
+	}
+	return query
+}
+
+func (d *SQLServerDialect) GetScalarListQuery(field, op string, n int) string {
+	return ""
+}
+
+func (d *SQLServerDialect) GetLimitOffsetSyntax(limit, offset int) (string, []interface{}) {
+	// OFFSET/FETCH exige ORDER BY; o builder adiciona ORDER BY (SELECT NULL) quando não há ordenação
+	if limit > 0 {
+		return "OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", []interface{}{offset, limit}
+	} else if offset > 0 {
+		return "OFFSET ? ROWS", []interface{}{offset}
+	}
+	return "", nil
+}
+
+func (d *SQLServerDialect) SupportsReturning() bool {
+	// O SQL Server usa OUTPUT, que fica antes de VALUES/WHERE
+	return false
+}
+
+func (d *SQLServerDialect) GetIndexHintSyntax(hint string) (string, string) {
+	return "", fmt.Sprintf("WITH (INDEX(%s))", d.QuoteIdentifier(hint))
+}
+
+func (d *SQLServerDialect) GetCollateSyntax(collation string) string {
+	if !isCollationName(collation, "") {
+		return ""
+	}
+	return "COLLATE " + collation
+}
+
+func (d *SQLServerDialect) GetDistinctOnSyntax(fields []string) (string, bool) {
+	// O SQL Server não tem DISTINCT ON e rejeita colunas fora do GROUP BY
+	return "", false
+}
+
+func (d *SQLServerDialect) GetLockSyntax(mode, wait string) string {
+	// O SQL Server bloqueia linhas com table hints (WITH (UPDLOCK)), não com uma cláusula no fim
+	return ""
+}
+
+func (d *SQLServerDialect) SupportsTupleIn() bool {
+	return false
+}
+
+func (d *SQLServerDialect) EscapeLikePattern(value string) string {
+	return sqlServerLikeReplacer.Replace(value)
+}
+
+func (d *SQLServerDialect) GetLikeQuery(field string, insensitive, not bool) string {
+	return lowerLikeQuery(d.QuoteIdentifier(field), insensitive, not) + ` ESCAPE '\'`
+}
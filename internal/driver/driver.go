@@ -19,6 +19,9 @@ type DB interface {
 	// Begin starts a transaction
 	Begin(ctx context.Context) (Tx, error)
 
+	// Ping verifies that the database is reachable
+	Ping(ctx context.Context) error
+
 	// SQLDB returns the underlying *sql.DB for migrations and introspection
 	// Returns nil if not available (e.g., for pgx pool)
 	SQLDB() *sql.DB
@@ -27,6 +30,14 @@ type DB interface {
 	Close()
 }
 
+// ConnAcquirer is implemented by pooled drivers that can reserve a dedicated connection
+// Every statement on the returned DB runs on that single connection, so session settings
+// (e.g. SET search_path) don't leak to other pool users. Close releases it back to the pool.
+type ConnAcquirer interface {
+	// Acquire reserves a dedicated connection from the pool
+	Acquire(ctx context.Context) (DB, error)
+}
+
 // Result represents the result of an Exec operation
 type Result interface {
 	// RowsAffected returns the number of rows affected
@@ -39,6 +50,22 @@ type Result interface {
 	LastInsertId() (int64, error)
 }
 
+// CommandTagResult is implemented by results that carry the driver's raw command tag
+// (e.g. PostgreSQL "INSERT 0 3"); check for it with a type assertion on Result
+type CommandTagResult interface {
+	Result
+	// CommandTag returns the raw command tag reported by the database
+	CommandTag() string
+}
+
+// ColumnsRows is implemented by rows that report the names of their result columns,
+// in result order; check for it with a type assertion on Rows
+type ColumnsRows interface {
+	Rows
+	// Columns returns the column names of the result set
+	Columns() ([]string, error)
+}
+
 // Rows represents a set of query results
 type Rows interface {
 	// Close closes the rows iterator
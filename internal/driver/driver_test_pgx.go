@@ -71,6 +71,11 @@ func TestSQLDBAdapter_PostgreSQL(t *testing.T) {
 
 	ctx := context.Background()
 
+	// Test Ping
+	if err := db.Ping(ctx); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+
 	// Test Exec
 	result, err := db.Exec(ctx, "CREATE TABLE IF NOT EXISTS test_table (id SERIAL PRIMARY KEY, name VARCHAR(255))")
 	if err != nil {
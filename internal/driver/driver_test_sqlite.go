@@ -54,6 +54,11 @@ func TestSQLDBAdapter_SQLite(t *testing.T) {
 
 	ctx := context.Background()
 
+	// Test Ping
+	if err := db.Ping(ctx); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+
 	// Test Exec
 	result, err := db.Exec(ctx, "CREATE TABLE IF NOT EXISTS test_table (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)")
 	if err != nil {
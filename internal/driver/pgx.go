@@ -56,6 +56,11 @@ func (a *PgxPoolAdapter) Begin(ctx context.Context) (Tx, error) {
 	return &PgxTx{tx: tx}, nil
 }
 
+// Ping verifies that the database is reachable, acquiring a connection from the pool
+func (a *PgxPoolAdapter) Ping(ctx context.Context) error {
+	return a.pool.Ping(ctx)
+}
+
 // SQLDB returns nil as pgxpool.Pool doesn't provide *sql.DB directly
 // For migrations, users should use database/sql with pgx stdlib driver
 func (a *PgxPoolAdapter) SQLDB() *sql.DB {
@@ -67,6 +72,68 @@ func (a *PgxPoolAdapter) Close() {
 	a.pool.Close()
 }
 
+// Acquire reserves a dedicated connection from the pool
+func (a *PgxPoolAdapter) Acquire(ctx context.Context) (DB, error) {
+	conn, err := a.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PgxConnAdapter{conn: conn}, nil
+}
+
+// PgxConnAdapter adapts a dedicated *pgxpool.Conn to the driver.DB interface
+type PgxConnAdapter struct {
+	conn *pgxpool.Conn
+}
+
+// Exec executes a query that doesn't return rows
+func (a *PgxConnAdapter) Exec(ctx context.Context, query string, args ...interface{}) (Result, error) {
+	result, err := a.conn.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &PgxResult{result: result}, nil
+}
+
+// Query executes a query that returns multiple rows
+func (a *PgxConnAdapter) Query(ctx context.Context, query string, args ...interface{}) (Rows, error) {
+	rows, err := a.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &PgxRows{rows: rows}, nil
+}
+
+// QueryRow executes a query that returns a single row
+func (a *PgxConnAdapter) QueryRow(ctx context.Context, query string, args ...interface{}) Row {
+	row := a.conn.QueryRow(ctx, query, args...)
+	return &PgxRow{row: row}
+}
+
+// Begin starts a transaction on the dedicated connection
+func (a *PgxConnAdapter) Begin(ctx context.Context) (Tx, error) {
+	tx, err := a.conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PgxTx{tx: tx}, nil
+}
+
+// Ping verifies that the dedicated connection is alive
+func (a *PgxConnAdapter) Ping(ctx context.Context) error {
+	return a.conn.Ping(ctx)
+}
+
+// SQLDB returns nil as pgxpool.Conn doesn't provide *sql.DB
+func (a *PgxConnAdapter) SQLDB() *sql.DB {
+	return nil
+}
+
+// Close releases the connection back to the pool
+func (a *PgxConnAdapter) Close() {
+	a.conn.Release()
+}
+
 // PgxResult wraps pgconn.CommandTag
 type PgxResult struct {
 	result pgconn.CommandTag
@@ -77,6 +144,11 @@ func (r *PgxResult) RowsAffected() int64 {
 	return r.result.RowsAffected()
 }
 
+// CommandTag returns the raw PostgreSQL command tag (e.g. "INSERT 0 3")
+func (r *PgxResult) CommandTag() string {
+	return r.result.String()
+}
+
 // LastInsertId returns the integer generated by the database
 // PostgreSQL não suporta LastInsertId diretamente, retorna erro
 func (r *PgxResult) LastInsertId() (int64, error) {
@@ -109,6 +181,16 @@ func (r *PgxRows) Scan(dest ...interface{}) error {
 	return r.rows.Scan(dest...)
 }
 
+// Columns returns the column names of the result set
+func (r *PgxRows) Columns() ([]string, error) {
+	fields := r.rows.FieldDescriptions()
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.Name
+	}
+	return columns, nil
+}
+
 // PgxRow wraps pgx.Row
 type PgxRow struct {
 	row pgx.Row
@@ -49,6 +49,11 @@ func (a *SQLDBAdapter) Begin(ctx context.Context) (Tx, error) {
 	return &SQLTx{tx: tx}, nil
 }
 
+// Ping verifies that the database is reachable, opening a connection if needed
+func (a *SQLDBAdapter) Ping(ctx context.Context) error {
+	return a.db.PingContext(ctx)
+}
+
 // SQLDB returns the underlying *sql.DB
 func (a *SQLDBAdapter) SQLDB() *sql.DB {
 	return a.db
@@ -60,6 +65,69 @@ func (a *SQLDBAdapter) Close() {
 	_ = a.db.Close()
 }
 
+// Acquire reserves a dedicated connection from the pool
+func (a *SQLDBAdapter) Acquire(ctx context.Context) (DB, error) {
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLConnAdapter{conn: conn}, nil
+}
+
+// SQLConnAdapter adapts a dedicated *sql.Conn to the driver.DB interface
+type SQLConnAdapter struct {
+	conn *sql.Conn
+}
+
+// Exec executes a query that doesn't return rows
+func (a *SQLConnAdapter) Exec(ctx context.Context, query string, args ...interface{}) (Result, error) {
+	result, err := a.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLResult{result: result}, nil
+}
+
+// Query executes a query that returns multiple rows
+func (a *SQLConnAdapter) Query(ctx context.Context, query string, args ...interface{}) (Rows, error) {
+	rows, err := a.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLRows{rows: rows}, nil
+}
+
+// QueryRow executes a query that returns a single row
+func (a *SQLConnAdapter) QueryRow(ctx context.Context, query string, args ...interface{}) Row {
+	row := a.conn.QueryRowContext(ctx, query, args...)
+	return &SQLRow{row: row}
+}
+
+// Begin starts a transaction on the dedicated connection
+func (a *SQLConnAdapter) Begin(ctx context.Context) (Tx, error) {
+	tx, err := a.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLTx{tx: tx}, nil
+}
+
+// Ping verifies that the dedicated connection is alive
+func (a *SQLConnAdapter) Ping(ctx context.Context) error {
+	return a.conn.PingContext(ctx)
+}
+
+// SQLDB returns nil as a dedicated connection doesn't expose its *sql.DB
+func (a *SQLConnAdapter) SQLDB() *sql.DB {
+	return nil
+}
+
+// Close returns the connection to the pool
+// Note: sql.Conn.Close() error is ignored to match interface signature
+func (a *SQLConnAdapter) Close() {
+	_ = a.conn.Close()
+}
+
 // SQLResult wraps sql.Result
 type SQLResult struct {
 	result sql.Result
@@ -101,6 +169,11 @@ func (r *SQLRows) Scan(dest ...interface{}) error {
 	return r.rows.Scan(dest...)
 }
 
+// Columns returns the column names of the result set
+func (r *SQLRows) Columns() ([]string, error) {
+	return r.rows.Columns()
+}
+
 // SQLRow wraps sql.Row
 type SQLRow struct {
 	row *sql.Row
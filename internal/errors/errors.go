@@ -29,6 +29,15 @@ var (
 
 	// ErrNoFieldsToUpdate é retornado quando não há campos para atualizar
 	ErrNoFieldsToUpdate = errors.New("no fields to update")
+
+	// ErrUniqueConstraint é retornado quando um INSERT viola uma constraint única (ex: primary key já existente)
+	ErrUniqueConstraint = errors.New("unique constraint violation")
+
+	// ErrDuplicateKey é um alias de ErrUniqueConstraint
+	ErrDuplicateKey = ErrUniqueConstraint
+
+	// ErrReadOnly é retornado quando uma escrita é feita por um client ou query somente leitura
+	ErrReadOnly = errors.New("write on a read-only client")
 )
 
 // SanitizeError sanitiza uma mensagem de erro para não expor informações internas
@@ -42,6 +51,11 @@ func SanitizeError(err error) error {
 		return err
 	}
 
+	// O sentinel de somente leitura não expõe detalhes e precisa continuar detectável com errors.Is
+	if errors.Is(err, ErrReadOnly) {
+		return ErrReadOnly
+	}
+
 	errMsg := err.Error()
 
 	// Remover nomes de tabelas e colunas
@@ -138,3 +152,21 @@ func IsNotFound(err error) bool {
 func IsValidation(err error) bool {
 	return errors.Is(err, ErrValidation)
 }
+
+// IsUniqueViolation verifica se o erro é uma violação de constraint única.
+// Reconhece ErrUniqueConstraint e as mensagens nativas de PostgreSQL (23505),
+// MySQL (1062) e SQLite (UNIQUE constraint failed).
+func IsUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrUniqueConstraint) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "SQLSTATE 23505") ||
+		strings.Contains(msg, "duplicate key value violates unique constraint") ||
+		strings.Contains(msg, "Error 1062") ||
+		strings.Contains(msg, "Duplicate entry") ||
+		strings.Contains(msg, "UNIQUE constraint failed")
+}
@@ -319,36 +319,9 @@ func formatIndexList(listVal []interface{}) string {
 				parts = append(parts, str)
 			}
 		} else if m, ok := item.(map[string]interface{}); ok {
-			// Handle function calls like created_at(sort: Desc)
-			if function, isFunction := m["function"].(string); isFunction {
-				args, _ := m["args"].([]interface{})
-				if len(args) == 0 {
-					parts = append(parts, fmt.Sprintf("%s()", function))
-				} else {
-					argStrs := make([]string, 0, len(args))
-					for _, arg := range args {
-						if argMap, ok := arg.(map[string]interface{}); ok {
-							if argName, hasArgName := argMap["name"].(string); hasArgName {
-								argValueRaw := argMap["value"]
-								var argValue string
-								if strVal, ok := argValueRaw.(string); ok {
-									if strings.HasPrefix(strVal, "\"") && strings.HasSuffix(strVal, "\"") && len(strVal) > 2 {
-										strVal = strVal[1 : len(strVal)-1]
-									}
-									if isValidIdentifier(strVal) {
-										argValue = strVal
-									} else {
-										argValue = fmt.Sprintf("%q", strVal)
-									}
-								} else {
-									argValue = fmt.Sprintf("%v", argValueRaw)
-								}
-								argStrs = append(argStrs, fmt.Sprintf("%s: %s", argName, argValue))
-							}
-						}
-					}
-					parts = append(parts, fmt.Sprintf("%s(%s)", function, strings.Join(argStrs, ", ")))
-				}
+			// Handle function calls like created_at(sort: Desc) or lower(email)
+			if _, isFunction := m["function"].(string); isFunction {
+				parts = append(parts, formatIndexValue(m))
 			} else if name, hasName := m["name"].(string); hasName {
 				// Handle {name: "col", sort: "Desc"}
 				if sort, hasSort := m["sort"].(string); hasSort {
@@ -366,6 +339,42 @@ func formatIndexList(listVal []interface{}) string {
 	return fmt.Sprintf("[%s]", strings.Join(parts, ", "))
 }
 
+// formatIndexValue formats an entry of an index list or one of its arguments: field
+// modifiers (sort: Desc, length: 10), raw("...") and the arguments of expressions
+func formatIndexValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "\"") && strings.HasSuffix(v, "\"") && len(v) > 2 {
+			v = v[1 : len(v)-1]
+		}
+		if _, err := strconv.Atoi(v); err == nil || isValidIdentifier(v) {
+			return v
+		}
+		return fmt.Sprintf("%q", v)
+	case map[string]interface{}:
+		if function, ok := v["function"].(string); ok {
+			args, _ := v["args"].([]interface{})
+			argStrs := make([]string, 0, len(args))
+			for _, arg := range args {
+				if argMap, ok := arg.(map[string]interface{}); ok {
+					if argName, hasArgName := argMap["name"].(string); hasArgName {
+						argStrs = append(argStrs, fmt.Sprintf("%s: %s", argName, formatIndexValue(argMap["value"])))
+						continue
+					}
+				}
+				// raw("...") always takes a string
+				if str, ok := arg.(string); ok && function == "raw" {
+					argStrs = append(argStrs, fmt.Sprintf("%q", strings.Trim(str, "\"")))
+					continue
+				}
+				argStrs = append(argStrs, formatIndexValue(arg))
+			}
+			return fmt.Sprintf("%s(%s)", function, strings.Join(argStrs, ", "))
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
 func formatAttributeArgument(arg *parser.AttributeArgument, attrName string) string {
 	return formatAttributeArgumentWithType(arg, attrName, nil)
 }
@@ -406,6 +415,13 @@ func formatAttributeArgumentWithTypeAndSchema(arg *parser.AttributeArgument, att
 		}
 	}
 
+	// The index type (type: Gin) is an identifier, not a string
+	if arg.Name == "type" && (attrName == "index" || attrName == "unique") {
+		if str, ok := arg.Value.(string); ok && isValidIdentifier(str) {
+			return str
+		}
+	}
+
 	return val
 }
 
@@ -716,9 +732,27 @@ func formatValueWithDepth(v interface{}, depth int, visited map[interface{}]bool
 	}
 }
 
+// formatFieldValue formats the value of a datasource or generator field: strings,
+// lists such as schemas = ["auth", "public"] and calls such as env("DATABASE_URL")
 func formatFieldValue(value interface{}) string {
-	if str, ok := value.(string); ok {
-		return fmt.Sprintf("%q", str)
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatFieldValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		if function, ok := v["function"].(string); ok {
+			args, _ := v["args"].([]interface{})
+			argStrs := make([]string, len(args))
+			for i, arg := range args {
+				argStrs[i] = formatFieldValue(arg)
+			}
+			return fmt.Sprintf("%s(%s)", function, strings.Join(argStrs, ", "))
+		}
 	}
 	return fmt.Sprintf("%v", value)
 }
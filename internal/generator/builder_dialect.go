@@ -10,6 +10,8 @@ func generateBuilderDialect(builderDir string) error {
 		"postgresql_dialect.tmpl",
 		"mysql_dialect.tmpl",
 		"sqlite_dialect.tmpl",
+		"sqlserver_dialect.tmpl",
+		"cockroachdb_dialect.tmpl",
 	}
 
 	data := FluentTemplateData{}
@@ -19,9 +19,21 @@ func generateBuilderFluent(builderDir string, provider string, utilsPath string)
 		"query_execution.tmpl",
 		"query_build_helpers.tmpl",
 		"query_scan.tmpl",
+		"aggregate.tmpl",
+		"returning.tmpl",
 		"fulltext.tmpl",
 		"logging.tmpl",
 		"transaction.tmpl",
+		"search_path.tmpl",
+		"json_serializer.tmpl",
+		"routing.tmpl",
+		"mysql_upsert.tmpl",
+		"read_only.tmpl",
+		"subquery.tmpl",
+		"query_recorder.tmpl",
+		"hooks.tmpl",
+		"dry_run.tmpl",
+		"raw_query.tmpl",
 	}
 
 	// Extract package name from utilsPath (last segment)
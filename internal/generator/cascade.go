@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+// cascadeRelation is a table that references a model through @relation(fields, references)
+type cascadeRelation struct {
+	Table             string
+	Columns           []string
+	ReferencedColumns []string
+	OnDelete          string
+	Children          []cascadeRelation
+}
+
+// getCascadeRelations returns the tables that depend on model, recursively.
+// Relations that cascade on delete (onDelete: Cascade, which is also the default
+// used by migrations) are followed; SetNull, Restrict and NoAction relations are
+// returned with their action and no children, and SetDefault ones are left out.
+// Self and cyclic relations are not supported: visited holds the models on the
+// current path so they are skipped.
+func getCascadeRelations(model *parser.Model, schema *parser.Schema, visited map[string]bool) []cascadeRelation {
+	visited[model.Name] = true
+	defer delete(visited, model.Name)
+
+	var relations []cascadeRelation
+	for _, child := range schema.Models {
+		if visited[child.Name] {
+			continue
+		}
+		for _, field := range child.Fields {
+			if field.Type == nil || field.Type.IsArray || field.Type.Name != model.Name {
+				continue
+			}
+			fields, references, onDelete := getRelationArguments(field)
+			switch onDelete {
+			case "", "Cascade", "SetNull", "Restrict", "NoAction":
+			default:
+				continue
+			}
+			if len(fields) == 0 || len(fields) != len(references) {
+				continue
+			}
+
+			rel := cascadeRelation{Table: getTableName(child)}
+			for _, f := range fields {
+				rel.Columns = append(rel.Columns, getFieldColumnName(child, f))
+			}
+			for _, r := range references {
+				rel.ReferencedColumns = append(rel.ReferencedColumns, getFieldColumnName(model, r))
+			}
+			if onDelete == "" || onDelete == "Cascade" {
+				rel.Children = getCascadeRelations(child, schema, visited)
+			} else {
+				rel.OnDelete = onDelete
+			}
+			relations = append(relations, rel)
+		}
+	}
+	return relations
+}
+
+// getRelationArguments extracts fields, references and onDelete from a field's @relation attribute
+func getRelationArguments(field *parser.ModelField) ([]string, []string, string) {
+	var fields, references []string
+	var onDelete string
+	for _, attr := range field.Attributes {
+		if attr.Name != "relation" {
+			continue
+		}
+		for _, arg := range attr.Arguments {
+			if arg.Name == "onDelete" {
+				if val, ok := arg.Value.(string); ok {
+					onDelete = strings.Trim(val, `"`)
+				}
+				continue
+			}
+			list, ok := arg.Value.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, item := range list {
+				name, ok := item.(string)
+				if !ok {
+					continue
+				}
+				name = strings.Trim(name, `"`)
+				switch arg.Name {
+				case "fields":
+					fields = append(fields, name)
+				case "references":
+					references = append(references, name)
+				}
+			}
+		}
+	}
+	return fields, references, onDelete
+}
+
+// getFieldColumnName returns the database column of a model field (respecting @map)
+func getFieldColumnName(model *parser.Model, fieldName string) string {
+	for _, field := range model.Fields {
+		if field.Name != fieldName {
+			continue
+		}
+		for _, attr := range field.Attributes {
+			if attr.Name == "map" && len(attr.Arguments) > 0 {
+				if val, ok := attr.Arguments[0].Value.(string); ok {
+					return val
+				}
+			}
+		}
+	}
+	return fieldName
+}
+
+// formatCascadeRelations renders relations as a []builder.CascadeRelation literal
+func formatCascadeRelations(relations []cascadeRelation) string {
+	var sb strings.Builder
+	sb.WriteString("[]builder.CascadeRelation{")
+	for i, rel := range relations {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "{Table: %q, Columns: %#v, ReferencedColumns: %#v", rel.Table, rel.Columns, rel.ReferencedColumns)
+		if rel.OnDelete != "" {
+			fmt.Fprintf(&sb, ", OnDelete: %q", rel.OnDelete)
+		}
+		if len(rel.Children) > 0 {
+			sb.WriteString(", Children: ")
+			sb.WriteString(formatCascadeRelations(rel.Children))
+		}
+		sb.WriteString("}")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
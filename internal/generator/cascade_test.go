@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+func TestGetCascadeRelations(t *testing.T) {
+	schema, errs, err := parser.Parse(`
+model User {
+  id       Int       @id
+  posts    Post[]
+  sessions Session[]
+  @@map("users")
+}
+
+model Post {
+  id       Int       @id
+  authorId Int       @map("author_id")
+  author   User      @relation(fields: [authorId], references: [id], onDelete: Cascade)
+  comments Comment[]
+  @@map("posts")
+}
+
+model Comment {
+  id     Int  @id
+  postId Int
+  post   Post @relation(fields: [postId], references: [id])
+}
+
+model Session {
+  id     Int   @id
+  userId Int?
+  user   User? @relation(fields: [userId], references: [id], onDelete: SetNull)
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+
+	var user *parser.Model
+	for _, m := range schema.Models {
+		if m.Name == "User" {
+			user = m
+		}
+	}
+
+	relations := getCascadeRelations(user, schema, map[string]bool{})
+	if len(relations) != 2 {
+		t.Fatalf("expected 2 relations, got %d", len(relations))
+	}
+	if sessions := relations[1]; sessions.Table != "Session" || sessions.OnDelete != "SetNull" || len(sessions.Children) != 0 {
+		t.Errorf("unexpected sessions relation: %+v", sessions)
+	}
+	posts := relations[0]
+	if posts.Table != "posts" || posts.Columns[0] != "author_id" || posts.ReferencedColumns[0] != "id" {
+		t.Errorf("unexpected posts relation: %+v", posts)
+	}
+	if len(posts.Children) != 1 || posts.Children[0].Table != "Comment" || posts.Children[0].Columns[0] != "postId" {
+		t.Errorf("unexpected posts children: %+v", posts.Children)
+	}
+
+	literal := formatCascadeRelations(relations)
+	if !strings.HasPrefix(literal, `[]builder.CascadeRelation{{Table: "posts", Columns: []string{"author_id"}`) ||
+		!strings.Contains(literal, `Children: []builder.CascadeRelation{{Table: "Comment"`) ||
+		!strings.Contains(literal, `{Table: "Session", Columns: []string{"userId"}, ReferencedColumns: []string{"id"}, OnDelete: "SetNull"}`) {
+		t.Errorf("unexpected literal: %s", literal)
+	}
+}
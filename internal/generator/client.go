@@ -97,9 +97,14 @@ func GenerateClient(schema *parser.Schema, outputDir string) error {
 		"logger_config.tmpl",
 		"new_client.tmpl",
 		"close_method.tmpl",
+		"ping_method.tmpl",
 		"raw_method.tmpl",
+		"stats_method.tmpl",
 		"transaction_client.tmpl",
 		"transaction_method.tmpl",
+		"search_path_method.tmpl",
+		"routing_method.tmpl",
+		"query_recorder_method.tmpl",
 	}
 
 	// Generate client.go using templates with package "generated" for root directory
@@ -164,18 +169,59 @@ func getPrimaryKey(model *parser.Model) string {
 }
 
 // getTableName returns the table name for a model
-// Checks for @@map attribute first, otherwise uses the exact model name as declared in schema
+// Checks for @@map attribute first, otherwise uses the exact model name as declared in schema.
+// Models with @@schema get the schema-qualified name (auth.users)
 func getTableName(model *parser.Model) string {
+	// Default to exact model name as declared in schema (no conversion)
+	tableName := model.Name
 	// Check for @@map attribute
 	for _, attr := range model.Attributes {
 		if attr.Name == "map" && len(attr.Arguments) > 0 {
 			if val, ok := attr.Arguments[0].Value.(string); ok {
+				tableName = val
+			}
+		}
+	}
+	if schemaName := getSchemaName(model); schemaName != "" {
+		return schemaName + "." + tableName
+	}
+	return tableName
+}
+
+// getSchemaName returns the Postgres schema of a model from @@schema, or "" for the default
+// public schema
+func getSchemaName(model *parser.Model) string {
+	for _, attr := range model.Attributes {
+		if attr.Name == "schema" && len(attr.Arguments) > 0 {
+			if val, ok := attr.Arguments[0].Value.(string); ok && val != "public" {
 				return val
 			}
 		}
 	}
-	// Default to exact model name as declared in schema (no conversion)
-	return model.Name
+	return ""
+}
+
+// getSoftDeleteColumn returns the soft delete column of a model, or "" when it has none.
+// The field named by @@softDelete(field) wins; otherwise a nullable DateTime field whose
+// column is deleted_at is used by convention.
+func getSoftDeleteColumn(model *parser.Model) string {
+	for _, attr := range model.Attributes {
+		if attr.Name == "softDelete" && len(attr.Arguments) > 0 {
+			if fieldName, ok := attr.Arguments[0].Value.(string); ok {
+				return getFieldColumnName(model, fieldName)
+			}
+		}
+	}
+
+	for _, field := range model.Fields {
+		if field.Type == nil || field.Type.Name != "DateTime" || !field.Type.IsOptional || field.Type.IsArray {
+			continue
+		}
+		if column := getFieldColumnName(model, field.Name); column == "deleted_at" {
+			return column
+		}
+	}
+	return ""
 }
 
 // determineClientImports determines which imports are needed for client.go
@@ -187,6 +233,8 @@ func determineClientImports(schema *parser.Schema, userModule, outputDir string)
 
 	// context is needed for Transaction method
 	imports["context"] = true
+	// fmt is needed for Stats errors
+	imports["fmt"] = true
 	// reflect is always needed for SetModelType
 	imports["reflect"] = true
 
@@ -221,18 +269,23 @@ func determineClientImports(schema *parser.Schema, userModule, outputDir string)
 	// Add driver import based on provider (blank import)
 	provider := migrations.GetProviderFromSchema(schema)
 	switch provider {
-	case "postgresql":
+	case "postgresql", "cockroachdb":
 		driverImports = append(driverImports, `_ "github.com/jackc/pgx/v5/stdlib"`)
 	case "mysql":
 		driverImports = append(driverImports, `_ "github.com/go-sql-driver/mysql"`)
 	case "sqlite":
 		driverImports = append(driverImports, `_ "github.com/mattn/go-sqlite3"`)
+	case "sqlserver":
+		driverImports = append(driverImports, `_ "github.com/microsoft/go-mssqldb"`)
 	}
 
 	result := []string{}
 	if imports["context"] {
 		result = append(result, "context")
 	}
+	if imports["fmt"] {
+		result = append(result, "fmt")
+	}
 	if imports["reflect"] {
 		result = append(result, "reflect")
 	}
@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+const createManySchema = `
+model Membership {
+  id     Int    @id @default(autoincrement())
+  org_id Int
+  email  String
+}
+`
+
+// ignoreExistingTest runs inside the generated queries package against a fake database
+// holding the memberships (1, a) and (2, b)
+const ignoreExistingTest = `package queries
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"test/db/builder"
+	"test/db/inputs"
+	"test/db/models"
+)
+
+type membershipRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (r *membershipRows) Close()     {}
+func (r *membershipRows) Err() error { return nil }
+func (r *membershipRows) Next() bool {
+	r.pos++
+	return r.pos <= len(r.rows)
+}
+func (r *membershipRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.rows[r.pos-1][i]))
+	}
+	return nil
+}
+
+type insertResult int64
+
+func (r insertResult) RowsAffected() int64          { return int64(r) }
+func (r insertResult) LastInsertId() (int64, error) { return 0, nil }
+
+type membershipDB struct {
+	builder.DB
+	selectSQL  string
+	selectArgs []interface{}
+	insertSQL  string
+	insertArgs []interface{}
+}
+
+func (d *membershipDB) Query(ctx context.Context, sql string, args ...interface{}) (builder.Rows, error) {
+	d.selectSQL, d.selectArgs = sql, args
+	return &membershipRows{rows: [][]interface{}{{1, "a"}, {2, "b"}}}, nil
+}
+
+func (d *membershipDB) Exec(ctx context.Context, sql string, args ...interface{}) (builder.Result, error) {
+	d.insertSQL, d.insertArgs = sql, args
+	return insertResult(strings.Count(sql, "(") - 1), nil
+}
+
+func TestIgnoreExisting_PartialOverlap(t *testing.T) {
+	db := &membershipDB{}
+	query := builder.NewQuery(db, "Membership", []string{"id", "org_id", "email"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	query.SetModelType(reflect.TypeOf(models.Membership{}))
+	memberships := &MembershipQuery{Query: query}
+
+	result, err := memberships.CreateMany().Data([]inputs.MembershipCreateInput{
+		{OrgId: 1, Email: "a"}, // existe
+		{OrgId: 1, Email: "b"},
+		{OrgId: 2, Email: "b"}, // existe
+		{OrgId: 1, Email: "b"}, // repetido no lote
+		{OrgId: 3, Email: "c"},
+	}).IgnoreExisting(inputs.MembershipFieldOrgId, inputs.MembershipFieldEmail).ExecWithContext(context.Background())
+	if err != nil {
+		t.Fatalf("CreateMany failed: %v", err)
+	}
+	if result.Count != 2 || result.Skipped != 3 {
+		t.Errorf("result = %+v, want Count 2 and Skipped 3", result)
+	}
+
+	if !strings.Contains(db.selectSQL, "WHERE (\"org_id\", \"email\") IN (($1, $2), ($3, $4), ($5, $6), ($7, $8), ($9, $10))") {
+		t.Errorf("lookup SQL = %s", db.selectSQL)
+	}
+	if fmt.Sprint(db.insertArgs) != "[1 b 3 c]" {
+		t.Errorf("insert SQL = %s args = %v", db.insertSQL, db.insertArgs)
+	}
+
+	// Sem sobreposição nenhum INSERT é pulado; tudo existente não chega a inserir
+	db.insertSQL = ""
+	result, err = memberships.CreateMany().Data([]inputs.MembershipCreateInput{{OrgId: 2, Email: "b"}}).
+		IgnoreExisting(inputs.MembershipFieldOrgId, inputs.MembershipFieldEmail).ExecWithContext(context.Background())
+	if err != nil || result.Count != 0 || result.Skipped != 1 || db.insertSQL != "" {
+		t.Errorf("all existing: result = %+v, err = %v, insert = %q", result, err, db.insertSQL)
+	}
+}
+`
+
+// TestCreateMany_IgnoreExisting compiles the generated client and checks that IgnoreExisting
+// filters a partially overlapping batch against existing rows and within the batch
+func TestCreateMany_IgnoreExisting(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "db")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(createManySchema)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateModels(schema, outputDir); err != nil {
+		t.Fatalf("GenerateModels failed: %v", err)
+	}
+	if err := GenerateUtils(outputDir); err != nil {
+		t.Fatalf("GenerateUtils failed: %v", err)
+	}
+	if err := GenerateBuilder(schema, outputDir); err != nil {
+		t.Fatalf("GenerateBuilder failed: %v", err)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+	if err := GenerateFilters(schema, outputDir); err != nil {
+		t.Fatalf("GenerateFilters failed: %v", err)
+	}
+
+	testFile := filepath.Join(outputDir, "queries", "ignore_existing_test.go")
+	if err := os.WriteFile(testFile, []byte(ignoreExistingTest), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	cmd := exec.Command(goBin, "test", "./db/queries/", "-run", "TestIgnoreExisting_")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated IgnoreExisting test failed: %v\n%s", err, output)
+	}
+}
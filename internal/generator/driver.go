@@ -1,6 +1,9 @@
 package generator
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/carlosnayan/prisma-go-client/internal/migrations"
@@ -38,7 +41,8 @@ func GenerateDriver(schema *parser.Schema, outputDir string) error {
 	templateNames = append(templateNames, "imports.tmpl")
 
 	switch provider {
-	case "postgresql":
+	case "postgresql", "cockroachdb":
+		// CockroachDB speaks the PostgreSQL wire protocol, so it reuses the pgx adapter
 		templateNames = append(templateNames,
 			"postgresql_driver.tmpl",
 			"config_helper.tmpl",
@@ -59,6 +63,13 @@ func GenerateDriver(schema *parser.Schema, outputDir string) error {
 			"setup_client_sql.tmpl",
 			"sqldb_adapter.tmpl",
 		)
+	case "sqlserver":
+		templateNames = append(templateNames,
+			"sqlserver_driver.tmpl",
+			"config_helper.tmpl",
+			"setup_client_sql.tmpl",
+			"sqldb_adapter.tmpl",
+		)
 	default:
 		// Default to PostgreSQL
 		templateNames = append(templateNames,
@@ -70,5 +81,37 @@ func GenerateDriver(schema *parser.Schema, outputDir string) error {
 	}
 
 	// Generate driver.go using templates with package "generated" for root directory
-	return executeTemplatesFromDirWithPackage(outputDir, "driver.go", "driver", templateNames, data, "generated")
+	if err := executeTemplatesFromDirWithPackage(outputDir, "driver.go", "driver", templateNames, data, "generated"); err != nil {
+		return err
+	}
+
+	return generateTestClient(schema, outputDir, data)
+}
+
+// generateTestClient generates test_client.go with NewTestClient for SQLite schemas.
+// It lives in its own file so it can be excluded from production builds; for other
+// providers a stale file from a previous generation is removed.
+func generateTestClient(schema *parser.Schema, outputDir string, data DriverTemplateData) error {
+	testClientPath := filepath.Join(outputDir, "test_client.go")
+	if data.Provider != "sqlite" {
+		if err := os.Remove(testClientPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove test_client.go: %w", err)
+		}
+		return nil
+	}
+
+	emptyDB := &migrations.DatabaseSchema{Tables: make(map[string]*migrations.TableInfo)}
+	diff, err := migrations.CompareSchema(schema, emptyDB, data.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to compare schema: %w", err)
+	}
+	// SQLite cannot add foreign keys with ALTER TABLE, so the test schema goes without them
+	diff.ForeignKeysToCreate = nil
+
+	data.SchemaSQL, err = migrations.GenerateMigrationSQL(diff, data.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to generate test schema SQL: %w", err)
+	}
+
+	return executeTemplatesFromDirWithPackage(outputDir, "test_client.go", "driver", []string{"test_client.tmpl"}, data, "generated")
 }
@@ -77,6 +77,9 @@ func generateFiltersTypesFile(filtersDir string, schema *parser.Schema) error {
 	if neededFilters["BytesFilter"] {
 		templateNames = append(templateNames, "bytes_filter.tmpl")
 	}
+	if neededFilters["ListFilter"] {
+		templateNames = append(templateNames, "list_filter.tmpl")
+	}
 
 	return executeFiltersTemplates(filtersFile, templateNames, data)
 }
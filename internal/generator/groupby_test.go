@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+func TestGenerate_GroupBy(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model Order {
+  id     Int    @id @default(autoincrement())
+  status String
+  amount Float  @map("total_amount")
+  count  Int
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateModels(schema, outputDir); err != nil {
+		t.Fatalf("GenerateModels failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	modelContent, err := os.ReadFile(filepath.Join(outputDir, "models", "order.go"))
+	if err != nil {
+		t.Fatalf("Failed to read model file: %v", err)
+	}
+	for _, want := range []string{
+		"type OrderGroupByResult struct {",
+		"Status string `json:\"status\" db:\"status\"`",
+		"GroupCount int64 `json:\"_count\" db:\"_count\"`",
+		"SumAmount *float64 `json:\"_sum_total_amount\" db:\"_sum_total_amount\"`",
+		"MaxCount *float64 `json:\"_max_count\" db:\"_max_count\"`",
+	} {
+		if !strings.Contains(string(modelContent), want) {
+			t.Errorf("generated model should contain %q", want)
+		}
+	}
+	if strings.Contains(string(modelContent), "SumStatus") {
+		t.Error("non-numeric fields should not get aggregate columns")
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "queries", "order_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	for _, want := range []string{
+		"func (q *OrderQuery) GroupBy() *OrderGroupByBuilder {",
+		"func (b *OrderGroupByBuilder) By(fields ...inputs.OrderField) *OrderGroupByBuilder {",
+		"func (b *OrderGroupByBuilder) Sum(field inputs.OrderField) *OrderGroupByBuilder {",
+		"func (b *OrderGroupByBuilder) ExecWithContext(ctx context.Context) ([]models.OrderGroupByResult, error) {",
+		"b.query.Query.GroupAggregate(ctx, fieldNames(b.by), b.aggregates, &results)",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/carlosnayan/prisma-go-client/internal/parser"
 )
@@ -48,9 +49,10 @@ func generateInputFile(filePath string, model *parser.Model, schema *parser.Sche
 			continue
 		}
 		fieldName := toPascalCase(field.Name)
-		goType := fieldTypeToGoBase(field.Type)
+		goType := inputGoType(field.Type, schema)
 		isOptional := field.Type != nil && field.Type.IsOptional
-		if isOptional {
+		// Fields with a @default are pointers too, so nil (use the default) is told apart from an explicit zero
+		if isOptional || hasDefaultValue(field) {
 			goType = "*" + goType
 		}
 		jsonTag := toSnakeCase(field.Name)
@@ -69,7 +71,7 @@ func generateInputFile(filePath string, model *parser.Model, schema *parser.Sche
 			continue
 		}
 		fieldName := toPascalCase(field.Name)
-		goType := fieldTypeToGoBase(field.Type)
+		goType := inputGoType(field.Type, schema)
 		// UpdateInput fields are always optional
 		goType = "*" + goType
 		jsonTag := toSnakeCase(field.Name)
@@ -92,9 +94,11 @@ func generateInputFile(filePath string, model *parser.Model, schema *parser.Sche
 		jsonTag := toSnakeCase(field.Name)
 
 		whereInputFields = append(whereInputFields, WhereInputFieldInfo{
-			FieldName:  fieldName,
-			FilterType: filterType,
-			JSONTag:    jsonTag,
+			FieldName:        fieldName,
+			FilterType:       filterType,
+			FilterTypeArgs:   getFilterTypeArgs(field, schema),
+			FieldBuilderType: getFieldBuilderType(filterType),
+			JSONTag:          jsonTag,
 		})
 	}
 
@@ -108,8 +112,10 @@ func generateInputFile(filePath string, model *parser.Model, schema *parser.Sche
 		jsonTag := toSnakeCase(field.Name)
 
 		selectFields = append(selectFields, InputSelectFieldInfo{
-			FieldName: fieldName,
-			JSONTag:   jsonTag,
+			FieldName:  fieldName,
+			JSONTag:    jsonTag,
+			ColumnName: getFieldColumnName(model, field.Name),
+			IsString:   isStringField(field),
 		})
 	}
 
@@ -123,20 +129,28 @@ func generateInputFile(filePath string, model *parser.Model, schema *parser.Sche
 	}
 
 	filtersPath := ""
+	modelsPath := ""
 	if inputsPath != "" {
 		baseImportPath := inputsPath[:len(inputsPath)-len("/inputs")]
 		filtersPath = baseImportPath + "/filters"
+		if usesEnums(model, schema) {
+			modelsPath = baseImportPath + "/models"
+		}
 	}
 
 	data := InputTemplateData{
-		ModelName:        model.Name,
-		PascalName:       pascalModelName,
-		StdlibImports:    stdlib,
-		FiltersPath:      filtersPath,
-		CreateFields:     createFields,
-		UpdateFields:     updateFields,
-		WhereInputFields: whereInputFields,
-		SelectFields:     selectFields,
+		ModelName:         model.Name,
+		PascalName:        pascalModelName,
+		StdlibImports:     stdlib,
+		FiltersPath:       filtersPath,
+		ModelsPath:        modelsPath,
+		CreateFields:      createFields,
+		UpdateFields:      updateFields,
+		WhereInputFields:  whereInputFields,
+		SelectFields:      selectFields,
+		NestedCreates:     getOneToManyRelations(model, schema),
+		BelongsTo:         getBelongsToRelations(model, schema),
+		UniqueConstraints: getUniqueConstraintInfos(model),
 	}
 
 	templateNames := []string{
@@ -144,12 +158,24 @@ func generateInputFile(filePath string, model *parser.Model, schema *parser.Sche
 		"create_input.tmpl",
 		"update_input.tmpl",
 		"where_input.tmpl",
+		"where_builder.tmpl",
 		"select_input.tmpl",
+		"order_by_input.tmpl",
+		"include_input.tmpl",
+		"unique_where_input.tmpl",
 	}
 
 	return executeInputTemplates(filePath, templateNames, data)
 }
 
+// inputGoType returns the base Go type of a field in the inputs package, where enums are models types
+func inputGoType(fieldType *parser.FieldType, schema *parser.Schema) string {
+	if enum := findEnum(schema, fieldType); enum != nil {
+		return strings.TrimPrefix(enumFieldGoType(fieldType, "models."+enumTypeName(enum)), "*")
+	}
+	return fieldTypeToGoBase(fieldType)
+}
+
 // fieldTypeToGoBase returns the base Go type without pointers (for input types)
 func fieldTypeToGoBase(fieldType *parser.FieldType) string {
 	if fieldType == nil {
@@ -179,6 +205,16 @@ func fieldTypeToGoBase(fieldType *parser.FieldType) string {
 	return goType
 }
 
+// usesEnums reports whether a model has an enum field, so its inputs import the models package
+func usesEnums(model *parser.Model, schema *parser.Schema) bool {
+	for _, field := range model.Fields {
+		if findEnum(schema, field.Type) != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // isAutoGenerated checks if a field is auto-generated (id with autoincrement)
 func isAutoGenerated(field *parser.ModelField) bool {
 	hasID := false
@@ -213,6 +249,11 @@ func isPrimaryKey(field *parser.ModelField) bool {
 	return false
 }
 
+// isStringField reports whether field is a scalar (non-list) String
+func isStringField(field *parser.ModelField) bool {
+	return field.Type != nil && field.Type.Name == "String" && !field.Type.IsArray
+}
+
 // isRelation checks if a field is a relationship (non-primitive type)
 // It returns false for enums, which are scalar types, not relationships
 func isRelation(field *parser.ModelField, schema *parser.Schema) bool {
@@ -317,6 +358,11 @@ func getFilterType(fieldType *parser.FieldType) string {
 		return "StringFilter" // Default
 	}
 
+	// Scalar lists share a generic filter; its element type comes from getFilterTypeArgs
+	if fieldType.IsArray {
+		return "ListFilter"
+	}
+
 	typeMapping := parser.GetTypeGoMapping()
 	if mapped, ok := typeMapping[fieldType.Name]; ok {
 		switch mapped {
@@ -343,6 +389,25 @@ func getFilterType(fieldType *parser.FieldType) string {
 	return "StringFilter"
 }
 
+// getFilterTypeArgs returns the type arguments of a generic filter (e.g. "[string]" for
+// ListFilter[string]), qualifying enum types with the models package
+func getFilterTypeArgs(field *parser.ModelField, schema *parser.Schema) string {
+	if field.Type == nil || !field.Type.IsArray {
+		return ""
+	}
+	return "[" + inputGoType(field.Type, schema)[len("[]"):] + "]"
+}
+
+// getFieldBuilderType returns the fluent field type for a Filter type.
+// Only scalar filters have one; Json and Bytes fields are left to the WhereInput struct.
+func getFieldBuilderType(filterType string) string {
+	switch filterType {
+	case "StringFilter", "IntFilter", "Int64Filter", "FloatFilter", "BooleanFilter", "DateTimeFilter":
+		return strings.TrimSuffix(filterType, "Filter") + "Field"
+	}
+	return ""
+}
+
 func generateInputHelpersFile(inputsDir string, schema *parser.Schema) error {
 	helpersFile := filepath.Join(inputsDir, "helpers.go")
 
@@ -76,6 +76,33 @@ func TestTableMap_WithAtAtMap(t *testing.T) {
 	}
 }
 
+// TestTableMap_WithAtAtSchema tests that @@schema qualifies the table name, and that the
+// public schema keeps it unqualified
+func TestTableMap_WithAtAtSchema(t *testing.T) {
+	model := func(attrs ...*parser.Attribute) *parser.Model {
+		return &parser.Model{Name: "User", Attributes: attrs}
+	}
+	mapAttr := &parser.Attribute{Name: "map", Arguments: []*parser.AttributeArgument{{Value: "users"}}}
+	schemaAttr := func(name string) *parser.Attribute {
+		return &parser.Attribute{Name: "schema", Arguments: []*parser.AttributeArgument{{Value: name}}}
+	}
+
+	tests := []struct {
+		model    *parser.Model
+		expected string
+	}{
+		{model(schemaAttr("auth")), "auth.User"},
+		{model(mapAttr, schemaAttr("auth")), "auth.users"},
+		{model(schemaAttr("auth"), mapAttr), "auth.users"},
+		{model(mapAttr, schemaAttr("public")), "users"},
+	}
+	for _, tt := range tests {
+		if tableName := getTableName(tt.model); tableName != tt.expected {
+			t.Errorf("getTableName() = %s, want %s", tableName, tt.expected)
+		}
+	}
+}
+
 // TestTableMap_WithoutAtAtMap tests that without @@map, snake_case is used
 func TestTableMap_WithoutAtAtMap(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -421,6 +448,71 @@ func TestColumnMap_InSelectFields(t *testing.T) {
 	}
 }
 
+// TestColumnMap_InSelectExcept tests that SelectExcept field constants use @map
+func TestColumnMap_InSelectExcept(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+
+	// Create a temporary go.mod file for module detection
+	goModPath := filepath.Join(tmpDir, "go.mod")
+	goModContent := "module test\n"
+	if err := os.WriteFile(goModPath, []byte(goModContent), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema := &parser.Schema{
+		Models: []*parser.Model{
+			{
+				Name: "User",
+				Fields: []*parser.ModelField{
+					{
+						Name: "id",
+						Type: &parser.FieldType{Name: "Int"},
+						Attributes: []*parser.Attribute{
+							{Name: "id"},
+						},
+					},
+					{
+						Name: "emailAddress",
+						Type: &parser.FieldType{Name: "String"},
+						Attributes: []*parser.Attribute{
+							{
+								Name: "map",
+								Arguments: []*parser.AttributeArgument{
+									{Value: "email_address"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	inputsContent, err := os.ReadFile(filepath.Join(outputDir, "inputs", "user_input.go"))
+	if err != nil {
+		t.Fatalf("Failed to read inputs file: %v", err)
+	}
+	if !strings.Contains(string(inputsContent), `UserFieldEmailaddress UserField = "email_address"`) {
+		t.Error("UserFieldEmailaddress should use 'email_address' (from @map), but not found")
+	}
+
+	queryContent, err := os.ReadFile(filepath.Join(outputDir, "queries", "user_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	if !strings.Contains(string(queryContent), "func (b *UserFindManyBuilder) SelectExcept(fields ...inputs.UserField)") {
+		t.Error("FindMany builder should expose a typed SelectExcept, but not found")
+	}
+}
+
 // TestTableAndColumnMap_Combined tests both @@map and @map together
 func TestTableAndColumnMap_Combined(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -514,3 +606,84 @@ func TestTableAndColumnMap_Combined(t *testing.T) {
 		t.Error("WhereInput converter should use 'email_address' from @map")
 	}
 }
+
+// TestModelTableMeta_WithMaps tests that the generated XTable() metadata uses @@map and @map names
+func TestModelTableMeta_WithMaps(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+
+	schema := &parser.Schema{
+		Models: []*parser.Model{
+			{
+				Name: "User",
+				Attributes: []*parser.Attribute{
+					{
+						Name: "map",
+						Arguments: []*parser.AttributeArgument{
+							{Value: "users"},
+						},
+					},
+				},
+				Fields: []*parser.ModelField{
+					{
+						Name: "id",
+						Type: &parser.FieldType{Name: "Int"},
+						Attributes: []*parser.Attribute{
+							{Name: "id"},
+							{
+								Name: "map",
+								Arguments: []*parser.AttributeArgument{
+									{Value: "user_id"},
+								},
+							},
+						},
+					},
+					{
+						Name: "emailAddress",
+						Type: &parser.FieldType{Name: "String"},
+						Attributes: []*parser.Attribute{
+							{
+								Name: "map",
+								Arguments: []*parser.AttributeArgument{
+									{Value: "email_address"},
+								},
+							},
+						},
+					},
+					{
+						Name: "name",
+						Type: &parser.FieldType{Name: "String"},
+					},
+				},
+			},
+		},
+	}
+
+	if err := GenerateModels(schema, outputDir); err != nil {
+		t.Fatalf("GenerateModels failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "models", "user.go"))
+	if err != nil {
+		t.Fatalf("Failed to read user.go: %v", err)
+	}
+	contentStr := string(content)
+
+	expected := []string{
+		"func UserTable() TableMeta {",
+		`Name:       "users",`,
+		`PrimaryKey: "user_id",`,
+		`"id": "user_id",`,
+		`"emailAddress": "email_address",`,
+		`"name": "name",`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(contentStr, exp) {
+			t.Errorf("Expected generated model to contain %q", exp)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(outputDir, "models", "table_meta.go")); err != nil {
+		t.Errorf("Expected models/table_meta.go to be generated: %v", err)
+	}
+}
@@ -16,6 +16,15 @@ func GenerateModels(schema *parser.Schema, outputDir string) error {
 		return fmt.Errorf("failed to create models directory: %w", err)
 	}
 
+	metaFile := filepath.Join(modelsDir, "table_meta.go")
+	if err := executeModelTemplate(metaFile, "models", "models", "table_meta.tmpl", nil); err != nil {
+		return fmt.Errorf("failed to generate table metadata: %w", err)
+	}
+
+	if err := generateEnumsFile(modelsDir, schema); err != nil {
+		return fmt.Errorf("failed to generate enums: %w", err)
+	}
+
 	for _, model := range schema.Models {
 		modelFile := filepath.Join(modelsDir, toSnakeCase(model.Name)+".go")
 		if err := generateModelFile(modelFile, model, schema); err != nil {
@@ -33,6 +42,7 @@ func generateModelFile(filePath string, model *parser.Model, schema *parser.Sche
 
 	// Prepare fields
 	fields := make([]FieldInfo, 0)
+	countField := "Count"
 	for _, field := range model.Fields {
 		// Skip relations - only include actual database columns
 		if isRelation(field, schema) {
@@ -41,6 +51,9 @@ func generateModelFile(filePath string, model *parser.Model, schema *parser.Sche
 
 		fieldName := toPascalCase(field.Name)
 		goType := fieldTypeToGo(field.Type, field.Attributes)
+		if enum := findEnum(schema, field.Type); enum != nil {
+			goType = enumFieldGoType(field.Type, enumTypeName(enum))
+		}
 		jsonTag := toSnakeCase(field.Name)
 		dbTag := field.Name
 
@@ -53,11 +66,23 @@ func generateModelFile(filePath string, model *parser.Model, schema *parser.Sche
 			}
 		}
 
+		if fieldName == countField {
+			countField = "GroupCount"
+		}
+
+		resultType := goType
+		if !strings.HasPrefix(goType, "*") {
+			resultType = "*" + goType
+		}
+
 		fields = append(fields, FieldInfo{
-			Name:    fieldName,
-			GoType:  goType,
-			JSONTag: jsonTag,
-			DBTag:   dbTag,
+			Name:       fieldName,
+			SchemaName: field.Name,
+			GoType:     goType,
+			JSONTag:    jsonTag,
+			DBTag:      dbTag,
+			Numeric:    isNumericField(field.Type),
+			ResultType: resultType,
 		})
 	}
 
@@ -65,14 +90,86 @@ func generateModelFile(filePath string, model *parser.Model, schema *parser.Sche
 	data := ModelTemplateData{
 		ModelName:  model.Name,
 		PascalName: toPascalCase(model.Name),
+		TableName:  getTableName(model),
+		PrimaryKey: getPrimaryKey(model),
 		Imports:    imports,
 		Fields:     fields,
+		CountField: countField,
+		BelongsTo:  getBelongsToRelations(model, schema),
+		HasMany:    getOneToManyRelations(model, schema),
 	}
 
 	// Generate model file using template
 	return executeModelTemplate(filePath, "models", "models", "model.tmpl", data)
 }
 
+// generateEnumsFile writes models/enums.go with a string type and constants per schema enum,
+// removing a stale file when the schema has no enums
+func generateEnumsFile(modelsDir string, schema *parser.Schema) error {
+	enumsFile := filepath.Join(modelsDir, "enums.go")
+	if len(schema.Enums) == 0 {
+		if err := os.Remove(enumsFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	enums := make([]EnumInfo, 0, len(schema.Enums))
+	for _, enum := range schema.Enums {
+		info := EnumInfo{Name: enum.Name, TypeName: enumTypeName(enum)}
+		for _, value := range enum.Values {
+			info.Values = append(info.Values, EnumValueInfo{
+				ConstName: info.TypeName + toPascalCase(value.Name),
+				Value:     getEnumValueName(value),
+			})
+		}
+		enums = append(enums, info)
+	}
+
+	return executeModelTemplate(enumsFile, "models", "models", "enums.tmpl", EnumsTemplateData{Enums: enums})
+}
+
+// findEnum returns the schema enum a field type refers to, or nil if it is not an enum
+func findEnum(schema *parser.Schema, fieldType *parser.FieldType) *parser.Enum {
+	if fieldType == nil {
+		return nil
+	}
+	for _, enum := range schema.Enums {
+		if enum.Name == fieldType.Name {
+			return enum
+		}
+	}
+	return nil
+}
+
+// enumTypeName returns the exported Go type name of an enum
+func enumTypeName(enum *parser.Enum) string {
+	return strings.ToUpper(enum.Name[:1]) + enum.Name[1:]
+}
+
+// getEnumValueName returns the value stored in the database for an enum value (from @map, or its name)
+func getEnumValueName(value *parser.EnumValue) string {
+	for _, attr := range value.Attributes {
+		if attr.Name == "map" && len(attr.Arguments) > 0 {
+			if val, ok := attr.Arguments[0].Value.(string); ok {
+				return val
+			}
+		}
+	}
+	return value.Name
+}
+
+// enumFieldGoType returns the Go type of an enum field: typeName, *typeName when optional or []typeName for a list
+func enumFieldGoType(fieldType *parser.FieldType, typeName string) string {
+	if fieldType.IsArray {
+		return "[]" + typeName
+	}
+	if fieldType.IsOptional {
+		return "*" + typeName
+	}
+	return typeName
+}
+
 // fieldTypeToGo converts a Prisma FieldType to Go type
 func fieldTypeToGo(fieldType *parser.FieldType, attributes []*parser.Attribute) string {
 	if fieldType == nil {
@@ -117,6 +214,18 @@ func fieldTypeToGo(fieldType *parser.FieldType, attributes []*parser.Attribute)
 	return goType
 }
 
+// isNumericField reports whether a field can be summed and averaged
+func isNumericField(fieldType *parser.FieldType) bool {
+	if fieldType == nil || fieldType.IsArray {
+		return false
+	}
+	switch fieldType.Name {
+	case "Int", "BigInt", "Float", "Decimal":
+		return true
+	}
+	return false
+}
+
 // determineImports determines which imports are needed
 func determineImports(model *parser.Model, schema *parser.Schema) []string {
 	imports := make(map[string]bool)
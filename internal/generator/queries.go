@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/carlosnayan/prisma-go-client/internal/parser"
 )
@@ -130,6 +131,8 @@ func generateQueryFile(filePath string, model *parser.Model, schema *parser.Sche
 		selectFields = append(selectFields, SelectFieldInfo{
 			FieldName:  fieldName,
 			ColumnName: columnName,
+			IsPointer:  strings.HasPrefix(fieldTypeToGo(field.Type, field.Attributes), "*"),
+			IsString:   isStringField(field),
 		})
 	}
 
@@ -179,6 +182,8 @@ func generateQueryFile(filePath string, model *parser.Model, schema *parser.Sche
 			FieldName:   fieldName,
 			DBFieldName: dbFieldName,
 			IsPointer:   isPointer,
+			IsEnum:      findEnum(schema, field.Type) != nil,
+			IsList:      field.Type != nil && field.Type.IsArray,
 		})
 	}
 
@@ -192,13 +197,15 @@ func generateQueryFile(filePath string, model *parser.Model, schema *parser.Sche
 		isOptional := field.Type != nil && field.Type.IsOptional
 		isNonPointerOptional := isNonPointerOptionalType(field.Type)
 		hasDefault := hasDefaultValue(field)
-		// Field is required if: not optional AND no default value
-		isRequired := !isOptional && !hasDefault
+		isList := field.Type != nil && field.Type.IsArray
 
 		createFields = append(createFields, CreateFieldInfo{
 			FieldName:            fieldName,
+			ColumnName:           getFieldColumnName(model, field.Name),
 			IsOptional:           isOptional,
-			IsRequired:           isRequired,
+			HasDefault:           !isOptional && hasDefault,
+			IsEnum:               findEnum(schema, field.Type) != nil,
+			IsList:               isList,
 			IsNonPointerOptional: isNonPointerOptional,
 		})
 	}
@@ -207,23 +214,32 @@ func generateQueryFile(filePath string, model *parser.Model, schema *parser.Sche
 	columns := getModelColumns(model, schema)
 	primaryKey := getPrimaryKey(model)
 	tableName := getTableName(model)
+	cascadeRelations := formatCascadeRelations(getCascadeRelations(model, schema, map[string]bool{}))
 
 	// Prepare template data
 	data := QueryTemplateData{
-		ModelName:         model.Name,
-		PascalName:        toPascalCase(model.Name),
-		StdlibImports:     stdlib,
-		ThirdPartyImports: thirdParty,
-		BuilderPath:       builderPath,
-		ModelsPath:        modelsPath,
-		InputsPath:        inputsPath,
-		Fields:            fields,
-		SelectFields:      selectFields,
-		UpdateFields:      updateFields,
-		CreateFields:      createFields,
-		Columns:           columns,
-		PrimaryKey:        primaryKey,
-		TableName:         tableName,
+		ModelName:          model.Name,
+		PascalName:         toPascalCase(model.Name),
+		StdlibImports:      stdlib,
+		ThirdPartyImports:  thirdParty,
+		BuilderPath:        builderPath,
+		ModelsPath:         modelsPath,
+		InputsPath:         inputsPath,
+		Fields:             fields,
+		SelectFields:       selectFields,
+		UpdateFields:       updateFields,
+		CreateFields:       createFields,
+		HasEnums:           usesEnums(model, schema),
+		Columns:            columns,
+		PrimaryKey:         primaryKey,
+		TableName:          tableName,
+		CascadeRelations:   cascadeRelations,
+		OneToManyRelations: getOneToManyRelations(model, schema),
+		BelongsToRelations: getBelongsToRelations(model, schema),
+		UniqueConstraints:  getUniqueConstraintInfos(model),
+		SoftDeleteColumn:   getSoftDeleteColumn(model),
+		CreatedTimestamps:  getTimestampFields(model, true),
+		UpdatedTimestamps:  getTimestampFields(model, false),
 	}
 
 	// Define template order
@@ -234,8 +250,13 @@ func generateQueryFile(filePath string, model *parser.Model, schema *parser.Sche
 		"where_input_converter.tmpl",
 		"apply_where_helper.tmpl",
 		"findfirst_builder.tmpl",
+		"findunique_builder.tmpl",
 		"findmany_builder.tmpl",
+		"include.tmpl",
+		"select_result.tmpl",
 		"count_builder.tmpl",
+		"exists_builder.tmpl",
+		"groupby_builder.tmpl",
 		"delete_builder.tmpl",
 		"deletemany_builder.tmpl",
 		"update_builder.tmpl",
@@ -243,6 +264,7 @@ func generateQueryFile(filePath string, model *parser.Model, schema *parser.Sche
 		"upsert_builder.tmpl",
 		"create_builder.tmpl",
 		"createmany_builder.tmpl",
+		"create_with_relations.tmpl",
 	}
 
 	// Generate query file using templates
@@ -262,13 +284,44 @@ func isNonPointerOptionalType(fieldType *parser.FieldType) bool {
 // hasDefaultValue checks if a field has a @default attribute
 func hasDefaultValue(field *parser.ModelField) bool {
 	for _, attr := range field.Attributes {
-		if attr.Name == "default" {
+		// @updatedAt is filled in on create like a default
+		if attr.Name == "default" || attr.Name == "updatedAt" {
 			return true
 		}
 	}
 	return false
 }
 
+// getTimestampFields returns the required DateTime fields set to the current time on write:
+// on create the @default(now()) and @updatedAt fields, otherwise only the @updatedAt ones
+func getTimestampFields(model *parser.Model, onCreate bool) []TimestampFieldInfo {
+	var fields []TimestampFieldInfo
+	for _, field := range model.Fields {
+		if field.Type == nil || field.Type.Name != "DateTime" || field.Type.IsOptional || field.Type.IsArray {
+			continue
+		}
+		for _, attr := range field.Attributes {
+			if attr.Name == "updatedAt" || (onCreate && attr.Name == "default" && isNowDefault(attr)) {
+				fields = append(fields, TimestampFieldInfo{
+					FieldName:  toPascalCase(field.Name),
+					ColumnName: getFieldColumnName(model, field.Name),
+				})
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// isNowDefault reports whether a @default attribute is now()
+func isNowDefault(attr *parser.Attribute) bool {
+	if len(attr.Arguments) == 0 {
+		return false
+	}
+	fn, ok := attr.Arguments[0].Value.(map[string]interface{})
+	return ok && fn["function"] == "now"
+}
+
 // determineQueryImports determines which imports are needed for query files
 func determineQueryImports(userModule, outputDir string) []string {
 	// Calculate import paths for generated packages
@@ -289,19 +342,13 @@ func determineQueryImports(userModule, outputDir string) []string {
 	// context is always needed for all query methods
 	// fmt is needed for fmt.Errorf in builders
 	// reflect is needed for Scan() method
-	// strings is needed for buildColumnToFieldMapForScan (strings.Index)
-	// database/sql is needed for sql.ErrNoRows in upsert
-	// errors is needed for errors.Is in upsert
 	// builder is always needed for Query embedding
 	// models is always needed for type references
 	// inputs is needed for WhereInput
 	return []string{
 		"context",
-		"database/sql",
-		"errors",
 		"fmt",
 		"reflect",
-		"strings",
 		builderPath,
 		modelsPath,
 		inputsPath,
@@ -67,6 +67,11 @@ func (m *mockBuilderDBTX) Begin(ctx context.Context) (driver.Tx, error) {
 	return nil, nil
 }
 
+// Ping implements builder.DBTX.Ping
+func (m *mockBuilderDBTX) Ping(ctx context.Context) error {
+	return nil
+}
+
 // SQLDB implements builder.DBTX.SQLDB
 func (m *mockBuilderDBTX) SQLDB() *sql.DB {
 	return nil
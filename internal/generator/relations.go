@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+// oneToManyRelation is a list field of a parent model (e.g. User.posts Post[])
+// resolved to the child model field that holds the foreign key
+type oneToManyRelation struct {
+	FieldName       string // PascalCase name of the list field on the parent (Posts)
+	JSONTag         string // JSON tag of the list field (posts)
+	ChildPascalName string // PascalCase name of the child model (Post)
+	ForeignKeyField string // PascalCase child field holding the FK (AuthorId)
+	ForeignKeyCol   string // Database column of the FK (author_id)
+	ForeignKeyOpt   bool   // Whether the FK field is optional (pointer in CreateInput)
+	ReferenceField  string // PascalCase parent field the FK references (Id)
+	Connectable     bool   // Whether the child has unique fields to connect by
+}
+
+// getOneToManyRelations returns the one-to-many relations of model whose
+// child side declares a single-column @relation(fields, references)
+func getOneToManyRelations(model *parser.Model, schema *parser.Schema) []oneToManyRelation {
+	var relations []oneToManyRelation
+	for _, field := range model.Fields {
+		if field.Type == nil || !field.Type.IsArray || !isRelation(field, schema) {
+			continue
+		}
+		child := findModel(schema, field.Type.Name)
+		if child == nil {
+			continue
+		}
+		fkField, fk, ref := findBackRelation(child, model.Name, getRelationName(field))
+		if fkField == nil {
+			continue
+		}
+		relations = append(relations, oneToManyRelation{
+			FieldName:       toPascalCase(field.Name),
+			JSONTag:         toSnakeCase(field.Name),
+			ChildPascalName: toPascalCase(child.Name),
+			ForeignKeyField: toPascalCase(fk),
+			ForeignKeyCol:   getFieldColumnName(child, fk),
+			ForeignKeyOpt:   (fkField.Type != nil && fkField.Type.IsOptional) || hasDefaultValue(fkField),
+			ReferenceField:  toPascalCase(ref),
+			Connectable:     len(getUniqueConstraintInfos(child)) > 0,
+		})
+	}
+	return relations
+}
+
+// belongsToRelation is a to-one field of a model that holds the foreign key itself
+// (e.g. Post.author User @relation(fields: [authorId], references: [id]))
+type belongsToRelation struct {
+	FieldName        string // PascalCase name of the relation field (Author)
+	JSONTag          string // JSON tag of the relation field (author)
+	ParentPascalName string // PascalCase name of the referenced model (User)
+	ForeignKeyField  string // PascalCase field holding the FK (Authorid)
+	ForeignKeyOpt    bool   // Whether the FK field is optional (pointer in the model)
+	ReferenceField   string // PascalCase referenced field (Id)
+	ReferenceCol     string // Database column of the referenced field (id)
+}
+
+// getBelongsToRelations returns the to-one relations of model that declare a
+// single-column @relation(fields, references)
+func getBelongsToRelations(model *parser.Model, schema *parser.Schema) []belongsToRelation {
+	var relations []belongsToRelation
+	for _, field := range model.Fields {
+		if field.Type == nil || field.Type.IsArray || !isRelation(field, schema) {
+			continue
+		}
+		parent := findModel(schema, field.Type.Name)
+		if parent == nil {
+			continue
+		}
+		fields, references, _ := getRelationArguments(field)
+		if len(fields) != 1 || len(references) != 1 {
+			continue
+		}
+		var fkField *parser.ModelField
+		for _, f := range model.Fields {
+			if f.Name == fields[0] {
+				fkField = f
+				break
+			}
+		}
+		if fkField == nil {
+			continue
+		}
+		relations = append(relations, belongsToRelation{
+			FieldName:        toPascalCase(field.Name),
+			JSONTag:          toSnakeCase(field.Name),
+			ParentPascalName: toPascalCase(parent.Name),
+			ForeignKeyField:  toPascalCase(fields[0]),
+			ForeignKeyOpt:    fkField.Type != nil && fkField.Type.IsOptional,
+			ReferenceField:   toPascalCase(references[0]),
+			ReferenceCol:     getFieldColumnName(parent, references[0]),
+		})
+	}
+	return relations
+}
+
+// findBackRelation finds the field of child pointing to parent with the given
+// relation name. It returns the FK scalar field and the fields/references pair.
+func findBackRelation(child *parser.Model, parent, relationName string) (*parser.ModelField, string, string) {
+	for _, field := range child.Fields {
+		if field.Type == nil || field.Type.IsArray || field.Type.Name != parent {
+			continue
+		}
+		if getRelationName(field) != relationName {
+			continue
+		}
+		fields, references, _ := getRelationArguments(field)
+		if len(fields) != 1 || len(references) != 1 {
+			continue
+		}
+		for _, fkField := range child.Fields {
+			if fkField.Name == fields[0] {
+				return fkField, fields[0], references[0]
+			}
+		}
+	}
+	return nil, "", ""
+}
+
+// getRelationName returns the name given to @relation("Name"), or "" if unnamed
+func getRelationName(field *parser.ModelField) string {
+	for _, attr := range field.Attributes {
+		if attr.Name != "relation" {
+			continue
+		}
+		for _, arg := range attr.Arguments {
+			if val, ok := arg.Value.(string); ok && (arg.Name == "" || arg.Name == "name") {
+				return strings.Trim(val, `"`)
+			}
+		}
+	}
+	return ""
+}
+
+// findModel returns the model with the given name, or nil
+func findModel(schema *parser.Schema, name string) *parser.Model {
+	for _, model := range schema.Models {
+		if model.Name == name {
+			return model
+		}
+	}
+	return nil
+}
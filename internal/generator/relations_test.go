@@ -0,0 +1,606 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+func TestGetOneToManyRelations(t *testing.T) {
+	schema, errs, err := parser.Parse(`
+model User {
+  id       Int    @id @default(autoincrement())
+  email    String
+  posts    Post[] @relation("Written")
+  reviews  Post[] @relation("Reviewed")
+  @@map("users")
+}
+
+model Post {
+  id         Int   @id @default(autoincrement())
+  title      String
+  authorId   Int   @map("author_id")
+  reviewerId Int?
+  author     User  @relation("Written", fields: [authorId], references: [id])
+  reviewer   User? @relation("Reviewed", fields: [reviewerId], references: [id])
+  @@map("posts")
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+
+	relations := getOneToManyRelations(schema.Models[0], schema)
+	if len(relations) != 2 {
+		t.Fatalf("expected 2 relations, got %d", len(relations))
+	}
+	posts := relations[0]
+	if posts.FieldName != "Posts" || posts.JSONTag != "posts" || posts.ChildPascalName != "Post" ||
+		posts.ForeignKeyField != "Authorid" || posts.ForeignKeyOpt || posts.ReferenceField != "Id" {
+		t.Errorf("unexpected posts relation: %+v", posts)
+	}
+	if reviews := relations[1]; reviews.FieldName != "Reviews" || reviews.ForeignKeyField != "Reviewerid" || !reviews.ForeignKeyOpt {
+		t.Errorf("unexpected reviews relation: %+v", reviews)
+	}
+}
+
+func TestGenerateQueries_CreateWithRelations(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model User {
+  id    Int    @id @default(autoincrement())
+  email String
+  posts Post[]
+}
+
+model Post {
+  id       Int    @id @default(autoincrement())
+  title    String
+  authorId Int
+  author   User   @relation(fields: [authorId], references: [id])
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "queries", "user_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	contentStr := string(content)
+
+	expected := []string{
+		"func (q *UserQuery) CreateWithPosts(ctx context.Context, data inputs.UserCreateInput, children []inputs.PostCreateInput) (*models.User, error)",
+		"data.Posts = inputs.PostCreateNested{Create: children}",
+		"b.query.Query.RunInTransaction(ctx, func(db builder.DBTX) error {",
+		"child.Authorid = parent.Id",
+		"newPostQueryOn(db, q.Query).CreateMany().Data(related).ExecWithContext(ctx)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(contentStr, want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+
+	postContent, err := os.ReadFile(filepath.Join(outputDir, "queries", "post_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	if strings.Contains(string(postContent), "CreateWith") {
+		t.Error("Post has no list relations and should not get CreateWith helpers")
+	}
+}
+
+func TestGenerate_NestedCreate(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model User {
+  id       Int       @id @default(autoincrement())
+  email    String
+  posts    Post[]
+  comments Comment[]
+}
+
+model Post {
+  id       Int    @id @default(autoincrement())
+  title    String
+  authorId Int
+  author   User   @relation(fields: [authorId], references: [id])
+}
+
+model Comment {
+  id     Int    @id @default(autoincrement())
+  body   String
+  userId Int?
+  user   User?  @relation(fields: [userId], references: [id])
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	inputsContent, err := os.ReadFile(filepath.Join(outputDir, "inputs", "user_input.go"))
+	if err != nil {
+		t.Fatalf("Failed to read inputs file: %v", err)
+	}
+	for _, want := range []string{
+		"Posts PostCreateNested `json:\"posts,omitempty\"`",
+		"Comments CommentCreateNested `json:\"comments,omitempty\"`",
+		"type UserCreateNested struct {",
+	} {
+		if !strings.Contains(string(inputsContent), want) {
+			t.Errorf("generated inputs should contain %q", want)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "queries", "user_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	for _, want := range []string{
+		"if b.hasNestedWrites() {",
+		"return len(b.data.Posts.Create) > 0 || len(b.data.Posts.Connect) > 0 || len(b.data.Comments.Create) > 0 || len(b.data.Comments.Connect) > 0",
+		"data.Posts = inputs.PostCreateNested{}",
+		"if err := b.query.createPosts(ctx, db, created, b.data.Posts.Create); err != nil {",
+		"if err := b.query.createComments(ctx, db, created, b.data.Comments.Create); err != nil {",
+		"child.Userid = &ref",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+
+	postContent, err := os.ReadFile(filepath.Join(outputDir, "queries", "post_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	if strings.Contains(string(postContent), "hasNestedWrites") {
+		t.Error("Post has no list relations and should not support nested creates")
+	}
+}
+
+func TestGenerate_NestedConnect(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model User {
+  id    Int    @id @default(autoincrement())
+  email String @unique
+  posts Post[]
+  tags  Tag[]
+}
+
+model Post {
+  id       Int    @id @default(autoincrement())
+  slug     String @unique
+  authorId Int    @map("author_id")
+  author   User   @relation(fields: [authorId], references: [id])
+}
+
+model Tag {
+  name   String
+  userId Int
+  user   User   @relation(fields: [userId], references: [id])
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	postInputs, err := os.ReadFile(filepath.Join(outputDir, "inputs", "post_input.go"))
+	if err != nil {
+		t.Fatalf("Failed to read inputs file: %v", err)
+	}
+	for _, want := range []string{
+		"type PostUniqueWhereInput struct {",
+		"Connect []PostUniqueWhereInput `json:\"connect,omitempty\"`",
+		"type PostUpdateNested struct {",
+	} {
+		if !strings.Contains(string(postInputs), want) {
+			t.Errorf("generated inputs should contain %q", want)
+		}
+	}
+
+	userInputs, err := os.ReadFile(filepath.Join(outputDir, "inputs", "user_input.go"))
+	if err != nil {
+		t.Fatalf("Failed to read inputs file: %v", err)
+	}
+	if !strings.Contains(string(userInputs), "Posts PostUpdateNested `json:\"posts,omitempty\"`") {
+		t.Error("UserUpdateInput should accept nested Posts writes")
+	}
+
+	tagInputs, err := os.ReadFile(filepath.Join(outputDir, "inputs", "tag_input.go"))
+	if err != nil {
+		t.Fatalf("Failed to read inputs file: %v", err)
+	}
+	if strings.Contains(string(tagInputs), "Connect []") {
+		t.Error("Tag has no unique fields and should not accept Connect")
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "queries", "user_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	for _, want := range []string{
+		"func (q *UserQuery) connectPosts(ctx context.Context, db builder.DBTX, parent *models.User, targets []inputs.PostUniqueWhereInput) error {",
+		"where := ConvertPostUniqueWhereInputToWhere(target)",
+		`Where(where).Update(ctx, "author_id", parent.Id)`,
+		"if err := b.query.connectPosts(ctx, db, created, b.data.Posts.Connect); err != nil {",
+		"func (b *UserUpdateBuilder) execNested(ctx context.Context, where builder.Where, updateData map[string]interface{}) error {",
+		"if err := b.query.connectPosts(ctx, db, parent, b.data.Posts.Connect); err != nil {",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+	if strings.Contains(string(content), "connectTags") {
+		t.Error("Tag has no unique fields and should not be connectable")
+	}
+
+	postContent, err := os.ReadFile(filepath.Join(outputDir, "queries", "post_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	for _, want := range []string{
+		"func ConvertPostUniqueWhereInputToWhere(where inputs.PostUniqueWhereInput) builder.Where {",
+		`result["slug"] = *where.Slug`,
+	} {
+		if !strings.Contains(string(postContent), want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+}
+
+func TestGenerate_NestedDisconnectAndSet(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model User {
+  id    Int    @id @default(autoincrement())
+  posts Post[]
+  notes Note[]
+}
+
+model Post {
+  id       Int  @id @default(autoincrement())
+  authorId Int
+  author   User @relation(fields: [authorId], references: [id])
+}
+
+model Note {
+  id     Int   @id @default(autoincrement())
+  userId Int?  @map("user_id")
+  user   User? @relation(fields: [userId], references: [id])
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	noteInputs, err := os.ReadFile(filepath.Join(outputDir, "inputs", "note_input.go"))
+	if err != nil {
+		t.Fatalf("Failed to read inputs file: %v", err)
+	}
+	for _, want := range []string{
+		"Disconnect []NoteUniqueWhereInput `json:\"disconnect,omitempty\"`",
+		"Set        []NoteUniqueWhereInput `json:\"set,omitempty\"`",
+	} {
+		if !strings.Contains(string(noteInputs), want) {
+			t.Errorf("generated inputs should contain %q", want)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "queries", "user_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	for _, want := range []string{
+		// Optional FK: disconnect and set null the column
+		`where["user_id"] = parent.Id`,
+		`Where(where).Update(ctx, "user_id", nil)`,
+		`current := builder.Where{"user_id": parent.Id}`,
+		"return q.connectNotes(ctx, db, parent, targets)",
+		// Required FK: both are rejected
+		"cannot disconnect Posts because Post.Authorid is required",
+		"cannot set Posts because Post.Authorid is required",
+		// Update applies set and disconnect before create and connect
+		"if err := b.query.setNotes(ctx, db, parent, b.data.Notes.Set); err != nil {",
+		"if err := b.query.disconnectNotes(ctx, db, parent, b.data.Notes.Disconnect); err != nil {",
+		"b.data.Notes.Set != nil",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+	setIdx := strings.Index(string(content), "b.query.setNotes(")
+	createIdx := strings.Index(string(content), "b.query.createNotes(ctx, db, parent")
+	if setIdx < 0 || createIdx < 0 || setIdx > createIdx {
+		t.Error("set should run before nested creates")
+	}
+}
+
+func TestGetBelongsToRelations(t *testing.T) {
+	schema, errs, err := parser.Parse(`
+model User {
+  id    Int    @id @default(autoincrement()) @map("user_id")
+  posts Post[]
+}
+
+model Post {
+  id       Int   @id @default(autoincrement())
+  authorId Int?  @map("author_id")
+  author   User? @relation(fields: [authorId], references: [id])
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+
+	if relations := getBelongsToRelations(schema.Models[0], schema); len(relations) != 0 {
+		t.Errorf("list relations are not belongs-to, got %+v", relations)
+	}
+	relations := getBelongsToRelations(schema.Models[1], schema)
+	if len(relations) != 1 {
+		t.Fatalf("expected 1 relation, got %d", len(relations))
+	}
+	author := relations[0]
+	if author.FieldName != "Author" || author.JSONTag != "author" || author.ParentPascalName != "User" ||
+		author.ForeignKeyField != "Authorid" || !author.ForeignKeyOpt || author.ReferenceField != "Id" || author.ReferenceCol != "user_id" {
+		t.Errorf("unexpected author relation: %+v", author)
+	}
+}
+
+func TestGenerate_IncludeBelongsTo(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model User {
+  id    Int    @id @default(autoincrement()) @map("user_id")
+  posts Post[]
+}
+
+model Post {
+  id       Int    @id @default(autoincrement())
+  authorId Int
+  author   User   @relation(fields: [authorId], references: [id])
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateModels(schema, outputDir); err != nil {
+		t.Fatalf("GenerateModels failed: %v", err)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	read := func(parts ...string) string {
+		content, err := os.ReadFile(filepath.Join(append([]string{outputDir}, parts...)...))
+		if err != nil {
+			t.Fatalf("Failed to read generated file: %v", err)
+		}
+		return string(content)
+	}
+	checks := map[string][]string{
+		read("models", "post.go"): {
+			"Author *User `json:\"author,omitempty\" db:\"-\"`",
+		},
+		read("inputs", "post_input.go"): {
+			"type PostInclude struct {",
+			"Author bool `json:\"author,omitempty\"`",
+		},
+		read("queries", "post_query.go"): {
+			"func (b *PostFindManyBuilder) Include(include inputs.PostInclude) *PostFindManyBuilder {",
+			"func (b *PostFindFirstBuilder) Include(include inputs.PostInclude) *PostFindFirstBuilder {",
+			"err = loadPostIncludes(ctx, b.query.Query, results, *b.include)",
+			`where := builder.Where{"user_id": builder.In(chunk...)}`,
+			"byKey[related[i].Id] = &related[i]",
+			"records[i].Author = byKey[records[i].Authorid]",
+		},
+	}
+	for content, wants := range checks {
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("generated code should contain %q", want)
+			}
+		}
+	}
+
+	// User só tem relações de lista: carrega Posts, sem relações belongs-to
+	userContent := read("queries", "user_query.go")
+	if !strings.Contains(userContent, "func loadUserPosts(") || strings.Contains(userContent, "func loadUserAuthor(") {
+		t.Error("User should only load its Posts list relation")
+	}
+}
+
+// includeBatchTest runs inside the generated queries package and checks the queries
+// issued by the relation loaders
+const includeBatchTest = `package queries
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"test/db/builder"
+	"test/db/inputs"
+	"test/db/models"
+)
+
+type emptyRows struct{}
+
+func (emptyRows) Close()                         {}
+func (emptyRows) Err() error                     { return nil }
+func (emptyRows) Next() bool                     { return false }
+func (emptyRows) Scan(dest ...interface{}) error { return nil }
+
+type recordingDB struct {
+	builder.DB
+	sqls []string
+	args [][]interface{}
+}
+
+func (r *recordingDB) Query(ctx context.Context, sql string, args ...interface{}) (builder.Rows, error) {
+	r.sqls = append(r.sqls, sql)
+	r.args = append(r.args, args)
+	return emptyRows{}, nil
+}
+
+func newRecordingQuery(db *recordingDB) *builder.Query {
+	query := builder.NewQuery(db, "users", []string{"user_id"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	return query
+}
+
+func TestInclude_HasManyChunked(t *testing.T) {
+	db := &recordingDB{}
+	users := make([]models.User, 2500)
+	for i := range users {
+		users[i].Id = i + 1
+	}
+	if err := loadUserIncludes(context.Background(), newRecordingQuery(db), users, inputs.UserInclude{Posts: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(db.sqls) != 3 || len(db.args[0]) != 1000 || len(db.args[1]) != 1000 || len(db.args[2]) != 500 {
+		t.Fatalf("expected 3 chunked queries of 1000/1000/500 keys, got %d", len(db.sqls))
+	}
+	if !strings.Contains(db.sqls[0], ` + "`" + `FROM "posts" WHERE "author_id" IN (` + "`" + `) {
+		t.Errorf("unexpected child query: %s", db.sqls[0])
+	}
+}
+
+func TestInclude_BelongsToDistinctKeys(t *testing.T) {
+	db := &recordingDB{}
+	posts := []models.Post{{Authorid: 1}, {Authorid: 2}, {Authorid: 1}}
+	if err := loadPostIncludes(context.Background(), newRecordingQuery(db), posts, inputs.PostInclude{Author: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(db.sqls) != 1 || len(db.args[0]) != 2 {
+		t.Fatalf("expected one query with the 2 distinct keys, got %v", db.args)
+	}
+	if !strings.Contains(db.sqls[0], ` + "`" + `FROM "users" WHERE "user_id" IN (` + "`" + `) {
+		t.Errorf("unexpected parent query: %s", db.sqls[0])
+	}
+}
+
+func TestInclude_EmptyParentsSkipQuery(t *testing.T) {
+	db := &recordingDB{}
+	if err := loadUserIncludes(context.Background(), newRecordingQuery(db), nil, inputs.UserInclude{Posts: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(db.sqls) != 0 {
+		t.Errorf("no query should run without parents, got %v", db.sqls)
+	}
+}
+`
+
+// TestGenerate_IncludeBatchedQueries compiles the generated client and checks that Include
+// loads relations with chunked IN queries through @map column names
+func TestGenerate_IncludeBatchedQueries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "db")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model User {
+  id    Int    @id @default(autoincrement()) @map("user_id")
+  email String
+  posts Post[]
+  @@map("users")
+}
+
+model Post {
+  id       Int    @id @default(autoincrement())
+  authorId Int    @map("author_id")
+  author   User   @relation(fields: [authorId], references: [id])
+  @@map("posts")
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	for _, generate := range []func(*parser.Schema, string) error{
+		GenerateModels, GenerateBuilder, GenerateInputs, GenerateQueries, GenerateFilters,
+	} {
+		if err := generate(schema, outputDir); err != nil {
+			t.Fatalf("generation failed: %v", err)
+		}
+	}
+	if err := GenerateUtils(outputDir); err != nil {
+		t.Fatalf("GenerateUtils failed: %v", err)
+	}
+
+	testFile := filepath.Join(outputDir, "queries", "include_test.go")
+	if err := os.WriteFile(testFile, []byte(includeBatchTest), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	cmd := exec.Command(goBin, "test", "./db/queries/", "-run", "TestInclude_")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated include test failed: %v\n%s", err, output)
+	}
+}
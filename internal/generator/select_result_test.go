@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+const selectResultSchema = `
+model User {
+  id         Int      @id @default(autoincrement())
+  age        Int
+  nickname   String?
+  created_at DateTime @map("created_on")
+}
+`
+
+// selectResultTest runs inside the generated queries package and checks that only the
+// selected fields of a SelectResult are set
+const selectResultTest = `package queries
+
+import (
+	"testing"
+	"time"
+
+	"test/db/inputs"
+	"test/db/models"
+)
+
+func TestSelectResult_OnlySelectedFields(t *testing.T) {
+	nickname := "neo"
+	record := models.User{Id: 1, Age: 0, Nickname: &nickname, CreatedAt: time.Unix(0, 0)}
+
+	result := buildUserSelectResult(&record, selectedUserColumns(&inputs.UserSelect{Age: true, Nickname: true}, nil))
+	// idade zero selecionada continua distinguível de um campo não selecionado
+	if result.Age == nil || *result.Age != 0 {
+		t.Errorf("selected Age = %v, want pointer to 0", result.Age)
+	}
+	if result.Nickname == nil || *result.Nickname != "neo" {
+		t.Errorf("selected Nickname = %v, want neo", result.Nickname)
+	}
+	if result.Id != nil || result.CreatedAt != nil {
+		t.Errorf("unselected fields should be nil, got Id=%v CreatedAt=%v", result.Id, result.CreatedAt)
+	}
+
+	// sem Select todos os campos são preenchidos
+	all := buildUserSelectResult(&record, selectedUserColumns(nil, nil))
+	if all.Id == nil || all.Age == nil || all.Nickname == nil || all.CreatedAt == nil {
+		t.Errorf("without Select every field should be set, got %+v", all)
+	}
+
+	// SelectExcept remove as colunas, usando o nome mapeado
+	except := buildUserSelectResult(&record, selectedUserColumns(nil, []inputs.UserField{inputs.UserFieldCreatedAt}))
+	if except.CreatedAt != nil || except.Id == nil {
+		t.Errorf("SelectExcept should only drop CreatedAt, got %+v", except)
+	}
+}
+`
+
+// TestGenerate_SelectResult tests that a SelectResult with pointer fields and ExecSelect are generated
+func TestGenerate_SelectResult(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(selectResultSchema)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateModels(schema, outputDir); err != nil {
+		t.Fatalf("GenerateModels failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	model, err := os.ReadFile(filepath.Join(outputDir, "models", "user.go"))
+	if err != nil {
+		t.Fatalf("Failed to read model file: %v", err)
+	}
+	for _, want := range []string{
+		"type UserSelectResult struct {",
+		"Age *int `json:\"age,omitempty\" db:\"age\"`",
+		"Nickname *string `json:\"nickname,omitempty\" db:\"nickname\"`",
+		"CreatedAt *time.Time `json:\"created_at,omitempty\" db:\"created_on\"`",
+	} {
+		if !strings.Contains(string(model), want) {
+			t.Errorf("generated model should contain %q", want)
+		}
+	}
+
+	query, err := os.ReadFile(filepath.Join(outputDir, "queries", "user_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	for _, want := range []string{
+		"func (b *UserFindManyBuilder) ExecSelect() ([]models.UserSelectResult, error) {",
+		"func (b *UserFindFirstBuilder) ExecSelect() (*models.UserSelectResult, error) {",
+		"result.Age = &record.Age",
+		"result.Nickname = record.Nickname",
+	} {
+		if !strings.Contains(string(query), want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+}
+
+// TestGenerate_SelectResultOnlySelectedFields compiles the generated client and checks
+// which SelectResult fields are set
+func TestGenerate_SelectResultOnlySelectedFields(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "db")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(selectResultSchema)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	for _, generate := range []func(*parser.Schema, string) error{
+		GenerateModels, GenerateBuilder, GenerateInputs, GenerateQueries, GenerateFilters,
+	} {
+		if err := generate(schema, outputDir); err != nil {
+			t.Fatalf("generation failed: %v", err)
+		}
+	}
+	if err := GenerateUtils(outputDir); err != nil {
+		t.Fatalf("GenerateUtils failed: %v", err)
+	}
+
+	testFile := filepath.Join(outputDir, "queries", "select_result_test.go")
+	if err := os.WriteFile(testFile, []byte(selectResultTest), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	cmd := exec.Command(goBin, "test", "./db/queries/", "-run", "TestSelectResult_")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated select result test failed: %v\n%s", err, output)
+	}
+}
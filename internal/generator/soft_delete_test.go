@@ -0,0 +1,231 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+const softDeleteSchema = `
+model User {
+  id         Int       @id @default(autoincrement())
+  email      String
+  deleted_at DateTime?
+}
+
+model Post {
+  id         Int       @id @default(autoincrement())
+  title      String
+  removed_at DateTime? @map("removed_on")
+  tenant_id  String
+  slug       String
+
+  @@softDelete(removed_at)
+  @@unique([tenant_id, slug])
+}
+
+model Tag {
+  id   Int    @id @default(autoincrement())
+  name String
+}
+`
+
+// softDeleteGeneratedTest runs inside the generated queries package and checks the SQL
+// of the soft-deleted read and Delete builders
+const softDeleteGeneratedTest = `package queries
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"test/db/builder"
+	"test/db/filters"
+	"test/db/inputs"
+)
+
+type recordingDB struct {
+	builder.DB
+	sql  []string
+	args [][]interface{}
+}
+
+func (r *recordingDB) record(sql string, args []interface{}) {
+	r.sql = append(r.sql, sql)
+	r.args = append(r.args, args)
+}
+
+func (r *recordingDB) Query(ctx context.Context, sql string, args ...interface{}) (builder.Rows, error) {
+	r.record(sql, args)
+	return nil, errors.New("recorded")
+}
+
+func (r *recordingDB) QueryRow(ctx context.Context, sql string, args ...interface{}) builder.Row {
+	r.record(sql, args)
+	return errRow{}
+}
+
+func (r *recordingDB) Exec(ctx context.Context, sql string, args ...interface{}) (builder.Result, error) {
+	r.record(sql, args)
+	return nil, errors.New("recorded")
+}
+
+type errRow struct{}
+
+func (errRow) Scan(dest ...interface{}) error { return errors.New("recorded") }
+
+func newUsers(db *recordingDB) *UserQuery {
+	query := builder.NewQuery(db, "User", []string{"id", "email", "deleted_at"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	return &UserQuery{Query: query}
+}
+
+func TestSoftDelete_FindExcludesDeleted(t *testing.T) {
+	db := &recordingDB{}
+	users := newUsers(db)
+	ctx := context.Background()
+
+	users.FindMany().ExecWithContext(ctx)
+	users.FindFirst().ExecWithContext(ctx)
+	var dest []struct{ Email string }
+	users.FindMany().ExecTypedWithContext(ctx, &dest)
+	users.Exists().ExecWithContext(ctx)
+	users.Count().ExecWithContext(ctx)
+	users.CountAll(ctx)
+	users.GroupBy().By(inputs.UserFieldEmail).Count().ExecWithContext(ctx)
+	if len(db.sql) != 7 {
+		t.Fatalf("expected 7 reads, got %v", db.sql)
+	}
+	for i, sql := range db.sql {
+		if !strings.Contains(sql, "WHERE \"deleted_at\" IS NULL") {
+			t.Errorf("query %d should exclude deleted rows: %s", i, sql)
+		}
+	}
+
+	db.sql = nil
+	users.FindMany().WithDeleted().ExecWithContext(ctx)
+	users.FindFirst().WithDeleted().ExecWithContext(ctx)
+	users.Exists().WithDeleted().ExecWithContext(ctx)
+	users.Count().WithDeleted().ExecWithContext(ctx)
+	users.GroupBy().By(inputs.UserFieldEmail).Count().WithDeleted().ExecWithContext(ctx)
+	for i, sql := range db.sql {
+		if strings.Contains(sql, "deleted_at\" IS NULL") {
+			t.Errorf("WithDeleted query %d should include deleted rows: %s", i, sql)
+		}
+	}
+}
+
+func TestSoftDelete_DeleteSetsColumn(t *testing.T) {
+	db := &recordingDB{}
+	users := newUsers(db)
+	ctx := context.Background()
+
+	users.Delete().Where(inputs.UserWhereInput{}).ExecWithContext(ctx)
+	users.DeleteMany().ExecWithContext(ctx)
+	if len(db.sql) != 2 {
+		t.Fatalf("expected 2 statements, got %v", db.sql)
+	}
+	for i, sql := range db.sql {
+		if !strings.HasPrefix(sql, "UPDATE \"User\" SET \"deleted_at\" = $1 WHERE \"deleted_at\" IS NULL") {
+			t.Errorf("delete %d should set deleted_at: %s", i, sql)
+		}
+	}
+
+	if _, err := users.Delete().Where(inputs.UserWhereInput{}).Cascade().ExecWithContext(ctx); err == nil {
+		t.Error("Cascade should be rejected on a soft-deleted model")
+	}
+}
+
+func TestSoftDelete_UpsertTargetsPartialUnique(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "Post", []string{"id", "title", "removed_on", "tenant_id", "slug"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	posts := &PostQuery{Query: query}
+
+	title := "Updated"
+	posts.Upsert().
+		Where(inputs.PostWhereInput{TenantId: filters.String("t1"), Slug: filters.String("hello")}).
+		Create(inputs.PostCreateInput{Title: "Hello", TenantId: "t1", Slug: "hello"}).
+		Update(inputs.PostUpdateInput{Title: &title}).
+		ExecWithContext(context.Background())
+	if len(db.sql) != 1 {
+		t.Fatalf("expected 1 statement, got %v", db.sql)
+	}
+	// The unique on (tenant_id, slug) only covers live rows, so the conflict target carries its predicate
+	if !strings.Contains(db.sql[0], "ON CONFLICT (\"tenant_id\", \"slug\") WHERE removed_on IS NULL DO UPDATE SET") {
+		t.Errorf("upsert should target the partial unique index: %s", db.sql[0])
+	}
+}
+`
+
+// TestSoftDelete_Generated tests that soft delete is generated from a deleted_at column or @@softDelete
+func TestSoftDelete_Generated(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "db")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(softDeleteSchema)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+
+	// A coluna vem do @@softDelete (respeitando @map) ou da convenção deleted_at
+	want := map[string]string{"User": "deleted_at", "Post": "removed_on", "Tag": ""}
+	for _, model := range schema.Models {
+		if got := getSoftDeleteColumn(model); got != want[model.Name] {
+			t.Errorf("getSoftDeleteColumn(%s) = %q, want %q", model.Name, got, want[model.Name])
+		}
+	}
+
+	if err := GenerateModels(schema, outputDir); err != nil {
+		t.Fatalf("GenerateModels failed: %v", err)
+	}
+	if err := GenerateUtils(outputDir); err != nil {
+		t.Fatalf("GenerateUtils failed: %v", err)
+	}
+	if err := GenerateBuilder(schema, outputDir); err != nil {
+		t.Fatalf("GenerateBuilder failed: %v", err)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+	if err := GenerateFilters(schema, outputDir); err != nil {
+		t.Fatalf("GenerateFilters failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "queries", "tag_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read tag query file: %v", err)
+	}
+	if strings.Contains(string(content), "WithDeleted") || strings.Contains(string(content), "softDelete(") {
+		t.Error("models without a soft delete column should keep hard deletes")
+	}
+
+	if testing.Short() {
+		return
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+	testFile := filepath.Join(outputDir, "queries", "soft_delete_test.go")
+	if err := os.WriteFile(testFile, []byte(softDeleteGeneratedTest), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	cmd := exec.Command(goBin, "test", "./db/queries/", "-run", "TestSoftDelete_")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated soft delete test failed: %v\n%s", err, output)
+	}
+}
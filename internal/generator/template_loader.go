@@ -20,6 +20,7 @@ type FluentTemplateData struct {
 type DriverTemplateData struct {
 	Provider    string
 	BuilderPath string
+	SchemaSQL   string // Migration SQL creating the schema, used by NewTestClient (SQLite only)
 }
 
 // ModelInfo holds information about a model for template generation
@@ -45,18 +46,44 @@ type ClientTemplateData struct {
 
 // FieldInfo holds information about a model field for template generation
 type FieldInfo struct {
-	Name    string
-	GoType  string
-	JSONTag string
-	DBTag   string
+	Name       string
+	SchemaName string
+	GoType     string
+	JSONTag    string
+	DBTag      string
+	Numeric    bool   // Int, BigInt, Float or Decimal (aggregated by GroupBy)
+	ResultType string // Pointer type of the field in SelectResult (GoType if it is already a pointer)
 }
 
 // ModelTemplateData holds data for model file template generation
 type ModelTemplateData struct {
 	ModelName  string
 	PascalName string
+	TableName  string
+	PrimaryKey string
 	Imports    []string
 	Fields     []FieldInfo
+	CountField string              // Name of the _count field in GroupByResult (avoids clashing with a model field)
+	BelongsTo  []belongsToRelation // To-one relation fields filled by Include
+	HasMany    []oneToManyRelation // List relation fields filled by Include
+}
+
+// EnumsTemplateData holds data for models/enums.go template generation
+type EnumsTemplateData struct {
+	Enums []EnumInfo
+}
+
+// EnumInfo holds information about a schema enum
+type EnumInfo struct {
+	Name     string // Enum name in the schema
+	TypeName string // Go type name
+	Values   []EnumValueInfo
+}
+
+// EnumValueInfo holds information about an enum value
+type EnumValueInfo struct {
+	ConstName string // Go constant name (e.g. RoleAdmin)
+	Value     string // Value stored in the database (from @map, or the value name)
 }
 
 // HelpersTemplateData holds data for helpers.go template generation
@@ -80,26 +107,42 @@ type FieldFilterInfo struct {
 
 // QueryTemplateData holds data for query file template generation
 type QueryTemplateData struct {
-	ModelName         string
-	PascalName        string
-	StdlibImports     []string
-	ThirdPartyImports []string
-	BuilderPath       string
-	ModelsPath        string
-	InputsPath        string
-	Fields            []FieldFilterInfo
-	SelectFields      []SelectFieldInfo // Fields for Select operations
-	UpdateFields      []UpdateFieldInfo // Fields for Update operations
-	CreateFields      []CreateFieldInfo // Fields for Create operations
-	Columns           []string
-	PrimaryKey        string
-	TableName         string
+	ModelName          string
+	PascalName         string
+	StdlibImports      []string
+	ThirdPartyImports  []string
+	BuilderPath        string
+	ModelsPath         string
+	InputsPath         string
+	Fields             []FieldFilterInfo
+	SelectFields       []SelectFieldInfo // Fields for Select operations
+	UpdateFields       []UpdateFieldInfo // Fields for Update operations
+	CreateFields       []CreateFieldInfo // Fields for Create operations
+	Columns            []string
+	PrimaryKey         string
+	TableName          string
+	CascadeRelations   string              // []builder.CascadeRelation literal for DeleteBuilder.Cascade
+	OneToManyRelations []oneToManyRelation // List relations for nested writes
+	BelongsToRelations []belongsToRelation // To-one relations loaded by Include
+	UniqueConstraints  []UniqueConstraintInfo
+	SoftDeleteColumn   string               // Column set by Delete instead of removing the row; "" disables soft delete
+	CreatedTimestamps  []TimestampFieldInfo // @default(now()) and @updatedAt fields set on create when zero
+	UpdatedTimestamps  []TimestampFieldInfo // @updatedAt fields set on every update
+	HasEnums           bool                 // Whether the model has enum fields to validate on write
+}
+
+// TimestampFieldInfo holds a DateTime field set to the current time on write
+type TimestampFieldInfo struct {
+	FieldName  string // PascalCase field name
+	ColumnName string // Database column name (@map)
 }
 
 // SelectFieldInfo holds information about a field for Select operations
 type SelectFieldInfo struct {
 	FieldName  string // PascalCase field name
 	ColumnName string // Actual database column name
+	IsPointer  bool   // Model field is already a pointer
+	IsString   bool   // Scalar String field, orderable case-insensitively
 }
 
 // UpdateFieldInfo holds information about a field for Update operations
@@ -107,14 +150,19 @@ type UpdateFieldInfo struct {
 	FieldName   string // PascalCase field name
 	DBFieldName string // Actual database column name
 	IsPointer   bool   // Whether the field in the model is a pointer type
+	IsEnum      bool   // Whether the field is an enum, validated before the update
+	IsList      bool   // Whether the field is a list
 }
 
 // CreateFieldInfo holds information about a field for Create operations
 type CreateFieldInfo struct {
 	FieldName            string // PascalCase field name
+	ColumnName           string // Database column name
 	IsOptional           bool   // Whether field is optional (pointer)
-	IsRequired           bool   // Whether field is required (not optional and no default)
+	HasDefault           bool   // Whether a required field has a @default (pointer in CreateInput, not in the model)
 	IsNonPointerOptional bool   // Whether field doesn't use pointer in model even when optional (Json, Bytes)
+	IsEnum               bool   // Whether the field is an enum, validated before the insert
+	IsList               bool   // Whether the field is a list
 }
 
 // FiltersTemplateData holds data for filters.go template generation
@@ -132,27 +180,35 @@ type InputFieldInfo struct {
 
 // WhereInputFieldInfo holds information about a field for WhereInput
 type WhereInputFieldInfo struct {
-	FieldName  string // PascalCase field name
-	FilterType string // Filter type name (StringFilter, IntFilter, etc.)
-	JSONTag    string // JSON tag name
+	FieldName        string // PascalCase field name
+	FilterType       string // Filter type name (StringFilter, IntFilter, etc.)
+	FilterTypeArgs   string // Type arguments of a generic filter, e.g. [string] for ListFilter
+	FieldBuilderType string // Fluent field type name (StringField, IntField, etc.), empty if not supported
+	JSONTag          string // JSON tag name
 }
 
 // InputSelectFieldInfo holds information about a field for Select in input types
 type InputSelectFieldInfo struct {
-	FieldName string // PascalCase field name
-	JSONTag   string // JSON tag name
+	FieldName  string // PascalCase field name
+	JSONTag    string // JSON tag name
+	ColumnName string // Database column name (respects @map)
+	IsString   bool   // Scalar String field, orderable case-insensitively
 }
 
 // InputTemplateData holds data for model input file template generation
 type InputTemplateData struct {
-	ModelName        string
-	PascalName       string
-	StdlibImports    []string
-	FiltersPath      string
-	CreateFields     []InputFieldInfo
-	UpdateFields     []InputFieldInfo
-	WhereInputFields []WhereInputFieldInfo
-	SelectFields     []InputSelectFieldInfo
+	ModelName         string
+	PascalName        string
+	StdlibImports     []string
+	FiltersPath       string
+	ModelsPath        string // Set when enum fields use models types
+	CreateFields      []InputFieldInfo
+	UpdateFields      []InputFieldInfo
+	WhereInputFields  []WhereInputFieldInfo
+	SelectFields      []InputSelectFieldInfo
+	NestedCreates     []oneToManyRelation // One-to-many relations accepted as nested writes
+	BelongsTo         []belongsToRelation // To-one relations that can be included
+	UniqueConstraints []UniqueConstraintInfo
 }
 
 // InputHelpersTemplateData holds data for inputs/helpers.go template generation
@@ -169,12 +225,14 @@ type UniqueConstraintInfo struct {
 	IsComposite bool
 	FieldName   string
 	GoType      string
+	Where       string // Predicate of a partial unique index, used as the upsert conflict target's WHERE
 }
 
 type UniqueFieldData struct {
-	FieldName string
-	GoType    string
-	JSONTag   string
+	FieldName  string
+	GoType     string
+	JSONTag    string
+	ColumnName string // Database column name (respects @map)
 }
 
 // executeTemplates executes multiple templates and writes them to a file
@@ -0,0 +1,265 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+const transactionSchema = `
+model User {
+  id    Int    @id @default(autoincrement())
+  email String
+}
+`
+
+// clientTransactionTest runs inside the generated client package against a fake database
+// that records the statements sent to it and to its transactions
+const clientTransactionTest = `package generated
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"test/db/builder"
+	"test/db/inputs"
+)
+
+type recordingResult int64
+
+func (r recordingResult) RowsAffected() int64          { return int64(r) }
+func (r recordingResult) LastInsertId() (int64, error) { return 0, nil }
+
+type recordingTx struct {
+	db *recordingDB
+}
+
+func (t *recordingTx) Commit(ctx context.Context) error {
+	t.db.log = append(t.db.log, "COMMIT")
+	return nil
+}
+
+func (t *recordingTx) Rollback(ctx context.Context) error {
+	t.db.log = append(t.db.log, "ROLLBACK")
+	return nil
+}
+
+func (t *recordingTx) Exec(ctx context.Context, sql string, args ...interface{}) (builder.Result, error) {
+	t.db.log = append(t.db.log, "tx: "+strings.Fields(sql)[0])
+	return recordingResult(1), nil
+}
+
+func (t *recordingTx) Query(ctx context.Context, sql string, args ...interface{}) (builder.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (t *recordingTx) QueryRow(ctx context.Context, sql string, args ...interface{}) builder.Row {
+	return nil
+}
+
+type recordingDB struct {
+	builder.DB
+	log []string
+}
+
+func (d *recordingDB) Begin(ctx context.Context) (builder.Tx, error) {
+	d.log = append(d.log, "BEGIN")
+	return &recordingTx{db: d}, nil
+}
+
+func (d *recordingDB) Exec(ctx context.Context, sql string, args ...interface{}) (builder.Result, error) {
+	d.log = append(d.log, "db: "+strings.Fields(sql)[0])
+	return recordingResult(1), nil
+}
+
+func (d *recordingDB) Close() {
+	d.log = append(d.log, "CLOSE")
+}
+
+func deleteUsers(ctx context.Context, client *Client) error {
+	_, err := client.User.DeleteMany().Where(inputs.UserWhereInput{}).ExecWithContext(ctx)
+	return err
+}
+
+func TestClientTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := &recordingDB{}
+	client := NewClient(db)
+
+	err := client.Transaction(ctx, func(tx *Client) error {
+		if err := deleteUsers(ctx, tx); err != nil {
+			return err
+		}
+		// Transações aninhadas reutilizam a transação aberta
+		return tx.Transaction(ctx, func(nested *Client) error {
+			return deleteUsers(ctx, nested)
+		})
+	})
+	if err != nil || strings.Join(db.log, ", ") != "BEGIN, tx: DELETE, tx: DELETE, COMMIT" {
+		t.Errorf("commit log = %v, err = %v", db.log, err)
+	}
+
+	db.log = nil
+	failed := errors.New("failed")
+	if err := client.Transaction(ctx, func(tx *TransactionClient) error { return failed }); err != failed {
+		t.Errorf("Transaction error = %v, want %v", err, failed)
+	}
+	if strings.Join(db.log, ", ") != "BEGIN, ROLLBACK" {
+		t.Errorf("rollback log = %v", db.log)
+	}
+
+	db.log = nil
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Transaction should re-panic")
+			}
+		}()
+		client.Transaction(ctx, func(tx *Client) error { panic("boom") })
+	}()
+	if strings.Join(db.log, ", ") != "BEGIN, ROLLBACK" {
+		t.Errorf("panic log = %v", db.log)
+	}
+
+	// Fora da transação as queries usam o banco diretamente
+	db.log = nil
+	if err := deleteUsers(ctx, client); err != nil || strings.Join(db.log, ", ") != "db: DELETE" {
+		t.Errorf("client log = %v, err = %v", db.log, err)
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	db := &recordingDB{}
+	NewClient(db).Close()
+	if strings.Join(db.log, ", ") != "CLOSE" {
+		t.Errorf("Close should reach the database, got %v", db.log)
+	}
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	ctx := context.Background()
+	db := &recordingDB{}
+	replica := NewReadOnlyClient(db)
+
+	if _, err := replica.User.Create().Data(inputs.UserCreateInput{Email: "a@example.com"}).ExecWithContext(ctx); !errors.Is(err, builder.ErrReadOnly) {
+		t.Errorf("Create error = %v, want ErrReadOnly", err)
+	}
+	if err := deleteUsers(ctx, replica); !errors.Is(err, builder.ErrReadOnly) {
+		t.Errorf("DeleteMany error = %v, want ErrReadOnly", err)
+	}
+	if _, err := replica.Raw().Exec(ctx, "UPDATE users SET email = ''"); !errors.Is(err, builder.ErrReadOnly) {
+		t.Errorf("Raw Exec error = %v, want ErrReadOnly", err)
+	}
+	// Transações do client somente leitura continuam somente leitura
+	err := replica.Transaction(ctx, func(tx *Client) error { return deleteUsers(ctx, tx) })
+	if !errors.Is(err, builder.ErrReadOnly) {
+		t.Errorf("Transaction error = %v, want ErrReadOnly", err)
+	}
+	if strings.Join(db.log, ", ") != "BEGIN, ROLLBACK" {
+		t.Errorf("writes should not reach the database, got %v", db.log)
+	}
+}
+
+func TestClientRecordQueries(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(&recordingDB{})
+	if _, err := client.RecordQueries(0); !errors.Is(err, builder.ErrInvalidInput) {
+		t.Errorf("RecordQueries(0) error = %v, want ErrInvalidInput", err)
+	}
+	if recent := client.RecentQueries(); recent != nil {
+		t.Errorf("RecentQueries without recording = %v", recent)
+	}
+
+	debug, err := client.RecordQueries(2)
+	if err != nil {
+		t.Fatalf("RecordQueries failed: %v", err)
+	}
+	deleteUsers(ctx, debug)
+	debug.Raw().Exec(ctx, "UPDATE users SET email = ?", "x")
+	debug.Transaction(ctx, func(tx *Client) error {
+		if len(tx.RecentQueries()) != 2 {
+			t.Errorf("transaction client RecentQueries = %v", tx.RecentQueries())
+		}
+		return deleteUsers(ctx, tx)
+	})
+
+	// Só as duas últimas ficam, da mais antiga para a mais recente
+	recent := debug.RecentQueries()
+	if len(recent) != 2 || !strings.HasPrefix(recent[0].SQL, "UPDATE") || !strings.HasPrefix(recent[1].SQL, "DELETE") {
+		t.Fatalf("RecentQueries = %v", recent)
+	}
+	if len(recent[0].Args) != 1 || recent[0].Args[0] != "x" {
+		t.Errorf("recorded args = %v", recent[0].Args)
+	}
+}
+`
+
+// TestClient_Transaction compiles the generated client and checks that Transaction commits,
+// rolls back on error and panic, that nested calls reuse the open transaction, that
+// Close reaches the database, that
+// a NewReadOnlyClient refuses writes and that RecordQueries keeps the last statements
+func TestClient_Transaction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "db")
+	// The client imports the config and driver dependencies, so reuse this module's requirements
+	goMod, err := os.ReadFile(filepath.Join("..", "..", "go.mod"))
+	if err != nil {
+		t.Fatalf("Failed to read go.mod: %v", err)
+	}
+	goSum, err := os.ReadFile(filepath.Join("..", "..", "go.sum"))
+	if err != nil {
+		t.Fatalf("Failed to read go.sum: %v", err)
+	}
+	goMod = []byte(strings.Replace(string(goMod), "module github.com/carlosnayan/prisma-go-client", "module test", 1))
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), goMod, 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.sum"), goSum, 0644); err != nil {
+		t.Fatalf("Failed to create go.sum: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(transactionSchema)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	for name, generate := range map[string]func() error{
+		"GenerateModels":  func() error { return GenerateModels(schema, outputDir) },
+		"GenerateUtils":   func() error { return GenerateUtils(outputDir) },
+		"GenerateBuilder": func() error { return GenerateBuilder(schema, outputDir) },
+		"GenerateInputs":  func() error { return GenerateInputs(schema, outputDir) },
+		"GenerateQueries": func() error { return GenerateQueries(schema, outputDir) },
+		"GenerateFilters": func() error { return GenerateFilters(schema, outputDir) },
+		"GenerateRaw":     func() error { return GenerateRaw(outputDir) },
+		"GenerateClient":  func() error { return GenerateClient(schema, outputDir) },
+	} {
+		if err := generate(); err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+	}
+
+	testFile := filepath.Join(outputDir, "transaction_test.go")
+	if err := os.WriteFile(testFile, []byte(clientTransactionTest), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	cmd := exec.Command(goBin, "test", "./db/", "-run", "TestClientTransaction|TestClientClose|TestReadOnlyClient|TestClientRecordQueries")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated transaction test failed: %v\n%s", err, output)
+	}
+}
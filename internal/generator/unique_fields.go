@@ -1,6 +1,9 @@
 package generator
 
 import (
+	"strings"
+
+	"github.com/carlosnayan/prisma-go-client/internal/migrations"
 	"github.com/carlosnayan/prisma-go-client/internal/parser"
 )
 
@@ -9,6 +12,8 @@ type UniqueConstraint struct {
 	Name         string
 	IsComposite  bool
 	IsPrimaryKey bool
+	// Where is the predicate of a partial unique index (where: or the soft-delete predicate)
+	Where string
 }
 
 func getUniqueConstraints(model *parser.Model) []UniqueConstraint {
@@ -35,16 +40,9 @@ func getUniqueConstraints(model *parser.Model) []UniqueConstraint {
 			constraint := UniqueConstraint{
 				IsPrimaryKey: attr.Name == "id",
 			}
+			// Expression uniques such as @@unique([lower(email)]) can't be looked up by field
+			constraint.Fields = parser.IndexFieldNames(attr)
 			for _, arg := range attr.Arguments {
-				if arg.Name == "" || arg.Name == "fields" {
-					if fields, ok := arg.Value.([]interface{}); ok {
-						for _, f := range fields {
-							if fieldName, ok := f.(string); ok {
-								constraint.Fields = append(constraint.Fields, fieldName)
-							}
-						}
-					}
-				}
 				if arg.Name == "map" {
 					if name, ok := arg.Value.(string); ok {
 						constraint.Name = name
@@ -53,6 +51,9 @@ func getUniqueConstraints(model *parser.Model) []UniqueConstraint {
 			}
 			if len(constraint.Fields) > 0 {
 				constraint.IsComposite = len(constraint.Fields) > 1
+				if attr.Name == "unique" {
+					constraint.Where = migrations.UniqueIndexPredicate(model, attr)
+				}
 				constraints = append(constraints, constraint)
 			}
 		}
@@ -61,6 +62,55 @@ func getUniqueConstraints(model *parser.Model) []UniqueConstraint {
 	return constraints
 }
 
+// getUniqueConstraintInfos returns the template data for a model's UniqueWhereInput.
+// Each unique field or composite key appears once.
+func getUniqueConstraintInfos(model *parser.Model) []UniqueConstraintInfo {
+	fieldsByName := make(map[string]*parser.ModelField, len(model.Fields))
+	for _, field := range model.Fields {
+		fieldsByName[field.Name] = field
+	}
+
+	var infos []UniqueConstraintInfo
+	seen := make(map[string]bool)
+	for _, constraint := range getUniqueConstraints(model) {
+		key := strings.Join(constraint.Fields, ",")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		info := UniqueConstraintInfo{IsComposite: constraint.IsComposite, Where: constraint.Where}
+		var structName, jsonTags []string
+		for _, name := range constraint.Fields {
+			field, ok := fieldsByName[name]
+			if !ok {
+				continue
+			}
+			info.Fields = append(info.Fields, UniqueFieldData{
+				FieldName:  toPascalCase(name),
+				GoType:     fieldTypeToGoBase(field.Type),
+				JSONTag:    toSnakeCase(name),
+				ColumnName: getFieldColumnName(model, name),
+			})
+			structName = append(structName, toPascalCase(name))
+			jsonTags = append(jsonTags, toSnakeCase(name))
+		}
+		if len(info.Fields) != len(constraint.Fields) {
+			continue
+		}
+		if info.IsComposite {
+			info.StructName = strings.Join(structName, "")
+			info.JSONTag = strings.Join(jsonTags, "_")
+		} else {
+			info.FieldName = info.Fields[0].FieldName
+			info.GoType = info.Fields[0].GoType
+			info.JSONTag = info.Fields[0].JSONTag
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
 func matchesUniqueConstraint(whereFields []string, constraints []UniqueConstraint) *UniqueConstraint {
 	for i := range constraints {
 		if slicesEqual(whereFields, constraints[i].Fields) {
@@ -0,0 +1,605 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+const whereBuilderSchema = `
+enum Role {
+  ADMIN
+  USER
+}
+
+model User {
+  id     Int     @id @default(autoincrement())
+  email  String  @unique
+  age    Int
+  active Boolean
+  meta   Json?
+  score  Int     @default(10)
+  created DateTime @default(now()) @map("created_at")
+  updated DateTime @updatedAt @map("updated_at")
+}
+
+model Post {
+  id   Int      @id @default(autoincrement())
+  tags String[]
+  role Role     @default(USER)
+}
+`
+
+// whereBuilderEquivalenceTest runs inside the generated queries package and checks that
+// the fluent builder and the equivalent WhereInput struct produce the same SQL and args
+const whereBuilderEquivalenceTest = `package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"test/db/builder"
+	"test/db/filters"
+	"test/db/inputs"
+	"test/db/models"
+)
+
+type recordingDB struct {
+	builder.DB
+	sql  string
+	args []interface{}
+}
+
+func (r *recordingDB) Query(ctx context.Context, sql string, args ...interface{}) (builder.Rows, error) {
+	r.sql, r.args = sql, args
+	return nil, errors.New("recorded")
+}
+
+func (r *recordingDB) Exec(ctx context.Context, sql string, args ...interface{}) (builder.Result, error) {
+	r.sql, r.args = sql, args
+	return nil, errors.New("recorded")
+}
+
+type recordedRow struct{}
+
+func (recordedRow) Scan(dest ...interface{}) error { return errors.New("recorded") }
+
+func (r *recordingDB) QueryRow(ctx context.Context, sql string, args ...interface{}) builder.Row {
+	r.sql, r.args = sql, args
+	return recordedRow{}
+}
+
+func findManySQL(where inputs.UserWhereInput) (string, []interface{}) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	query.SetPrimaryKey("id")
+	(&UserQuery{Query: query}).FindMany().Where(where).ExecWithContext(context.Background())
+	return db.sql, db.args
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestWhereBuilder_SameSQLAsStruct(t *testing.T) {
+	tests := []struct {
+		name   string
+		fluent inputs.UserWhereInput
+		input  inputs.UserWhereInput
+	}{
+		{
+			name:   "and",
+			fluent: inputs.UserWhere().Email().Equals("x").And().Age().Gt(18).Build(),
+			input:  inputs.UserWhereInput{Email: &filters.StringFilter{Equals: ptr("x")}, Age: &filters.IntFilter{Gt: ptr(18)}},
+		},
+		{
+			name:   "same field",
+			fluent: inputs.UserWhere().Age().Gte(18).And().Age().Lt(65).Build(),
+			input:  inputs.UserWhereInput{Age: &filters.IntFilter{Gte: ptr(18), Lt: ptr(65)}},
+		},
+		{
+			name:   "between",
+			fluent: inputs.UserWhere().Age().Between(18, 65).Build(),
+			input:  inputs.UserWhereInput{Age: &filters.IntFilter{Between: &[2]int{18, 65}}},
+		},
+		{
+			name:   "or",
+			fluent: inputs.UserWhere().Email().Contains("a").Or().Age().In(1, 2).And().Active().IsNull().Build(),
+			input: inputs.UserWhereInput{Or: []inputs.UserWhereInput{
+				{Email: &filters.StringFilter{Contains: ptr("a")}},
+				{Age: &filters.IntFilter{In: []int{1, 2}}, Active: &filters.BooleanFilter{IsNull: ptr(true)}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fluentSQL, fluentArgs := findManySQL(tt.fluent)
+			inputSQL, inputArgs := findManySQL(tt.input)
+			if fluentSQL == "" || fluentSQL != inputSQL {
+				t.Errorf("fluent SQL =\n%s\nwant\n%s", fluentSQL, inputSQL)
+			}
+			if fmt.Sprint(fluentArgs) != fmt.Sprint(inputArgs) {
+				t.Errorf("fluent args = %v, want %v", fluentArgs, inputArgs)
+			}
+			if !reflect.DeepEqual(tt.fluent, tt.input) {
+				t.Errorf("fluent input = %+v, want %+v", tt.fluent, tt.input)
+			}
+		})
+	}
+}
+
+func TestWhereRaw_ComposesWithWhereInput(t *testing.T) {
+	where := inputs.UserWhereInput{
+		Age: &filters.IntFilter{Gt: ptr(18)},
+		Or:  []inputs.UserWhereInput{{Email: &filters.StringFilter{Equals: ptr("a")}}, {Email: &filters.StringFilter{Equals: ptr("b")}}},
+	}
+	want := "WHERE ((\"email\" = $1) OR (\"email\" = $2)) AND \"age\" > $3 AND (lower(email) LIKE $4 OR age = $5)"
+
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+	users.FindMany().Where(where).WhereRaw("lower(email) LIKE ? OR age = ?", "%x", 99).ExecWithContext(context.Background())
+	if !strings.Contains(db.sql, want) {
+		t.Errorf("Exec SQL =\n%s\nwant it to contain\n%s", db.sql, want)
+	}
+	if fmt.Sprint(db.args) != "[a b 18 %x 99]" {
+		t.Errorf("Exec args = %v", db.args)
+	}
+
+	db.sql, db.args = "", nil
+	var dest []struct{ Email string }
+	users.FindMany().Where(inputs.UserWhereInput{Age: &filters.IntFilter{Gt: ptr(18)}}).WhereRaw("age % 2 = ?", 0).ExecTypedWithContext(context.Background(), &dest)
+	if !strings.Contains(db.sql, "WHERE \"age\" > $1 AND (age % 2 = $2)") {
+		t.Errorf("ExecTyped SQL = %s", db.sql)
+	}
+}
+
+func TestJsonPath_Converter(t *testing.T) {
+	sql, args := findManySQL(inputs.UserWhereInput{Meta: filters.JsonPathEquals([]string{"address", "city"}, "Lisbon")})
+	if !strings.Contains(sql, "WHERE (\"meta\" #>> '{\"address\",\"city\"}') = $1") || fmt.Sprint(args) != "[Lisbon]" {
+		t.Errorf("JsonPathEquals SQL = %s args = %v", sql, args)
+	}
+	sql, args = findManySQL(inputs.UserWhereInput{Meta: filters.JsonPathContains([]string{"city"}, "50%")})
+	if !strings.Contains(sql, "WHERE (\"meta\"->>'city') LIKE $1 ESCAPE '\\'") || fmt.Sprint(args) != "[%50\\%%]" {
+		t.Errorf("JsonPathContains SQL = %s args = %v", sql, args)
+	}
+}
+
+func TestList_Converter(t *testing.T) {
+	tests := []struct {
+		filter *filters.ListFilter[string]
+		where  string
+		args   string
+	}{
+		{&filters.ListFilter[string]{Equals: []string{"go", "sql"}}, "WHERE \"tags\" = $1", "[[go sql]]"},
+		{&filters.ListFilter[string]{Has: ptr("go")}, "WHERE \"tags\" @> ARRAY[$1]", "[go]"},
+		{&filters.ListFilter[string]{HasEvery: []string{"go", "sql"}}, "WHERE \"tags\" @> ARRAY[$1, $2]", "[go sql]"},
+		{&filters.ListFilter[string]{HasSome: []string{"go", "sql"}}, "WHERE \"tags\" && ARRAY[$1, $2]", "[go sql]"},
+		{&filters.ListFilter[string]{IsEmpty: ptr(true)}, "WHERE COALESCE(array_length(\"tags\", 1), 0) = 0", "[]"},
+		{&filters.ListFilter[string]{IsEmpty: ptr(false)}, "WHERE NOT (COALESCE(array_length(\"tags\", 1), 0) = 0)", "[]"},
+	}
+	for _, tt := range tests {
+		db := &recordingDB{}
+		query := builder.NewQuery(db, "Post", []string{"id", "tags", "role"})
+		query.SetDialect(builder.GetDialect("postgresql"))
+		(&PostQuery{Query: query}).FindMany().Where(inputs.PostWhereInput{Tags: tt.filter}).ExecWithContext(context.Background())
+		sql, args := db.sql, db.args
+		if !strings.HasSuffix(sql, tt.where) || fmt.Sprint(args) != tt.args {
+			t.Errorf("ListFilter SQL = %s args = %v, want %s %s", sql, args, tt.where, tt.args)
+		}
+	}
+}
+
+func TestCreate_Enum(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "Post", []string{"id", "tags", "role"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	posts := &PostQuery{Query: query}
+
+	// O enum é um tipo string dos models, aceito pelos inputs e pelo model; a lista não é obrigatória
+	role := models.RoleAdmin
+	posts.Create().Data(inputs.PostCreateInput{Role: &role}).ExecWithContext(context.Background())
+	if !strings.HasPrefix(db.sql, "INSERT INTO \"Post\" (\"tags\", \"role\") VALUES ($1, $2)") || fmt.Sprint(db.args) != "[[] ADMIN]" {
+		t.Errorf("Create with role SQL = %s args = %v", db.sql, db.args)
+	}
+	post := models.Post{Role: models.RoleUser, Tags: []string{"go"}}
+	if string(post.Role) != "USER" {
+		t.Errorf("RoleUser = %q", post.Role)
+	}
+
+	// Valores fora do enum são rejeitados antes de qualquer SQL
+	db.sql = ""
+	bogus := models.Role("BOGUS")
+	if _, err := posts.Create().Data(inputs.PostCreateInput{Role: &bogus}).ExecWithContext(context.Background()); !errors.Is(err, builder.ErrInvalidInput) {
+		t.Errorf("Create with invalid role err = %v", err)
+	}
+	if _, err := posts.Update().Where(inputs.PostWhereInput{}).Data(inputs.PostUpdateInput{Role: &bogus}).ExecWithContext(context.Background()); !errors.Is(err, builder.ErrInvalidInput) {
+		t.Errorf("Update with invalid role err = %v", err)
+	}
+	if db.sql != "" {
+		t.Errorf("invalid role ran SQL: %s", db.sql)
+	}
+	if !models.RoleAdmin.IsValid() || bogus.IsValid() || len(models.RoleValues()) != 2 {
+		t.Errorf("IsValid/RoleValues = %v", models.RoleValues())
+	}
+}
+
+func TestBetween_Converter(t *testing.T) {
+	for _, age := range []*filters.IntFilter{filters.IntBetween(18, 65), {Gte: ptr(18), Lte: ptr(65)}} {
+		sql, args := findManySQL(inputs.UserWhereInput{Age: age})
+		if !strings.HasSuffix(sql, "WHERE \"age\" BETWEEN $1 AND $2") || fmt.Sprint(args) != "[18 65]" {
+			t.Errorf("Between SQL = %s args = %v", sql, args)
+		}
+	}
+}
+
+func TestContains_Escaped(t *testing.T) {
+	sql, args := findManySQL(inputs.UserWhereInput{Email: filters.Contains("50%")})
+	if !strings.HasSuffix(sql, "WHERE \"email\" LIKE $1 ESCAPE '\\'") || fmt.Sprint(args) != "[%50\\%%]" {
+		t.Errorf("Contains SQL = %s args = %v", sql, args)
+	}
+}
+
+func TestNotLike_Converter(t *testing.T) {
+	sql, args := findManySQL(inputs.UserWhereInput{Email: filters.NotEndsWith("_test")})
+	if !strings.HasSuffix(sql, "WHERE \"email\" NOT LIKE $1 ESCAPE '\\'") || fmt.Sprint(args) != "[%\\_test]" {
+		t.Errorf("NotEndsWith SQL = %s args = %v", sql, args)
+	}
+
+	sql, args = findManySQL(inputs.UserWhereInput{Email: filters.NotContainsInsensitive("Admin")})
+	if !strings.HasSuffix(sql, "WHERE \"email\" NOT ILIKE $1 ESCAPE '\\'") || fmt.Sprint(args) != "[%Admin%]" {
+		t.Errorf("NotContainsInsensitive SQL = %s args = %v", sql, args)
+	}
+}
+
+func TestExists_Builder(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+
+	users.Exists().Where(inputs.UserWhereInput{Email: filters.String("a@example.com")}).ExecWithContext(context.Background())
+	if db.sql != "SELECT EXISTS(SELECT 1 FROM \"users\" WHERE \"email\" = $1 LIMIT $2)" || fmt.Sprint(db.args) != "[a@example.com 1]" {
+		t.Errorf("Exists SQL = %s args = %v", db.sql, db.args)
+	}
+}
+
+func TestExists_CountDistinct(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+
+	users.Count().Distinct(inputs.UserFieldAge).Where(inputs.UserWhereInput{Active: filters.Bool(true)}).ExecWithContext(context.Background())
+	if db.sql != "SELECT COUNT(DISTINCT \"age\") FROM \"users\" WHERE \"active\" = $1" || fmt.Sprint(db.args) != "[true]" {
+		t.Errorf("Count Distinct SQL = %s args = %v", db.sql, db.args)
+	}
+}
+
+func TestCreate_ExplicitZero(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta", "score"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+
+	// Score nil fica com o default do banco; Score 0 explícito é inserido
+	users.Create().Data(inputs.UserCreateInput{Email: "a@example.com", Age: 30, Active: true}).ExecWithContext(context.Background())
+	if !strings.HasPrefix(db.sql, "INSERT INTO \"User\" (\"email\", \"age\", \"active\", \"created_at\", \"updated_at\") VALUES ($1, $2, $3, $4, $5)") {
+		t.Errorf("Create without score SQL = %s", db.sql)
+	}
+	users.Create().Data(inputs.UserCreateInput{Email: "a@example.com", Age: 30, Active: true, Score: inputs.Int(0)}).ExecWithContext(context.Background())
+	if !strings.HasPrefix(db.sql, "INSERT INTO \"User\" (\"email\", \"age\", \"active\", \"score\", \"created_at\", \"updated_at\") VALUES ($1, $2, $3, $4, $5, $6)") || fmt.Sprint(db.args[:4]) != "[a@example.com 30 true 0]" {
+		t.Errorf("Create with zero score SQL = %s args = %v", db.sql, db.args)
+	}
+
+	// Campos obrigatórios com valor zero são inseridos, não rejeitados como ausentes
+	db.sql = ""
+	users.Create().Data(inputs.UserCreateInput{Email: "a@example.com", Age: 0, Active: false}).ExecWithContext(context.Background())
+	if !strings.HasPrefix(db.sql, "INSERT INTO \"User\" (\"email\", \"age\", \"active\",") || fmt.Sprint(db.args[:3]) != "[a@example.com 0 false]" {
+		t.Errorf("Create with zero required fields SQL = %s args = %v", db.sql, db.args)
+	}
+	db.sql = ""
+	users.CreateMany().Data([]inputs.UserCreateInput{{Email: "b@example.com"}}).ExecWithContext(context.Background())
+	if !strings.HasPrefix(db.sql, "INSERT INTO \"User\" (\"email\", \"age\", \"active\",") {
+		t.Errorf("CreateMany with zero required fields SQL = %s", db.sql)
+	}
+}
+
+func TestCreate_ManyMixedDefaults(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta", "score"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+
+	// Score 0 explícito é inserido; o registro sem Score fica com o default do banco
+	users.CreateMany().Data([]inputs.UserCreateInput{
+		{Email: "a@example.com", Age: 30, Active: true, Score: inputs.Int(0)},
+		{Email: "b@example.com", Age: 31, Active: true},
+	}).ExecWithContext(context.Background())
+	if !strings.HasPrefix(db.sql, "INSERT INTO \"User\" (\"email\", \"age\", \"active\", \"score\", \"created_at\", \"updated_at\") VALUES ($1, $2, $3, $4, $5, $6), ($7, $8, $9, DEFAULT, $10, $11)") || fmt.Sprint(db.args[:4]) != "[a@example.com 30 true 0]" {
+		t.Errorf("CreateMany SQL = %s args = %v", db.sql, db.args)
+	}
+}
+
+type statementHook struct{ statements *[]string }
+
+func (h statementHook) Before(ctx context.Context, sql string, args []interface{}) context.Context {
+	*h.statements = append(*h.statements, "before "+strings.Fields(sql)[0])
+	return ctx
+}
+
+func (h statementHook) After(ctx context.Context, sql string, err error, duration time.Duration) {
+	*h.statements = append(*h.statements, "after "+strings.Fields(sql)[0])
+}
+
+func TestCreate_Hooks(t *testing.T) {
+	var statements []string
+	query := builder.NewQuery(&recordingDB{}, "users", []string{"id", "email", "age", "active", "meta", "score"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	query.AddHook(statementHook{statements: &statements})
+	users := &UserQuery{Query: query}
+
+	// Create, CreateMany e DeleteMany passam pelo TableQueryBuilder, que chama os hooks da query
+	users.Create().Data(inputs.UserCreateInput{Email: "a@example.com", Age: 30, Active: true}).ExecWithContext(context.Background())
+	users.CreateMany().Data([]inputs.UserCreateInput{{Email: "b@example.com", Age: 31, Active: true}}).ExecWithContext(context.Background())
+	users.DeleteMany().Where(inputs.UserWhereInput{Email: filters.Contains("a@")}).ExecWithContext(context.Background())
+	if got := strings.Join(statements, ","); got != "before INSERT,after INSERT,before INSERT,after INSERT,before DELETE,after DELETE" {
+		t.Errorf("hooked statements = %s", got)
+	}
+}
+
+func TestCreate_Timestamps(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta", "score", "created_at", "updated_at"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+
+	// created e updated zerados recebem o horário atual
+	before := time.Now()
+	users.Create().Data(inputs.UserCreateInput{Email: "a@example.com", Age: 30, Active: true}).ExecWithContext(context.Background())
+	if len(db.args) != 5 {
+		t.Fatalf("Create args = %v", db.args)
+	}
+	for _, arg := range db.args[3:] {
+		if ts, ok := arg.(time.Time); !ok || ts.Before(before) {
+			t.Errorf("Create timestamp arg = %v", arg)
+		}
+	}
+
+	// Um valor explícito é mantido
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	users.Create().Data(inputs.UserCreateInput{Email: "a@example.com", Age: 30, Active: true, Created: &createdAt}).ExecWithContext(context.Background())
+	if len(db.args) != 5 || db.args[3] != createdAt {
+		t.Errorf("Create with createdAt args = %v", db.args)
+	}
+}
+
+func TestUpdate_Timestamps(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta", "score", "created_at", "updated_at"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+
+	// updated_at é sempre atualizado, created_at não
+	where := inputs.UserWhereInput{Id: &filters.IntFilter{Equals: ptr(1)}}
+	users.Update().Where(where).Data(inputs.UserUpdateInput{Age: inputs.Int(31)}).ExecWithContext(context.Background())
+	if !strings.Contains(db.sql, "\"updated_at\" = ") || strings.Contains(db.sql, "created_at") {
+		t.Errorf("Update SQL = %s", db.sql)
+	}
+
+	users.UpdateMany().Where(where).Data(inputs.UserUpdateInput{Age: inputs.Int(31)}).ExecWithContext(context.Background())
+	if !strings.Contains(db.sql, "\"updated_at\" = ") || strings.Contains(db.sql, "created_at") {
+		t.Errorf("UpdateMany SQL = %s", db.sql)
+	}
+}
+
+func TestFindUnique_Builder(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+
+	email := "a@example.com"
+	users.FindUnique().Where(inputs.UserWhereUniqueInput{Email: &email}).ExecWithContext(context.Background())
+	if !strings.HasSuffix(db.sql, "WHERE \"email\" = $1 LIMIT $2") || fmt.Sprint(db.args) != "[a@example.com 1]" {
+		t.Errorf("FindUnique SQL = %s args = %v", db.sql, db.args)
+	}
+
+	// Sem campo único a busca não roda
+	db.sql = ""
+	if _, err := users.FindUnique().Where(inputs.UserWhereUniqueInput{}).Exec(); !errors.Is(err, builder.ErrInvalidInput) || db.sql != "" {
+		t.Errorf("FindUnique without a unique field = %v (ran %q)", err, db.sql)
+	}
+}
+
+func TestQueryAccessor_NoConditionLeak(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+	ctx := context.Background()
+
+	users.FindMany().Where(inputs.UserWhereInput{Age: &filters.IntFilter{Gt: ptr(18)}}).ExecWithContext(ctx)
+	users.FindMany().Where(inputs.UserWhereInput{Email: &filters.StringFilter{Equals: ptr("x")}}).ExecWithContext(ctx)
+	if !strings.HasSuffix(db.sql, "WHERE \"email\" = $1") || fmt.Sprint(db.args) != "[x]" {
+		t.Errorf("second FindMany SQL = %s args = %v", db.sql, db.args)
+	}
+
+	// Count também começa de uma query limpa
+	users.Count().Where(inputs.UserWhereInput{Age: &filters.IntFilter{Gt: ptr(18)}}).ExecWithContext(ctx)
+	users.Count().ExecWithContext(ctx)
+	if db.sql != "SELECT COUNT(*) FROM \"users\"" || len(db.args) != 0 {
+		t.Errorf("second Count SQL = %s args = %v", db.sql, db.args)
+	}
+}
+
+// txRecordingDB abre uma transação cujas queries ficam gravadas em tx
+type txRecordingDB struct {
+	builder.DB
+	tx *recordingDB
+}
+
+func (d *txRecordingDB) Begin(ctx context.Context) (builder.Tx, error) {
+	return recordingTx{rec: d.tx}, nil
+}
+
+type recordingTx struct {
+	builder.Tx
+	rec *recordingDB
+}
+
+func (t recordingTx) QueryRow(ctx context.Context, sql string, args ...interface{}) builder.Row {
+	return t.rec.QueryRow(ctx, sql, args...)
+}
+
+func TestQueryAccessor_WithTx(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+	ctx := context.Background()
+
+	txDB := &txRecordingDB{tx: &recordingDB{}}
+	tx, err := builder.BeginTransaction(ctx, txDB)
+	if err != nil {
+		t.Fatalf("BeginTransaction failed: %v", err)
+	}
+	users.WithTx(tx).Count().Where(inputs.UserWhereInput{Age: &filters.IntFilter{Gt: ptr(18)}}).ExecWithContext(ctx)
+	if txDB.tx.sql != "SELECT COUNT(*) FROM \"users\" WHERE \"age\" > $1" || db.sql != "" {
+		t.Errorf("WithTx ran %q on the transaction and %q on the client", txDB.tx.sql, db.sql)
+	}
+}
+
+func TestFromSubquery_TypedFilters(t *testing.T) {
+	db := &recordingDB{}
+	query := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	query.SetDialect(builder.GetDialect("postgresql"))
+	users := &UserQuery{Query: query}
+
+	sub := builder.NewQuery(db, "users", []string{"id", "email", "age", "active", "meta"})
+	sub.SetDialect(builder.GetDialect("postgresql"))
+	sub.Where("active = ?", true)
+	users.FromSubquery(sub, "u").FindMany().Where(inputs.UserWhereInput{Age: &filters.IntFilter{Gt: ptr(18)}}).ExecWithContext(context.Background())
+
+	want := "FROM (SELECT \"id\", \"email\", \"age\", \"active\", \"meta\" FROM \"users\" WHERE active = $1) AS \"u\" WHERE \"age\" > $2"
+	if !strings.Contains(db.sql, want) {
+		t.Errorf("FromSubquery SQL =\n%s\nwant it to contain\n%s", db.sql, want)
+	}
+	if fmt.Sprint(db.args) != "[true 18]" {
+		t.Errorf("FromSubquery args = %v", db.args)
+	}
+
+	// A base query continua lendo a tabela
+	users.FindMany().ExecWithContext(context.Background())
+	if strings.Contains(db.sql, "AS \"u\"") {
+		t.Errorf("FromSubquery changed the base query: %s", db.sql)
+	}
+
+	if _, err := users.FromSubquery(sub, "u").UpdateMany().Where(inputs.UserWhereInput{}).Data(inputs.UserUpdateInput{}).Exec(); !errors.Is(err, builder.ErrInvalidInput) {
+		t.Errorf("UpdateMany through a subquery = %v, want ErrInvalidInput", err)
+	}
+}
+`
+
+// TestWhereBuilder_Generated tests that a fluent where builder is generated for scalar fields only
+func TestWhereBuilder_Generated(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(whereBuilderSchema)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "inputs", "user_input.go"))
+	if err != nil {
+		t.Fatalf("Failed to read input file: %v", err)
+	}
+
+	for _, want := range []string{
+		"func UserWhere() *UserWhereBuilder {",
+		"func (b *UserWhereBuilder) Email() filters.StringField[*UserWhereBuilder] {",
+		"func (b *UserWhereBuilder) Age() filters.IntField[*UserWhereBuilder] {",
+		"func (b *UserWhereBuilder) Active() filters.BooleanField[*UserWhereBuilder] {",
+		"func (b *UserWhereBuilder) Or() *UserWhereBuilder {",
+		"func (b *UserWhereBuilder) Build() UserWhereInput {",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generated inputs should contain %q", want)
+		}
+	}
+	if strings.Contains(string(content), "func (b *UserWhereBuilder) Meta()") {
+		t.Error("Json fields should not get a fluent condition")
+	}
+}
+
+// TestWhereBuilder_SameSQLAsStruct compiles the generated client and checks that the fluent
+// builder produces the same SQL as the WhereInput struct, that WhereRaw is ANDed with it
+// that JsonFilter.Path and Between are converted to their conditions, that FromSubquery reads from
+// the aliased subquery and that conditions don't leak between Execs on the same accessor
+func TestWhereBuilder_SameSQLAsStruct(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "db")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(whereBuilderSchema)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateModels(schema, outputDir); err != nil {
+		t.Fatalf("GenerateModels failed: %v", err)
+	}
+	if err := GenerateUtils(outputDir); err != nil {
+		t.Fatalf("GenerateUtils failed: %v", err)
+	}
+	if err := GenerateBuilder(schema, outputDir); err != nil {
+		t.Fatalf("GenerateBuilder failed: %v", err)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+	if err := GenerateFilters(schema, outputDir); err != nil {
+		t.Fatalf("GenerateFilters failed: %v", err)
+	}
+
+	testFile := filepath.Join(outputDir, "queries", "where_builder_test.go")
+	if err := os.WriteFile(testFile, []byte(whereBuilderEquivalenceTest), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	cmd := exec.Command(goBin, "test", "./db/queries/", "-run", "TestWhereBuilder_SameSQLAsStruct|TestWhereRaw_|TestJsonPath_|TestFromSubquery_|TestQueryAccessor_|TestBetween_|TestNotLike_|TestContains_|TestExists_|TestFindUnique_|TestCreate_|TestUpdate_|TestList_")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated equivalence test failed: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+// generateUserQuery generates the queries of a single User model and returns user_query.go
+func generateUserQuery(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model User {
+  id     Int    @id @default(autoincrement())
+  status String
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "queries", "user_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	return string(content)
+}
+
+// TestWhereConverter_NotGroup tests that Not is converted to a NOT (...) group instead of being merged flat
+func TestWhereConverter_NotGroup(t *testing.T) {
+	content := generateUserQuery(t)
+
+	for _, want := range []string{
+		`result["$not"] = builder.Not(notMap)`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+	if strings.Contains(content, "For now, combine with AND") {
+		t.Error("Not conditions should no longer be merged into the parent map")
+	}
+}
+
+// TestWhereConverter_OrAndGroups tests that each Or/And element is kept as its own group
+func TestWhereConverter_OrAndGroups(t *testing.T) {
+	content := generateUserQuery(t)
+
+	for _, want := range []string{
+		`result["$or"] = builder.Or(orConditions...)`,
+		`result["$and"] = builder.And(andConditions...)`,
+		"query.WhereGroup(func(group *builder.Query) {",
+		"group.Or(ConvertUserWhereInputToWhere(orWhere))",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+	if strings.Contains(content, "nestedWhereKey") {
+		t.Error("Or/And conditions should no longer be merged into the parent map")
+	}
+}
+
+// TestFindMany_ProjectInto tests that ProjectInto derives the SELECT list from the DTO fields
+func TestFindMany_ProjectInto(t *testing.T) {
+	content := generateUserQuery(t)
+
+	for _, want := range []string{
+		"func (b *UserFindManyBuilder) ProjectInto(dest interface{}) error {",
+		"func (b *UserFindManyBuilder) ProjectIntoWithContext(ctx context.Context, dest interface{}) error {",
+		"columns := b.query.Query.MappedColumns(destType.Elem().Elem())",
+		"b.query.Query.SelectAll().Select(b.projection...)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+}
+
+// TestUpdateDelete_ReturnRowsAffected tests that the Update and Delete builders return the affected row count
+func TestUpdateDelete_ReturnRowsAffected(t *testing.T) {
+	content := generateUserQuery(t)
+
+	for _, want := range []string{
+		"func (b *UserUpdateBuilder) Exec() (int64, error) {",
+		"func (b *UserUpdateBuilder) ExecWithContext(ctx context.Context) (int64, error) {",
+		"return b.query.UpdatesResult(ctx, updateData)",
+		"func (b *UserDeleteBuilder) Exec() (int64, error) {",
+		"func (b *UserDeleteBuilder) ExecWithContext(ctx context.Context) (int64, error) {",
+		"return b.query.Query.DeleteResult(ctx, &models.User{})",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+}
+
+// TestUpsert_SingleStatement tests that Upsert derives the conflict target from the where and runs a single statement
+func TestUpsert_SingleStatement(t *testing.T) {
+	content := generateUserQuery(t)
+
+	for _, want := range []string{
+		"conflictColumns, conflictWhere := b.conflictColumns()",
+		"tableBuilder.Upsert(ctx, result, conflictColumns, buildUserUpdateData(*b.update))",
+		"if b.where.Id != nil && b.where.Id.Equals != nil {",
+		`return []string{"id"}, ""`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+	if strings.Contains(content, "upsert find failed") {
+		t.Error("Upsert should no longer find the record before writing")
+	}
+}
+
+// TestFindMany_OrderByInput tests that OrderBy applies the typed input in declaration order through @map column names
+func TestFindMany_OrderByInput(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "generated")
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+
+	schema, errs, err := parser.Parse(`
+model User {
+  id        Int      @id @default(autoincrement())
+  created   DateTime @map("created_at")
+  name      String
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	if err := GenerateQueries(schema, outputDir); err != nil {
+		t.Fatalf("GenerateQueries failed: %v", err)
+	}
+	if err := GenerateInputs(schema, outputDir); err != nil {
+		t.Fatalf("GenerateInputs failed: %v", err)
+	}
+
+	queries, err := os.ReadFile(filepath.Join(outputDir, "queries", "user_query.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query file: %v", err)
+	}
+	content := string(queries)
+	for _, want := range []string{
+		"func (b *UserFindManyBuilder) OrderBy(orderBy ...inputs.UserOrderByInput) *UserFindManyBuilder {",
+		`b.query.Query.Order("created_at " + orderBy.Created.Direction())`,
+		`b.query.Query.OrderInsensitive("name", orderBy.NameInsensitive.Direction())`,
+		"if !ordered[b.cursor.column] {",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+	// Os campos são aplicados na ordem de declaração do modelo
+	if strings.Index(content, `Order("id " +`) > strings.Index(content, `Order("created_at " +`) ||
+		strings.Index(content, `Order("created_at " +`) > strings.Index(content, `Order("name " +`) {
+		t.Error("OrderBy fields should be applied in declaration order")
+	}
+
+	inputs, err := os.ReadFile(filepath.Join(outputDir, "inputs", "user_input.go"))
+	if err != nil {
+		t.Fatalf("Failed to read input file: %v", err)
+	}
+	if !strings.Contains(string(inputs), "type UserOrderByInput struct {") {
+		t.Error("generated inputs should contain UserOrderByInput")
+	}
+	// Só campos String ganham o modo case-insensitive
+	if !strings.Contains(string(inputs), "NameInsensitive *SortOrderInsensitive") {
+		t.Error("String fields should get an Insensitive sort mode")
+	}
+	if strings.Contains(string(inputs), "CreatedInsensitive") || strings.Contains(content, "orderBy.IdInsensitive") {
+		t.Error("non-String fields should not get an Insensitive sort mode")
+	}
+}
+
+// TestFindMany_TakeSkip tests that Take/Skip are applied in Exec and ExecTyped, ignoring non-positive values
+func TestFindMany_TakeSkip(t *testing.T) {
+	content := generateUserQuery(t)
+
+	for _, want := range []string{
+		"func (b *UserFindManyBuilder) Take(n int) *UserFindManyBuilder {",
+		"func (b *UserFindManyBuilder) Skip(n int) *UserFindManyBuilder {",
+		"if b.take > 0 {\n\t\tb.query.Query.Take(b.take)",
+		"if b.skip > 0 {\n\t\tb.query.Query.Skip(b.skip)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated query should contain %q", want)
+		}
+	}
+	// Exec e ExecTyped aplicam a paginação
+	if got := strings.Count(content, "b.applyPagination()"); got != 2 {
+		t.Errorf("applyPagination should be called by Exec and ExecTyped, got %d calls", got)
+	}
+}
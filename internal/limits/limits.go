@@ -24,6 +24,17 @@ const (
 	// MaxSelectFields is the maximum number of SELECT fields
 	MaxSelectFields = 100
 
+	// MaxPlaceholders is the maximum number of bind parameters in a single statement
+	// PostgreSQL and MySQL accept up to 65535
+	MaxPlaceholders = 65535
+
+	// MaxPlaceholdersSQLite is the bind parameter limit of SQLite (since 3.32; 999 before)
+	MaxPlaceholdersSQLite = 32766
+
+	// MaxIncludeBatchSize is the maximum number of keys in the IN list of a relation
+	// loading (Include) query; larger key sets are split into several queries
+	MaxIncludeBatchSize = 1000
+
 	// MaxRawQuerySize is the maximum size in bytes for raw SQL queries
 	// This prevents DoS attacks via extremely large queries
 	// Set to 10MB to allow legitimate large queries while preventing abuse
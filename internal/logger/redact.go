@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// ArgRedaction define como os args das queries chegam aos logs e hooks
+type ArgRedaction int32
+
+const (
+	// RedactOff repassa os args como estão, truncando strings e bytes longos
+	RedactOff ArgRedaction = iota
+	// RedactFull troca cada arg por ***REDACTED***
+	RedactFull
+	// RedactTypes troca cada arg pelo seu tipo e, para strings, bytes e slices, pelo tamanho
+	RedactTypes
+)
+
+// redactedArg substitui os args no modo RedactFull
+const redactedArg = "***REDACTED***"
+
+var (
+	argRedaction atomic.Int32
+	maxArgLength atomic.Int64
+)
+
+func init() {
+	maxArgLength.Store(100)
+}
+
+// SetArgRedaction define o modo de redação global dos args
+func SetArgRedaction(mode ArgRedaction) {
+	argRedaction.Store(int32(mode))
+}
+
+// GetArgRedaction retorna o modo de redação global dos args
+func GetArgRedaction() ArgRedaction {
+	return ArgRedaction(argRedaction.Load())
+}
+
+// SetMaxArgLength define o tamanho acima do qual strings e bytes são truncados no modo
+// RedactOff (padrão 100); 0 desativa o truncamento
+func SetMaxArgLength(n int) {
+	maxArgLength.Store(int64(n))
+}
+
+// RedactArgs retorna uma cópia de args com o modo aplicado, pronta para logs e hooks.
+// A query continua usando os args originais.
+func RedactArgs(args []interface{}, mode ArgRedaction) []interface{} {
+	if len(args) == 0 {
+		return args
+	}
+	limit := int(maxArgLength.Load())
+	redacted := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch mode {
+		case RedactFull:
+			redacted[i] = redactedArg
+		case RedactTypes:
+			redacted[i] = argType(arg)
+		default:
+			redacted[i] = truncateArg(arg, limit)
+		}
+	}
+	return redacted
+}
+
+// argType descreve arg pelo tipo e, quando houver, pelo tamanho (ex.: string(12))
+func argType(arg interface{}) string {
+	if arg == nil {
+		return "nil"
+	}
+	val := reflect.ValueOf(arg)
+	switch val.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%s(%d)", val.Type(), val.Len())
+	}
+	return val.Type().String()
+}
+
+// truncateArg corta strings e bytes maiores que limit
+func truncateArg(arg interface{}, limit int) interface{} {
+	if limit <= 0 {
+		return arg
+	}
+	switch v := arg.(type) {
+	case string:
+		if len(v) > limit {
+			return v[:limit] + "...(truncated)"
+		}
+	case []byte:
+		if len(v) > limit {
+			return append(v[:limit:limit], "...(truncated)"...)
+		}
+	}
+	return arg
+}
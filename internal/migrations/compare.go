@@ -2,8 +2,11 @@ package migrations
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
 	"github.com/carlosnayan/prisma-go-client/internal/parser"
 )
 
@@ -30,6 +33,7 @@ func CompareSchema(schema *parser.Schema, dbSchema *DatabaseSchema, provider str
 		ForeignKeysToCreate: []ForeignKeyDefinition{},
 		ForeignKeysToAlter:  []ForeignKeyDefinition{},
 		ForeignKeysToDrop:   []ForeignKeyDefinition{},
+		Previous:            dbSchema,
 	}
 
 	prismaTables := make(map[string]*TableDefinition)
@@ -39,6 +43,7 @@ func CompareSchema(schema *parser.Schema, dbSchema *DatabaseSchema, provider str
 			Name:        tableName,
 			Columns:     []ColumnDefinition{},
 			CompositePK: []string{},
+			Checks:      extractChecks(tableName, model),
 		}
 
 		for _, attr := range model.Attributes {
@@ -83,9 +88,10 @@ func CompareSchema(schema *parser.Schema, dbSchema *DatabaseSchema, provider str
 
 			columnName := getColumnNameFromField(field)
 			col := ColumnDefinition{
-				Name:       columnName,
-				Type:       field.Type.Name,
-				IsNullable: field.Type.IsOptional,
+				Name:        columnName,
+				Type:        field.Type.Name,
+				IsNullable:  field.Type.IsOptional,
+				RenamedFrom: getRenamedFromField(field, columnName),
 			}
 
 			hasCompositePK := len(table.CompositePK) > 0
@@ -208,16 +214,26 @@ func CompareSchema(schema *parser.Schema, dbSchema *DatabaseSchema, provider str
 				}
 			}
 
+			if !field.Type.IsArray {
+				applyEnumType(schema, field, &col)
+			}
 			table.Columns = append(table.Columns, col)
 		}
 
 		prismaTables[tableName] = table
 	}
 
+	d := dialect.GetDialect(provider)
+
+	// Enum types already used by an existing column are not created again
+	var newColumns []ColumnDefinition
+	existingEnums := make(map[string]bool)
+
 	for tableName, prismaTable := range prismaTables {
 		dbTable, exists := dbSchema.Tables[tableName]
 		if !exists {
 			diff.TablesToCreate = append(diff.TablesToCreate, *prismaTable)
+			newColumns = append(newColumns, prismaTable.Columns...)
 			continue
 		}
 
@@ -226,77 +242,101 @@ func CompareSchema(schema *parser.Schema, dbSchema *DatabaseSchema, provider str
 			AddColumns:   []ColumnDefinition{},
 			DropColumns:  []string{},
 			AlterColumns: []ColumnAlteration{},
+			Table:        prismaTable,
+		}
+
+		schemaColumns := make(map[string]bool, len(prismaTable.Columns))
+		for _, prismaCol := range prismaTable.Columns {
+			schemaColumns[prismaCol.Name] = true
 		}
+		// Database columns taken over by a renamed column, which are not dropped
+		renamed := make(map[string]bool)
 
 		for _, prismaCol := range prismaTable.Columns {
 			dbCol, exists := dbTable.Columns[prismaCol.Name]
 			if !exists {
-				alteration.AddColumns = append(alteration.AddColumns, prismaCol)
-				continue
+				from := prismaCol.RenamedFrom
+				if from == "" || schemaColumns[from] || renamed[from] || dbTable.Columns[from] == nil {
+					alteration.AddColumns = append(alteration.AddColumns, prismaCol)
+					newColumns = append(newColumns, prismaCol)
+					continue
+				}
+				// The old column is still in the database and no longer in the schema:
+				// rename it, then compare it like any existing column
+				alteration.RenameColumns = append(alteration.RenameColumns, ColumnRename{From: from, To: prismaCol.Name})
+				renamed[from] = true
+				dbCol = dbTable.Columns[from]
 			}
 
-			prismaTypeSQL := mapTypeToSQL(prismaCol.Type, provider)
-			if dbCol.Type != prismaTypeSQL || dbCol.IsNullable != prismaCol.IsNullable {
+			// The database reports enum columns by their underlying type, so
+			// only nullability and the default are compared
+			nullableChanged := dbCol.IsNullable != prismaCol.IsNullable
+			changed := nullableChanged
+			typeChanged := false
+			if len(prismaCol.EnumValues) > 0 {
+				existingEnums[prismaCol.Type] = true
+			} else if columnTypeChanged(d, provider, dbCol, prismaCol) {
+				typeChanged = true
+				changed = true
+			}
+			defaultChanged := !defaultsEqual(prismaCol.DefaultValue, dbCol.DefaultValue, prismaCol.Type == "Boolean")
+			if changed || defaultChanged {
 				alteration.AlterColumns = append(alteration.AlterColumns, ColumnAlteration{
-					ColumnName:  prismaCol.Name,
-					NewType:     prismaCol.Type,
-					NewNullable: prismaCol.IsNullable,
+					ColumnName:      prismaCol.Name,
+					NewType:         prismaCol.Type,
+					NewNullable:     prismaCol.IsNullable,
+					DefaultChanged:  defaultChanged,
+					NewDefault:      prismaCol.DefaultValue,
+					NullableChanged: nullableChanged,
+					TypeChanged:     typeChanged,
+					OldType:         previousColumnType(d, provider, dbCol),
+					Column:          prismaCol,
 				})
 			}
 		}
 
 		for dbColName := range dbTable.Columns {
-			found := false
-			for _, prismaCol := range prismaTable.Columns {
-				if prismaCol.Name == dbColName {
-					found = true
-					break
-				}
-			}
-			if !found {
+			if !schemaColumns[dbColName] && !renamed[dbColName] {
 				alteration.DropColumns = append(alteration.DropColumns, dbColName)
 			}
 		}
 
-		if len(alteration.AddColumns) > 0 || len(alteration.DropColumns) > 0 || len(alteration.AlterColumns) > 0 {
+		compareChecks(diff, tableName, prismaTable.Checks, dbTable)
+
+		if len(alteration.AddColumns) > 0 || len(alteration.DropColumns) > 0 || len(alteration.AlterColumns) > 0 || len(alteration.RenameColumns) > 0 {
 			diff.TablesToAlter = append(diff.TablesToAlter, alteration)
 		}
 	}
 
+	diff.EnumsToCreate = enumsToCreate(schema, newColumns, existingEnums)
+
 	for dbTableName := range dbSchema.Tables {
 		if _, exists := prismaTables[dbTableName]; !exists {
 			diff.TablesToDrop = append(diff.TablesToDrop, dbTableName)
 		}
 	}
 
-	// Calculate Indexes to Drop
-	expectedIndexes := make(map[string]map[string]bool)
+	// Calculate Indexes to Drop; an index whose predicate, access method, sort order or
+	// expressions changed is dropped and created again
+	expectedIndexes := make(map[string]map[string]IndexDefinition) // table -> index name -> index
 	for _, model := range schema.Models {
 		tableName := getTableNameFromModel(model)
-		expectedIndexes[tableName] = make(map[string]bool)
+		expectedIndexes[tableName] = make(map[string]IndexDefinition)
 
 		// Field level @unique
 		for _, field := range model.Fields {
 			colName := getColumnNameFromField(field)
 			for _, attr := range field.Attributes {
 				if attr.Name == "unique" {
-					indexName := fmt.Sprintf("%s_%s_key", tableName, colName)
-					expectedIndexes[tableName][indexName] = true
+					indexName := fmt.Sprintf("%s_%s_key", unqualifiedTableName(tableName), colName)
+					expectedIndexes[tableName][indexName] = IndexDefinition{Name: indexName, TableName: tableName, Columns: []string{colName}, IsUnique: true}
 				}
 			}
 		}
 
 		// Model level @@unique and @@index
-		for _, attr := range model.Attributes {
-			if attr.Name == "unique" {
-				if idx := extractUniqueIndex(tableName, attr); idx != nil {
-					expectedIndexes[tableName][idx.Name] = true
-				}
-			} else if attr.Name == "index" {
-				if idx := extractIndex(tableName, attr); idx != nil {
-					expectedIndexes[tableName][idx.Name] = true
-				}
-			}
+		for _, idx := range modelIndexes(d, model, tableName) {
+			expectedIndexes[tableName][idx.Name] = idx
 		}
 	}
 
@@ -310,21 +350,21 @@ func CompareSchema(schema *parser.Schema, dbSchema *DatabaseSchema, provider str
 			// Check if index is expected (case-insensitive)
 			expected := false
 			if expectedMap, ok := expectedIndexes[tableName]; ok {
-				for expectedName := range expectedMap {
+				for expectedName, idx := range expectedMap {
 					if strings.EqualFold(expectedName, dbIdx.Name) {
-						expected = true
+						expected = indexShapeEqual(provider, idx, dbIdx)
 						break
 					}
 				}
 			}
 
 			if !expected {
-				diff.IndexesToDrop = append(diff.IndexesToDrop, dbIdx.Name)
+				diff.IndexesToDrop = append(diff.IndexesToDrop, qualifiedIndexName(tableName, dbIdx.Name))
 			}
 		}
 	}
 
-	processRelationsAndUnique(schema, diff, dbSchema)
+	processRelationsAndUnique(schema, diff, dbSchema, provider)
 
 	// Detect FKs that exist in database but not in schema (need to be dropped)
 	detectOrphanedForeignKeys(schema, diff, dbSchema)
@@ -332,6 +372,112 @@ func CompareSchema(schema *parser.Schema, dbSchema *DatabaseSchema, provider str
 	return diff, nil
 }
 
+// SchemaToDatabaseSchema describes the database a schema migrates to, in the form
+// CompareSchema reads from introspection, so two schemas can be diffed without a
+// database. Table and column names follow @@map and @map.
+func SchemaToDatabaseSchema(schema *parser.Schema, provider string) (*DatabaseSchema, error) {
+	created, err := SchemaToSQL(schema, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	d := dialect.GetDialect(provider)
+	dbSchema := &DatabaseSchema{Tables: make(map[string]*TableInfo)}
+	for _, table := range created.TablesToCreate {
+		tableInfo := &TableInfo{
+			Name:    table.Name,
+			Columns: make(map[string]*ColumnInfo),
+			Indexes: []*IndexInfo{},
+		}
+		for _, col := range table.Columns {
+			colInfo := &ColumnInfo{
+				Name:         col.Name,
+				Type:         columnTypeSQL(d, provider, col),
+				IsNullable:   col.IsNullable,
+				IsPrimaryKey: col.IsPrimaryKey,
+				IsUnique:     col.IsUnique,
+			}
+			if col.DefaultValue != "" {
+				defaultValue := col.DefaultValue
+				colInfo.DefaultValue = &defaultValue
+			}
+			tableInfo.Columns[col.Name] = colInfo
+			tableInfo.ColumnOrder = append(tableInfo.ColumnOrder, col.Name)
+		}
+		for _, check := range table.Checks {
+			tableInfo.Checks = append(tableInfo.Checks, &CheckInfo{Name: check.Name, Expression: check.Expression})
+		}
+		dbSchema.Tables[table.Name] = tableInfo
+	}
+
+	for _, idx := range created.IndexesToCreate {
+		if tableInfo, ok := dbSchema.Tables[idx.TableName]; ok {
+			columnInfos := make([]IndexColumnInfo, len(idx.Columns))
+			for i, col := range idx.Columns {
+				column := idx.column(i)
+				columnInfos[i] = IndexColumnInfo{ColumnName: col, SortOrder: "ASC", Expression: column.Expression}
+				if column.Sort == "DESC" {
+					columnInfos[i].SortOrder = "DESC"
+				}
+				if provider == "mysql" {
+					columnInfos[i].Length = column.Length
+				}
+			}
+			tableInfo.Indexes = append(tableInfo.Indexes, &IndexInfo{
+				Name:        idx.Name,
+				TableName:   idx.TableName,
+				Columns:     idx.Columns,
+				ColumnInfos: columnInfos,
+				IsUnique:    idx.IsUnique,
+				Where:       indexPredicate(provider, idx.Where),
+				Type:        indexMethod(provider, idx.Type),
+			})
+		}
+	}
+	for _, fk := range created.ForeignKeysToCreate {
+		if tableInfo, ok := dbSchema.Tables[fk.TableName]; ok {
+			tableInfo.ForeignKeys = append(tableInfo.ForeignKeys, &ForeignKeyInfo{
+				Name:              fk.Name,
+				TableName:         fk.TableName,
+				Columns:           fk.Columns,
+				ReferencedTable:   fk.ReferencedTable,
+				ReferencedColumns: fk.ReferencedColumns,
+				OnDelete:          fk.OnDelete,
+				OnUpdate:          fk.OnUpdate,
+			})
+		}
+	}
+
+	return dbSchema, nil
+}
+
+// compareChecks adds the CHECK constraints of tableName missing from dbTable to ChecksToCreate
+// and the database's constraints missing from the schema to ChecksToDrop. Constraints are
+// matched by name, since databases rewrite the expression (e.g. "rating >= 1" becomes
+// "((rating >= 1))" on PostgreSQL); rename a check with map: to change its expression.
+func compareChecks(diff *SchemaDiff, tableName string, checks []CheckDefinition, dbTable *TableInfo) {
+	dbChecks := make(map[string]bool, len(dbTable.Checks))
+	for _, dbCheck := range dbTable.Checks {
+		dbChecks[strings.ToLower(dbCheck.Name)] = true
+	}
+	expected := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		expected[strings.ToLower(check.Name)] = true
+		if !dbChecks[strings.ToLower(check.Name)] {
+			diff.ChecksToCreate = append(diff.ChecksToCreate, check)
+		}
+	}
+	for _, dbCheck := range dbTable.Checks {
+		if !expected[strings.ToLower(dbCheck.Name)] {
+			diff.ChecksToDrop = append(diff.ChecksToDrop, CheckDefinition{
+				Name:       dbCheck.Name,
+				TableName:  tableName,
+				Expression: dbCheck.Expression,
+			})
+		}
+	}
+}
+
 // detectOrphanedForeignKeys finds foreign keys that exist in the database but not in the schema
 func detectOrphanedForeignKeys(schema *parser.Schema, diff *SchemaDiff, dbSchema *DatabaseSchema) {
 	modelMap := make(map[string]*parser.Model)
@@ -417,7 +563,8 @@ func createFKStructureKey(tableName string, columns []string, referencedTable st
 	return fmt.Sprintf("%s|%s|%s|%s", strings.ToLower(tableName), strings.ToLower(colsKey), strings.ToLower(referencedTable), strings.ToLower(refColsKey))
 }
 
-func processRelationsAndUnique(schema *parser.Schema, diff *SchemaDiff, dbSchema *DatabaseSchema) {
+func processRelationsAndUnique(schema *parser.Schema, diff *SchemaDiff, dbSchema *DatabaseSchema, provider string) {
+	d := dialect.GetDialect(provider)
 	modelMap := make(map[string]*parser.Model)
 	for _, model := range schema.Models {
 		modelMap[model.Name] = model
@@ -426,19 +573,9 @@ func processRelationsAndUnique(schema *parser.Schema, diff *SchemaDiff, dbSchema
 	for _, model := range schema.Models {
 		tableName := getTableNameFromModel(model)
 
-		for _, attr := range model.Attributes {
-			var indexDef *IndexDefinition
-			if attr.Name == "unique" {
-				indexDef = extractUniqueIndex(tableName, attr)
-			} else if attr.Name == "index" {
-				indexDef = extractIndex(tableName, attr)
-			}
-			if indexDef != nil {
-				mappedColumns := mapColumnNames(model, indexDef.Columns)
-				indexDef.Columns = mappedColumns
-				if !indexExists(dbSchema, tableName, indexDef.Name, indexDef.Columns) {
-					diff.IndexesToCreate = append(diff.IndexesToCreate, *indexDef)
-				}
+		for _, indexDef := range modelIndexes(d, model, tableName) {
+			if !indexExists(dbSchema, provider, indexDef) {
+				diff.IndexesToCreate = append(diff.IndexesToCreate, indexDef)
 			}
 		}
 
@@ -447,14 +584,15 @@ func processRelationsAndUnique(schema *parser.Schema, diff *SchemaDiff, dbSchema
 			for _, attr := range field.Attributes {
 				if attr.Name == "unique" {
 					// Field-level unique attribute
-					indexName := fmt.Sprintf("%s_%s_key", tableName, columnName)
-					if !indexExists(dbSchema, tableName, indexName, []string{columnName}) {
-						diff.IndexesToCreate = append(diff.IndexesToCreate, IndexDefinition{
-							Name:      indexName,
-							TableName: tableName,
-							Columns:   []string{columnName},
-							IsUnique:  true,
-						})
+					indexName := fmt.Sprintf("%s_%s_key", unqualifiedTableName(tableName), columnName)
+					indexDef := IndexDefinition{
+						Name:      indexName,
+						TableName: tableName,
+						Columns:   []string{columnName},
+						IsUnique:  true,
+					}
+					if !indexExists(dbSchema, provider, indexDef) {
+						diff.IndexesToCreate = append(diff.IndexesToCreate, indexDef)
 					}
 				}
 				if attr.Name == "relation" {
@@ -599,6 +737,40 @@ func processRelationsAndUnique(schema *parser.Schema, diff *SchemaDiff, dbSchema
 	}
 }
 
+// modelIndexes returns the @@unique and @@index indexes of a model with the column names
+// mapped and the expressions rendered for d. Uniques of a soft-delete model get its predicate.
+func modelIndexes(d dialect.Dialect, model *parser.Model, tableName string) []IndexDefinition {
+	var indexes []IndexDefinition
+	for _, attr := range model.Attributes {
+		var idx *IndexDefinition
+		if attr.Name == "unique" {
+			if idx = extractUniqueIndex(tableName, attr); idx != nil {
+				applySoftDeletePredicate(model, idx)
+			}
+		} else if attr.Name == "index" {
+			idx = extractIndex(tableName, attr)
+		}
+		if idx == nil {
+			continue
+		}
+		idx.Columns = mapColumnNames(model, idx.Columns)
+		for i, column := range idx.ColumnOptions {
+			if column.Expression {
+				idx.Columns[i] = renderIndexExpression(column.expression, func(ref string) string {
+					for _, field := range model.Fields {
+						if field.Name == ref {
+							return d.QuoteIdentifier(getColumnNameFromField(field))
+						}
+					}
+					return d.QuoteString(ref)
+				})
+			}
+		}
+		indexes = append(indexes, *idx)
+	}
+	return indexes
+}
+
 func mapColumnNames(model *parser.Model, columns []string) []string {
 	mapped := make([]string, len(columns))
 	for i, col := range columns {
@@ -705,23 +877,180 @@ func normalizeCascadeActionForComparison(action string) string {
 	return normalizeCascadeAction(action)
 }
 
-func indexExists(dbSchema *DatabaseSchema, tableName, indexName string, columns []string) bool {
-	dbTable, exists := dbSchema.Tables[tableName]
+// defaultsEqual reports whether a schema default (as in ColumnDefinition.DefaultValue) and
+// the default reported by the database are the same value. Databases report defaults in
+// their own form ('x'::text or now() on PostgreSQL, x on MySQL, 1 for TRUE on MySQL and
+// SQLite), so both sides are reduced with canonicalDefault first.
+func defaultsEqual(schemaDefault string, dbDefault *string, isBoolean bool) bool {
+	db := ""
+	if dbDefault != nil {
+		db = *dbDefault
+	}
+	return canonicalDefault(schemaDefault, isBoolean) == canonicalDefault(db, isBoolean)
+}
+
+// canonicalDefault strips casts, wrapping parentheses and quotes from a default and maps
+// the forms of the current timestamp and of generated keys to a single value each
+func canonicalDefault(value string, isBoolean bool) string {
+	value = strings.TrimSpace(value)
+	for isWrappedInParens(value) {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	// PostgreSQL casts literals: 'x'::text, 'USER'::"Role"
+	if i := strings.LastIndex(value, "::"); i > 0 && strings.HasSuffix(value[:i], "'") {
+		value = value[:i]
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+
+	lower := strings.ToLower(value)
+	switch {
+	case lower == "now()" || strings.HasPrefix(lower, "current_timestamp"):
+		return "CURRENT_TIMESTAMP"
+	case strings.HasPrefix(lower, "nextval(") || lower == "unique_rowid()":
+		return "" // @default(autoincrement()) has no default in the schema
+	}
+	if isBoolean {
+		if b, ok := normalizeBooleanDefault(value); ok {
+			return strconv.FormatBool(b)
+		}
+	}
+	return value
+}
+
+// isWrappedInParens reports whether the whole value is enclosed in one pair of parentheses
+func isWrappedInParens(value string) bool {
+	if !strings.HasPrefix(value, "(") || !strings.HasSuffix(value, ")") {
+		return false
+	}
+	depth := 0
+	for i, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i == len(value)-1
+			}
+		}
+	}
+	return false
+}
+
+// normalizeBooleanDefault reads a boolean default in any of the dialect forms
+// (TRUE, 't', 1, '1', b'1', 'true'::boolean...), so true and 1 compare equal.
+// ok is false when the value is not a boolean literal.
+func normalizeBooleanDefault(value string) (result bool, ok bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.Index(value, "::"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	value = strings.TrimPrefix(strings.Trim(value, "()"), "b")
+	value = strings.Trim(value, "'")
+	switch value {
+	case "true", "t", "1":
+		return true, true
+	case "false", "f", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// indexExists reports whether the database has the index, by name or by columns. The
+// predicate, access method, sort order and expressions of the index are part of its identity.
+func indexExists(dbSchema *DatabaseSchema, provider string, idx IndexDefinition) bool {
+	dbTable, exists := dbSchema.Tables[idx.TableName]
 	if !exists {
 		return false
 	}
 
 	for _, dbIndex := range dbTable.Indexes {
-		if strings.EqualFold(dbIndex.Name, indexName) {
+		if !indexShapeEqual(provider, idx, dbIndex) {
+			continue
+		}
+		if strings.EqualFold(dbIndex.Name, idx.Name) {
 			return true
 		}
-		if len(dbIndex.Columns) == len(columns) && columnsMatch(dbIndex.Columns, columns) {
+		if len(dbIndex.Columns) == len(idx.Columns) && columnsMatch(dbIndex.Columns, idx.Columns) {
 			return true
 		}
 	}
 	return false
 }
 
+// indexShapeEqual reports whether a database index has the predicate, access method,
+// sort order and expressions of idx. Column names are left to the callers: a renamed
+// column keeps its indexes, which are then still found by name.
+func indexShapeEqual(provider string, idx IndexDefinition, dbIndex *IndexInfo) bool {
+	if !predicatesEqual(indexPredicate(provider, idx.Where), dbIndex.Where) {
+		return false
+	}
+	if indexMethod(provider, idx.Type) != indexMethod(provider, dbIndex.Type) {
+		return false
+	}
+	for i := range idx.Columns {
+		if i >= len(dbIndex.ColumnInfos) {
+			break
+		}
+		column, dbColumn := idx.column(i), dbIndex.ColumnInfos[i]
+		if (column.Sort == "DESC") != (dbColumn.SortOrder == "DESC") {
+			return false
+		}
+		if column.Expression != dbColumn.Expression {
+			return false
+		}
+		if column.Expression && !predicatesEqual(idx.Columns[i], dbColumn.ColumnName) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexPredicate returns the predicate an index gets on provider; MySQL has no partial
+// indexes, so it creates the full index
+func indexPredicate(provider, where string) string {
+	if provider == "mysql" {
+		return ""
+	}
+	return where
+}
+
+// indexMethod returns the access method an index gets on provider. Only PostgreSQL
+// creates them, and btree is its default, so both sides of a diff omit it.
+func indexMethod(provider, method string) string {
+	if provider != "postgresql" || method == "btree" {
+		return ""
+	}
+	return method
+}
+
+// predicateCastPattern matches the casts PostgreSQL adds when it stores a predicate,
+// e.g. 'active'::text or 'x'::character varying
+var predicateCastPattern = regexp.MustCompile(`::(character varying|timestamp with(out)? time zone|double precision|[a-z_][a-z0-9_]*)(\[\])?`)
+
+// predicateNoise removes the parentheses, identifier quotes and spacing databases add
+var predicateNoise = strings.NewReplacer("(", "", ")", "", `"`, "", "`", "", " ", "", "\t", "", "\n", "")
+
+// predicatesEqual reports whether two partial index predicates are the same once the
+// rewriting of the database is undone: PostgreSQL stores "deleted_at IS NULL" as
+// "(deleted_at IS NULL)" and adds casts to literals. String literals are compared as is.
+func predicatesEqual(a, b string) bool {
+	return normalizePredicate(a) == normalizePredicate(b)
+}
+
+// normalizePredicate lowercases a predicate and strips casts, parentheses, quotes and
+// spacing outside its string literals
+func normalizePredicate(predicate string) string {
+	parts := strings.Split(predicate, "'")
+	for i := 0; i < len(parts); i += 2 {
+		part := predicateCastPattern.ReplaceAllString(strings.ToLower(parts[i]), "")
+		parts[i] = predicateNoise.Replace(part)
+	}
+	return strings.Join(parts, "'")
+}
+
 func columnsMatch(cols1, cols2 []string) bool {
 	if len(cols1) != len(cols2) {
 		return false
@@ -733,3 +1062,157 @@ func columnsMatch(cols1, cols2 []string) bool {
 	}
 	return true
 }
+
+// columnTypeChanged reports whether the schema declares another type for a column than
+// the database column has. Types are compared in the detail introspection keeps:
+// PostgreSQL by type name and the length of character types, MySQL and SQLite by the
+// Prisma type they map to, plus the length of MySQL VARCHARs when it is known.
+func columnTypeChanged(d dialect.Dialect, provider string, dbCol *ColumnInfo, col ColumnDefinition) bool {
+	oldType := previousColumnType(d, provider, dbCol)
+	newType := columnTypeSQL(d, provider, col)
+
+	if provider == "mysql" || provider == "sqlite" {
+		if columnTypeFamily(provider, oldType) != columnTypeFamily(provider, newType) {
+			return true
+		}
+		oldName, oldArgs := splitSQLType(oldType)
+		newName, newArgs := splitSQLType(newType)
+		lengthKnown := !isBuiltInType(dbCol.Type) || dbCol.CharacterMaximumLength != nil
+		return provider == "mysql" && lengthKnown && oldName == "varchar" && newName == "varchar" && oldArgs != newArgs
+	}
+
+	// Scalar lists are not columns of the schema tables
+	if dbCol.Type == "ARRAY" {
+		return false
+	}
+	oldName, oldArgs := canonicalPostgresType(oldType)
+	newName, newArgs := canonicalPostgresType(newType)
+	if oldName != newName {
+		return true
+	}
+	// Numeric and timestamp precision are not introspected, so only lengths are compared
+	switch newName {
+	case "varchar", "char", "bit", "varbit":
+		return oldArgs != newArgs
+	}
+	return false
+}
+
+// columnTypeFamily returns the Prisma type MySQL or SQLite introspection reports for a
+// column declared with sqlType
+func columnTypeFamily(provider, sqlType string) string {
+	name, _ := splitSQLType(sqlType)
+	if provider == "sqlite" {
+		return mapSQLiteType(name)
+	}
+	// information_schema reports the underlying type of MySQL aliases
+	switch name {
+	case "boolean", "bool":
+		name = "tinyint"
+	case "integer":
+		name = "int"
+	case "double precision", "real":
+		name = "double"
+	}
+	return mapMySQLType(name)
+}
+
+// canonicalPostgresType returns the name and arguments of a PostgreSQL type with aliases
+// resolved, so "character varying(100)" and "VARCHAR(100)" compare equal
+func canonicalPostgresType(sqlType string) (string, string) {
+	name, args := splitSQLType(sqlType)
+	switch name {
+	case "character varying":
+		name = "varchar"
+	case "character", "bpchar":
+		name = "char"
+	case "int", "int4", "serial":
+		name = "integer"
+	case "int8", "bigserial":
+		name = "bigint"
+	case "int2", "smallserial":
+		name = "smallint"
+	case "float8", "float", "double":
+		name = "double precision"
+	case "float4":
+		name = "real"
+	case "decimal":
+		name = "numeric"
+	case "bool":
+		name = "boolean"
+	case "timestamp without time zone":
+		name = "timestamp"
+	case "timestamp with time zone":
+		name = "timestamptz"
+	case "time without time zone":
+		name = "time"
+	case "time with time zone":
+		name = "timetz"
+	case "bit varying":
+		name = "varbit"
+	}
+	return name, args
+}
+
+// postgresTypeGroups groups the PostgreSQL types that convert into each other implicitly
+var postgresTypeGroups = map[string]string{
+	"smallint":         "number",
+	"integer":          "number",
+	"bigint":           "number",
+	"numeric":          "number",
+	"real":             "number",
+	"double precision": "number",
+	"varchar":          "string",
+	"char":             "string",
+	"text":             "string",
+	"timestamp":        "datetime",
+	"timestamptz":      "datetime",
+	"date":             "datetime",
+}
+
+// needsUsingCast reports whether PostgreSQL needs a USING cast to change a column from
+// oldType to newType. Any type converts to a string type implicitly.
+func needsUsingCast(oldType, newType string) bool {
+	from, _ := canonicalPostgresType(oldType)
+	to, _ := canonicalPostgresType(newType)
+	if from == to || postgresTypeGroups[to] == "string" {
+		return false
+	}
+	return postgresTypeGroups[from] == "" || postgresTypeGroups[from] != postgresTypeGroups[to]
+}
+
+// typeCastMayFail reports whether converting existing values from oldType to newType
+// can fail, e.g. text to integer
+func typeCastMayFail(provider, oldType, newType string) bool {
+	switch provider {
+	case "postgresql":
+		return needsUsingCast(oldType, newType)
+	case "mysql":
+		from, to := columnTypeFamily(provider, oldType), columnTypeFamily(provider, newType)
+		return from != to && to != "String" && !(isNumericType(from) && isNumericType(to))
+	}
+	return false
+}
+
+// isNumericType reports whether a Prisma type is stored as a number
+func isNumericType(prismaType string) bool {
+	switch prismaType {
+	case "Int", "BigInt", "Float", "Decimal":
+		return true
+	}
+	return false
+}
+
+// splitSQLType splits a SQL type into its lowercase name and parenthesized arguments,
+// e.g. "TIMESTAMP(3) WITHOUT TIME ZONE" into "timestamp without time zone" and "(3)"
+func splitSQLType(sqlType string) (string, string) {
+	name := strings.ToLower(sqlType)
+	args := ""
+	if open := strings.Index(name, "("); open >= 0 {
+		if end := strings.Index(name[open:], ")"); end >= 0 {
+			args = strings.ReplaceAll(name[open:open+end+1], " ", "")
+			name = name[:open] + " " + name[open+end+1:]
+		}
+	}
+	return strings.Join(strings.Fields(name), " "), args
+}
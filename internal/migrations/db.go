@@ -95,3 +95,34 @@ func GetProviderFromSchema(schema *parser.Schema) string {
 	}
 	return "postgresql" // Default
 }
+
+// GetSchemasFromSchema gets the Postgres schemas the parsed schema manages: the datasource
+// schemas list, or else public plus every schema named by @@schema
+func GetSchemasFromSchema(schema *parser.Schema) []string {
+	if len(schema.Datasources) > 0 {
+		for _, field := range schema.Datasources[0].Fields {
+			if field.Name != "schemas" {
+				continue
+			}
+			if values, ok := field.Value.([]interface{}); ok {
+				var schemas []string
+				for _, value := range values {
+					if str, ok := value.(string); ok {
+						schemas = append(schemas, strings.Trim(str, `"`))
+					}
+				}
+				return schemas
+			}
+		}
+	}
+
+	schemas := []string{defaultSchemaName}
+	seen := map[string]bool{defaultSchemaName: true}
+	for _, model := range schema.Models {
+		if name := getSchemaNameFromModel(model); name != "" && !seen[name] {
+			seen[name] = true
+			schemas = append(schemas, name)
+		}
+	}
+	return schemas
+}
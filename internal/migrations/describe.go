@@ -0,0 +1,133 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+// DescribeModel returns a readable description of how a model maps to SQL:
+// the resolved table name, columns with types and nullability, indexes, and
+// the foreign keys from and to the table. It reuses SchemaToSQL, so the output
+// matches what a migration would create.
+func DescribeModel(schema *parser.Schema, modelName, provider string) (string, error) {
+	var model *parser.Model
+	for _, m := range schema.Models {
+		if m.Name == modelName {
+			model = m
+			break
+		}
+	}
+	if model == nil {
+		return "", fmt.Errorf("model %s not found in schema", modelName)
+	}
+
+	diff, err := SchemaToSQL(schema, provider)
+	if err != nil {
+		return "", err
+	}
+	diff = sortDiff(diff)
+
+	tableName := getTableNameFromModel(model)
+	var table *TableDefinition
+	for i := range diff.TablesToCreate {
+		if diff.TablesToCreate[i].Name == tableName {
+			table = &diff.TablesToCreate[i]
+			break
+		}
+	}
+	if table == nil {
+		return "", fmt.Errorf("table %s not found for model %s", tableName, modelName)
+	}
+
+	d := dialect.GetDialect(provider)
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Model %s -> table %s (%s)\n", model.Name, d.QuoteIdentifier(tableName), provider))
+
+	output.WriteString("\nColumns:\n")
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	for _, col := range table.Columns {
+		var details []string
+		if col.IsNullable {
+			details = append(details, "NULL")
+		} else {
+			details = append(details, "NOT NULL")
+		}
+		if col.IsPrimaryKey || containsString(table.CompositePK, col.Name) {
+			details = append(details, "PRIMARY KEY")
+		}
+		if col.DefaultValue != "" {
+			details = append(details, "DEFAULT "+columnDefaultSQL(d, col.DefaultValue))
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", col.Name, d.MapType(col.Type, col.IsNullable), strings.Join(details, " "))
+	}
+	w.Flush()
+
+	var indexes []string
+	for _, idx := range diff.IndexesToCreate {
+		if idx.TableName != tableName {
+			continue
+		}
+		line := fmt.Sprintf("  %s (%s)", idx.Name, strings.Join(idx.Columns, ", "))
+		if idx.IsUnique {
+			line = fmt.Sprintf("  UNIQUE %s (%s)", idx.Name, strings.Join(idx.Columns, ", "))
+		}
+		if idx.Where != "" {
+			line += " WHERE " + idx.Where
+		}
+		indexes = append(indexes, line)
+	}
+	if len(indexes) > 0 {
+		output.WriteString("\nIndexes:\n")
+		output.WriteString(strings.Join(indexes, "\n") + "\n")
+	}
+
+	var relations []string
+	for _, fk := range diff.ForeignKeysToCreate {
+		if fk.TableName == tableName {
+			relations = append(relations, fmt.Sprintf("  %s: (%s) -> %s (%s) ON DELETE %s ON UPDATE %s",
+				fk.Name,
+				strings.Join(fk.Columns, ", "),
+				fk.ReferencedTable,
+				strings.Join(fk.ReferencedColumns, ", "),
+				fkAction(fk.OnDelete),
+				fkAction(fk.OnUpdate)))
+		}
+	}
+	for _, fk := range diff.ForeignKeysToCreate {
+		if fk.ReferencedTable == tableName && fk.TableName != tableName {
+			relations = append(relations, fmt.Sprintf("  %s: referenced by %s (%s) -> (%s)",
+				fk.Name,
+				fk.TableName,
+				strings.Join(fk.Columns, ", "),
+				strings.Join(fk.ReferencedColumns, ", ")))
+		}
+	}
+	if len(relations) > 0 {
+		output.WriteString("\nRelations:\n")
+		output.WriteString(strings.Join(relations, "\n") + "\n")
+	}
+
+	return output.String(), nil
+}
+
+// fkAction returns the referential action, defaulting to CASCADE like GenerateMigrationSQL
+func fkAction(action string) string {
+	if action == "" {
+		return "CASCADE"
+	}
+	return action
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
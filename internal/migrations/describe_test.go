@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+const describeSchema = `
+datasource db {
+  provider = "postgresql"
+}
+
+model User {
+  id        Int      @id @default(autoincrement())
+  email     String   @unique @map("email_address")
+  createdAt DateTime @default(now()) @map("created_at")
+  posts     Post[]
+
+  @@map("users")
+}
+
+model Post {
+  id       Int     @id @default(autoincrement())
+  title    String?
+  authorId Int     @map("author_id")
+  author   User    @relation(fields: [authorId], references: [id], onDelete: Cascade)
+
+  @@index([authorId])
+  @@map("posts")
+}
+`
+
+func TestDescribeModel(t *testing.T) {
+	schema, errs, err := parser.Parse(describeSchema)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+
+	post, err := DescribeModel(schema, "Post", "postgresql")
+	if err != nil {
+		t.Fatalf("DescribeModel failed: %v", err)
+	}
+	for _, want := range []string{
+		`Model Post -> table "posts" (postgresql)`,
+		"author_id",
+		"title",
+		"NULL",
+		"(author_id) -> users (id) ON DELETE CASCADE",
+	} {
+		if !strings.Contains(post, want) {
+			t.Errorf("description of Post should contain %q, got:\n%s", want, post)
+		}
+	}
+	// o campo de relação não vira coluna
+	if strings.Contains(post, "  author ") {
+		t.Errorf("relation field should not be listed as a column:\n%s", post)
+	}
+
+	user, err := DescribeModel(schema, "User", "postgresql")
+	if err != nil {
+		t.Fatalf("DescribeModel failed: %v", err)
+	}
+	for _, want := range []string{
+		`Model User -> table "users" (postgresql)`,
+		"email_address",
+		"created_at",
+		"referenced by posts (author_id) -> (id)",
+	} {
+		if !strings.Contains(user, want) {
+			t.Errorf("description of User should contain %q, got:\n%s", want, user)
+		}
+	}
+
+	if _, err := DescribeModel(schema, "Comment", "postgresql"); err == nil {
+		t.Error("DescribeModel should fail for an unknown model")
+	}
+}
@@ -102,6 +102,9 @@ func buildDriftReason(diff *SchemaDiff) string {
 		for _, colName := range alter.DropColumns {
 			parts = append(parts, fmt.Sprintf("  [-] Removed column `%s`", colName))
 		}
+		for _, rename := range alter.RenameColumns {
+			parts = append(parts, fmt.Sprintf("  [*] Renamed column `%s` to `%s`", rename.From, rename.To))
+		}
 		for _, colAlter := range alter.AlterColumns {
 			parts = append(parts, fmt.Sprintf("  [*] Changed column `%s`", colAlter.ColumnName))
 		}
@@ -2,6 +2,9 @@ package migrations
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/carlosnayan/prisma-go-client/internal/dialect"
@@ -10,6 +13,7 @@ import (
 
 // SchemaDiff represents differences between schema and database
 type SchemaDiff struct {
+	EnumsToCreate       []EnumDefinition // Enum types created before the tables (PostgreSQL)
 	TablesToCreate      []TableDefinition
 	TablesToAlter       []TableAlteration
 	TablesToDrop        []string
@@ -18,6 +22,9 @@ type SchemaDiff struct {
 	ForeignKeysToCreate []ForeignKeyDefinition
 	ForeignKeysToAlter  []ForeignKeyDefinition // FKs that need to be altered (drop + recreate)
 	ForeignKeysToDrop   []ForeignKeyDefinition // FKs that need to be removed
+	ChecksToCreate      []CheckDefinition      // CHECK constraints added to existing tables
+	ChecksToDrop        []CheckDefinition      // CHECK constraints removed from existing tables
+	Previous            *DatabaseSchema        // Database state the diff was computed against, used by GenerateRollbackSQL
 }
 
 // ForeignKeyDefinition represents a foreign key constraint
@@ -31,11 +38,25 @@ type ForeignKeyDefinition struct {
 	OnUpdate          string   // "CASCADE", "SET NULL", "RESTRICT", "NO ACTION"
 }
 
+// CheckDefinition represents a CHECK constraint
+type CheckDefinition struct {
+	Name       string // Constraint name (e.g., "reviews_rating_check")
+	TableName  string // Table the constraint belongs to
+	Expression string // SQL condition (e.g., "rating BETWEEN 1 AND 5")
+}
+
+// EnumDefinition represents an enum type
+type EnumDefinition struct {
+	Name   string
+	Values []string
+}
+
 // TableDefinition represents a table to be created
 type TableDefinition struct {
 	Name        string
 	Columns     []ColumnDefinition
-	CompositePK []string // For composite primary keys from @@id([field1, field2])
+	CompositePK []string          // For composite primary keys from @@id([field1, field2])
+	Checks      []CheckDefinition // CHECK constraints from @@check
 }
 
 // ColumnDefinition represents a column
@@ -46,29 +67,107 @@ type ColumnDefinition struct {
 	IsPrimaryKey bool
 	IsUnique     bool
 	DefaultValue string
+	EnumValues   []string // Allowed values of an enum column; Type is the enum name
+	RenamedFrom  string   // Existing column to rename to this one (see getRenamedFromField)
 }
 
 // TableAlteration represents alterations to a table
 type TableAlteration struct {
-	TableName    string
-	AddColumns   []ColumnDefinition
-	DropColumns  []string
-	AlterColumns []ColumnAlteration
+	TableName     string
+	AddColumns    []ColumnDefinition
+	DropColumns   []string
+	AlterColumns  []ColumnAlteration
+	RenameColumns []ColumnRename
+	// Table is the schema definition of the table, for dialects that recreate the
+	// table to alter its columns (SQLite)
+	Table *TableDefinition
+}
+
+// ColumnRename represents a column renamed in place, keeping its data
+type ColumnRename struct {
+	From string
+	To   string
 }
 
 // ColumnAlteration represents an alteration to a column
 type ColumnAlteration struct {
-	ColumnName  string
-	NewType     string
-	NewNullable bool
+	ColumnName     string
+	NewType        string
+	NewNullable    bool
+	DefaultChanged bool   // The schema default differs from the database default
+	NewDefault     string // Default to set, like ColumnDefinition.DefaultValue; empty drops it
+	// NullableChanged reports that the column goes from NULL to NOT NULL or back (NewNullable)
+	NullableChanged bool
+	// TypeChanged reports that the column changes type, from OldType to the type of Column
+	TypeChanged bool
+	OldType     string // SQL type of the database column
+	// Column is the schema definition of the column, for dialects that restate the
+	// whole column to change it (MySQL MODIFY)
+	Column ColumnDefinition
 }
 
 // IndexDefinition represents an index
 type IndexDefinition struct {
-	Name      string
-	TableName string
-	Columns   []string
-	IsUnique  bool
+	Name          string
+	TableName     string
+	Columns       []string // Column names, or SQL expressions for expression entries
+	ColumnOptions []IndexColumn
+	IsUnique      bool
+	Where         string // Predicate for partial indexes (e.g., "deleted_at IS NULL")
+	Type          string // Access method (PostgreSQL), e.g. "gin"; empty for the default btree
+}
+
+// IndexColumn holds the modifiers of an index entry, from
+// @@index([created_at(sort: Desc), name(length: 10), lower(email)])
+type IndexColumn struct {
+	Sort       string // "DESC" for descending entries, empty for the default order
+	Length     int    // Indexed prefix length (MySQL), 0 for the whole value
+	Ops        string // Operator class (PostgreSQL), e.g. "text_pattern_ops"
+	Expression bool   // The entry is a SQL expression rather than a column name
+
+	expression map[string]interface{} // Parsed expression, rendered once the model is known
+}
+
+// column returns the modifiers of the i-th entry of Columns
+func (idx IndexDefinition) column(i int) IndexColumn {
+	if i < len(idx.ColumnOptions) {
+		return idx.ColumnOptions[i]
+	}
+	return IndexColumn{}
+}
+
+// indexColumnsSQL renders the entries of an index with their modifiers. Expressions are
+// wrapped in parentheses, which all providers accept. Prefix lengths only exist on MySQL
+// and operator classes only on PostgreSQL; elsewhere they are left out with a warning.
+func indexColumnsSQL(d dialect.Dialect, provider string, idx IndexDefinition) (string, []string) {
+	var warnings []string
+	parts := make([]string, len(idx.Columns))
+	for i, col := range idx.Columns {
+		column := idx.column(i)
+		part := d.QuoteIdentifier(col)
+		if column.Expression {
+			part = "(" + col + ")"
+		}
+		if column.Length > 0 {
+			if provider == "mysql" {
+				part += fmt.Sprintf("(%d)", column.Length)
+			} else {
+				warnings = append(warnings, fmt.Sprintf("only MySQL supports index prefix lengths, ignoring length %d on %s.%s", column.Length, idx.Name, col))
+			}
+		}
+		if column.Ops != "" {
+			if provider == "postgresql" {
+				part += " " + column.Ops
+			} else {
+				warnings = append(warnings, fmt.Sprintf("only PostgreSQL supports operator classes, ignoring %s on %s.%s", column.Ops, idx.Name, col))
+			}
+		}
+		if column.Sort == "DESC" {
+			part += " DESC"
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ", "), warnings
 }
 
 // needsUUIDExtension checks if the migration needs the pgcrypto extension for gen_random_uuid()
@@ -94,10 +193,28 @@ func needsUUIDExtension(diff *SchemaDiff) bool {
 	return false
 }
 
+// newTableSchemas returns the sorted schemas of the tables to create, other than public.
+// They may already exist, so they are created with IF NOT EXISTS and never dropped.
+func newTableSchemas(diff *SchemaDiff) []string {
+	seen := make(map[string]bool)
+	var schemas []string
+	for _, table := range diff.TablesToCreate {
+		if schemaName, _ := splitTableName(table.Name); schemaName != "" && !seen[schemaName] {
+			seen[schemaName] = true
+			schemas = append(schemas, schemaName)
+		}
+	}
+	sort.Strings(schemas)
+	return schemas
+}
+
 // GenerateMigrationSQL generates migration SQL based on differences
+// Operations are emitted in a deterministic order (see sortDiff), so generating
+// the same diff twice yields byte-identical SQL
 func GenerateMigrationSQL(diff *SchemaDiff, provider string) (string, error) {
 	var steps []string
 	d := dialect.GetDialect(provider)
+	diff = sortDiff(diff)
 
 	// If PostgreSQL and needs gen_random_uuid(), create extension
 	if provider == "postgresql" && needsUUIDExtension(diff) {
@@ -107,64 +224,54 @@ func GenerateMigrationSQL(diff *SchemaDiff, provider string) (string, error) {
 		steps = append(steps, sql.String())
 	}
 
+	// Create the schemas of new tables outside public (@@schema)
+	if schemas := newTableSchemas(diff); len(schemas) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- CreateSchema\n")
+		for _, schemaName := range schemas {
+			sql.WriteString(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;\n", d.QuoteIdentifier(schemaName)))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	// Create enum types; MySQL and SQLite declare the values on each column instead
+	if hasEnumTypes(provider) && len(diff.EnumsToCreate) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- CreateEnum\n")
+		for _, enum := range diff.EnumsToCreate {
+			sql.WriteString(fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);\n", d.QuoteIdentifier(enum.Name), quotedValues(d, enum.Values)))
+		}
+		steps = append(steps, sql.String())
+	}
+
 	// Create tables
 	if len(diff.TablesToCreate) > 0 {
 		var sql strings.Builder
 		sql.WriteString("-- CreateTable\n")
 		for _, table := range diff.TablesToCreate {
-			sql.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", d.QuoteIdentifier(table.Name)))
-
-			var columns []string
-			var primaryKeys []string
-
-			for _, col := range table.Columns {
-				colDef := fmt.Sprintf("  %s %s", d.QuoteIdentifier(col.Name), d.MapType(col.Type, col.IsNullable))
-
-				if !col.IsNullable {
-					colDef += " NOT NULL"
-				}
-
-				if col.DefaultValue != "" {
-					colDef += " DEFAULT " + col.DefaultValue
-				}
-
-				if col.IsPrimaryKey {
-					primaryKeys = append(primaryKeys, col.Name)
-				}
-
-				columns = append(columns, colDef)
-			}
-
-			sql.WriteString(strings.Join(columns, ",\n"))
+			sql.WriteString(createTableSQL(d, provider, table.Name, table))
+		}
+		steps = append(steps, sql.String())
+	}
 
-			// Handle composite primary key from @@id
-			if len(table.CompositePK) > 0 {
-				quotedPKs := make([]string, len(table.CompositePK))
-				for i, pk := range table.CompositePK {
-					quotedPKs[i] = d.QuoteIdentifier(pk)
-				}
-				if provider == "mysql" {
-					sql.WriteString(fmt.Sprintf(",\n  PRIMARY KEY (%s)", strings.Join(quotedPKs, ", ")))
-				} else {
-					sql.WriteString(fmt.Sprintf(",\n  CONSTRAINT %s PRIMARY KEY (%s)",
-						d.QuoteIdentifier(table.Name+"_pkey"),
-						strings.Join(quotedPKs, ", ")))
-				}
-			} else if len(primaryKeys) > 0 {
-				quotedPKs := make([]string, len(primaryKeys))
-				for i, pk := range primaryKeys {
-					quotedPKs[i] = d.QuoteIdentifier(pk)
-				}
-				if provider == "mysql" {
-					sql.WriteString(fmt.Sprintf(",\n  PRIMARY KEY (%s)", strings.Join(quotedPKs, ", ")))
-				} else {
-					sql.WriteString(fmt.Sprintf(",\n  CONSTRAINT %s PRIMARY KEY (%s)",
-						d.QuoteIdentifier(table.Name+"_pkey"),
-						strings.Join(quotedPKs, ", ")))
-				}
+	// Rename columns before anything refers to them by the new name
+	for _, alter := range diff.TablesToAlter {
+		if len(alter.RenameColumns) > 0 {
+			var sql strings.Builder
+			sql.WriteString("-- AlterTable\n")
+			for _, rename := range alter.RenameColumns {
+				sql.WriteString(renameColumnSQL(d, alter.TableName, rename.From, rename.To))
 			}
+			steps = append(steps, sql.String())
+		}
+	}
 
-			sql.WriteString("\n);\n")
+	// Drop CHECK constraints before the columns they may reference
+	if len(diff.ChecksToDrop) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- DropCheck\n")
+		for _, check := range diff.ChecksToDrop {
+			sql.WriteString(dropCheckSQL(d, provider, check))
 		}
 		steps = append(steps, sql.String())
 	}
@@ -202,14 +309,14 @@ func GenerateMigrationSQL(diff *SchemaDiff, provider string) (string, error) {
 				colDef := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s",
 					d.QuoteIdentifier(alter.TableName),
 					d.QuoteIdentifier(col.Name),
-					d.MapType(col.Type, col.IsNullable))
+					columnTypeSQL(d, provider, col))
 
 				if !col.IsNullable {
 					colDef += " NOT NULL"
 				}
 
 				if col.DefaultValue != "" {
-					colDef += " DEFAULT " + col.DefaultValue
+					colDef += " DEFAULT " + columnDefaultSQL(d, col.DefaultValue)
 				}
 
 				sql.WriteString(colDef + ";\n")
@@ -218,6 +325,41 @@ func GenerateMigrationSQL(diff *SchemaDiff, provider string) (string, error) {
 		}
 	}
 
+	// Add CHECK constraints after the columns they may reference
+	if len(diff.ChecksToCreate) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- AddCheck\n")
+		for _, check := range diff.ChecksToCreate {
+			sql.WriteString(addCheckSQL(d, provider, check))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	// Alter tables (column types, defaults and nullability)
+	for _, alter := range diff.TablesToAlter {
+		if rebuildsTable(provider, alter) {
+			continue
+		}
+		var statements []string
+		for _, col := range alter.AlterColumns {
+			// MySQL restates the whole column, default included, so a nullability change
+			// carries the new type and the default needs no statement of its own
+			modifies := provider == "mysql" && (col.TypeChanged || col.NullableChanged)
+			if col.TypeChanged && (provider != "mysql" || !col.NullableChanged) {
+				statements = append(statements, alterTypeSQL(d, provider, alter.TableName, col, columnTypeSQL(d, provider, col.Column)))
+			}
+			if col.DefaultChanged && !modifies {
+				statements = append(statements, alterDefaultSQL(d, provider, alter.TableName, col))
+			}
+			if col.NullableChanged {
+				statements = append(statements, alterNullabilitySQL(d, provider, alter.TableName, col))
+			}
+		}
+		if len(statements) > 0 {
+			steps = append(steps, "-- AlterTable\n"+strings.Join(statements, "\n")+"\n")
+		}
+	}
+
 	// Drop indexes
 	if len(diff.IndexesToDrop) > 0 {
 		var sql strings.Builder
@@ -228,6 +370,13 @@ func GenerateMigrationSQL(diff *SchemaDiff, provider string) (string, error) {
 		steps = append(steps, sql.String())
 	}
 
+	// Recreate the tables SQLite cannot alter in place, after their dropped indexes are gone
+	for _, alter := range diff.TablesToAlter {
+		if rebuildsTable(provider, alter) {
+			steps = append(steps, rebuildTableSQL(d, provider, *alter.Table, rebuiltIndexes(diff, alter)))
+		}
+	}
+
 	// Drop tables
 	if len(diff.TablesToDrop) > 0 {
 		var sql strings.Builder
@@ -247,15 +396,32 @@ func GenerateMigrationSQL(diff *SchemaDiff, provider string) (string, error) {
 			if idx.IsUnique {
 				unique = "UNIQUE "
 			}
-			quotedCols := make([]string, len(idx.Columns))
-			for i, col := range idx.Columns {
-				quotedCols[i] = d.QuoteIdentifier(col)
+			columns, warnings := indexColumnsSQL(d, provider, idx)
+			for _, warning := range warnings {
+				sql.WriteString("-- WARNING: " + warning + "\n")
 			}
-			sql.WriteString(fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);\n",
+			using := ""
+			if method := indexMethod(provider, idx.Type); method != "" {
+				using = " USING " + strings.ToUpper(method)
+			} else if idx.Type != "" && idx.Type != "btree" {
+				sql.WriteString(fmt.Sprintf("-- WARNING: only PostgreSQL supports index types, creating %s as a regular index\n", idx.Name))
+			}
+			where := ""
+			if idx.Where != "" {
+				if provider == "mysql" {
+					// MySQL has no partial indexes; the predicate cannot be enforced
+					sql.WriteString(fmt.Sprintf("-- WARNING: MySQL does not support partial indexes, ignoring WHERE %s\n", idx.Where))
+				} else {
+					where = " WHERE " + idx.Where
+				}
+			}
+			sql.WriteString(fmt.Sprintf("CREATE %sINDEX %s ON %s%s (%s)%s;\n",
 				unique,
 				d.QuoteIdentifier(idx.Name),
 				d.QuoteIdentifier(idx.TableName),
-				strings.Join(quotedCols, ", ")))
+				using,
+				columns,
+				where))
 		}
 		steps = append(steps, sql.String())
 	}
@@ -289,32 +455,7 @@ func GenerateMigrationSQL(diff *SchemaDiff, provider string) (string, error) {
 		var sql strings.Builder
 		sql.WriteString("-- AddForeignKey\n")
 		for _, fk := range diff.ForeignKeysToCreate {
-			quotedCols := make([]string, len(fk.Columns))
-			for i, col := range fk.Columns {
-				quotedCols[i] = d.QuoteIdentifier(col)
-			}
-			quotedRefCols := make([]string, len(fk.ReferencedColumns))
-			for i, col := range fk.ReferencedColumns {
-				quotedRefCols[i] = d.QuoteIdentifier(col)
-			}
-
-			onDelete := fk.OnDelete
-			if onDelete == "" {
-				onDelete = "CASCADE"
-			}
-			onUpdate := fk.OnUpdate
-			if onUpdate == "" {
-				onUpdate = "CASCADE"
-			}
-
-			sql.WriteString(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE %s ON UPDATE %s;\n",
-				d.QuoteIdentifier(fk.TableName),
-				d.QuoteIdentifier(fk.Name),
-				strings.Join(quotedCols, ", "),
-				d.QuoteIdentifier(fk.ReferencedTable),
-				strings.Join(quotedRefCols, ", "),
-				onDelete,
-				onUpdate))
+			sql.WriteString(addForeignKeySQL(d, fk))
 		}
 		steps = append(steps, sql.String())
 	}
@@ -324,42 +465,334 @@ func GenerateMigrationSQL(diff *SchemaDiff, provider string) (string, error) {
 		var sql strings.Builder
 		sql.WriteString("-- AlterForeignKey (recreate with new attributes)\n")
 		for _, fk := range diff.ForeignKeysToAlter {
-			quotedCols := make([]string, len(fk.Columns))
-			for i, col := range fk.Columns {
-				quotedCols[i] = d.QuoteIdentifier(col)
+			sql.WriteString(addForeignKeySQL(d, fk))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	return strings.Join(steps, "\n"), nil
+}
+
+// createTableSQL renders the CREATE TABLE statement for table under the given name
+func createTableSQL(d dialect.Dialect, provider, name string, table TableDefinition) string {
+	var sql strings.Builder
+	sql.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", d.QuoteIdentifier(name)))
+
+	var columns []string
+	var primaryKeys []string
+
+	for _, col := range table.Columns {
+		colDef := fmt.Sprintf("  %s %s", d.QuoteIdentifier(col.Name), columnTypeSQL(d, provider, col))
+
+		if !col.IsNullable {
+			colDef += " NOT NULL"
+		}
+
+		if col.DefaultValue != "" {
+			colDef += " DEFAULT " + columnDefaultSQL(d, col.DefaultValue)
+		}
+
+		if col.IsPrimaryKey {
+			primaryKeys = append(primaryKeys, col.Name)
+		}
+
+		columns = append(columns, colDef)
+	}
+
+	sql.WriteString(strings.Join(columns, ",\n"))
+
+	// Handle composite primary key from @@id
+	if len(table.CompositePK) > 0 {
+		primaryKeys = table.CompositePK
+	}
+	sql.WriteString(tableConstraintsSQL(d, provider, table.Name, primaryKeys, table.Checks))
+
+	sql.WriteString("\n);\n")
+	return sql.String()
+}
+
+// rebuildsTable reports whether alter is applied by recreating the table, which is
+// how SQLite changes the type of a column
+func rebuildsTable(provider string, alter TableAlteration) bool {
+	if provider != "sqlite" || alter.Table == nil {
+		return false
+	}
+	for _, col := range alter.AlterColumns {
+		if col.TypeChanged {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildTableSQL recreates a SQLite table from its schema definition: the rows are
+// copied into a new table that replaces the old one, and indexes are created again.
+// The new table also picks up any default and nullability changes of its columns.
+func rebuildTableSQL(d dialect.Dialect, provider string, table TableDefinition, indexes []*IndexInfo) string {
+	newName := "new_" + table.Name
+	quotedCols := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		quotedCols[i] = d.QuoteIdentifier(col.Name)
+	}
+	columns := strings.Join(quotedCols, ", ")
+
+	var sql strings.Builder
+	sql.WriteString("-- RedefineTables\n")
+	sql.WriteString("PRAGMA defer_foreign_keys=ON;\n")
+	sql.WriteString("PRAGMA foreign_keys=OFF;\n")
+	sql.WriteString(createTableSQL(d, provider, newName, table))
+	sql.WriteString(fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s;\n",
+		d.QuoteIdentifier(newName), columns, columns, d.QuoteIdentifier(table.Name)))
+	sql.WriteString(fmt.Sprintf("DROP TABLE %s;\n", d.QuoteIdentifier(table.Name)))
+	sql.WriteString(fmt.Sprintf("ALTER TABLE %s RENAME TO %s;\n", d.QuoteIdentifier(newName), d.QuoteIdentifier(table.Name)))
+	for _, idx := range indexes {
+		sql.WriteString(createIndexInfoSQL(d, table.Name, idx))
+	}
+	sql.WriteString("PRAGMA foreign_keys=ON;\n")
+	sql.WriteString("PRAGMA defer_foreign_keys=OFF;\n")
+	return sql.String()
+}
+
+// rebuiltIndexes returns the database indexes of a rebuilt table that the migration keeps,
+// with renamed columns under their new names. Indexes the migration creates are added
+// by the CreateIndex step.
+func rebuiltIndexes(diff *SchemaDiff, alter TableAlteration) []*IndexInfo {
+	if diff.Previous == nil || diff.Previous.Tables[alter.TableName] == nil {
+		return nil
+	}
+	dropped := make(map[string]bool, len(diff.IndexesToDrop))
+	for _, name := range diff.IndexesToDrop {
+		dropped[strings.ToLower(name)] = true
+	}
+	renamed := make(map[string]string, len(alter.RenameColumns))
+	for _, rename := range alter.RenameColumns {
+		renamed[rename.From] = rename.To
+	}
+	columns := make(map[string]bool, len(alter.Table.Columns))
+	for _, col := range alter.Table.Columns {
+		columns[col.Name] = true
+	}
+
+	var indexes []*IndexInfo
+	for _, idx := range diff.Previous.Tables[alter.TableName].Indexes {
+		if dropped[strings.ToLower(idx.Name)] {
+			continue
+		}
+		kept := *idx
+		kept.Columns = make([]string, len(idx.Columns))
+		var keyColumns []string // expressions are copied as they are
+		for i, col := range idx.Columns {
+			if i < len(idx.ColumnInfos) && idx.ColumnInfos[i].Expression {
+				kept.Columns[i] = col
+				continue
 			}
-			quotedRefCols := make([]string, len(fk.ReferencedColumns))
-			for i, col := range fk.ReferencedColumns {
-				quotedRefCols[i] = d.QuoteIdentifier(col)
+			if to, ok := renamed[col]; ok {
+				col = to
 			}
+			kept.Columns[i] = col
+			keyColumns = append(keyColumns, col)
+		}
+		if allColumnsExist(keyColumns, columns) {
+			indexes = append(indexes, &kept)
+		}
+	}
+	return indexes
+}
+
+// allColumnsExist reports whether every name in names is in columns
+func allColumnsExist(names []string, columns map[string]bool) bool {
+	for _, name := range names {
+		if !columns[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// tableConstraintsSQL renders the primary key and CHECK constraints closing a CREATE TABLE
+func tableConstraintsSQL(d dialect.Dialect, provider, tableName string, primaryKeys []string, checks []CheckDefinition) string {
+	var sql strings.Builder
+	if len(primaryKeys) > 0 {
+		quotedPKs := make([]string, len(primaryKeys))
+		for i, pk := range primaryKeys {
+			quotedPKs[i] = d.QuoteIdentifier(pk)
+		}
+		if provider == "mysql" {
+			sql.WriteString(fmt.Sprintf(",\n  PRIMARY KEY (%s)", strings.Join(quotedPKs, ", ")))
+		} else {
+			sql.WriteString(fmt.Sprintf(",\n  CONSTRAINT %s PRIMARY KEY (%s)",
+				d.QuoteIdentifier(unqualifiedTableName(tableName)+"_pkey"),
+				strings.Join(quotedPKs, ", ")))
+		}
+	}
+	for _, check := range checks {
+		sql.WriteString(fmt.Sprintf(",\n  CONSTRAINT %s CHECK (%s)", d.QuoteIdentifier(check.Name), check.Expression))
+	}
+	return sql.String()
+}
+
+// addForeignKeySQL renders the statement adding fk; actions default to CASCADE
+func addForeignKeySQL(d dialect.Dialect, fk ForeignKeyDefinition) string {
+	quotedCols := make([]string, len(fk.Columns))
+	for i, col := range fk.Columns {
+		quotedCols[i] = d.QuoteIdentifier(col)
+	}
+	quotedRefCols := make([]string, len(fk.ReferencedColumns))
+	for i, col := range fk.ReferencedColumns {
+		quotedRefCols[i] = d.QuoteIdentifier(col)
+	}
+
+	onDelete := fk.OnDelete
+	if onDelete == "" {
+		onDelete = "CASCADE"
+	}
+	onUpdate := fk.OnUpdate
+	if onUpdate == "" {
+		onUpdate = "CASCADE"
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE %s ON UPDATE %s;\n",
+		d.QuoteIdentifier(fk.TableName),
+		d.QuoteIdentifier(fk.Name),
+		strings.Join(quotedCols, ", "),
+		d.QuoteIdentifier(fk.ReferencedTable),
+		strings.Join(quotedRefCols, ", "),
+		onDelete,
+		onUpdate)
+}
+
+// sortDiff returns a copy of diff with every operation list in a deterministic order:
+// tables to create by dependency (referenced tables first) then name, and everything
+// else by table and name. Columns keep their schema order.
+func sortDiff(diff *SchemaDiff) *SchemaDiff {
+	sorted := &SchemaDiff{
+		EnumsToCreate:       append([]EnumDefinition(nil), diff.EnumsToCreate...),
+		TablesToCreate:      sortTablesByDependency(diff.TablesToCreate, diff.ForeignKeysToCreate),
+		TablesToAlter:       make([]TableAlteration, len(diff.TablesToAlter)),
+		TablesToDrop:        append([]string(nil), diff.TablesToDrop...),
+		IndexesToCreate:     append([]IndexDefinition(nil), diff.IndexesToCreate...),
+		IndexesToDrop:       append([]string(nil), diff.IndexesToDrop...),
+		ForeignKeysToCreate: sortForeignKeys(diff.ForeignKeysToCreate),
+		ForeignKeysToAlter:  sortForeignKeys(diff.ForeignKeysToAlter),
+		ForeignKeysToDrop:   sortForeignKeys(diff.ForeignKeysToDrop),
+		ChecksToCreate:      sortChecks(diff.ChecksToCreate),
+		ChecksToDrop:        sortChecks(diff.ChecksToDrop),
+		Previous:            diff.Previous,
+	}
+
+	for i, alter := range diff.TablesToAlter {
+		alter.DropColumns = append([]string(nil), alter.DropColumns...)
+		sort.Strings(alter.DropColumns)
+		alter.RenameColumns = append([]ColumnRename(nil), alter.RenameColumns...)
+		sort.SliceStable(alter.RenameColumns, func(a, b int) bool {
+			return alter.RenameColumns[a].To < alter.RenameColumns[b].To
+		})
+		alter.AlterColumns = append([]ColumnAlteration(nil), alter.AlterColumns...)
+		sort.SliceStable(alter.AlterColumns, func(a, b int) bool {
+			return alter.AlterColumns[a].ColumnName < alter.AlterColumns[b].ColumnName
+		})
+		sorted.TablesToAlter[i] = alter
+	}
+	sort.SliceStable(sorted.TablesToAlter, func(i, j int) bool {
+		return sorted.TablesToAlter[i].TableName < sorted.TablesToAlter[j].TableName
+	})
+	sort.Strings(sorted.TablesToDrop)
+	sort.Strings(sorted.IndexesToDrop)
+	sort.SliceStable(sorted.IndexesToCreate, func(i, j int) bool {
+		a, b := sorted.IndexesToCreate[i], sorted.IndexesToCreate[j]
+		if a.TableName != b.TableName {
+			return a.TableName < b.TableName
+		}
+		return a.Name < b.Name
+	})
+	return sorted
+}
+
+// sortForeignKeys returns a copy of fks ordered by table and constraint name
+func sortForeignKeys(fks []ForeignKeyDefinition) []ForeignKeyDefinition {
+	sorted := append([]ForeignKeyDefinition(nil), fks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TableName != sorted[j].TableName {
+			return sorted[i].TableName < sorted[j].TableName
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
 
-			onDelete := fk.OnDelete
-			if onDelete == "" {
-				onDelete = "CASCADE"
+// sortChecks returns a copy of checks ordered by table and constraint name
+func sortChecks(checks []CheckDefinition) []CheckDefinition {
+	sorted := append([]CheckDefinition(nil), checks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TableName != sorted[j].TableName {
+			return sorted[i].TableName < sorted[j].TableName
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// sortTablesByDependency orders tables so that a table comes after the tables its
+// foreign keys reference, breaking ties by name. Tables in a reference cycle are
+// appended by name, since their FKs are added after every table is created anyway.
+func sortTablesByDependency(tables []TableDefinition, fks []ForeignKeyDefinition) []TableDefinition {
+	pending := append([]TableDefinition(nil), tables...)
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Name < pending[j].Name
+	})
+
+	// Dependências apenas entre tabelas criadas nesta migração
+	creating := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		creating[table.Name] = true
+	}
+	dependsOn := make(map[string][]string)
+	for _, fk := range fks {
+		if creating[fk.ReferencedTable] && fk.ReferencedTable != fk.TableName {
+			dependsOn[fk.TableName] = append(dependsOn[fk.TableName], fk.ReferencedTable)
+		}
+	}
+
+	sorted := make([]TableDefinition, 0, len(tables))
+	emitted := make(map[string]bool, len(tables))
+	for len(pending) > 0 {
+		next := 0 // Ciclo: sem tabela pronta, emite a primeira por nome
+		for i, table := range pending {
+			ready := true
+			for _, dep := range dependsOn[table.Name] {
+				if !emitted[dep] {
+					ready = false
+					break
+				}
 			}
-			onUpdate := fk.OnUpdate
-			if onUpdate == "" {
-				onUpdate = "CASCADE"
+			if ready {
+				next = i
+				break
 			}
-
-			sql.WriteString(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE %s ON UPDATE %s;\n",
-				d.QuoteIdentifier(fk.TableName),
-				d.QuoteIdentifier(fk.Name),
-				strings.Join(quotedCols, ", "),
-				d.QuoteIdentifier(fk.ReferencedTable),
-				strings.Join(quotedRefCols, ", "),
-				onDelete,
-				onUpdate))
 		}
-		steps = append(steps, sql.String())
+		sorted = append(sorted, pending[next])
+		emitted[pending[next].Name] = true
+		pending = append(pending[:next], pending[next+1:]...)
 	}
+	return sorted
+}
 
-	return strings.Join(steps, "\n"), nil
+// SchemaOptions controls optional behavior of SchemaToSQLWithOptions
+type SchemaOptions struct {
+	// IndexForeignKeys creates an index on each foreign key that no index or primary
+	// key already covers. PostgreSQL and SQLite don't index FK columns automatically,
+	// so joins and cascades through them would scan the whole table.
+	IndexForeignKeys bool
 }
 
 // SchemaToSQL converts a Prisma schema to SQL (creates everything from scratch)
 // Use CompareSchema to detect incremental changes
 func SchemaToSQL(schema *parser.Schema, provider string) (*SchemaDiff, error) {
+	return SchemaToSQLWithOptions(schema, provider, SchemaOptions{})
+}
+
+// SchemaToSQLWithOptions converts a Prisma schema to SQL like SchemaToSQL, applying options
+func SchemaToSQLWithOptions(schema *parser.Schema, provider string, options SchemaOptions) (*SchemaDiff, error) {
 	diff := &SchemaDiff{
 		ForeignKeysToCreate: []ForeignKeyDefinition{},
 		IndexesToCreate:     []IndexDefinition{},
@@ -377,6 +810,7 @@ func SchemaToSQL(schema *parser.Schema, provider string) (*SchemaDiff, error) {
 		table := TableDefinition{
 			Name:    tableName,
 			Columns: []ColumnDefinition{},
+			Checks:  extractChecks(tableName, model),
 		}
 
 		for _, field := range model.Fields {
@@ -407,7 +841,7 @@ func SchemaToSQL(schema *parser.Schema, provider string) (*SchemaDiff, error) {
 				case "unique":
 					col.IsUnique = true
 					// Add explicit unique index
-					indexName := fmt.Sprintf("%s_%s_key", tableName, columnName)
+					indexName := fmt.Sprintf("%s_%s_key", unqualifiedTableName(tableName), columnName)
 					diff.IndexesToCreate = append(diff.IndexesToCreate, IndexDefinition{
 						Name:      indexName,
 						TableName: tableName,
@@ -528,20 +962,85 @@ func SchemaToSQL(schema *parser.Schema, provider string) (*SchemaDiff, error) {
 				}
 			}
 
+			applyEnumType(schema, field, &col)
 			table.Columns = append(table.Columns, col)
 		}
 
 		diff.TablesToCreate = append(diff.TablesToCreate, table)
 	}
 
+	var columns []ColumnDefinition
+	for _, table := range diff.TablesToCreate {
+		columns = append(columns, table.Columns...)
+	}
+	diff.EnumsToCreate = enumsToCreate(schema, columns, nil)
+
 	// Process relations and @@unique attributes
-	processRelationsAndUniqueForSchema(schema, diff, modelMap)
+	processRelationsAndUniqueForSchema(schema, diff, modelMap, dialect.GetDialect(provider))
+
+	if options.IndexForeignKeys {
+		addForeignKeyIndexes(diff)
+	}
 
 	return diff, nil
 }
 
+// addForeignKeyIndexes adds a <table>_<columns>_idx index for each foreign key
+// whose columns are not the leading columns of an existing index or primary key
+func addForeignKeyIndexes(diff *SchemaDiff) {
+	for _, fk := range diff.ForeignKeysToCreate {
+		if isForeignKeyIndexed(diff, fk) {
+			continue
+		}
+		diff.IndexesToCreate = append(diff.IndexesToCreate, IndexDefinition{
+			Name:      fmt.Sprintf("%s_%s_idx", unqualifiedTableName(fk.TableName), strings.Join(fk.Columns, "_")),
+			TableName: fk.TableName,
+			Columns:   append([]string{}, fk.Columns...),
+		})
+	}
+}
+
+// isForeignKeyIndexed checks if an index or the primary key of the FK table starts
+// with the FK columns. Partial indexes don't count since they skip rows, and neither
+// do indexes of other types than btree (GIN, GiST, ...), which aren't built for the
+// lookups of a join.
+func isForeignKeyIndexed(diff *SchemaDiff, fk ForeignKeyDefinition) bool {
+	for _, index := range diff.IndexesToCreate {
+		if index.TableName == fk.TableName && index.Where == "" && (index.Type == "" || index.Type == "btree") && hasColumnPrefix(index.Columns, fk.Columns) {
+			return true
+		}
+	}
+	for _, table := range diff.TablesToCreate {
+		if table.Name != fk.TableName {
+			continue
+		}
+		if hasColumnPrefix(table.CompositePK, fk.Columns) {
+			return true
+		}
+		for _, col := range table.Columns {
+			if col.IsPrimaryKey && hasColumnPrefix([]string{col.Name}, fk.Columns) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasColumnPrefix checks if columns starts with prefix, in the same order
+func hasColumnPrefix(columns, prefix []string) bool {
+	if len(prefix) == 0 || len(columns) < len(prefix) {
+		return false
+	}
+	for i, col := range prefix {
+		if columns[i] != col {
+			return false
+		}
+	}
+	return true
+}
+
 // processRelationsAndUniqueForSchema processes @relation, @@unique, and @@index for SchemaToSQL
-func processRelationsAndUniqueForSchema(schema *parser.Schema, diff *SchemaDiff, modelMap map[string]*parser.Model) {
+func processRelationsAndUniqueForSchema(schema *parser.Schema, diff *SchemaDiff, modelMap map[string]*parser.Model, d dialect.Dialect) {
 	// Process each model
 	for _, model := range schema.Models {
 		// Get mapped table name
@@ -599,52 +1098,8 @@ func processRelationsAndUniqueForSchema(schema *parser.Schema, diff *SchemaDiff,
 			}
 		}
 
-		// Process @@unique attributes
-		for _, attr := range model.Attributes {
-			if attr.Name == "unique" {
-				indexDef := extractUniqueIndex(tableName, attr)
-				if indexDef != nil {
-					// Map column names in index
-					mappedColumns := make([]string, len(indexDef.Columns))
-					for i, col := range indexDef.Columns {
-						// Find field and get mapped name
-						for _, field := range model.Fields {
-							if field.Name == col {
-								mappedColumns[i] = getColumnNameFromField(field)
-								break
-							}
-						}
-						if mappedColumns[i] == "" {
-							mappedColumns[i] = col // Fallback to original name
-						}
-					}
-					indexDef.Columns = mappedColumns
-					diff.IndexesToCreate = append(diff.IndexesToCreate, *indexDef)
-				}
-			}
-			// Process @@index attributes (non-unique indexes)
-			if attr.Name == "index" {
-				indexDef := extractIndex(tableName, attr)
-				if indexDef != nil {
-					// Map column names in index
-					mappedColumns := make([]string, len(indexDef.Columns))
-					for i, col := range indexDef.Columns {
-						// Find field and get mapped name
-						for _, field := range model.Fields {
-							if field.Name == col {
-								mappedColumns[i] = getColumnNameFromField(field)
-								break
-							}
-						}
-						if mappedColumns[i] == "" {
-							mappedColumns[i] = col // Fallback to original name
-						}
-					}
-					indexDef.Columns = mappedColumns
-					diff.IndexesToCreate = append(diff.IndexesToCreate, *indexDef)
-				}
-			}
-		}
+		// Process @@unique and @@index attributes
+		diff.IndexesToCreate = append(diff.IndexesToCreate, modelIndexes(d, model, tableName)...)
 
 		// Process @relation attributes to extract foreign keys
 		for _, field := range model.Fields {
@@ -697,121 +1152,145 @@ func processRelationsAndUniqueForSchema(schema *parser.Schema, diff *SchemaDiff,
 // extractUniqueIndex extracts a unique index from @@unique attribute
 // tableName should already be the mapped table name
 func extractUniqueIndex(tableName string, attr *parser.Attribute) *IndexDefinition {
-	var columns []string
-	var indexName string
+	return extractIndexDefinition(tableName, attr, true)
+}
 
-	// Extract fields from the unique attribute
-	// @@unique([field1, field2], map: "index_name")
-	for _, arg := range attr.Arguments {
-		if arg.Name == "map" {
-			if name, ok := arg.Value.(string); ok {
-				indexName = strings.Trim(name, `"`)
+// extractChecks extracts the CHECK constraints of a model from its @@check attributes
+// @@check("rating BETWEEN 1 AND 5", map: "reviews_rating_check")
+// Unnamed checks are called <table>_check, <table>_check1, ... in schema order
+func extractChecks(tableName string, model *parser.Model) []CheckDefinition {
+	var checks []CheckDefinition
+	unnamed := 0
+	for _, attr := range model.Attributes {
+		if attr.Name != "check" {
+			continue
+		}
+		check := CheckDefinition{TableName: tableName}
+		for _, arg := range attr.Arguments {
+			value, ok := arg.Value.(string)
+			if !ok {
+				continue
 			}
-		} else if arg.Name == "" || arg.Name == "fields" {
-			// First unnamed argument should be the array of fields
-			if fields, ok := arg.Value.([]interface{}); ok {
-				for _, field := range fields {
-					if fieldStr, ok := field.(string); ok {
-						columns = append(columns, strings.Trim(fieldStr, `"`))
-					}
-				}
+			switch arg.Name {
+			case "map":
+				check.Name = strings.Trim(value, `"`)
+			case "", "expression":
+				check.Expression = strings.TrimSpace(strings.Trim(value, `"`))
 			}
 		}
-	}
-
-	// If no fields found, try to get from first argument (might be array without name)
-	if len(columns) == 0 && len(attr.Arguments) > 0 {
-		firstArg := attr.Arguments[0]
-		if firstArg.Name == "" {
-			if fields, ok := firstArg.Value.([]interface{}); ok {
-				for _, field := range fields {
-					if fieldStr, ok := field.(string); ok {
-						columns = append(columns, strings.Trim(fieldStr, `"`))
-					}
-				}
-			}
+		if check.Expression == "" {
+			continue
 		}
-	}
-
-	if len(columns) == 0 {
-		return nil
-	}
-
-	// Generate index name if not provided
-	if indexName == "" {
-		if len(columns) == 1 {
-			indexName = fmt.Sprintf("%s_%s_key", tableName, columns[0])
-		} else {
-			indexName = fmt.Sprintf("%s_%s_key", tableName, columns[0])
+		if check.Name == "" {
+			check.Name = unqualifiedTableName(tableName) + "_check"
+			if unnamed > 0 {
+				check.Name += strconv.Itoa(unnamed)
+			}
+			unnamed++
 		}
+		checks = append(checks, check)
 	}
-
-	return &IndexDefinition{
-		Name:      indexName,
-		TableName: tableName,
-		Columns:   columns,
-		IsUnique:  true,
-	}
+	return checks
 }
 
 // extractIndex extracts a non-unique index from @@index attribute
 // tableName should already be the mapped table name
 func extractIndex(tableName string, attr *parser.Attribute) *IndexDefinition {
-	var columns []string
-	var indexName string
+	return extractIndexDefinition(tableName, attr, false)
+}
+
+// extractIndexDefinition extracts the index of a @@unique or @@index attribute
+// @@index([created_at(sort: Desc), lower(email)], map: "index_name", where: "deleted_at IS NULL", type: Gin)
+// Columns holds field names, and expressions name their fields until modelIndexes maps them.
+func extractIndexDefinition(tableName string, attr *parser.Attribute, unique bool) *IndexDefinition {
+	fields := parser.IndexFields(attr)
+	if len(fields) == 0 {
+		return nil
+	}
 
-	// Extract fields from the index attribute
-	// @@index([field1, field2], map: "index_name")
+	idx := &IndexDefinition{TableName: tableName, IsUnique: unique}
 	for _, arg := range attr.Arguments {
 		if arg.Name == "map" {
 			if name, ok := arg.Value.(string); ok {
-				indexName = strings.Trim(name, `"`)
+				idx.Name = strings.Trim(name, `"`)
 			}
-		} else if arg.Name == "" || arg.Name == "fields" {
-			// First unnamed argument should be the array of fields
-			if fields, ok := arg.Value.([]interface{}); ok {
-				for _, field := range fields {
-					if fieldStr, ok := field.(string); ok {
-						columns = append(columns, strings.Trim(fieldStr, `"`))
-					}
-				}
+		} else if arg.Name == "where" {
+			idx.Where = extractIndexPredicate(arg)
+		} else if arg.Name == "type" {
+			if method, ok := arg.Value.(string); ok {
+				idx.Type = strings.ToLower(strings.Trim(method, `"`))
 			}
 		}
 	}
 
-	// If no fields found, try to get from first argument (might be array without name)
-	if len(columns) == 0 && len(attr.Arguments) > 0 {
-		firstArg := attr.Arguments[0]
-		if firstArg.Name == "" {
-			if fields, ok := firstArg.Value.([]interface{}); ok {
-				for _, field := range fields {
-					if fieldStr, ok := field.(string); ok {
-						columns = append(columns, strings.Trim(fieldStr, `"`))
-					}
-				}
-			}
+	for _, field := range fields {
+		column := IndexColumn{Length: field.Length, Ops: field.Ops}
+		if field.Sort == "Desc" {
+			column.Sort = "DESC"
 		}
+		name := field.Name
+		if field.IsExpression() {
+			column.Expression = true
+			column.expression = field.Expression
+			name = renderIndexExpression(field.Expression, func(ref string) string { return ref })
+		}
+		idx.Columns = append(idx.Columns, name)
+		idx.ColumnOptions = append(idx.ColumnOptions, column)
 	}
 
-	if len(columns) == 0 {
-		return nil
+	// Generate index name if not provided
+	if idx.Name == "" {
+		suffix := "idx"
+		if unique {
+			suffix = "key"
+		}
+		first := idx.Columns[0]
+		if idx.ColumnOptions[0].Expression {
+			first = strings.Trim(nonIdentifierChars.ReplaceAllString(first, "_"), "_")
+		}
+		idx.Name = fmt.Sprintf("%s_%s_%s", unqualifiedTableName(tableName), first, suffix)
 	}
 
-	// Generate index name if not provided
-	if indexName == "" {
-		if len(columns) == 1 {
-			indexName = fmt.Sprintf("%s_%s_idx", tableName, columns[0])
-		} else {
-			indexName = fmt.Sprintf("%s_%s_idx", tableName, columns[0])
+	return idx
+}
+
+// nonIdentifierChars matches the runs of characters of an expression that can't be part of an index name
+var nonIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// renderIndexExpression renders a parsed index expression such as lower(email) as SQL.
+// The parser drops the quotes of string literals, so ref decides how a bare argument
+// renders: as a column when it names a field, as a literal otherwise.
+func renderIndexExpression(value interface{}, ref func(string) string) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		function, _ := v["function"].(string)
+		args, _ := v["args"].([]interface{})
+		parts := make([]string, 0, len(args))
+		for _, arg := range args {
+			if named, ok := arg.(map[string]interface{}); ok && named["function"] == nil {
+				arg = named["value"]
+			}
+			parts = append(parts, renderIndexExpression(arg, ref))
+		}
+		return function + "(" + strings.Join(parts, ", ") + ")"
+	case string:
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return v
 		}
+		return ref(v)
+	case bool:
+		return strconv.FormatBool(v)
 	}
+	return ""
+}
 
-	return &IndexDefinition{
-		Name:      indexName,
-		TableName: tableName,
-		Columns:   columns,
-		IsUnique:  false, // Non-unique index
+// extractIndexPredicate returns the SQL predicate of a partial index from its where: argument
+func extractIndexPredicate(arg *parser.AttributeArgument) string {
+	value, ok := arg.Value.(string)
+	if !ok {
+		return ""
 	}
+	return strings.TrimSpace(strings.Trim(value, `"`))
 }
 
 // extractForeignKey extracts foreign key information from @relation attribute
@@ -914,6 +1393,7 @@ func extractForeignKey(tableName string, field *parser.ModelField, attr *parser.
 
 // generateForeignKeyName generates a foreign key constraint name
 func generateForeignKeyName(tableName string, columns []string) string {
+	tableName = unqualifiedTableName(tableName)
 	if len(columns) == 1 {
 		return fmt.Sprintf("%s_%s_fkey", tableName, columns[0])
 	}
@@ -943,6 +1423,14 @@ func extractDefaultValue(arg *parser.AttributeArgument) string {
 		return fmt.Sprintf("'%s'", strings.ReplaceAll(str, "'", "''"))
 	}
 
+	// Booleans are kept as TRUE/FALSE and rendered per dialect by columnDefaultSQL
+	if b, ok := arg.Value.(bool); ok {
+		if b {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+
 	// If it's a function (autoincrement, now, dbgenerated, etc.)
 	if m, ok := arg.Value.(map[string]interface{}); ok {
 		if fn, ok := m["function"].(string); ok {
@@ -969,97 +1457,238 @@ func extractDefaultValue(arg *parser.AttributeArgument) string {
 	return ""
 }
 
-// mapTypeToSQL maps Prisma type to SQL
-func mapTypeToSQL(prismaType string, provider string) string {
+// hasEnumTypes reports whether the database has named enum types (CREATE TYPE ... AS ENUM)
+func hasEnumTypes(provider string) bool {
+	return provider == "postgresql" || provider == "cockroachdb"
+}
+
+// columnTypeSQL renders the type of col. Enum columns use the enum type on PostgreSQL,
+// ENUM(...) on MySQL and a text column with a CHECK constraint on the other databases
+func columnTypeSQL(d dialect.Dialect, provider string, col ColumnDefinition) string {
+	if len(col.EnumValues) == 0 {
+		return d.MapType(col.Type, col.IsNullable)
+	}
+	switch {
+	case hasEnumTypes(provider):
+		return d.QuoteIdentifier(col.Type)
+	case provider == "mysql":
+		return "ENUM(" + quotedValues(d, col.EnumValues) + ")"
+	}
+	return fmt.Sprintf("%s CHECK (%s IN (%s))", d.MapType("String", col.IsNullable), d.QuoteIdentifier(col.Name), quotedValues(d, col.EnumValues))
+}
+
+// quotedValues renders values as a comma-separated list of string literals
+func quotedValues(d dialect.Dialect, values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = d.QuoteString(value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// applyEnumType marks col as an enum column when the field's type is a schema enum.
+// A default naming an enum value is rewritten to the value stored for it (@map)
+func applyEnumType(schema *parser.Schema, field *parser.ModelField, col *ColumnDefinition) {
+	for _, enum := range schema.Enums {
+		if enum.Name != field.Type.Name {
+			continue
+		}
+		for _, value := range enum.Values {
+			stored := getEnumValueName(value)
+			col.EnumValues = append(col.EnumValues, stored)
+			if col.DefaultValue == "'"+value.Name+"'" {
+				col.DefaultValue = "'" + strings.ReplaceAll(stored, "'", "''") + "'"
+			}
+		}
+		return
+	}
+}
+
+// getEnumValueName returns the value stored for an enum value (from @map, or its name)
+func getEnumValueName(value *parser.EnumValue) string {
+	for _, attr := range value.Attributes {
+		if attr.Name == "map" && len(attr.Arguments) > 0 {
+			if val, ok := attr.Arguments[0].Value.(string); ok {
+				return val
+			}
+		}
+	}
+	return value.Name
+}
+
+// enumsToCreate returns, in schema order, the enums used by columns except those in existing
+func enumsToCreate(schema *parser.Schema, columns []ColumnDefinition, existing map[string]bool) []EnumDefinition {
+	used := make(map[string]bool)
+	for _, col := range columns {
+		if len(col.EnumValues) > 0 && !existing[col.Type] {
+			used[col.Type] = true
+		}
+	}
+	var enums []EnumDefinition
+	for _, enum := range schema.Enums {
+		if !used[enum.Name] {
+			continue
+		}
+		def := EnumDefinition{Name: enum.Name}
+		for _, value := range enum.Values {
+			def.Values = append(def.Values, getEnumValueName(value))
+		}
+		enums = append(enums, def)
+	}
+	return enums
+}
+
+// columnDefaultSQL renders a column default for the dialect. Boolean literals become
+// TRUE/FALSE on PostgreSQL and 1/0 on MySQL (TINYINT(1)) and SQLite (INTEGER), matching
+// how the drivers bind Go bools on insert.
+func columnDefaultSQL(d dialect.Dialect, value string) string {
+	switch value {
+	case "TRUE", "FALSE":
+		return d.MapDefaultValue(value)
+	}
+	return value
+}
+
+// dropCheckSQL renders the statement dropping check; SQLite gets a warning comment
+func dropCheckSQL(d dialect.Dialect, provider string, check CheckDefinition) string {
 	switch provider {
-	case "postgresql":
-		return mapTypeToPostgreSQL(prismaType)
-	case "mysql":
-		return mapTypeToMySQL(prismaType)
 	case "sqlite":
-		return mapTypeToSQLite(prismaType)
-	default:
-		return mapTypeToPostgreSQL(prismaType) // Default
+		// SQLite can only change constraints by recreating the table
+		return fmt.Sprintf("-- WARNING: SQLite cannot drop CHECK constraint %s, recreate table %s without it\n", check.Name, check.TableName)
+	case "mysql":
+		return fmt.Sprintf("ALTER TABLE %s DROP CHECK %s;\n", d.QuoteIdentifier(check.TableName), d.QuoteIdentifier(check.Name))
 	}
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;\n", d.QuoteIdentifier(check.TableName), d.QuoteIdentifier(check.Name))
 }
 
-func mapTypeToPostgreSQL(prismaType string) string {
-	switch prismaType {
-	case "String":
-		return "VARCHAR(255)"
-	case "Int":
-		return "INTEGER"
-	case "BigInt":
-		return "BIGINT"
-	case "Boolean":
-		return "BOOLEAN"
-	case "DateTime":
-		return "TIMESTAMP"
-	case "Float":
-		return "DOUBLE PRECISION"
-	case "Decimal":
-		return "DECIMAL"
-	case "Json":
-		return "JSONB"
-	case "Bytes":
-		return "BYTEA"
-	case "UUID", "Uuid":
-		return "UUID"
-	default:
-		// If it starts with VARCHAR, return as is (already comes from @db.VarChar)
-		if strings.HasPrefix(prismaType, "VARCHAR") {
-			return prismaType
-		}
-		return "TEXT"
+// addCheckSQL renders the statement adding check; SQLite gets a warning comment
+func addCheckSQL(d dialect.Dialect, provider string, check CheckDefinition) string {
+	if provider == "sqlite" {
+		return fmt.Sprintf("-- WARNING: SQLite cannot add CHECK constraint %s (%s), recreate table %s with it\n", check.Name, check.Expression, check.TableName)
 	}
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);\n",
+		d.QuoteIdentifier(check.TableName),
+		d.QuoteIdentifier(check.Name),
+		check.Expression)
 }
 
-func mapTypeToMySQL(prismaType string) string {
-	switch prismaType {
-	case "String":
-		return "VARCHAR(255)"
-	case "Int":
-		return "INT"
-	case "BigInt":
-		return "BIGINT"
-	case "Boolean":
-		return "BOOLEAN"
-	case "DateTime":
-		return "DATETIME"
-	case "Float":
-		return "DOUBLE"
-	case "Decimal":
-		return "DECIMAL(65,30)"
-	case "Json":
-		return "JSON"
-	case "Bytes":
-		return "BLOB"
-	default:
-		return "TEXT"
+// renameColumnSQL renders the statement renaming a column (MySQL 8.0+, SQLite 3.25+)
+func renameColumnSQL(d dialect.Dialect, tableName, from, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;\n",
+		d.QuoteIdentifier(tableName),
+		d.QuoteIdentifier(from),
+		d.QuoteIdentifier(to))
+}
+
+// alterDefaultSQL renders the statement setting or dropping the default of a column.
+// MySQL only takes literals in ALTER ... SET DEFAULT, so expressions such as
+// CURRENT_TIMESTAMP go in parentheses (MySQL 8.0.13+). SQLite cannot change a
+// column default without recreating the table, so it gets a warning comment.
+func alterDefaultSQL(d dialect.Dialect, provider, tableName string, col ColumnAlteration) string {
+	if provider == "sqlite" {
+		return fmt.Sprintf("-- WARNING: SQLite cannot change the default of %s.%s, recreate the table to apply it",
+			tableName, col.ColumnName)
+	}
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", d.QuoteIdentifier(tableName), d.QuoteIdentifier(col.ColumnName))
+	if col.NewDefault == "" {
+		return prefix + " DROP DEFAULT;"
 	}
+	value := columnDefaultSQL(d, col.NewDefault)
+	if provider == "mysql" && !isLiteralDefault(value) {
+		value = "(" + value + ")"
+	}
+	return prefix + " SET DEFAULT " + value + ";"
 }
 
-func mapTypeToSQLite(prismaType string) string {
-	switch prismaType {
-	case "String":
-		return "TEXT"
-	case "Int":
-		return "INTEGER"
-	case "BigInt":
-		return "INTEGER"
-	case "Boolean":
-		return "INTEGER"
-	case "DateTime":
-		return "TEXT"
-	case "Float":
-		return "REAL"
-	case "Decimal":
-		return "TEXT"
-	case "Json":
-		return "TEXT"
-	case "Bytes":
-		return "BLOB"
+// alterNullabilitySQL renders the statement making a column NULL or NOT NULL. MySQL
+// changes it by restating the whole column with MODIFY, and SQLite only by recreating
+// the table, so it gets a warning comment. Setting NOT NULL is preceded by a warning,
+// since the statement fails if the column already holds NULL values.
+func alterNullabilitySQL(d dialect.Dialect, provider, tableName string, col ColumnAlteration) string {
+	if provider == "sqlite" {
+		return fmt.Sprintf("-- WARNING: SQLite cannot change the nullability of %s.%s, recreate the table to apply it",
+			tableName, col.ColumnName)
+	}
+
+	var sql strings.Builder
+	if !col.NewNullable {
+		sql.WriteString(fmt.Sprintf("-- WARNING: %s.%s becomes NOT NULL, so the migration fails if it holds NULL values; update them first\n",
+			tableName, col.ColumnName))
+	}
+	table := d.QuoteIdentifier(tableName)
+	switch {
+	case provider == "mysql":
+		sql.WriteString(modifyColumnSQL(d, provider, tableName, col))
+	case col.NewNullable:
+		sql.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", table, d.QuoteIdentifier(col.ColumnName)))
 	default:
-		return "TEXT"
+		sql.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", table, d.QuoteIdentifier(col.ColumnName)))
+	}
+	return sql.String()
+}
+
+// alterTypeSQL renders the statements changing the type of a column from col.OldType
+// to newType. PostgreSQL converts with a USING cast when there is no implicit conversion,
+// dropping the default around it since USING does not convert the default. MySQL
+// restates the whole column with MODIFY COLUMN. Conversions that fail on values that
+// cannot be cast are preceded by a warning.
+func alterTypeSQL(d dialect.Dialect, provider, tableName string, col ColumnAlteration, newType string) string {
+	var sql strings.Builder
+	if typeCastMayFail(provider, col.OldType, newType) {
+		sql.WriteString(fmt.Sprintf("-- WARNING: %s.%s changes type from %s to %s, so the migration fails if a value cannot be converted\n",
+			tableName, col.ColumnName, col.OldType, newType))
+	}
+	if provider == "mysql" {
+		sql.WriteString(modifyColumnSQL(d, provider, tableName, col))
+		return sql.String()
+	}
+
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", d.QuoteIdentifier(tableName), d.QuoteIdentifier(col.ColumnName))
+	if !needsUsingCast(col.OldType, newType) {
+		sql.WriteString(fmt.Sprintf("%s TYPE %s;", prefix, newType))
+		return sql.String()
+	}
+	sql.WriteString(prefix + " DROP DEFAULT;\n")
+	sql.WriteString(fmt.Sprintf("%s TYPE %s USING %s::%s;", prefix, newType, d.QuoteIdentifier(col.ColumnName), newType))
+	// A changed default is set by its own statement
+	if col.Column.DefaultValue != "" && !col.DefaultChanged {
+		sql.WriteString("\n" + alterDefaultSQL(d, provider, tableName, ColumnAlteration{
+			ColumnName: col.ColumnName,
+			NewDefault: col.Column.DefaultValue,
+		}))
 	}
+	return sql.String()
+}
+
+// modifyColumnSQL renders a MySQL MODIFY COLUMN restating the column as col.Column
+// defines it, with the nullability of col
+func modifyColumnSQL(d dialect.Dialect, provider, tableName string, col ColumnAlteration) string {
+	definition := col.Column
+	definition.Name = col.ColumnName
+	definition.IsNullable = col.NewNullable
+	colDef := fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s",
+		d.QuoteIdentifier(tableName),
+		d.QuoteIdentifier(col.ColumnName),
+		columnTypeSQL(d, provider, definition))
+	if !definition.IsNullable {
+		colDef += " NOT NULL"
+	}
+	if definition.DefaultValue != "" {
+		colDef += " DEFAULT " + columnDefaultSQL(d, definition.DefaultValue)
+	}
+	return colDef + ";"
+}
+
+// isLiteralDefault reports whether a rendered default is a string or numeric literal
+func isLiteralDefault(value string) bool {
+	if strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return true
+	}
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+// mapTypeToSQL maps a Prisma type to the SQL type the dialect creates for it
+func mapTypeToSQL(prismaType string, provider string) string {
+	return dialect.GetDialect(provider).MapType(prismaType, false)
 }
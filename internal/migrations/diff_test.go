@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
 	"github.com/carlosnayan/prisma-go-client/internal/parser"
 )
 
@@ -717,6 +718,83 @@ func TestOrderOfOperations(t *testing.T) {
 	}
 }
 
+// TestGenerateMigrationSQL_Deterministic tests that the same diff always yields byte-identical SQL,
+// whatever the order of its operation lists, with referenced tables created first
+func TestGenerateMigrationSQL_Deterministic(t *testing.T) {
+	newDiff := func(reverse bool) *SchemaDiff {
+		diff := &SchemaDiff{
+			TablesToCreate: []TableDefinition{
+				{Name: "users", Columns: []ColumnDefinition{{Name: "id", Type: "Int", IsPrimaryKey: true}, {Name: "email", Type: "String"}}},
+				{Name: "posts", Columns: []ColumnDefinition{{Name: "id", Type: "Int", IsPrimaryKey: true}, {Name: "author_id", Type: "Int"}}},
+				{Name: "audit", Columns: []ColumnDefinition{{Name: "id", Type: "Int", IsPrimaryKey: true}}},
+			},
+			TablesToAlter: []TableAlteration{
+				{TableName: "tags", DropColumns: []string{"b", "a"}},
+				{TableName: "comments", AddColumns: []ColumnDefinition{{Name: "body", Type: "String", IsNullable: true}}},
+			},
+			TablesToDrop: []string{"old_b", "old_a"},
+			IndexesToCreate: []IndexDefinition{
+				{Name: "users_email_key", TableName: "users", Columns: []string{"email"}, IsUnique: true},
+				{Name: "posts_author_id_idx", TableName: "posts", Columns: []string{"author_id"}},
+			},
+			IndexesToDrop: []string{"idx_b", "idx_a"},
+			ForeignKeysToCreate: []ForeignKeyDefinition{
+				{Name: "posts_author_id_fkey", TableName: "posts", Columns: []string{"author_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+				{Name: "audit_id_fkey", TableName: "audit", Columns: []string{"id"}, ReferencedTable: "posts", ReferencedColumns: []string{"id"}},
+			},
+		}
+		if reverse {
+			for i, j := 0, len(diff.TablesToCreate)-1; i < j; i, j = i+1, j-1 {
+				diff.TablesToCreate[i], diff.TablesToCreate[j] = diff.TablesToCreate[j], diff.TablesToCreate[i]
+			}
+			diff.TablesToAlter[0], diff.TablesToAlter[1] = diff.TablesToAlter[1], diff.TablesToAlter[0]
+			diff.TablesToDrop[0], diff.TablesToDrop[1] = diff.TablesToDrop[1], diff.TablesToDrop[0]
+			diff.IndexesToCreate[0], diff.IndexesToCreate[1] = diff.IndexesToCreate[1], diff.IndexesToCreate[0]
+			diff.IndexesToDrop[0], diff.IndexesToDrop[1] = diff.IndexesToDrop[1], diff.IndexesToDrop[0]
+			diff.ForeignKeysToCreate[0], diff.ForeignKeysToCreate[1] = diff.ForeignKeysToCreate[1], diff.ForeignKeysToCreate[0]
+		}
+		return diff
+	}
+
+	for _, provider := range []string{"postgresql", "mysql", "sqlite"} {
+		t.Run(provider, func(t *testing.T) {
+			first, err := GenerateMigrationSQL(newDiff(false), provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			second, err := GenerateMigrationSQL(newDiff(false), provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			reversed, err := GenerateMigrationSQL(newDiff(true), provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			if first != second || first != reversed {
+				t.Fatalf("expected identical SQL across generations, got:\n%s\n---\n%s\n---\n%s", first, second, reversed)
+			}
+
+			// users antes de posts (referenciada), posts antes de audit
+			d := dialect.GetDialect(provider)
+			users := strings.Index(first, "CREATE TABLE "+d.QuoteIdentifier("users"))
+			posts := strings.Index(first, "CREATE TABLE "+d.QuoteIdentifier("posts"))
+			audit := strings.Index(first, "CREATE TABLE "+d.QuoteIdentifier("audit"))
+			if users < 0 || posts < 0 || audit < 0 || users > posts || posts > audit {
+				t.Errorf("tables should be created in dependency order, got:\n%s", first)
+			}
+		})
+	}
+
+	// A ordem de entrada do diff não é alterada
+	diff := newDiff(true)
+	if _, err := GenerateMigrationSQL(diff, "postgresql"); err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	if diff.TablesToCreate[0].Name != "audit" || diff.TablesToDrop[0] != "old_a" {
+		t.Error("GenerateMigrationSQL should not reorder the caller's diff")
+	}
+}
+
 // TestIndexGeneration tests @@index (non-unique index) generation
 func TestIndexGeneration(t *testing.T) {
 	schema := &parser.Schema{
@@ -1479,3 +1557,92 @@ func TestCompositePKWithMap(t *testing.T) {
 		t.Errorf("Expected composite PK with mapped names, got:\n%s", sql)
 	}
 }
+
+// TestForeignKeyIndexes tests that IndexForeignKeys indexes FK columns not covered by an existing index
+func TestForeignKeyIndexes(t *testing.T) {
+	relation := func(field string) *parser.Attribute {
+		return &parser.Attribute{Name: "relation", Arguments: []*parser.AttributeArgument{
+			{Name: "fields", Value: []interface{}{field}},
+			{Name: "references", Value: []interface{}{"id"}},
+		}}
+	}
+	schema := &parser.Schema{
+		Models: []*parser.Model{
+			{
+				Name: "authors",
+				Fields: []*parser.ModelField{
+					{Name: "id", Type: &parser.FieldType{Name: "Int"}, Attributes: []*parser.Attribute{{Name: "id"}}},
+				},
+			},
+			{
+				Name: "books",
+				Fields: []*parser.ModelField{
+					{Name: "id", Type: &parser.FieldType{Name: "Int"}, Attributes: []*parser.Attribute{{Name: "id"}}},
+					{
+						Name: "authorId",
+						Type: &parser.FieldType{Name: "Int"},
+						Attributes: []*parser.Attribute{
+							{Name: "map", Arguments: []*parser.AttributeArgument{{Value: `"author_id"`}}},
+						},
+					},
+					{Name: "author", Type: &parser.FieldType{Name: "authors"}, Attributes: []*parser.Attribute{relation("authorId")}},
+				},
+			},
+			{
+				Name: "reviews",
+				Fields: []*parser.ModelField{
+					{Name: "id", Type: &parser.FieldType{Name: "Int"}, Attributes: []*parser.Attribute{{Name: "id"}}},
+					{Name: "book_id", Type: &parser.FieldType{Name: "Int"}},
+					{Name: "created_at", Type: &parser.FieldType{Name: "DateTime"}},
+					{Name: "book", Type: &parser.FieldType{Name: "books"}, Attributes: []*parser.Attribute{relation("book_id")}},
+				},
+				Attributes: []*parser.Attribute{
+					{Name: "index", Arguments: []*parser.AttributeArgument{
+						{Name: "", Value: []interface{}{"book_id", "created_at"}},
+					}},
+				},
+			},
+		},
+	}
+
+	countIndexes := func(diff *SchemaDiff, table string, columns ...string) int {
+		count := 0
+		for _, index := range diff.IndexesToCreate {
+			if index.TableName == table && strings.Join(index.Columns, ",") == strings.Join(columns, ",") {
+				count++
+			}
+		}
+		return count
+	}
+
+	// Without the option no index is added
+	diff, err := SchemaToSQL(schema, "postgresql")
+	if err != nil {
+		t.Fatalf("SchemaToSQL failed: %v", err)
+	}
+	if countIndexes(diff, "books", "author_id") != 0 {
+		t.Errorf("FK index should only be created with IndexForeignKeys, got %+v", diff.IndexesToCreate)
+	}
+
+	diff, err = SchemaToSQLWithOptions(schema, "postgresql", SchemaOptions{IndexForeignKeys: true})
+	if err != nil {
+		t.Fatalf("SchemaToSQLWithOptions failed: %v", err)
+	}
+
+	// The FK column uses its @map name
+	if countIndexes(diff, "books", "author_id") != 1 {
+		t.Errorf("expected an index on books.author_id, got %+v", diff.IndexesToCreate)
+	}
+	// An @@index starting with the FK already covers it
+	if countIndexes(diff, "reviews", "book_id") != 0 {
+		t.Errorf("reviews.book_id is covered by @@index([book_id, created_at]), got %+v", diff.IndexesToCreate)
+	}
+
+	sql, err := GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	if !strings.Contains(sql, `CREATE INDEX "books_author_id_idx" ON "books" ("author_id")`) {
+		t.Errorf("Expected FK index, got:\n%s", sql)
+	}
+}
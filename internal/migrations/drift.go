@@ -48,6 +48,9 @@ func FormatDriftDiff(diff *SchemaDiff) string {
 		for _, colName := range alter.DropColumns {
 			output.WriteString(fmt.Sprintf("  [-] Removed column `%s`\n", colName))
 		}
+		for _, rename := range alter.RenameColumns {
+			output.WriteString(fmt.Sprintf("  [*] Renamed column `%s` to `%s`\n", rename.From, rename.To))
+		}
 		for _, colAlter := range alter.AlterColumns {
 			output.WriteString(fmt.Sprintf("  [*] Changed column `%s`\n", colAlter.ColumnName))
 		}
@@ -72,5 +75,13 @@ func FormatDriftDiff(diff *SchemaDiff) string {
 		output.WriteString(fmt.Sprintf("[-] Removed index `%s`\n", idxName))
 	}
 
+	for _, check := range diff.ChecksToCreate {
+		output.WriteString(fmt.Sprintf("[+] Added check `%s` on `%s`\n", check.Name, check.TableName))
+	}
+
+	for _, check := range diff.ChecksToDrop {
+		output.WriteString(fmt.Sprintf("[-] Removed check `%s` on `%s`\n", check.Name, check.TableName))
+	}
+
 	return output.String()
 }
@@ -3,6 +3,7 @@ package migrations
 import (
 	"database/sql"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -18,6 +19,14 @@ type TableInfo struct {
 	ColumnOrder []string // Preserves the order of columns as they appear in the database
 	Indexes     []*IndexInfo
 	ForeignKeys []*ForeignKeyInfo
+	Checks      []*CheckInfo
+}
+
+// CheckInfo represents a CHECK constraint. Expression is the condition as the
+// database reports it, which may differ in form from the one in the schema.
+type CheckInfo struct {
+	Name       string
+	Expression string
 }
 
 // ForeignKeyInfo represents information about a foreign key constraint
@@ -46,8 +55,10 @@ type ColumnInfo struct {
 
 // IndexColumnInfo represents a column in an index with its sort order
 type IndexColumnInfo struct {
-	ColumnName string
+	ColumnName string // Column name, or the SQL of an expression entry
 	SortOrder  string // "ASC" or "DESC"
+	Length     int    // Indexed prefix length (MySQL), 0 for the whole value
+	Expression bool   // The entry is an expression such as lower(email)
 }
 
 // IndexInfo represents information about an index
@@ -57,17 +68,29 @@ type IndexInfo struct {
 	Columns     []string
 	ColumnInfos []IndexColumnInfo // Detailed column info with sort order
 	IsUnique    bool
+	Where       string // Predicate of a partial index, as the database reports it
+	Type        string // Access method (PostgreSQL), e.g. "gin"
 }
 
 // IntrospectDatabase performs database introspection
 func IntrospectDatabase(db *sql.DB, provider string) (*DatabaseSchema, error) {
+	return IntrospectDatabaseSchemas(db, provider, nil)
+}
+
+// IntrospectDatabaseSchemas performs database introspection of the given Postgres schemas
+// (the public schema when empty). Tables outside public are keyed by their qualified name,
+// e.g. "auth.users". Other providers have no schemas and ignore the list.
+func IntrospectDatabaseSchemas(db *sql.DB, provider string, schemas []string) (*DatabaseSchema, error) {
 	schema := &DatabaseSchema{
 		Tables: make(map[string]*TableInfo),
 	}
 
 	switch provider {
-	case "postgresql", "postgres":
-		return introspectPostgreSQL(db, schema)
+	case "postgresql", "postgres", "cockroachdb":
+		if len(schemas) == 0 {
+			schemas = []string{defaultSchemaName}
+		}
+		return introspectPostgreSQL(db, schema, schemas)
 	case "mysql":
 		return introspectMySQL(db, schema)
 	case "sqlite":
@@ -78,15 +101,20 @@ func IntrospectDatabase(db *sql.DB, provider string) (*DatabaseSchema, error) {
 }
 
 // introspectPostgreSQL performs PostgreSQL introspection
-func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, error) {
+func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema, schemas []string) (*DatabaseSchema, error) {
+	quotedSchemas := make([]string, len(schemas))
+	for i, name := range schemas {
+		quotedSchemas[i] = "'" + strings.ReplaceAll(name, "'", "''") + "'"
+	}
+
 	// Get list of tables (excluding system tables)
 	query := `
-		SELECT table_name 
+		SELECT table_schema, table_name 
 		FROM information_schema.tables 
-		WHERE table_schema = 'public' 
+		WHERE table_schema IN (` + strings.Join(quotedSchemas, ", ") + `) 
 		AND table_type = 'BASE TABLE'
 		AND table_name NOT LIKE '_prisma%'
-		ORDER BY table_name
+		ORDER BY table_schema, table_name
 	`
 
 	rows, err := db.Query(query)
@@ -95,17 +123,21 @@ func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema,
 	}
 	defer rows.Close()
 
-	var tableNames []string
+	var tableSchemas, relNames []string
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var schemaName, name string
+		if err := rows.Scan(&schemaName, &name); err != nil {
 			return nil, fmt.Errorf("error reading table name: %w", err)
 		}
-		tableNames = append(tableNames, name)
+		tableSchemas = append(tableSchemas, schemaName)
+		relNames = append(relNames, name)
 	}
 
-	// For each table, get columns
-	for _, tableName := range tableNames {
+	// For each table, get columns. The queries take the bare table name and its schema,
+	// while the table is keyed by its qualified name
+	for i, relName := range relNames {
+		tableSchema := tableSchemas[i]
+		tableName := qualifiedTableName(tableSchema, relName)
 		table := &TableInfo{
 			Name:        tableName,
 			Columns:     make(map[string]*ColumnInfo),
@@ -132,14 +164,14 @@ func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema,
 					ON tc.constraint_name = ku.constraint_name
 					AND tc.table_schema = ku.table_schema
 				WHERE tc.constraint_type = 'PRIMARY KEY'
-				AND tc.table_schema = 'public'
+				AND tc.table_schema = $2
 			) pk ON c.table_name = pk.table_name AND c.column_name = pk.column_name
-			WHERE c.table_schema = 'public'
+			WHERE c.table_schema = $2
 			AND c.table_name = $1
 			ORDER BY c.ordinal_position
 		`
 
-		colsRows, err := db.Query(colsQuery, tableName)
+		colsRows, err := db.Query(colsQuery, relName, tableSchema)
 		if err != nil {
 			return nil, fmt.Errorf("error getting columns for table %s: %w", tableName, err)
 		}
@@ -184,57 +216,63 @@ func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema,
 		}
 		colsRows.Close()
 
-		// Get indexes with correct order and sort direction
+		// Get indexes with correct order and sort direction. pg_get_indexdef renders each
+		// key entry, which is the column name or the SQL of an expression (indkey 0)
 		idxQuery := `
 			SELECT
-				i.indexname,
-				a.attname,
+				c.relname as indexname,
+				pg_get_indexdef(ix.indexrelid, k.n, true) as entry,
+				ix.indkey[k.n - 1] = 0 as is_expression,
 				ix.indisunique,
-				array_position(ix.indkey, a.attnum) as col_order,
-				CASE 
-					WHEN (ix.indoption[array_position(ix.indkey, a.attnum)] & 2) = 2 THEN 'DESC'
+				CASE
+					WHEN (ix.indoption[k.n - 1] & 1) = 1 THEN 'DESC'
 					ELSE 'ASC'
-				END as sort_order
-			FROM pg_indexes i
-			JOIN pg_index ix ON i.indexname = (SELECT relname FROM pg_class WHERE oid = ix.indexrelid)
-			JOIN pg_attribute a ON a.attrelid = ix.indrelid AND a.attnum = ANY(ix.indkey)
-			WHERE i.schemaname = 'public'
-			AND i.tablename = $1
-			AND i.indexname NOT LIKE '%_pkey'
-			AND a.attname IS NOT NULL
-			ORDER BY i.indexname, array_position(ix.indkey, a.attnum)
+				END as sort_order,
+				COALESCE(pg_get_expr(ix.indpred, ix.indrelid), '') as predicate,
+				am.amname as method
+			FROM pg_index ix
+			JOIN pg_class c ON c.oid = ix.indexrelid
+			JOIN pg_am am ON am.oid = c.relam
+			JOIN pg_class t ON t.oid = ix.indrelid
+			JOIN pg_namespace ns ON ns.oid = t.relnamespace
+			CROSS JOIN LATERAL generate_series(1, ix.indnkeyatts) AS k(n)
+			WHERE ns.nspname = $2
+			AND t.relname = $1
+			AND NOT ix.indisprimary
+			ORDER BY c.relname, k.n
 		`
 
-		idxRows, err := db.Query(idxQuery, tableName)
+		idxRows, err := db.Query(idxQuery, relName, tableSchema)
 		if err == nil {
 			indexMap := make(map[string]*IndexInfo)
 			for idxRows.Next() {
-				var idxName, colName, sortOrder string
-				var isUnique bool
-				var colOrder int
-				if err := idxRows.Scan(&idxName, &colName, &isUnique, &colOrder, &sortOrder); err == nil {
-					// Skip if column name is empty
-					if colName == "" {
+				var idxName, entry, sortOrder, predicate, method string
+				var isExpression, isUnique bool
+				if err := idxRows.Scan(&idxName, &entry, &isExpression, &isUnique, &sortOrder, &predicate, &method); err == nil {
+					// Skip if the entry is empty
+					if entry == "" {
 						continue
 					}
+					if !isExpression {
+						entry = unquotePostgresIdentifier(entry)
+					}
+					info := IndexColumnInfo{
+						ColumnName: entry,
+						SortOrder:  sortOrder,
+						Expression: isExpression,
+					}
 					if idx, exists := indexMap[idxName]; exists {
-						idx.Columns = append(idx.Columns, colName)
-						idx.ColumnInfos = append(idx.ColumnInfos, IndexColumnInfo{
-							ColumnName: colName,
-							SortOrder:  sortOrder,
-						})
+						idx.Columns = append(idx.Columns, entry)
+						idx.ColumnInfos = append(idx.ColumnInfos, info)
 					} else {
 						indexMap[idxName] = &IndexInfo{
-							Name:      idxName,
-							TableName: tableName,
-							Columns:   []string{colName},
-							ColumnInfos: []IndexColumnInfo{
-								{
-									ColumnName: colName,
-									SortOrder:  sortOrder,
-								},
-							},
-							IsUnique: isUnique,
+							Name:        idxName,
+							TableName:   tableName,
+							Columns:     []string{entry},
+							ColumnInfos: []IndexColumnInfo{info},
+							IsUnique:    isUnique,
+							Where:       predicate,
+							Type:        method,
 						}
 					}
 				}
@@ -257,6 +295,7 @@ func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema,
 			SELECT
 				tc.constraint_name,
 				kcu.column_name,
+				ccu.table_schema AS foreign_table_schema,
 				ccu.table_name AS foreign_table_name,
 				ccu.column_name AS foreign_column_name,
 				COALESCE(rc.delete_rule, 'NO ACTION') AS delete_rule,
@@ -272,17 +311,17 @@ func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema,
 				ON tc.constraint_name = rc.constraint_name
 				AND tc.table_schema = rc.constraint_schema
 			WHERE tc.constraint_type = 'FOREIGN KEY'
-				AND tc.table_schema = 'public'
+				AND tc.table_schema = $2
 				AND tc.table_name = $1
 			ORDER BY tc.constraint_name, kcu.ordinal_position
 		`
 
-		fkRows, err := db.Query(fkQuery, tableName)
+		fkRows, err := db.Query(fkQuery, relName, tableSchema)
 		if err == nil {
 			fkMap := make(map[string]*ForeignKeyInfo)
 			for fkRows.Next() {
-				var constraintName, columnName, foreignTableName, foreignColumnName, deleteRule, updateRule sql.NullString
-				if err := fkRows.Scan(&constraintName, &columnName, &foreignTableName, &foreignColumnName, &deleteRule, &updateRule); err == nil {
+				var constraintName, columnName, foreignTableSchema, foreignTableName, foreignColumnName, deleteRule, updateRule sql.NullString
+				if err := fkRows.Scan(&constraintName, &columnName, &foreignTableSchema, &foreignTableName, &foreignColumnName, &deleteRule, &updateRule); err == nil {
 					if !constraintName.Valid {
 						continue
 					}
@@ -302,7 +341,7 @@ func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema,
 							Name:              constraintName.String,
 							TableName:         tableName,
 							Columns:           []string{columnName.String},
-							ReferencedTable:   foreignTableName.String,
+							ReferencedTable:   qualifiedTableName(foreignTableSchema.String, foreignTableName.String),
 							ReferencedColumns: []string{foreignColumnName.String},
 							OnDelete:          deleteRuleStr,
 							OnUpdate:          updateRuleStr,
@@ -317,6 +356,30 @@ func introspectPostgreSQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema,
 			}
 		}
 
+		// Get CHECK constraints
+		checkRows, err := db.Query(`
+			SELECT con.conname, pg_get_constraintdef(con.oid)
+			FROM pg_constraint con
+			JOIN pg_class rel ON rel.oid = con.conrelid
+			JOIN pg_namespace nsp ON nsp.oid = rel.relnamespace
+			WHERE con.contype = 'c'
+				AND nsp.nspname = $2
+				AND rel.relname = $1
+			ORDER BY con.conname
+		`, relName, tableSchema)
+		if err == nil {
+			for checkRows.Next() {
+				var name, definition string
+				if err := checkRows.Scan(&name, &definition); err == nil {
+					table.Checks = append(table.Checks, &CheckInfo{
+						Name:       name,
+						Expression: strings.TrimPrefix(definition, "CHECK "),
+					})
+				}
+			}
+			checkRows.Close()
+		}
+
 		schema.Tables[tableName] = table
 	}
 
@@ -365,6 +428,7 @@ func introspectMySQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, error
 			SELECT 
 				c.column_name,
 				c.data_type,
+				c.character_maximum_length,
 				c.is_nullable,
 				c.column_default,
 				CASE WHEN pk.column_name IS NOT NULL THEN true ELSE false END as is_primary_key
@@ -391,9 +455,10 @@ func introspectMySQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, error
 		for colsRows.Next() {
 			var colName, dataType, isNullable string
 			var columnDefault sql.NullString
+			var characterMaxLength sql.NullInt64
 			var isPrimaryKey bool
 
-			if err := colsRows.Scan(&colName, &dataType, &isNullable, &columnDefault, &isPrimaryKey); err != nil {
+			if err := colsRows.Scan(&colName, &dataType, &characterMaxLength, &isNullable, &columnDefault, &isPrimaryKey); err != nil {
 				colsRows.Close()
 				return nil, fmt.Errorf("error reading column: %w", err)
 			}
@@ -406,6 +471,12 @@ func introspectMySQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, error
 				IsUnique:     false, // Will be filled later
 			}
 
+			// Keep the length of VARCHAR columns, which the Prisma type drops
+			if characterMaxLength.Valid && strings.EqualFold(dataType, "varchar") {
+				maxLength := int(characterMaxLength.Int64)
+				col.CharacterMaximumLength = &maxLength
+			}
+
 			if columnDefault.Valid {
 				col.DefaultValue = &columnDefault.String
 			}
@@ -415,11 +486,14 @@ func introspectMySQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, error
 		}
 		colsRows.Close()
 
-		// Get unique indexes
+		// Get unique indexes; functional key parts have no column_name but an expression
 		idxQuery := `
 			SELECT
 				s.index_name,
 				s.column_name,
+				%s as expression,
+				s.collation,
+				s.sub_part,
 				s.non_unique = 0 as is_unique
 			FROM information_schema.statistics s
 			WHERE s.table_schema = DATABASE()
@@ -428,21 +502,37 @@ func introspectMySQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, error
 			ORDER BY s.index_name, s.seq_in_index
 		`
 
-		idxRows, err := db.Query(idxQuery, tableName)
+		idxRows, err := db.Query(fmt.Sprintf(idxQuery, "s.expression"), tableName)
+		if err != nil {
+			// information_schema.statistics has no expression column before MySQL 8.0.13
+			idxRows, err = db.Query(fmt.Sprintf(idxQuery, "NULL"), tableName)
+		}
 		if err == nil {
 			indexMap := make(map[string]*IndexInfo)
 			for idxRows.Next() {
-				var idxName, colName string
+				var idxName string
+				var colName, expression, collation sql.NullString
+				var subPart sql.NullInt64
 				var isUnique bool
-				if err := idxRows.Scan(&idxName, &colName, &isUnique); err == nil {
+				if err := idxRows.Scan(&idxName, &colName, &expression, &collation, &subPart, &isUnique); err == nil {
+					info := IndexColumnInfo{ColumnName: colName.String, SortOrder: "ASC", Length: int(subPart.Int64)}
+					if !colName.Valid && expression.Valid {
+						info.ColumnName = expression.String
+						info.Expression = true
+					}
+					if collation.String == "D" {
+						info.SortOrder = "DESC"
+					}
 					if idx, exists := indexMap[idxName]; exists {
-						idx.Columns = append(idx.Columns, colName)
+						idx.Columns = append(idx.Columns, info.ColumnName)
+						idx.ColumnInfos = append(idx.ColumnInfos, info)
 					} else {
 						indexMap[idxName] = &IndexInfo{
-							Name:      idxName,
-							TableName: tableName,
-							Columns:   []string{colName},
-							IsUnique:  isUnique,
+							Name:        idxName,
+							TableName:   tableName,
+							Columns:     []string{info.ColumnName},
+							ColumnInfos: []IndexColumnInfo{info},
+							IsUnique:    isUnique,
 						}
 					}
 				}
@@ -460,6 +550,28 @@ func introspectMySQL(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, error
 			}
 		}
 
+		// Get CHECK constraints (information_schema.check_constraints exists since MySQL 8.0.16)
+		checkRows, err := db.Query(`
+			SELECT tc.constraint_name, cc.check_clause
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.check_constraints cc
+				ON cc.constraint_schema = tc.constraint_schema
+				AND cc.constraint_name = tc.constraint_name
+			WHERE tc.table_schema = DATABASE()
+			AND tc.table_name = ?
+			AND tc.constraint_type = 'CHECK'
+			ORDER BY tc.constraint_name
+		`, tableName)
+		if err == nil {
+			for checkRows.Next() {
+				var name, clause string
+				if err := checkRows.Scan(&name, &clause); err == nil {
+					table.Checks = append(table.Checks, &CheckInfo{Name: name, Expression: clause})
+				}
+			}
+			checkRows.Close()
+		}
+
 		schema.Tables[tableName] = table
 	}
 
@@ -551,7 +663,14 @@ func introspectSQLite(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, erro
 			for idxListRows.Next() {
 				var seq int
 				var idxName, isUnique sql.NullString
-				if err := idxListRows.Scan(&seq, &idxName, &isUnique); err == nil {
+				// Newer SQLite versions add origin and partial columns, which are not needed
+				dest := []interface{}{&seq, &idxName, &isUnique}
+				if columns, err := idxListRows.Columns(); err == nil {
+					for len(dest) < len(columns) {
+						dest = append(dest, new(interface{}))
+					}
+				}
+				if err := idxListRows.Scan(dest...); err == nil {
 					if !idxName.Valid {
 						continue
 					}
@@ -568,18 +687,26 @@ func introspectSQLite(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, erro
 						IsUnique:  unique,
 					}
 
-					// Get index columns
-					idxInfoQuery := fmt.Sprintf("PRAGMA index_info(%s)", idxName.String)
+					// Get index columns; index_xinfo also reports the sort order and marks
+					// expression entries with cid -2
+					idxInfoQuery := fmt.Sprintf("PRAGMA index_xinfo(%s)", idxName.String)
 					idxInfoRows, err := db.Query(idxInfoQuery)
 					if err == nil {
 						for idxInfoRows.Next() {
-							var seqNo int
-							var cid int
-							var colName sql.NullString
-							if err := idxInfoRows.Scan(&seqNo, &cid, &colName); err == nil {
-								if colName.Valid {
-									indexMap[idxName.String].Columns = append(indexMap[idxName.String].Columns, colName.String)
+							var seqNo, cid, desc, key int
+							var colName, collation sql.NullString
+							if err := idxInfoRows.Scan(&seqNo, &cid, &colName, &desc, &collation, &key); err == nil {
+								// Auxiliary entries (the rowid) are not part of the key
+								if key == 0 {
+									continue
 								}
+								info := IndexColumnInfo{ColumnName: colName.String, SortOrder: "ASC", Expression: cid == -2}
+								if desc == 1 {
+									info.SortOrder = "DESC"
+								}
+								idx := indexMap[idxName.String]
+								idx.Columns = append(idx.Columns, info.ColumnName)
+								idx.ColumnInfos = append(idx.ColumnInfos, info)
 							}
 						}
 						idxInfoRows.Close()
@@ -589,6 +716,19 @@ func introspectSQLite(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, erro
 			idxListRows.Close()
 
 			for _, idx := range indexMap {
+				// The predicate of a partial index and the SQL of its expressions are only
+				// in its CREATE INDEX statement
+				var idxSQL sql.NullString
+				if err := db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?", idx.Name).Scan(&idxSQL); err == nil {
+					idx.Where = parseSQLiteIndexPredicate(idxSQL.String)
+					entries := parseSQLiteIndexEntries(idxSQL.String)
+					for i, info := range idx.ColumnInfos {
+						if info.Expression && i < len(entries) {
+							idx.Columns[i] = entries[i]
+							idx.ColumnInfos[i].ColumnName = entries[i]
+						}
+					}
+				}
 				table.Indexes = append(table.Indexes, idx)
 				// Mark columns as unique if the index is unique
 				if idx.IsUnique && len(idx.Columns) == 1 {
@@ -599,12 +739,104 @@ func introspectSQLite(db *sql.DB, schema *DatabaseSchema) (*DatabaseSchema, erro
 			}
 		}
 
+		// Get named CHECK constraints from the CREATE TABLE statement
+		var createSQL sql.NullString
+		if err := db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", tableName).Scan(&createSQL); err == nil {
+			table.Checks = parseSQLiteChecks(createSQL.String)
+		}
+
 		schema.Tables[tableName] = table
 	}
 
 	return schema, nil
 }
 
+// parseSQLiteChecks extracts the named CHECK constraints (CONSTRAINT name CHECK (...))
+// from a CREATE TABLE statement, since SQLite has no catalog table for them
+func parseSQLiteChecks(createSQL string) []*CheckInfo {
+	var checks []*CheckInfo
+	for _, match := range sqliteCheckPattern.FindAllStringSubmatchIndex(createSQL, -1) {
+		name := createSQL[match[2]:match[3]]
+		// match[1] is right after the opening parenthesis of the expression
+		depth := 1
+		for i := match[1]; i < len(createSQL); i++ {
+			switch createSQL[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 {
+				checks = append(checks, &CheckInfo{
+					Name:       strings.Trim(name, "\"`[]"),
+					Expression: strings.TrimSpace(createSQL[match[1]:i]),
+				})
+				break
+			}
+		}
+	}
+	return checks
+}
+
+// sqliteIndexWherePattern matches the WHERE clause closing a CREATE INDEX statement
+var sqliteIndexWherePattern = regexp.MustCompile(`(?is)\)\s*WHERE\s+(.+?)\s*;?\s*$`)
+
+// parseSQLiteIndexPredicate returns the predicate of a partial index from its CREATE INDEX statement
+func parseSQLiteIndexPredicate(createSQL string) string {
+	if match := sqliteIndexWherePattern.FindStringSubmatch(createSQL); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// sqliteIndexColumnsPattern matches ON <table> ( in a CREATE INDEX statement
+var sqliteIndexColumnsPattern = regexp.MustCompile(`(?i)\bON\s+("[^"]+"|` + "`[^`]+`" + `|\[[^\]]+\]|\w+)\s*\(`)
+
+// sqliteIndexSortSuffix matches the sort order at the end of an index entry
+var sqliteIndexSortSuffix = regexp.MustCompile(`(?i)\s+(ASC|DESC)$`)
+
+// parseSQLiteIndexEntries returns the entries of a CREATE INDEX statement without their
+// sort order, e.g. "title" and (lower("email")), since SQLite reports no SQL for expressions
+func parseSQLiteIndexEntries(createSQL string) []string {
+	loc := sqliteIndexColumnsPattern.FindStringIndex(createSQL)
+	if loc == nil {
+		return nil
+	}
+	var entries []string
+	depth, start, inString := 0, loc[1], false
+	for i := loc[1]; i < len(createSQL); i++ {
+		switch ch := createSQL[i]; {
+		case ch == '\'':
+			inString = !inString
+		case inString:
+		case ch == '(':
+			depth++
+		case ch == ',' && depth == 0, ch == ')' && depth == 0:
+			entry := strings.TrimSpace(createSQL[start:i])
+			entries = append(entries, strings.TrimSpace(sqliteIndexSortSuffix.ReplaceAllString(entry, "")))
+			if ch == ')' {
+				return entries
+			}
+			start = i + 1
+		case ch == ')':
+			depth--
+		}
+	}
+	return entries
+}
+
+// unquotePostgresIdentifier removes the quotes pg_get_indexdef adds to column names
+// that need them, e.g. "createdAt"
+func unquotePostgresIdentifier(name string) string {
+	if len(name) >= 2 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+		return strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+	}
+	return name
+}
+
+// sqliteCheckPattern matches CONSTRAINT <name> CHECK ( in a CREATE TABLE statement
+var sqliteCheckPattern = regexp.MustCompile(`(?i)CONSTRAINT\s+("[^"]+"|` + "`[^`]+`" + `|\[[^\]]+\]|\w+)\s+CHECK\s*\(`)
+
 // mapPostgreSQLType mapeia tipo PostgreSQL para tipo Prisma
 // Retorna o tipo original do PostgreSQL (não mapeado) para uso em pull.go
 
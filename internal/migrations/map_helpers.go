@@ -7,16 +7,64 @@ import (
 	"github.com/carlosnayan/prisma-go-client/internal/parser"
 )
 
-// getTableNameFromModel returns the actual table name considering @@map attribute
+// defaultSchemaName is the Postgres schema of tables without @@schema
+const defaultSchemaName = "public"
+
+// getTableNameFromModel returns the actual table name considering @@map attribute,
+// qualified by the @@schema attribute (auth.users) outside the public schema
 func getTableNameFromModel(model *parser.Model) string {
+	tableName := model.Name
 	for _, attr := range model.Attributes {
 		if attr.Name == "map" && len(attr.Arguments) > 0 {
+			if name, ok := attr.Arguments[0].Value.(string); ok {
+				tableName = strings.Trim(name, `"`)
+			}
+		}
+	}
+	return qualifiedTableName(getSchemaNameFromModel(model), tableName)
+}
+
+// getSchemaNameFromModel returns the Postgres schema of a model from @@schema, or "" if it has none
+func getSchemaNameFromModel(model *parser.Model) string {
+	for _, attr := range model.Attributes {
+		if attr.Name == "schema" && len(attr.Arguments) > 0 {
 			if name, ok := attr.Arguments[0].Value.(string); ok {
 				return strings.Trim(name, `"`)
 			}
 		}
 	}
-	return model.Name
+	return ""
+}
+
+// qualifiedTableName returns the name a table is keyed by: the bare name in the public
+// schema and schema.table elsewhere
+func qualifiedTableName(schemaName, tableName string) string {
+	if schemaName == "" || schemaName == defaultSchemaName {
+		return tableName
+	}
+	return schemaName + "." + tableName
+}
+
+// splitTableName splits a qualified table name into its schema ("" for the public schema)
+// and the bare table name
+func splitTableName(name string) (string, string) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// unqualifiedTableName returns the bare table name, used to derive constraint and index names
+func unqualifiedTableName(name string) string {
+	_, tableName := splitTableName(name)
+	return tableName
+}
+
+// qualifiedIndexName returns the name DROP INDEX needs for an index of tableName: indexes
+// live in the schema of their table, so outside public the name is schema-qualified
+func qualifiedIndexName(tableName, indexName string) string {
+	schemaName, _ := splitTableName(tableName)
+	return qualifiedTableName(schemaName, indexName)
 }
 
 // getColumnNameFromField returns the actual column name considering @map attribute
@@ -31,6 +79,22 @@ func getColumnNameFromField(field *parser.ModelField) string {
 	return field.Name
 }
 
+// getRenamedFromField returns the column a field's column may have been renamed from:
+// the @renamedFrom argument, or the field name when @map gives the column another name
+func getRenamedFromField(field *parser.ModelField, columnName string) string {
+	for _, attr := range field.Attributes {
+		if attr.Name == "renamedFrom" && len(attr.Arguments) > 0 {
+			if name, ok := attr.Arguments[0].Value.(string); ok {
+				return strings.Trim(name, `"`)
+			}
+		}
+	}
+	if field.Name != columnName {
+		return field.Name
+	}
+	return ""
+}
+
 // getNumericValue extracts a numeric value as string from an attribute argument
 // Handles both string and numeric types
 func getNumericValue(value interface{}) string {
@@ -48,3 +112,71 @@ func getNumericValue(value interface{}) string {
 	}
 	return ""
 }
+
+// getSoftDeleteColumn returns the mapped column of a soft-delete timestamp: the field named by
+// @@softDelete(field), or else deletedAt / deleted_at. Only nullable DateTime fields count.
+// Returns "" if the model is not soft-deletable.
+func getSoftDeleteColumn(model *parser.Model) string {
+	for _, attr := range model.Attributes {
+		if attr.Name != "softDelete" || len(attr.Arguments) == 0 {
+			continue
+		}
+		if fieldName, ok := attr.Arguments[0].Value.(string); ok {
+			for _, field := range model.Fields {
+				if field.Name == fieldName {
+					return getColumnNameFromField(field)
+				}
+			}
+		}
+	}
+	for _, field := range model.Fields {
+		if field.Name != "deletedAt" && field.Name != "deleted_at" {
+			continue
+		}
+		if field.Type != nil && field.Type.Name == "DateTime" && field.Type.IsOptional {
+			return getColumnNameFromField(field)
+		}
+	}
+	return ""
+}
+
+// isTenantField checks if a field scopes rows by tenant (tenantId, tenant_id, id_tenant)
+func isTenantField(name string) bool {
+	switch name {
+	case "tenantId", "tenant_id", "id_tenant":
+		return true
+	}
+	return false
+}
+
+// applySoftDeletePredicate makes a tenant-scoped composite unique partial on soft-deletable models,
+// so a soft-deleted row does not block re-creating the same key: UNIQUE (tenant_id, email) WHERE deleted_at IS NULL
+// indexDef.Columns must still hold schema field names (before @map is applied). An explicit
+// where: predicate is kept as written.
+func applySoftDeletePredicate(model *parser.Model, indexDef *IndexDefinition) {
+	if len(indexDef.Columns) < 2 || indexDef.Where != "" {
+		return
+	}
+	deletedCol := getSoftDeleteColumn(model)
+	if deletedCol == "" {
+		return
+	}
+	for _, col := range indexDef.Columns {
+		if isTenantField(col) {
+			indexDef.Where = deletedCol + " IS NULL"
+			return
+		}
+	}
+}
+
+// UniqueIndexPredicate returns the WHERE predicate of the index a @@unique attribute of model
+// creates: its where: argument, or the soft-delete predicate of a tenant-scoped key. It is ""
+// when the index is not partial. Upserts use it as the conflict target's predicate.
+func UniqueIndexPredicate(model *parser.Model, attr *parser.Attribute) string {
+	idx := extractUniqueIndex("", attr)
+	if idx == nil {
+		return ""
+	}
+	applySoftDeletePredicate(model, idx)
+	return idx.Where
+}
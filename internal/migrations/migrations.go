@@ -1,6 +1,7 @@
 package migrations
 
 import (
+	"context"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/carlosnayan/prisma-go-client/internal/config"
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
 	"github.com/carlosnayan/prisma-go-client/internal/uuid"
 )
 
@@ -28,6 +30,7 @@ type Manager struct {
 	config         *config.Config
 	db             *sql.DB
 	migrationsPath string
+	provider       string // Set by Apply; otherwise detected from the config's database URL
 }
 
 // NewManager creates a new migration manager
@@ -68,7 +71,7 @@ func (m *Manager) EnsureMigrationsTable() error {
 				applied_steps_count INTEGER NOT NULL DEFAULT 0
 			)
 		`
-	case "mysql":
+	case "mysql", "mariadb":
 		// MySQL: Use DATETIME(3) for millisecond precision (matching Prisma)
 		query = `
 			CREATE TABLE IF NOT EXISTS _prisma_migrations (
@@ -122,6 +125,9 @@ func (m *Manager) EnsureMigrationsTable() error {
 
 // getProvider determines the database provider from the connection string
 func (m *Manager) getProvider() string {
+	if m.provider != "" {
+		return m.provider
+	}
 	if m.config == nil || m.config.Datasource == nil {
 		return "postgresql" // Default
 	}
@@ -288,6 +294,31 @@ func isValidMigrationName(name string) bool {
 	return true
 }
 
+// migrationTimestampLayout is the sortable UTC prefix of migration directory names
+const migrationTimestampLayout = "20060102150405"
+
+// NewMigrationName returns the directory name for a new migration: a UTC
+// YYYYMMDDHHMMSS timestamp followed by name (e.g. 20240101120000_add_users).
+// The timestamp is bumped past the newest migration in migrationsPath, so names
+// created within the same second (or on a machine with a lagging clock) still sort
+// in creation order.
+func NewMigrationName(migrationsPath, name string, now time.Time) string {
+	timestamp := now.UTC().Truncate(time.Second)
+
+	entries, _ := os.ReadDir(migrationsPath)
+	for _, entry := range entries {
+		if !entry.IsDir() || !isValidMigrationName(entry.Name()) {
+			continue
+		}
+		existing, err := time.Parse(migrationTimestampLayout, entry.Name()[:14])
+		if err == nil && !existing.Before(timestamp) {
+			timestamp = existing.Add(time.Second)
+		}
+	}
+
+	return fmt.Sprintf("%s_%s", timestamp.Format(migrationTimestampLayout), name)
+}
+
 // GetPendingMigrations returns pending migrations (local but not applied)
 func (m *Manager) GetPendingMigrations() ([]*Migration, error) {
 	local, err := m.GetLocalMigrations()
@@ -346,64 +377,134 @@ func (m *Manager) GetMissingMigrations() ([]string, error) {
 
 // ApplyMigration applies a migration to the database
 func (m *Manager) ApplyMigration(migration *Migration) error {
+	return m.ApplyMigrationContext(context.Background(), migration)
+}
+
+// ApplyMigrationContext applies a migration and records it in _prisma_migrations.
+// Where DDL is transactional (PostgreSQL, SQLite) the statements and the record run
+// in one transaction, so a failed migration leaves nothing behind. MySQL commits each
+// DDL statement implicitly, so there the record is inserted first and only gets
+// finished_at once every statement ran; a failure leaves it unfinished with the
+// error in logs.
+func (m *Manager) ApplyMigrationContext(ctx context.Context, migration *Migration) error {
 	if err := m.EnsureMigrationsTable(); err != nil {
 		return err
 	}
 
+	provider := m.getProvider()
+	d := dialect.GetDialect(provider)
+	insertQuery := fmt.Sprintf(`
+		INSERT INTO _prisma_migrations (id, checksum, migration_name, started_at, applied_steps_count)
+		VALUES (%s, %s, %s, %s, 1)
+	`, d.GetPlaceholder(1), d.GetPlaceholder(2), d.GetPlaceholder(3), d.GetPlaceholder(4))
+	updateQuery := fmt.Sprintf(`
+		UPDATE _prisma_migrations 
+		SET finished_at = %s, applied_steps_count = %s
+		WHERE id = %s
+	`, d.GetPlaceholder(1), d.GetPlaceholder(2), d.GetPlaceholder(3))
+
+	migrationID := generateMigrationID()
+	checksum := calculateChecksum(migration.SQL)
+	statements := SplitSQLStatements(migration.SQL)
+
+	if !supportsTransactionalDDL(provider) {
+		if _, err := m.db.ExecContext(ctx, insertQuery, migrationID, checksum, migration.Name, time.Now()); err != nil {
+			return fmt.Errorf("error registering migration: %w", err)
+		}
+		if err := execMigrationStatements(ctx, m.db, migration.Name, statements); err != nil {
+			logsQuery := fmt.Sprintf(`UPDATE _prisma_migrations SET logs = %s WHERE id = %s`, d.GetPlaceholder(1), d.GetPlaceholder(2))
+			_, _ = m.db.ExecContext(ctx, logsQuery, err.Error(), migrationID)
+			return err
+		}
+		if _, err := m.db.ExecContext(ctx, updateQuery, time.Now(), len(statements), migrationID); err != nil {
+			return fmt.Errorf("error finishing migration: %w", err)
+		}
+		return nil
+	}
+
 	// Start transaction
-	tx, err := m.db.Begin()
+	tx, err := m.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("error starting transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
 	// Register migration start
-	migrationID := generateMigrationID()
-	startedAt := time.Now()
+	if _, err := tx.ExecContext(ctx, insertQuery, migrationID, checksum, migration.Name, time.Now()); err != nil {
+		return fmt.Errorf("error registering migration: %w", err)
+	}
 
-	insertQuery := `
-		INSERT INTO _prisma_migrations (id, checksum, migration_name, started_at, applied_steps_count)
-		VALUES ($1, $2, $3, $4, 1)
-	`
+	if err := execMigrationStatements(ctx, tx, migration.Name, statements); err != nil {
+		return err
+	}
 
-	checksum := calculateChecksum(migration.SQL)
-	_, err = tx.Exec(insertQuery, migrationID, checksum, migration.Name, startedAt)
-	if err != nil {
-		return fmt.Errorf("error registering migration: %w", err)
+	// Mark as finished
+	if _, err := tx.ExecContext(ctx, updateQuery, time.Now(), len(statements), migrationID); err != nil {
+		return fmt.Errorf("error finishing migration: %w", err)
 	}
 
-	// Execute migration SQL
-	// Split by ; to execute multiple statements
-	statements := SplitSQLStatements(migration.SQL)
+	// Commit
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing migration: %w", err)
+	}
+
+	return nil
+}
+
+// execer is implemented by *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// execMigrationStatements runs the statements of a migration in order
+func execMigrationStatements(ctx context.Context, db execer, name string, statements []string) error {
 	for _, stmt := range statements {
 		stmt = strings.TrimSpace(stmt)
 		if stmt == "" {
 			continue
 		}
 
-		if _, err := tx.Exec(stmt); err != nil {
-			return fmt.Errorf("error executing migration %s: %w\nSQL: %s", migration.Name, err, stmt)
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("error executing migration %s: %w\nSQL: %s", name, err, stmt)
 		}
 	}
+	return nil
+}
 
-	// Mark as finished
-	finishedAt := time.Now()
-	updateQuery := `
-		UPDATE _prisma_migrations 
-		SET finished_at = $1, applied_steps_count = $2
-		WHERE id = $3
-	`
-	_, err = tx.Exec(updateQuery, finishedAt, len(statements), migrationID)
-	if err != nil {
-		return fmt.Errorf("error finishing migration: %w", err)
+// supportsTransactionalDDL reports whether schema changes can be rolled back with the
+// transaction they run in. MySQL commits implicitly before and after each DDL statement.
+func supportsTransactionalDDL(provider string) bool {
+	return provider != "mysql" && provider != "mariadb"
+}
+
+// Apply applies the pending migrations in migrationsDir (directories named
+// YYYYMMDDHHMMSS_name holding a migration.sql) in name order, recording each one
+// with its checksum in _prisma_migrations, which is created if needed. Migrations
+// already recorded there are skipped. It stops at the first failure and returns the
+// names of the migrations applied until then.
+func Apply(ctx context.Context, db *sql.DB, provider, migrationsDir string) ([]string, error) {
+	m := &Manager{
+		db:             db,
+		migrationsPath: migrationsDir,
+		provider:       strings.ToLower(provider),
 	}
 
-	// Commit
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("error committing migration: %w", err)
+	pending, err := m.GetPendingMigrations()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	var applied []string
+	for _, migration := range pending {
+		if err := ctx.Err(); err != nil {
+			return applied, err
+		}
+		if err := m.ApplyMigrationContext(ctx, migration); err != nil {
+			return applied, fmt.Errorf("error applying migration %s: %w", migration.Name, err)
+		}
+		applied = append(applied, migration.Name)
+	}
+	return applied, nil
 }
 
 // generateMigrationID generates a unique UUID v4 for the migration
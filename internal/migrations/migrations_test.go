@@ -1,9 +1,11 @@
 package migrations
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	testutil "github.com/carlosnayan/prisma-go-client/internal/testing"
 )
@@ -96,4 +98,102 @@ func TestMigrations_MySQL(t *testing.T) {
 	}
 }
 
+// TestApply tests that Apply records applied migrations, skips them on the next run
+// and leaves a failed migration unrecorded
+func TestApply(t *testing.T) {
+	for _, provider := range []string{"postgresql", "mysql"} {
+		t.Run(provider, func(t *testing.T) {
+			testutil.SkipIfNoDatabase(t, provider)
+			db, cleanup := testutil.SetupTestDB(t, provider)
+			defer cleanup()
+
+			sqlDB := db.SQLDB()
+			if sqlDB == nil {
+				t.Fatal("database does not support SQLDB()")
+			}
+			defer func() {
+				_, _ = sqlDB.Exec("DROP TABLE IF EXISTS apply_second")
+				_, _ = sqlDB.Exec("DROP TABLE IF EXISTS apply_first")
+				_, _ = sqlDB.Exec("DROP TABLE IF EXISTS _prisma_migrations")
+			}()
+
+			migrationsDir := t.TempDir()
+			writeMigration := func(name, sql string) {
+				dir := filepath.Join(migrationsDir, name)
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatalf("failed to create migration directory: %v", err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "migration.sql"), []byte(sql), 0644); err != nil {
+					t.Fatalf("failed to write migration file: %v", err)
+				}
+			}
+			writeMigration("20240101000000_first", "CREATE TABLE apply_first (id INTEGER PRIMARY KEY);")
+			writeMigration("20240102000000_second", "CREATE TABLE apply_second (id INTEGER PRIMARY KEY);")
+
+			ctx := context.Background()
+			applied, err := Apply(ctx, sqlDB, provider, migrationsDir)
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+			if len(applied) != 2 || applied[0] != "20240101000000_first" || applied[1] != "20240102000000_second" {
+				t.Fatalf("applied = %v", applied)
+			}
+
+			var checksum string
+			if err := sqlDB.QueryRow("SELECT checksum FROM _prisma_migrations WHERE migration_name = '20240101000000_first'").Scan(&checksum); err != nil {
+				t.Fatalf("migration was not recorded: %v", err)
+			}
+			if checksum != calculateChecksum("CREATE TABLE apply_first (id INTEGER PRIMARY KEY);") {
+				t.Errorf("checksum = %s", checksum)
+			}
+
+			// Applied migrations are skipped on the next run
+			applied, err = Apply(ctx, sqlDB, provider, migrationsDir)
+			if err != nil || len(applied) != 0 {
+				t.Fatalf("second Apply = %v, %v; want no migrations", applied, err)
+			}
+
+			// A failed migration is not marked as applied
+			writeMigration("20240103000000_broken", "CREATE TABLE apply_first (id INTEGER PRIMARY KEY);")
+			if _, err := Apply(ctx, sqlDB, provider, migrationsDir); err == nil {
+				t.Fatal("expected the broken migration to fail")
+			}
+			var finished int
+			if err := sqlDB.QueryRow("SELECT COUNT(*) FROM _prisma_migrations WHERE migration_name = '20240103000000_broken' AND finished_at IS NOT NULL").Scan(&finished); err != nil {
+				t.Fatalf("failed to query _prisma_migrations: %v", err)
+			}
+			if finished != 0 {
+				t.Error("broken migration was marked as applied")
+			}
+		})
+	}
+}
+
 // TestMigrations_SQLite is tested in migrations_test_sqlite.go (requires build tag)
+
+// TestNewMigrationName tests that migration names are sortable UTC timestamps that stay
+// after the newest existing migration
+func TestNewMigrationName(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 1, 9, 0, 0, 500, time.FixedZone("BRT", -3*60*60))
+
+	if got := NewMigrationName(dir, "add_users", now); got != "20240101120000_add_users" {
+		t.Errorf("NewMigrationName = %q, want 20240101120000_add_users", got)
+	}
+
+	// Mesmo segundo de uma migração existente: o timestamp avança
+	if err := os.Mkdir(filepath.Join(dir, "20240101120000_add_users"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := NewMigrationName(dir, "add_posts", now); got != "20240101120001_add_posts" {
+		t.Errorf("NewMigrationName = %q, want 20240101120001_add_posts", got)
+	}
+
+	// Migração existente no futuro (relógio atrasado): continua ordenando depois dela
+	if err := os.Mkdir(filepath.Join(dir, "20240102000000_later"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := NewMigrationName(dir, "add_tags", now); got != "20240102000001_add_tags" {
+		t.Errorf("NewMigrationName = %q, want 20240102000001_add_tags", got)
+	}
+}
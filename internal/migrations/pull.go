@@ -253,6 +253,18 @@ func mapDatabaseTypeToPrisma(dbType, udtName string, provider string, enumMap ma
 func convertDefaultValue(defaultVal, dbType string, provider string, udtName string, enumMap map[string]*EnumInfo) *parser.Attribute {
 	defaultVal = strings.TrimSpace(defaultVal)
 
+	// MySQL and SQLite store boolean defaults as 1/0 (or b'1'); pull them back as true/false
+	if isBooleanColumnType(dbType, udtName, provider, enumMap) {
+		if b, ok := normalizeBooleanDefault(defaultVal); ok {
+			return &parser.Attribute{
+				Name: "default",
+				Arguments: []*parser.AttributeArgument{
+					{Value: b},
+				},
+			}
+		}
+	}
+
 	if strings.Contains(defaultVal, "nextval") || strings.Contains(defaultVal, "gen_random_uuid") {
 		if strings.Contains(defaultVal, "gen_random_uuid") {
 			return &parser.Attribute{
@@ -497,6 +509,19 @@ func convertDefaultValue(defaultVal, dbType string, provider string, udtName str
 	return nil
 }
 
+// isBooleanColumnType reports whether the introspected column type maps to Boolean.
+// MySQL and SQLite columns may already carry the Prisma type from introspection.
+func isBooleanColumnType(dbType, udtName string, provider string, enumMap map[string]*EnumInfo) bool {
+	switch provider {
+	case "mysql":
+		return mapMySQLType(dbType) == "Boolean"
+	case "sqlite":
+		return mapSQLiteType(dbType) == "Boolean"
+	default:
+		return mapDatabaseTypeToPrisma(dbType, udtName, provider, enumMap) == "Boolean"
+	}
+}
+
 func convertToDbAttribute(colInfo *ColumnInfo, provider string) *parser.Attribute {
 	dbType := strings.ToLower(strings.TrimSpace(colInfo.Type))
 	udtName := strings.ToLower(strings.TrimSpace(colInfo.UdtName))
@@ -773,10 +798,34 @@ func mapOnDeleteAction(action string) string {
 	}
 }
 
+// indexTypeNames maps the PostgreSQL access methods other than btree to their type: names
+var indexTypeNames = map[string]string{
+	"hash":   "Hash",
+	"gist":   "Gist",
+	"gin":    "Gin",
+	"spgist": "SpGist",
+	"brin":   "Brin",
+}
+
+// hasIndexExpression reports whether an introspected index has an expression entry
+func hasIndexExpression(idx *IndexInfo) bool {
+	for _, info := range idx.ColumnInfos {
+		if info.Expression {
+			return true
+		}
+	}
+	return false
+}
+
 func generateIndexes(tableName string, tableInfo *TableInfo) []*parser.Attribute {
 	indexes := []*parser.Attribute{}
 
 	for _, idx := range tableInfo.Indexes {
+		// Expression indexes have no field list to write them back as
+		if hasIndexExpression(idx) {
+			continue
+		}
+
 		// Build column list with sort order if needed
 		columnList := make([]interface{}, 0, len(idx.Columns))
 		// Create a map for quick lookup of column info by column name
@@ -868,6 +917,12 @@ func generateIndexes(tableName string, tableInfo *TableInfo) []*parser.Attribute
 					})
 				}
 			}
+			if method, ok := indexTypeNames[idx.Type]; ok {
+				indexAttr.Arguments = append(indexAttr.Arguments, &parser.AttributeArgument{
+					Name:  "type",
+					Value: method,
+				})
+			}
 			indexes = append(indexes, indexAttr)
 		}
 	}
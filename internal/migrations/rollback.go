@@ -0,0 +1,456 @@
+package migrations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
+)
+
+// GenerateRollbackSQL generates the SQL that reverts the migration generated for diff:
+// created tables, columns, indexes, foreign keys and enum types are dropped, and dropped
+// ones are recreated from diff.Previous (the database state CompareSchema compared
+// against). Objects whose previous definition is unknown get a warning comment.
+//
+// The rollback restores the schema shape only. Data removed by the forward migration
+// (dropped tables and columns) is not restored, and re-adding a NOT NULL column without
+// a default fails on a table that has rows.
+func GenerateRollbackSQL(diff *SchemaDiff, provider string) (string, error) {
+	var steps []string
+	d := dialect.GetDialect(provider)
+	diff = sortDiff(diff)
+	previous := diff.Previous
+	if previous == nil {
+		previous = &DatabaseSchema{Tables: map[string]*TableInfo{}}
+	}
+
+	// Drop foreign keys added by the migration, including the new form of altered ones
+	dropFKs := append(append([]ForeignKeyDefinition(nil), diff.ForeignKeysToCreate...), diff.ForeignKeysToAlter...)
+	if len(dropFKs) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- DropForeignKey\n")
+		for _, fk := range dropFKs {
+			sql.WriteString(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;\n",
+				d.QuoteIdentifier(fk.TableName),
+				d.QuoteIdentifier(fk.Name)))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	// Drop indexes created by the migration
+	if len(diff.IndexesToCreate) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- DropIndex\n")
+		for _, idx := range diff.IndexesToCreate {
+			sql.WriteString(fmt.Sprintf("DROP INDEX %s;\n", d.QuoteIdentifier(qualifiedIndexName(idx.TableName, idx.Name))))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	// Drop created tables, referencing tables first
+	if len(diff.TablesToCreate) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- DropTable\n")
+		for i := len(diff.TablesToCreate) - 1; i >= 0; i-- {
+			sql.WriteString(fmt.Sprintf("DROP TABLE %s;\n", d.QuoteIdentifier(diff.TablesToCreate[i].Name)))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	// Recreate dropped tables; their indexes and foreign keys are added further down
+	var recreatedTables []string
+	if len(diff.TablesToDrop) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- CreateTable\n")
+		for _, tableName := range diff.TablesToDrop {
+			table, ok := previous.Tables[tableName]
+			if !ok {
+				sql.WriteString(fmt.Sprintf("-- WARNING: the definition of dropped table %s is unknown, recreate it manually\n", tableName))
+				continue
+			}
+			recreatedTables = append(recreatedTables, tableName)
+
+			var columns, primaryKeys []string
+			for _, col := range previousColumns(table) {
+				columns = append(columns, "  "+previousColumnSQL(d, provider, col))
+				if col.IsPrimaryKey {
+					primaryKeys = append(primaryKeys, col.Name)
+				}
+			}
+			checks := make([]CheckDefinition, len(table.Checks))
+			for i, check := range table.Checks {
+				checks[i] = CheckDefinition{Name: check.Name, TableName: tableName, Expression: check.Expression}
+			}
+
+			sql.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", d.QuoteIdentifier(tableName)))
+			sql.WriteString(strings.Join(columns, ",\n"))
+			sql.WriteString(tableConstraintsSQL(d, provider, tableName, primaryKeys, checks))
+			sql.WriteString("\n);\n")
+		}
+		steps = append(steps, sql.String())
+	}
+
+	// Restore previous column types, defaults and nullability
+	for _, alter := range diff.TablesToAlter {
+		var statements []string
+		for _, col := range alter.AlterColumns {
+			if !col.TypeChanged && !col.DefaultChanged && !col.NullableChanged {
+				continue
+			}
+			prevCol := previousColumn(previous, alter.TableName, previousColumnName(alter, col.ColumnName))
+			if prevCol == nil {
+				statements = append(statements, fmt.Sprintf("-- WARNING: the previous definition of %s.%s is unknown", alter.TableName, col.ColumnName))
+				continue
+			}
+			if provider == "mysql" && (col.TypeChanged || col.NullableChanged) {
+				// MODIFY COLUMN restates the column as it was; it is still under its new name here
+				restored := *prevCol
+				restored.Name = col.ColumnName
+				if col.NullableChanged && !restored.IsNullable {
+					statements = append(statements, fmt.Sprintf("-- WARNING: %s.%s becomes NOT NULL again, so the rollback fails if it holds NULL values; update them first", alter.TableName, col.ColumnName))
+				}
+				statements = append(statements, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;",
+					d.QuoteIdentifier(alter.TableName),
+					previousColumnSQL(d, provider, &restored)))
+				continue
+			}
+
+			restoreDefault := col.DefaultChanged
+			if col.TypeChanged {
+				prevType := previousColumnType(d, provider, prevCol)
+				if provider == "sqlite" {
+					statements = append(statements, fmt.Sprintf("-- WARNING: SQLite cannot change the type of %s.%s back to %s, recreate the table to apply it",
+						alter.TableName, col.ColumnName, prevType))
+				} else {
+					newType := columnTypeSQL(d, provider, col.Column)
+					// The previous default is restored below instead of the schema one
+					statements = append(statements, alterTypeSQL(d, provider, alter.TableName, ColumnAlteration{
+						ColumnName:     col.ColumnName,
+						OldType:        newType,
+						DefaultChanged: true,
+					}, prevType))
+					restoreDefault = restoreDefault || (needsUsingCast(newType, prevType) && prevCol.DefaultValue != nil)
+				}
+			}
+			if restoreDefault {
+				statements = append(statements, alterDefaultSQL(d, provider, alter.TableName, ColumnAlteration{
+					ColumnName: col.ColumnName,
+					NewDefault: previousDefaultSQL(d, provider, prevCol.DefaultValue),
+				}))
+			}
+			if col.NullableChanged {
+				statements = append(statements, alterNullabilitySQL(d, provider, alter.TableName, ColumnAlteration{
+					ColumnName:  col.ColumnName,
+					NewNullable: prevCol.IsNullable,
+				}))
+			}
+		}
+		if len(statements) > 0 {
+			steps = append(steps, "-- AlterTable\n"+strings.Join(statements, "\n")+"\n")
+		}
+	}
+
+	// Drop CHECK constraints added by the migration
+	if len(diff.ChecksToCreate) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- DropCheck\n")
+		for _, check := range diff.ChecksToCreate {
+			sql.WriteString(dropCheckSQL(d, provider, check))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	// Drop added columns
+	for _, alter := range diff.TablesToAlter {
+		if len(alter.AddColumns) > 0 {
+			var sql strings.Builder
+			sql.WriteString("-- AlterTable\n")
+			for _, col := range alter.AddColumns {
+				sql.WriteString(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;\n",
+					d.QuoteIdentifier(alter.TableName),
+					d.QuoteIdentifier(col.Name)))
+			}
+			steps = append(steps, sql.String())
+		}
+	}
+
+	// Rename columns back
+	for _, alter := range diff.TablesToAlter {
+		if len(alter.RenameColumns) > 0 {
+			var sql strings.Builder
+			sql.WriteString("-- AlterTable\n")
+			for _, rename := range alter.RenameColumns {
+				sql.WriteString(renameColumnSQL(d, alter.TableName, rename.To, rename.From))
+			}
+			steps = append(steps, sql.String())
+		}
+	}
+
+	// Re-add dropped columns with their previous definition
+	for _, alter := range diff.TablesToAlter {
+		if len(alter.DropColumns) > 0 {
+			var sql strings.Builder
+			sql.WriteString("-- AlterTable\n")
+			for _, colName := range alter.DropColumns {
+				col := previousColumn(previous, alter.TableName, colName)
+				if col == nil {
+					sql.WriteString(fmt.Sprintf("-- WARNING: the definition of dropped column %s.%s is unknown, re-add it manually\n", alter.TableName, colName))
+					continue
+				}
+				sql.WriteString(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;\n",
+					d.QuoteIdentifier(alter.TableName),
+					previousColumnSQL(d, provider, col)))
+			}
+			steps = append(steps, sql.String())
+		}
+	}
+
+	// Re-add dropped CHECK constraints
+	if len(diff.ChecksToDrop) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- AddCheck\n")
+		for _, check := range diff.ChecksToDrop {
+			sql.WriteString(addCheckSQL(d, provider, check))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	// Recreate dropped indexes and the indexes of recreated tables
+	var indexes []string
+	for _, idxName := range diff.IndexesToDrop {
+		tableName, idx := previousIndex(previous, idxName)
+		if idx == nil {
+			indexes = append(indexes, fmt.Sprintf("-- WARNING: the definition of dropped index %s is unknown, recreate it manually\n", idxName))
+			continue
+		}
+		indexes = append(indexes, createIndexInfoSQL(d, tableName, idx))
+	}
+	for _, tableName := range recreatedTables {
+		for _, idx := range previous.Tables[tableName].Indexes {
+			indexes = append(indexes, createIndexInfoSQL(d, tableName, idx))
+		}
+	}
+	if len(indexes) > 0 {
+		steps = append(steps, "-- CreateIndex\n"+strings.Join(indexes, ""))
+	}
+
+	// Recreate dropped foreign keys, the previous form of altered ones and the
+	// foreign keys of recreated tables
+	var fks []string
+	for _, fk := range diff.ForeignKeysToDrop {
+		fks = append(fks, addForeignKeySQL(d, fk))
+	}
+	for _, fk := range diff.ForeignKeysToAlter {
+		prevFK := previousForeignKey(previous, fk)
+		if prevFK == nil {
+			fks = append(fks, fmt.Sprintf("-- WARNING: the previous definition of foreign key %s is unknown, recreate it manually\n", fk.Name))
+			continue
+		}
+		fks = append(fks, addForeignKeySQL(d, foreignKeyFromInfo(fk.TableName, prevFK)))
+	}
+	for _, tableName := range recreatedTables {
+		for _, fk := range previous.Tables[tableName].ForeignKeys {
+			fks = append(fks, addForeignKeySQL(d, foreignKeyFromInfo(tableName, fk)))
+		}
+	}
+	if len(fks) > 0 {
+		steps = append(steps, "-- AddForeignKey\n"+strings.Join(fks, ""))
+	}
+
+	// Drop enum types once no column uses them
+	if hasEnumTypes(provider) && len(diff.EnumsToCreate) > 0 {
+		var sql strings.Builder
+		sql.WriteString("-- DropEnum\n")
+		for _, enum := range diff.EnumsToCreate {
+			sql.WriteString(fmt.Sprintf("DROP TYPE %s;\n", d.QuoteIdentifier(enum.Name)))
+		}
+		steps = append(steps, sql.String())
+	}
+
+	return strings.Join(steps, "\n"), nil
+}
+
+// previousColumns returns the columns of table in database order
+func previousColumns(table *TableInfo) []*ColumnInfo {
+	names := table.ColumnOrder
+	if len(names) != len(table.Columns) {
+		names = make([]string, 0, len(table.Columns))
+		for name := range table.Columns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	columns := make([]*ColumnInfo, 0, len(names))
+	for _, name := range names {
+		if col, ok := table.Columns[name]; ok {
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
+
+// previousColumn looks up a column of the previous database state, nil when unknown
+func previousColumn(previous *DatabaseSchema, tableName, colName string) *ColumnInfo {
+	table, ok := previous.Tables[tableName]
+	if !ok {
+		return nil
+	}
+	return table.Columns[colName]
+}
+
+// previousColumnName returns the name colName had before the migration renamed it
+func previousColumnName(alter TableAlteration, colName string) string {
+	for _, rename := range alter.RenameColumns {
+		if rename.To == colName {
+			return rename.From
+		}
+	}
+	return colName
+}
+
+// previousIndex looks up an index of the previous database state by name
+func previousIndex(previous *DatabaseSchema, idxName string) (string, *IndexInfo) {
+	tableNames := make([]string, 0, len(previous.Tables))
+	for name := range previous.Tables {
+		tableNames = append(tableNames, name)
+	}
+	sort.Strings(tableNames)
+	for _, tableName := range tableNames {
+		for _, idx := range previous.Tables[tableName].Indexes {
+			if strings.EqualFold(qualifiedIndexName(tableName, idx.Name), idxName) {
+				return tableName, idx
+			}
+		}
+	}
+	return "", nil
+}
+
+// previousForeignKey looks up the database foreign key that fk alters, matched by structure
+func previousForeignKey(previous *DatabaseSchema, fk ForeignKeyDefinition) *ForeignKeyInfo {
+	table, ok := previous.Tables[fk.TableName]
+	if !ok {
+		return nil
+	}
+	key := createFKStructureKey(fk.TableName, fk.Columns, fk.ReferencedTable, fk.ReferencedColumns)
+	for _, dbFK := range table.ForeignKeys {
+		if createFKStructureKey(fk.TableName, dbFK.Columns, dbFK.ReferencedTable, dbFK.ReferencedColumns) == key {
+			return dbFK
+		}
+	}
+	return nil
+}
+
+// foreignKeyFromInfo converts an introspected foreign key of tableName into a definition
+func foreignKeyFromInfo(tableName string, fk *ForeignKeyInfo) ForeignKeyDefinition {
+	return ForeignKeyDefinition{
+		Name:              fk.Name,
+		TableName:         tableName,
+		Columns:           fk.Columns,
+		ReferencedTable:   fk.ReferencedTable,
+		ReferencedColumns: fk.ReferencedColumns,
+		OnDelete:          fk.OnDelete,
+		OnUpdate:          fk.OnUpdate,
+	}
+}
+
+// createIndexInfoSQL renders the statement recreating an introspected index of tableName
+func createIndexInfoSQL(d dialect.Dialect, tableName string, idx *IndexInfo) string {
+	unique := ""
+	if idx.IsUnique {
+		unique = "UNIQUE "
+	}
+	quotedCols := make([]string, len(idx.Columns))
+	for i, col := range idx.Columns {
+		quotedCols[i] = d.QuoteIdentifier(col)
+		if i >= len(idx.ColumnInfos) {
+			continue
+		}
+		info := idx.ColumnInfos[i]
+		if info.Expression {
+			quotedCols[i] = "(" + col + ")"
+		}
+		if info.Length > 0 {
+			quotedCols[i] += fmt.Sprintf("(%d)", info.Length)
+		}
+		if info.SortOrder == "DESC" {
+			quotedCols[i] += " DESC"
+		}
+	}
+	using := ""
+	if idx.Type != "" && idx.Type != "btree" {
+		using = " USING " + strings.ToUpper(idx.Type)
+	}
+	where := ""
+	if idx.Where != "" {
+		where = " WHERE " + idx.Where
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s%s (%s)%s;\n",
+		unique,
+		d.QuoteIdentifier(idx.Name),
+		d.QuoteIdentifier(tableName),
+		using,
+		strings.Join(quotedCols, ", "),
+		where)
+}
+
+// previousColumnSQL renders an introspected column as it was: name, type, nullability and default
+func previousColumnSQL(d dialect.Dialect, provider string, col *ColumnInfo) string {
+	def := d.QuoteIdentifier(col.Name) + " " + previousColumnType(d, provider, col)
+	if !col.IsNullable {
+		def += " NOT NULL"
+	}
+	if value := previousDefaultSQL(d, provider, col.DefaultValue); value != "" {
+		def += " DEFAULT " + value
+	}
+	return def
+}
+
+// previousColumnType renders the type of an introspected column. MySQL and SQLite report
+// Prisma types, which are mapped again (with the length of MySQL VARCHARs); PostgreSQL
+// reports information_schema data types, which need the length, precision, enum or array
+// element type put back.
+func previousColumnType(d dialect.Dialect, provider string, col *ColumnInfo) string {
+	if isBuiltInType(col.Type) {
+		mapped := d.MapType(col.Type, col.IsNullable)
+		if col.CharacterMaximumLength != nil && strings.HasPrefix(strings.ToUpper(mapped), "VARCHAR") {
+			return fmt.Sprintf("VARCHAR(%d)", *col.CharacterMaximumLength)
+		}
+		return mapped
+	}
+	if provider != "postgresql" {
+		return col.Type
+	}
+	switch col.Type {
+	case "USER-DEFINED":
+		return d.QuoteIdentifier(col.UdtName)
+	case "ARRAY":
+		return strings.TrimPrefix(col.UdtName, "_") + "[]"
+	case "character varying", "character", "bit", "bit varying":
+		if col.CharacterMaximumLength != nil {
+			return fmt.Sprintf("%s(%d)", col.Type, *col.CharacterMaximumLength)
+		}
+	case "timestamp without time zone", "timestamp with time zone", "time without time zone", "time with time zone":
+		if col.DateTimePrecision != nil {
+			name, zone, _ := strings.Cut(col.Type, " ")
+			return fmt.Sprintf("%s(%d) %s", name, *col.DateTimePrecision, zone)
+		}
+	}
+	return col.Type
+}
+
+// previousDefaultSQL renders an introspected default as SQL. PostgreSQL and SQLite report
+// defaults as SQL expressions already; MySQL reports string literals unquoted.
+func previousDefaultSQL(d dialect.Dialect, provider string, value *string) string {
+	if value == nil {
+		return ""
+	}
+	if provider != "mysql" || isLiteralDefault(*value) || strings.Contains(*value, "(") {
+		return *value
+	}
+	upper := strings.ToUpper(*value)
+	if upper == "NULL" || strings.HasPrefix(upper, "CURRENT_TIMESTAMP") {
+		return *value
+	}
+	return d.QuoteString(*value)
+}
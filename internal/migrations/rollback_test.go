@@ -0,0 +1,147 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/carlosnayan/prisma-go-client/internal/parser"
+)
+
+func TestGenerateRollbackSQL(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model users {
+  id       Int     @id
+  email    String  @unique
+  nickname String?
+  posts    posts[]
+}
+
+model posts {
+  id       Int   @id
+  authorId Int
+  author   users @relation(fields: [authorId], references: [id])
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	strPtr := func(s string) *string { return &s }
+	length := 50
+	dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+		"users": {
+			Name: "users",
+			Columns: map[string]*ColumnInfo{
+				"id":     {Name: "id", Type: "integer", IsPrimaryKey: true},
+				"email":  {Name: "email", Type: "character varying", CharacterMaximumLength: &length},
+				"legacy": {Name: "legacy", Type: "character varying", CharacterMaximumLength: &length, DefaultValue: strPtr("'none'::character varying")},
+			},
+			ColumnOrder: []string{"id", "email", "legacy"},
+			Indexes: []*IndexInfo{
+				{Name: "users_email_key", Columns: []string{"email"}, IsUnique: true},
+				{Name: "users_legacy_idx", Columns: []string{"legacy"}},
+			},
+		},
+		"audit_logs": {
+			Name: "audit_logs",
+			Columns: map[string]*ColumnInfo{
+				"id":      {Name: "id", Type: "integer", IsPrimaryKey: true},
+				"userId":  {Name: "userId", Type: "integer", IsNullable: true},
+				"message": {Name: "message", Type: "text"},
+			},
+			ColumnOrder: []string{"id", "userId", "message"},
+			Indexes:     []*IndexInfo{{Name: "audit_logs_userId_idx", Columns: []string{"userId"}}},
+			ForeignKeys: []*ForeignKeyInfo{{Name: "audit_logs_userId_fkey", Columns: []string{"userId"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}, OnDelete: "SET NULL", OnUpdate: "CASCADE"}},
+		},
+	}}
+
+	diff, err := CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	sql, err := GenerateRollbackSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateRollbackSQL failed: %v", err)
+	}
+
+	// Cada operação da migração é desfeita, na ordem em que as dependências permitem
+	want := []string{
+		`ALTER TABLE "posts" DROP CONSTRAINT "posts_authorId_fkey";`,
+		`DROP TABLE "posts";`,
+		"CREATE TABLE \"audit_logs\" (\n  \"id\" integer NOT NULL,\n  \"userId\" integer,\n  \"message\" text NOT NULL,\n  CONSTRAINT \"audit_logs_pkey\" PRIMARY KEY (\"id\")\n);",
+		`ALTER TABLE "users" DROP COLUMN "nickname";`,
+		`ALTER TABLE "users" ADD COLUMN "legacy" character varying(50) NOT NULL DEFAULT 'none'::character varying;`,
+		`CREATE INDEX "users_legacy_idx" ON "users" ("legacy");`,
+		`CREATE INDEX "audit_logs_userId_idx" ON "audit_logs" ("userId");`,
+		`ALTER TABLE "audit_logs" ADD CONSTRAINT "audit_logs_userId_fkey" FOREIGN KEY ("userId") REFERENCES "users" ("id") ON DELETE SET NULL ON UPDATE CASCADE;`,
+	}
+	last := -1
+	for _, w := range want {
+		i := strings.Index(sql, w)
+		if i < 0 {
+			t.Errorf("missing %q in:\n%s", w, sql)
+			continue
+		}
+		if i < last {
+			t.Errorf("%q is out of order in:\n%s", w, sql)
+		}
+		last = i
+	}
+	if strings.Contains(sql, "users_email_key") {
+		t.Errorf("unchanged index should not be touched:\n%s", sql)
+	}
+}
+
+func TestGenerateRollbackSQL_Defaults(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	diff := &SchemaDiff{
+		TablesToAlter: []TableAlteration{{
+			TableName: "posts",
+			AlterColumns: []ColumnAlteration{
+				{ColumnName: "status", DefaultChanged: true, NewDefault: "'published'"},
+				{ColumnName: "views", DefaultChanged: true, NewDefault: "'0'"},
+			},
+		}},
+		Previous: &DatabaseSchema{Tables: map[string]*TableInfo{
+			"posts": {Columns: map[string]*ColumnInfo{
+				"status": {Name: "status", DefaultValue: strPtr("draft")},
+				"views":  {Name: "views"},
+			}},
+		}},
+	}
+
+	sql, err := GenerateRollbackSQL(diff, "mysql")
+	if err != nil {
+		t.Fatalf("GenerateRollbackSQL failed: %v", err)
+	}
+	// O MySQL informa literais sem aspas, que precisam voltar entre aspas
+	for _, want := range []string{
+		"ALTER TABLE `posts` ALTER COLUMN `status` SET DEFAULT 'draft';",
+		"ALTER TABLE `posts` ALTER COLUMN `views` DROP DEFAULT;",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("missing %q in:\n%s", want, sql)
+		}
+	}
+}
+
+func TestGenerateRollbackSQL_UnknownPrevious(t *testing.T) {
+	diff := &SchemaDiff{
+		TablesToDrop:  []string{"sessions"},
+		TablesToAlter: []TableAlteration{{TableName: "users", DropColumns: []string{"legacy"}}},
+	}
+
+	sql, err := GenerateRollbackSQL(diff, "sqlite")
+	if err != nil {
+		t.Fatalf("GenerateRollbackSQL failed: %v", err)
+	}
+	// Sem o estado anterior, o rollback avisa em vez de inventar uma definição
+	for _, want := range []string{
+		"-- WARNING: the definition of dropped table sessions is unknown, recreate it manually",
+		"-- WARNING: the definition of dropped column users.legacy is unknown, re-add it manually",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("missing %q in:\n%s", want, sql)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/carlosnayan/prisma-go-client/internal/dialect"
 	"github.com/carlosnayan/prisma-go-client/internal/parser"
 )
 
@@ -341,3 +342,1333 @@ func TestCompareSchema_CompositeIndexes(t *testing.T) {
 		t.Errorf("SQL missing named unique index 'chatbot_variables_unique_name_per_flow'")
 	}
 }
+
+func TestSchemaToSQL_TenantSoftDeleteUnique(t *testing.T) {
+	schema := &parser.Schema{
+		Models: []*parser.Model{
+			{
+				Name: "users",
+				Fields: []*parser.ModelField{
+					{Name: "id", Type: &parser.FieldType{Name: "String"}, Attributes: []*parser.Attribute{{Name: "id"}}},
+					{
+						Name:       "tenantId",
+						Type:       &parser.FieldType{Name: "String"},
+						Attributes: []*parser.Attribute{{Name: "map", Arguments: []*parser.AttributeArgument{{Value: "tenant_id"}}}},
+					},
+					{Name: "email", Type: &parser.FieldType{Name: "String"}},
+					{
+						Name:       "deletedAt",
+						Type:       &parser.FieldType{Name: "DateTime", IsOptional: true},
+						Attributes: []*parser.Attribute{{Name: "map", Arguments: []*parser.AttributeArgument{{Value: "deleted_at"}}}},
+					},
+				},
+				Attributes: []*parser.Attribute{
+					{
+						Name: "unique",
+						Arguments: []*parser.AttributeArgument{
+							{Value: []interface{}{"tenantId", "email"}},
+							{Name: "map", Value: "users_tenant_email_key"},
+						},
+					},
+				},
+			},
+			{
+				// Tenant-scoped but not soft-deletable: stays a plain composite unique
+				Name: "teams",
+				Fields: []*parser.ModelField{
+					{Name: "id", Type: &parser.FieldType{Name: "String"}, Attributes: []*parser.Attribute{{Name: "id"}}},
+					{Name: "tenant_id", Type: &parser.FieldType{Name: "String"}},
+					{Name: "name", Type: &parser.FieldType{Name: "String"}},
+				},
+				Attributes: []*parser.Attribute{
+					{
+						Name: "unique",
+						Arguments: []*parser.AttributeArgument{
+							{Value: []interface{}{"tenant_id", "name"}},
+							{Name: "map", Value: "teams_tenant_name_key"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	diff, err := SchemaToSQL(schema, "postgresql")
+	if err != nil {
+		t.Fatalf("SchemaToSQL failed: %v", err)
+	}
+
+	sql, err := GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+
+	if !strings.Contains(sql, `CREATE UNIQUE INDEX "users_tenant_email_key" ON "users" ("tenant_id", "email") WHERE deleted_at IS NULL;`) {
+		t.Errorf("SQL missing partial composite unique index for tenant + soft delete:\n%s", sql)
+	}
+	if !strings.Contains(sql, `CREATE UNIQUE INDEX "teams_tenant_name_key" ON "teams" ("tenant_id", "name");`) {
+		t.Errorf("SQL should keep a plain composite unique index when the model is not soft-deletable:\n%s", sql)
+	}
+
+	// Same result when diffing against an empty database
+	diff, err = CompareSchema(schema, &DatabaseSchema{Tables: make(map[string]*TableInfo)}, "sqlite")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	sql, err = GenerateMigrationSQL(diff, "sqlite")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	if !strings.Contains(sql, `("tenant_id", "email") WHERE deleted_at IS NULL;`) {
+		t.Errorf("SQLite SQL missing partial composite unique index:\n%s", sql)
+	}
+}
+
+func TestUniqueIndexPredicate(t *testing.T) {
+	schema, errs, err := parser.Parse(`
+model Member {
+  id         Int       @id
+  tenant_id  String
+  email      String
+  handle     String
+  removed_at DateTime? @map("removed_on")
+
+  @@softDelete(removed_at)
+  @@unique([tenant_id, email])
+  @@unique([handle], where: "handle <> ''")
+  @@unique([email, handle])
+}
+`)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	model := schema.Models[0]
+
+	// @@softDelete names the column of the tenant predicate; where: is kept; other uniques are total
+	want := []string{"removed_on IS NULL", "handle <> ''", ""}
+	var got []string
+	for _, attr := range model.Attributes {
+		if attr.Name == "unique" {
+			got = append(got, UniqueIndexPredicate(model, attr))
+		}
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("UniqueIndexPredicate = %q, want %q", got, want)
+	}
+}
+
+func TestSchemaToSQL_BooleanDefaults(t *testing.T) {
+	schema := &parser.Schema{
+		Models: []*parser.Model{
+			{
+				Name: "flags",
+				Fields: []*parser.ModelField{
+					{Name: "id", Type: &parser.FieldType{Name: "Int"}, Attributes: []*parser.Attribute{{Name: "id"}}},
+					{
+						Name:       "active",
+						Type:       &parser.FieldType{Name: "Boolean"},
+						Attributes: []*parser.Attribute{{Name: "default", Arguments: []*parser.AttributeArgument{{Value: true}}}},
+					},
+					{
+						Name:       "archived",
+						Type:       &parser.FieldType{Name: "Boolean"},
+						Attributes: []*parser.Attribute{{Name: "default", Arguments: []*parser.AttributeArgument{{Value: false}}}},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		provider string
+		active   string
+		archived string
+	}{
+		{"postgresql", `"active" BOOLEAN NOT NULL DEFAULT TRUE`, `"archived" BOOLEAN NOT NULL DEFAULT FALSE`},
+		{"mysql", "`active` TINYINT(1) NOT NULL DEFAULT 1", "`archived` TINYINT(1) NOT NULL DEFAULT 0"},
+		{"sqlite", `"active" INTEGER NOT NULL DEFAULT 1`, `"archived" INTEGER NOT NULL DEFAULT 0`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			diff, err := SchemaToSQL(schema, tt.provider)
+			if err != nil {
+				t.Fatalf("SchemaToSQL failed: %v", err)
+			}
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range []string{tt.active, tt.archived} {
+				if !strings.Contains(sql, want) {
+					t.Errorf("SQL missing %q:\n%s", want, sql)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertDefaultValue_BooleanForms(t *testing.T) {
+	tests := []struct {
+		defaultVal string
+		dbType     string
+		provider   string
+		expected   bool
+	}{
+		{"true", "boolean", "postgresql", true},
+		{"false", "boolean", "postgresql", false},
+		{"1", "tinyint(1)", "mysql", true},
+		{"b'0'", "tinyint(1)", "mysql", false},
+		{"'1'", "boolean", "sqlite", true},
+		{"0", "boolean", "sqlite", false},
+	}
+
+	for _, tt := range tests {
+		attr := convertDefaultValue(tt.defaultVal, tt.dbType, tt.provider, "", nil)
+		if attr == nil || len(attr.Arguments) != 1 {
+			t.Fatalf("convertDefaultValue(%q, %q) returned no default", tt.defaultVal, tt.dbType)
+		}
+		if value, ok := attr.Arguments[0].Value.(bool); !ok || value != tt.expected {
+			t.Errorf("convertDefaultValue(%q, %q) = %v, want %v", tt.defaultVal, tt.dbType, attr.Arguments[0].Value, tt.expected)
+		}
+	}
+
+	// Integer columns keep numeric defaults
+	attr := convertDefaultValue("1", "integer", "postgresql", "", nil)
+	if attr == nil || attr.Arguments[0].Value != 1 {
+		t.Errorf("integer default should stay numeric, got %+v", attr)
+	}
+}
+
+func TestSchemaToSQL_Enums(t *testing.T) {
+	schema := &parser.Schema{
+		Enums: []*parser.Enum{
+			{Name: "Role", Values: []*parser.EnumValue{
+				{Name: "ADMIN"},
+				{Name: "USER", Attributes: []*parser.Attribute{{Name: "map", Arguments: []*parser.AttributeArgument{{Value: "user"}}}}},
+			}},
+			{Name: "Unused", Values: []*parser.EnumValue{{Name: "A"}}},
+		},
+		Models: []*parser.Model{
+			{
+				Name: "members",
+				Fields: []*parser.ModelField{
+					{Name: "id", Type: &parser.FieldType{Name: "Int"}, Attributes: []*parser.Attribute{{Name: "id"}}},
+					{
+						Name:       "role",
+						Type:       &parser.FieldType{Name: "Role"},
+						Attributes: []*parser.Attribute{{Name: "default", Arguments: []*parser.AttributeArgument{{Value: "USER"}}}},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"postgresql", []string{`CREATE TYPE "Role" AS ENUM ('ADMIN', 'user');`, `"role" "Role" NOT NULL DEFAULT 'user'`}},
+		{"mysql", []string{"`role` ENUM('ADMIN', 'user') NOT NULL DEFAULT 'user'"}},
+		{"sqlite", []string{`"role" TEXT CHECK ("role" IN ('ADMIN', 'user')) NOT NULL DEFAULT 'user'`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			diff, err := SchemaToSQL(schema, tt.provider)
+			if err != nil {
+				t.Fatalf("SchemaToSQL failed: %v", err)
+			}
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("SQL missing %q:\n%s", want, sql)
+				}
+			}
+			if strings.Contains(sql, "Unused") {
+				t.Errorf("unused enum should not be created:\n%s", sql)
+			}
+			if tt.provider != "postgresql" && strings.Contains(sql, "CREATE TYPE") {
+				t.Errorf("CREATE TYPE is PostgreSQL only:\n%s", sql)
+			}
+		})
+	}
+}
+
+func TestCompareSchema_Enums(t *testing.T) {
+	schema := &parser.Schema{
+		Enums: []*parser.Enum{
+			{Name: "Role", Values: []*parser.EnumValue{{Name: "ADMIN"}, {Name: "USER"}}},
+			{Name: "Status", Values: []*parser.EnumValue{{Name: "OPEN"}, {Name: "CLOSED"}}},
+		},
+		Models: []*parser.Model{
+			{
+				Name: "members",
+				Fields: []*parser.ModelField{
+					{Name: "id", Type: &parser.FieldType{Name: "Int"}, Attributes: []*parser.Attribute{{Name: "id"}}},
+					{Name: "role", Type: &parser.FieldType{Name: "Role"}},
+					{Name: "status", Type: &parser.FieldType{Name: "Status"}},
+				},
+			},
+		},
+	}
+	dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+		"members": {
+			Name: "members",
+			Columns: map[string]*ColumnInfo{
+				"id":   {Name: "id", Type: "integer"},
+				"role": {Name: "role", Type: "USER-DEFINED"},
+			},
+		},
+	}}
+
+	diff, err := CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.EnumsToCreate) != 1 || diff.EnumsToCreate[0].Name != "Status" {
+		t.Fatalf("EnumsToCreate = %+v, want only Status", diff.EnumsToCreate)
+	}
+	if len(diff.TablesToAlter) != 1 {
+		t.Fatalf("TablesToAlter = %+v, want members", diff.TablesToAlter)
+	}
+	for _, alter := range diff.TablesToAlter[0].AlterColumns {
+		if alter.ColumnName == "role" {
+			t.Errorf("existing enum column should not be altered: %+v", alter)
+		}
+	}
+
+	sql, err := GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	for _, want := range []string{`CREATE TYPE "Status" AS ENUM ('OPEN', 'CLOSED');`, `ADD COLUMN "status" "Status" NOT NULL`} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("SQL missing %q:\n%s", want, sql)
+		}
+	}
+}
+
+func TestSchemaToSQL_Checks(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model Review {
+  id     Int @id
+  rating Int
+  title  String
+
+  @@map("reviews")
+  @@check("rating BETWEEN 1 AND 5", map: "reviews_rating_check")
+  @@check("length(title) > 0")
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"postgresql", []string{`CONSTRAINT "reviews_rating_check" CHECK (rating BETWEEN 1 AND 5)`, `CONSTRAINT "reviews_check" CHECK (length(title) > 0)`}},
+		{"mysql", []string{"CONSTRAINT `reviews_rating_check` CHECK (rating BETWEEN 1 AND 5)", "CONSTRAINT `reviews_check` CHECK (length(title) > 0)"}},
+		{"sqlite", []string{`CONSTRAINT "reviews_rating_check" CHECK (rating BETWEEN 1 AND 5)`, `CONSTRAINT "reviews_check" CHECK (length(title) > 0)`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			diff, err := SchemaToSQL(schema, tt.provider)
+			if err != nil {
+				t.Fatalf("SchemaToSQL failed: %v", err)
+			}
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("SQL missing %q:\n%s", want, sql)
+				}
+			}
+			// Checks de tabelas novas ficam no CREATE TABLE, não em ALTER TABLE
+			if strings.Contains(sql, "ADD CONSTRAINT") {
+				t.Errorf("checks of new tables should be inline:\n%s", sql)
+			}
+		})
+	}
+}
+
+func TestCompareSchema_Checks(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model reviews {
+  id     Int @id
+  rating Int
+
+  @@check("rating BETWEEN 1 AND 5", map: "reviews_rating_check")
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"postgresql", []string{
+			`ALTER TABLE "reviews" DROP CONSTRAINT "reviews_old_check";`,
+			`ALTER TABLE "reviews" ADD CONSTRAINT "reviews_rating_check" CHECK (rating BETWEEN 1 AND 5);`,
+		}},
+		{"mysql", []string{
+			"ALTER TABLE `reviews` DROP CHECK `reviews_old_check`;",
+			"ALTER TABLE `reviews` ADD CONSTRAINT `reviews_rating_check` CHECK (rating BETWEEN 1 AND 5);",
+		}},
+		{"sqlite", []string{
+			"-- WARNING: SQLite cannot drop CHECK constraint reviews_old_check",
+			"-- WARNING: SQLite cannot add CHECK constraint reviews_rating_check",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+				"reviews": {
+					Name: "reviews",
+					Columns: map[string]*ColumnInfo{
+						"id":     {Name: "id", Type: mapTypeToSQL("Int", tt.provider), IsPrimaryKey: true},
+						"rating": {Name: "rating", Type: mapTypeToSQL("Int", tt.provider)},
+					},
+					Checks: []*CheckInfo{{Name: "reviews_old_check", Expression: "(rating > 0)"}},
+				},
+			}}
+
+			diff, err := CompareSchema(schema, dbSchema, tt.provider)
+			if err != nil {
+				t.Fatalf("CompareSchema failed: %v", err)
+			}
+			if len(diff.ChecksToCreate) != 1 || diff.ChecksToCreate[0].Name != "reviews_rating_check" {
+				t.Errorf("ChecksToCreate = %+v", diff.ChecksToCreate)
+			}
+			if len(diff.ChecksToDrop) != 1 || diff.ChecksToDrop[0].Name != "reviews_old_check" || diff.ChecksToDrop[0].TableName != "reviews" {
+				t.Errorf("ChecksToDrop = %+v", diff.ChecksToDrop)
+			}
+
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("SQL missing %q:\n%s", want, sql)
+				}
+			}
+
+			// Uma check existente com o mesmo nome não muda, mesmo com a expressão reescrita pelo banco
+			dbSchema.Tables["reviews"].Checks = []*CheckInfo{{Name: "REVIEWS_RATING_CHECK", Expression: "((rating >= 1) AND (rating <= 5))"}}
+			diff, err = CompareSchema(schema, dbSchema, tt.provider)
+			if err != nil {
+				t.Fatalf("CompareSchema failed: %v", err)
+			}
+			if len(diff.ChecksToCreate) != 0 || len(diff.ChecksToDrop) != 0 {
+				t.Errorf("unchanged check should not be diffed: create %+v, drop %+v", diff.ChecksToCreate, diff.ChecksToDrop)
+			}
+		})
+	}
+}
+
+func TestParseSQLiteChecks(t *testing.T) {
+	checks := parseSQLiteChecks(`CREATE TABLE "reviews" (
+  "id" INTEGER NOT NULL,
+  "rating" INTEGER NOT NULL CHECK (rating > 0),
+  "title" TEXT NOT NULL,
+  CONSTRAINT "reviews_pkey" PRIMARY KEY ("id"),
+  CONSTRAINT "reviews_rating_check" CHECK (rating BETWEEN 1 AND 5),
+  CONSTRAINT reviews_check CHECK (length(title) > 0 AND (rating < 5 OR title != ''))
+)`)
+	if len(checks) != 2 {
+		t.Fatalf("checks = %+v, want the 2 named ones", checks)
+	}
+	if checks[0].Name != "reviews_rating_check" || checks[0].Expression != "rating BETWEEN 1 AND 5" {
+		t.Errorf("checks[0] = %+v", checks[0])
+	}
+	if checks[1].Name != "reviews_check" || checks[1].Expression != "length(title) > 0 AND (rating < 5 OR title != '')" {
+		t.Errorf("checks[1] = %+v", checks[1])
+	}
+}
+
+func TestCompareSchema_DefaultChanges(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model posts {
+  id        Int      @id @default(autoincrement())
+  views     Int      @default(1)
+  title     String   @default("untitled")
+  published Boolean  @default(true)
+  createdAt DateTime @default(now())
+  note      String?
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		provider string
+		defaults map[string]*string
+		want     []string
+	}{
+		{"postgresql", map[string]*string{
+			"id":        strPtr(`nextval('posts_id_seq'::regclass)`),
+			"views":     strPtr("0"),
+			"title":     strPtr("'untitled'::text"),
+			"published": strPtr("true"),
+			"note":      strPtr("'draft'::text"),
+		}, []string{
+			`ALTER TABLE "posts" ALTER COLUMN "views" SET DEFAULT '1';`,
+			`ALTER TABLE "posts" ALTER COLUMN "createdAt" SET DEFAULT CURRENT_TIMESTAMP;`,
+			`ALTER TABLE "posts" ALTER COLUMN "note" DROP DEFAULT;`,
+		}},
+		{"mysql", map[string]*string{
+			"views":     strPtr("0"),
+			"title":     strPtr("untitled"),
+			"published": strPtr("1"),
+			"note":      strPtr("draft"),
+		}, []string{
+			"ALTER TABLE `posts` ALTER COLUMN `views` SET DEFAULT '1';",
+			"ALTER TABLE `posts` ALTER COLUMN `createdAt` SET DEFAULT (CURRENT_TIMESTAMP);",
+			"ALTER TABLE `posts` ALTER COLUMN `note` DROP DEFAULT;",
+		}},
+		{"sqlite", map[string]*string{
+			"views":     strPtr("0"),
+			"title":     strPtr("'untitled'"),
+			"published": strPtr("1"),
+			"note":      strPtr("'draft'"),
+		}, []string{
+			"-- WARNING: SQLite cannot change the default of posts.views, recreate the table to apply it",
+			"-- WARNING: SQLite cannot change the default of posts.createdAt, recreate the table to apply it",
+			"-- WARNING: SQLite cannot change the default of posts.note, recreate the table to apply it",
+		}},
+	}
+
+	types := map[string]string{"id": "Int", "views": "Int", "title": "String", "published": "Boolean", "createdAt": "DateTime", "note": "String"}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			columns := map[string]*ColumnInfo{}
+			for name, typ := range types {
+				columns[name] = &ColumnInfo{
+					Name:         name,
+					Type:         mapTypeToSQL(typ, tt.provider),
+					IsNullable:   name == "note",
+					IsPrimaryKey: name == "id",
+					DefaultValue: tt.defaults[name],
+				}
+			}
+			dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+				"posts": {Name: "posts", Columns: columns},
+			}}
+
+			diff, err := CompareSchema(schema, dbSchema, tt.provider)
+			if err != nil {
+				t.Fatalf("CompareSchema failed: %v", err)
+			}
+			if len(diff.TablesToAlter) != 1 {
+				t.Fatalf("expected 1 table to alter, got %d", len(diff.TablesToAlter))
+			}
+			changed := map[string]bool{}
+			for _, col := range diff.TablesToAlter[0].AlterColumns {
+				if col.DefaultChanged {
+					changed[col.ColumnName] = true
+				}
+			}
+			for _, name := range []string{"id", "title", "published"} {
+				if changed[name] {
+					t.Errorf("default of %s reported as changed", name)
+				}
+			}
+
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("missing %q in:\n%s", want, sql)
+				}
+			}
+		})
+	}
+}
+
+func TestCanonicalDefault(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		isBoolean bool
+		equal     bool
+	}{
+		{"'x'", "'x'::text", false, true},
+		{"'USER'", `'USER'::"Role"`, false, true},
+		{"CURRENT_TIMESTAMP", "CURRENT_TIMESTAMP(3)", false, true},
+		{"CURRENT_TIMESTAMP", "now()", false, true},
+		{"CURRENT_TIMESTAMP", "(datetime('now'))", false, false},
+		{"TRUE", "1", true, true},
+		{"FALSE", "'t'", true, false},
+		{"'0'", "0", false, true},
+		{"'0'", "1", false, false},
+		{"gen_random_uuid()", "(gen_random_uuid())", false, true},
+	}
+
+	for _, tt := range tests {
+		got := canonicalDefault(tt.a, tt.isBoolean) == canonicalDefault(tt.b, tt.isBoolean)
+		if got != tt.equal {
+			t.Errorf("canonicalDefault(%q) == canonicalDefault(%q) = %v, want %v", tt.a, tt.b, got, tt.equal)
+		}
+	}
+}
+
+func TestCompareSchema_RenameColumns(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model users {
+  id       Int    @id
+  email    String @map("email_address")
+  name     String @renamedFrom("full_name")
+  nickname String
+  bio      String? @renamedFrom("nickname")
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+		"users": {
+			Name: "users",
+			Columns: map[string]*ColumnInfo{
+				"id":        {Name: "id", Type: mapTypeToSQL("Int", "postgresql"), IsPrimaryKey: true},
+				"email":     {Name: "email", Type: mapTypeToSQL("String", "postgresql")},
+				"full_name": {Name: "full_name", Type: mapTypeToSQL("String", "postgresql")},
+				"nickname":  {Name: "nickname", Type: mapTypeToSQL("String", "postgresql")},
+			},
+		},
+	}}
+
+	diff, err := CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.TablesToAlter) != 1 {
+		t.Fatalf("expected 1 table to alter, got %d", len(diff.TablesToAlter))
+	}
+	alter := diff.TablesToAlter[0]
+	if len(alter.DropColumns) != 0 {
+		t.Errorf("renamed columns should not be dropped: %v", alter.DropColumns)
+	}
+	// nickname continua no schema, então bio é uma coluna nova e não um rename
+	if len(alter.AddColumns) != 1 || alter.AddColumns[0].Name != "bio" {
+		t.Errorf("AddColumns = %+v", alter.AddColumns)
+	}
+
+	sql, err := GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	for _, want := range []string{
+		`ALTER TABLE "users" RENAME COLUMN "email" TO "email_address";`,
+		`ALTER TABLE "users" RENAME COLUMN "full_name" TO "name";`,
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("missing %q in:\n%s", want, sql)
+		}
+	}
+	if strings.Contains(sql, "DROP COLUMN") {
+		t.Errorf("rename should not drop columns:\n%s", sql)
+	}
+
+	rollback, err := GenerateRollbackSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateRollbackSQL failed: %v", err)
+	}
+	if !strings.Contains(rollback, `ALTER TABLE "users" RENAME COLUMN "email_address" TO "email";`) {
+		t.Errorf("rollback should rename the column back:\n%s", rollback)
+	}
+}
+
+func TestCompareSchema_Nullability(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model users {
+  id   Int     @id
+  name String
+  bio  String? @default("")
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"postgresql", []string{
+			"-- WARNING: users.name becomes NOT NULL, so the migration fails if it holds NULL values; update them first",
+			`ALTER TABLE "users" ALTER COLUMN "name" SET NOT NULL;`,
+			`ALTER TABLE "users" ALTER COLUMN "bio" DROP NOT NULL;`,
+		}},
+		{"mysql", []string{
+			"-- WARNING: users.name becomes NOT NULL, so the migration fails if it holds NULL values; update them first",
+			"ALTER TABLE `users` MODIFY COLUMN `name` VARCHAR(191) NOT NULL;",
+			"ALTER TABLE `users` MODIFY COLUMN `bio` VARCHAR(191) DEFAULT '';",
+		}},
+		{"sqlite", []string{
+			"-- WARNING: SQLite cannot change the nullability of users.name, recreate the table to apply it",
+			"-- WARNING: SQLite cannot change the nullability of users.bio, recreate the table to apply it",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			bioDefault := "''"
+			dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+				"users": {
+					Name: "users",
+					Columns: map[string]*ColumnInfo{
+						"id":   {Name: "id", Type: mapTypeToSQL("Int", tt.provider), IsPrimaryKey: true},
+						"name": {Name: "name", Type: mapTypeToSQL("String", tt.provider), IsNullable: true},
+						"bio":  {Name: "bio", Type: mapTypeToSQL("String", tt.provider), DefaultValue: &bioDefault},
+					},
+				},
+			}}
+
+			diff, err := CompareSchema(schema, dbSchema, tt.provider)
+			if err != nil {
+				t.Fatalf("CompareSchema failed: %v", err)
+			}
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("missing %q in:\n%s", want, sql)
+				}
+			}
+			if strings.Contains(sql, "DEFAULT;") || strings.Contains(sql, "users.bio becomes NOT NULL") {
+				t.Errorf("unexpected statements in:\n%s", sql)
+			}
+
+			// O rollback volta a nulabilidade anterior
+			if tt.provider == "postgresql" {
+				rollback, err := GenerateRollbackSQL(diff, tt.provider)
+				if err != nil {
+					t.Fatalf("GenerateRollbackSQL failed: %v", err)
+				}
+				for _, want := range []string{
+					`ALTER TABLE "users" ALTER COLUMN "name" DROP NOT NULL;`,
+					`ALTER TABLE "users" ALTER COLUMN "bio" SET NOT NULL;`,
+				} {
+					if !strings.Contains(rollback, want) {
+						t.Errorf("missing %q in rollback:\n%s", want, rollback)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCompareSchema_TypeChanges(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model users {
+  id    Int    @id
+  views BigInt
+  code  String @db.VarChar(100)
+  age   Int
+  name  String
+
+  @@index([name])
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	codeLength := 50
+	tests := []struct {
+		provider string
+		// Tipos como a introspecção de cada banco os reporta
+		types   map[string]string
+		want    []string
+		notWant []string
+	}{
+		{
+			provider: "postgresql",
+			types:    map[string]string{"id": "integer", "views": "integer", "code": "character varying", "age": "text", "name": "text"},
+			want: []string{
+				`ALTER TABLE "users" ALTER COLUMN "views" TYPE BIGINT;`,
+				`ALTER TABLE "users" ALTER COLUMN "code" TYPE VARCHAR(100);`,
+				"-- WARNING: users.age changes type from text to INTEGER, so the migration fails if a value cannot be converted",
+				`ALTER TABLE "users" ALTER COLUMN "age" DROP DEFAULT;`,
+				`ALTER TABLE "users" ALTER COLUMN "age" TYPE INTEGER USING "age"::INTEGER;`,
+			},
+			notWant: []string{`"id" TYPE`, `"name" TYPE`, `"views" TYPE BIGINT USING`},
+		},
+		{
+			provider: "mysql",
+			types:    map[string]string{"id": "Int", "views": "Int", "code": "String", "age": "String", "name": "String"},
+			want: []string{
+				"ALTER TABLE `users` MODIFY COLUMN `views` BIGINT NOT NULL;",
+				"ALTER TABLE `users` MODIFY COLUMN `code` VARCHAR(100) NOT NULL;",
+				"-- WARNING: users.age changes type from VARCHAR(191) to INT, so the migration fails if a value cannot be converted",
+				"ALTER TABLE `users` MODIFY COLUMN `age` INT NOT NULL;",
+			},
+			notWant: []string{"MODIFY COLUMN `id`", "MODIFY COLUMN `name`"},
+		},
+		{
+			// O SQLite não distingue BigInt de Int nem guarda o tamanho do VARCHAR,
+			// então só a coluna age muda e a tabela é recriada
+			provider: "sqlite",
+			types:    map[string]string{"id": "Int", "views": "Int", "code": "String", "age": "String", "name": "String"},
+			want: []string{
+				"-- RedefineTables\nPRAGMA defer_foreign_keys=ON;\nPRAGMA foreign_keys=OFF;\nCREATE TABLE \"new_users\" (",
+				`INSERT INTO "new_users" ("id", "views", "code", "age", "name") SELECT "id", "views", "code", "age", "name" FROM "users";`,
+				"DROP TABLE \"users\";\nALTER TABLE \"new_users\" RENAME TO \"users\";\nCREATE INDEX \"users_name_idx\" ON \"users\" (\"name\");\nPRAGMA foreign_keys=ON;\nPRAGMA defer_foreign_keys=OFF;",
+			},
+			notWant: []string{"WARNING"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			columns := make(map[string]*ColumnInfo)
+			for name, typ := range tt.types {
+				columns[name] = &ColumnInfo{Name: name, Type: typ, IsPrimaryKey: name == "id"}
+			}
+			columns["code"].CharacterMaximumLength = &codeLength
+			dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+				"users": {
+					Name:        "users",
+					Columns:     columns,
+					ColumnOrder: []string{"id", "views", "code", "age", "name"},
+					Indexes:     []*IndexInfo{{Name: "users_name_idx", TableName: "users", Columns: []string{"name"}}},
+				},
+			}}
+
+			diff, err := CompareSchema(schema, dbSchema, tt.provider)
+			if err != nil {
+				t.Fatalf("CompareSchema failed: %v", err)
+			}
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("missing %q in:\n%s", want, sql)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(sql, notWant) {
+					t.Errorf("unexpected %q in:\n%s", notWant, sql)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRollbackSQL_TypeChanges(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model users {
+  id    Int    @id
+  views BigInt
+  age   Int
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+		"users": {
+			Name: "users",
+			Columns: map[string]*ColumnInfo{
+				"id":    {Name: "id", Type: "integer", IsPrimaryKey: true},
+				"views": {Name: "views", Type: "integer"},
+				"age":   {Name: "age", Type: "text"},
+			},
+		},
+	}}
+
+	diff, err := CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	rollback, err := GenerateRollbackSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateRollbackSQL failed: %v", err)
+	}
+
+	// Voltar para text não precisa de USING; voltar de BIGINT para integer também não
+	for _, want := range []string{
+		`ALTER TABLE "users" ALTER COLUMN "age" TYPE text;`,
+		`ALTER TABLE "users" ALTER COLUMN "views" TYPE integer;`,
+	} {
+		if !strings.Contains(rollback, want) {
+			t.Errorf("missing %q in rollback:\n%s", want, rollback)
+		}
+	}
+}
+
+func TestColumnTypeChanged_Introspected(t *testing.T) {
+	// Colunas criadas a partir do schema e lidas de volta pela introspecção não mudam de tipo
+	length := 255
+	postgres := []struct {
+		prismaType string
+		dbCol      ColumnInfo
+	}{
+		{"String", ColumnInfo{Type: "text"}},
+		{"VARCHAR(255)", ColumnInfo{Type: "character varying", CharacterMaximumLength: &length}},
+		{"Int", ColumnInfo{Type: "integer"}},
+		{"BigInt", ColumnInfo{Type: "bigint"}},
+		{"Boolean", ColumnInfo{Type: "boolean"}},
+		{"DateTime", ColumnInfo{Type: "timestamp without time zone"}},
+		{"Float", ColumnInfo{Type: "double precision"}},
+		{"Decimal", ColumnInfo{Type: "numeric"}},
+		{"Json", ColumnInfo{Type: "jsonb"}},
+		{"Bytes", ColumnInfo{Type: "bytea"}},
+		{"TIMESTAMPTZ", ColumnInfo{Type: "timestamp with time zone"}},
+	}
+	d := dialect.GetDialect("postgresql")
+	for _, tt := range postgres {
+		dbCol := tt.dbCol
+		if columnTypeChanged(d, "postgresql", &dbCol, ColumnDefinition{Type: tt.prismaType}) {
+			t.Errorf("postgresql: %s read back as %s was diffed", tt.prismaType, tt.dbCol.Type)
+		}
+	}
+
+	for _, provider := range []string{"mysql", "sqlite"} {
+		d := dialect.GetDialect(provider)
+		for _, prismaType := range []string{"String", "Int", "BigInt", "Boolean", "DateTime", "Float", "Decimal", "Json", "Bytes"} {
+			dbCol := ColumnInfo{Type: columnTypeFamily(provider, mapTypeToSQL(prismaType, provider))}
+			if columnTypeChanged(d, provider, &dbCol, ColumnDefinition{Type: prismaType}) {
+				t.Errorf("%s: %s read back as %s was diffed", provider, prismaType, dbCol.Type)
+			}
+		}
+	}
+}
+
+func TestCompareSchema_PartialIndexes(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model posts {
+  id         Int       @id
+  title      String
+  deleted_at DateTime?
+
+  @@index([title], where: "deleted_at IS NULL")
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"postgresql", []string{`CREATE INDEX "posts_title_idx" ON "posts" ("title") WHERE deleted_at IS NULL;`}},
+		{"sqlite", []string{`CREATE INDEX "posts_title_idx" ON "posts" ("title") WHERE deleted_at IS NULL;`}},
+		{"mysql", []string{
+			"-- WARNING: MySQL does not support partial indexes",
+			"CREATE INDEX `posts_title_idx` ON `posts` (`title`);",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+				"posts": {
+					Name: "posts",
+					Columns: map[string]*ColumnInfo{
+						"id":         {Name: "id", Type: mapTypeToSQL("Int", tt.provider), IsPrimaryKey: true},
+						"title":      {Name: "title", Type: mapTypeToSQL("String", tt.provider)},
+						"deleted_at": {Name: "deleted_at", Type: mapTypeToSQL("DateTime", tt.provider), IsNullable: true},
+					},
+				},
+			}}
+
+			diff, err := CompareSchema(schema, dbSchema, tt.provider)
+			if err != nil {
+				t.Fatalf("CompareSchema failed: %v", err)
+			}
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("missing %q in:\n%s", want, sql)
+				}
+			}
+		})
+	}
+
+	// O PostgreSQL devolve o predicado reescrito, o que não é uma mudança
+	dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+		"posts": {
+			Name: "posts",
+			Columns: map[string]*ColumnInfo{
+				"id":         {Name: "id", Type: "integer", IsPrimaryKey: true},
+				"title":      {Name: "title", Type: "text"},
+				"deleted_at": {Name: "deleted_at", Type: "timestamp without time zone", IsNullable: true},
+			},
+			Indexes: []*IndexInfo{{Name: "posts_title_idx", TableName: "posts", Columns: []string{"title"}, Where: "(deleted_at IS NULL)"}},
+		},
+	}}
+	diff, err := CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.IndexesToCreate) != 0 || len(diff.IndexesToDrop) != 0 {
+		t.Errorf("unchanged partial index was diffed: create %v, drop %v", diff.IndexesToCreate, diff.IndexesToDrop)
+	}
+
+	// Um predicado diferente recria o índice
+	dbSchema.Tables["posts"].Indexes[0].Where = "(deleted_at IS NOT NULL)"
+	diff, err = CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.IndexesToCreate) != 1 || len(diff.IndexesToDrop) != 1 {
+		t.Errorf("expected the index to be recreated, got create %v, drop %v", diff.IndexesToCreate, diff.IndexesToDrop)
+	}
+}
+
+func TestPredicatesEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"deleted_at IS NULL", "(deleted_at IS NULL)", true},
+		{`"status" = 'active'`, "((status)::text = 'active'::text)", true},
+		{"status = 'active'", "status = 'Active'", false},
+		{"deleted_at IS NULL", "deleted_at IS NOT NULL", false},
+	}
+	for _, tt := range tests {
+		if got := predicatesEqual(tt.a, tt.b); got != tt.want {
+			t.Errorf("predicatesEqual(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseSQLiteIndexPredicate(t *testing.T) {
+	got := parseSQLiteIndexPredicate(`CREATE INDEX "posts_title_idx" ON "posts" ("title") WHERE deleted_at IS NULL`)
+	if got != "deleted_at IS NULL" {
+		t.Errorf("parseSQLiteIndexPredicate = %q", got)
+	}
+	if got := parseSQLiteIndexPredicate(`CREATE INDEX "posts_title_idx" ON "posts" ("title")`); got != "" {
+		t.Errorf("expected no predicate, got %q", got)
+	}
+}
+
+func TestCompareSchema_IndexModifiers(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model users {
+  id        Int      @id
+  email     String
+  name      String
+  createdAt DateTime @map("created_at")
+
+  @@index([createdAt(sort: Desc)])
+  @@index([name(length: 10, ops: TextPatternOps)])
+  @@unique([lower(email)])
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"postgresql", []string{
+			`CREATE INDEX "users_createdAt_idx" ON "users" ("created_at" DESC);`,
+			"-- WARNING: only MySQL supports index prefix lengths, ignoring length 10 on users_name_idx.name",
+			`CREATE INDEX "users_name_idx" ON "users" ("name" text_pattern_ops);`,
+			`CREATE UNIQUE INDEX "users_lower_email_key" ON "users" ((lower("email")));`,
+		}},
+		{"mysql", []string{
+			"CREATE INDEX `users_createdAt_idx` ON `users` (`created_at` DESC);",
+			"-- WARNING: only PostgreSQL supports operator classes, ignoring text_pattern_ops on users_name_idx.name",
+			"CREATE INDEX `users_name_idx` ON `users` (`name`(10));",
+			"CREATE UNIQUE INDEX `users_lower_email_key` ON `users` ((lower(`email`)));",
+		}},
+		{"sqlite", []string{
+			`CREATE INDEX "users_createdAt_idx" ON "users" ("created_at" DESC);`,
+			`CREATE UNIQUE INDEX "users_lower_email_key" ON "users" ((lower("email")));`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			diff, err := SchemaToSQL(schema, tt.provider)
+			if err != nil {
+				t.Fatalf("SchemaToSQL failed: %v", err)
+			}
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("missing %q in:\n%s", want, sql)
+				}
+			}
+		})
+	}
+
+	// Índices lidos do PostgreSQL, com a expressão reescrita pelo banco
+	dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+		"users": {
+			Name: "users",
+			Columns: map[string]*ColumnInfo{
+				"id":         {Name: "id", Type: "integer", IsPrimaryKey: true},
+				"email":      {Name: "email", Type: "text"},
+				"name":       {Name: "name", Type: "text"},
+				"created_at": {Name: "created_at", Type: "timestamp without time zone"},
+			},
+			Indexes: []*IndexInfo{
+				{Name: "users_createdAt_idx", TableName: "users", Columns: []string{"created_at"},
+					ColumnInfos: []IndexColumnInfo{{ColumnName: "created_at", SortOrder: "DESC"}}},
+				{Name: "users_name_idx", TableName: "users", Columns: []string{"name"},
+					ColumnInfos: []IndexColumnInfo{{ColumnName: "name", SortOrder: "ASC"}}},
+				{Name: "users_lower_email_key", TableName: "users", Columns: []string{"lower(email::text)"}, IsUnique: true,
+					ColumnInfos: []IndexColumnInfo{{ColumnName: "lower(email::text)", SortOrder: "ASC", Expression: true}}},
+			},
+		},
+	}}
+	diff, err := CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.IndexesToCreate) != 0 || len(diff.IndexesToDrop) != 0 {
+		t.Errorf("unchanged indexes were diffed: create %v, drop %v", diff.IndexesToCreate, diff.IndexesToDrop)
+	}
+
+	// A direção da ordenação faz parte do índice
+	dbSchema.Tables["users"].Indexes[0].ColumnInfos[0].SortOrder = "ASC"
+	diff, err = CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.IndexesToDrop) != 1 || diff.IndexesToDrop[0] != "users_createdAt_idx" || len(diff.IndexesToCreate) != 1 {
+		t.Errorf("expected users_createdAt_idx to be recreated, got create %v, drop %v", diff.IndexesToCreate, diff.IndexesToDrop)
+	}
+}
+
+func TestParseSQLiteIndexEntries(t *testing.T) {
+	got := parseSQLiteIndexEntries(`CREATE UNIQUE INDEX "users_idx" ON "users" ((lower("email")), "created_at" DESC, substr(name, 1, ',')) WHERE deleted_at IS NULL`)
+	want := []string{`(lower("email"))`, `"created_at"`, `substr(name, 1, ',')`}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("parseSQLiteIndexEntries = %q, want %q", got, want)
+	}
+}
+
+func TestCompareSchema_IndexTypes(t *testing.T) {
+	schema, _, err := parser.Parse(`
+model posts {
+  id       Int      @id
+  metadata Json
+  tags     String[]
+  title    String
+
+  @@index([metadata(ops: JsonbPathOps)], type: Gin)
+  @@index([tags], type: Gin)
+  @@index([title], type: Btree)
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"postgresql", []string{
+			`CREATE INDEX "posts_metadata_idx" ON "posts" USING GIN ("metadata" jsonb_path_ops);`,
+			`CREATE INDEX "posts_tags_idx" ON "posts" USING GIN ("tags");`,
+			`CREATE INDEX "posts_title_idx" ON "posts" ("title");`,
+		}},
+		{"mysql", []string{
+			"-- WARNING: only PostgreSQL supports index types, creating posts_metadata_idx as a regular index",
+			"CREATE INDEX `posts_metadata_idx` ON `posts` (`metadata`);",
+			"CREATE INDEX `posts_title_idx` ON `posts` (`title`);",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			diff, err := SchemaToSQL(schema, tt.provider)
+			if err != nil {
+				t.Fatalf("SchemaToSQL failed: %v", err)
+			}
+			sql, err := GenerateMigrationSQL(diff, tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateMigrationSQL failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Errorf("missing %q in:\n%s", want, sql)
+				}
+			}
+			if strings.Contains(sql, "USING BTREE") || strings.Contains(sql, "posts_title_idx as a regular index") {
+				t.Errorf("btree is the default and needs no USING or warning:\n%s", sql)
+			}
+		})
+	}
+
+	// O PostgreSQL informa o método de cada índice, inclusive o btree padrão
+	dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+		"posts": {
+			Name: "posts",
+			Columns: map[string]*ColumnInfo{
+				"id":       {Name: "id", Type: "integer", IsPrimaryKey: true},
+				"metadata": {Name: "metadata", Type: "jsonb"},
+				"tags":     {Name: "tags", Type: "ARRAY", UdtName: "_text"},
+				"title":    {Name: "title", Type: "text"},
+			},
+			Indexes: []*IndexInfo{
+				{Name: "posts_metadata_idx", TableName: "posts", Columns: []string{"metadata"}, Type: "gin"},
+				{Name: "posts_tags_idx", TableName: "posts", Columns: []string{"tags"}, Type: "gin"},
+				{Name: "posts_title_idx", TableName: "posts", Columns: []string{"title"}, Type: "btree"},
+			},
+		},
+	}}
+	diff, err := CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.IndexesToCreate) != 0 || len(diff.IndexesToDrop) != 0 {
+		t.Errorf("unchanged indexes were diffed: create %v, drop %v", diff.IndexesToCreate, diff.IndexesToDrop)
+	}
+
+	// Trocar o método recria o índice, e o rollback volta o método anterior
+	dbSchema.Tables["posts"].Indexes[1].Type = "btree"
+	diff, err = CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.IndexesToDrop) != 1 || diff.IndexesToDrop[0] != "posts_tags_idx" || len(diff.IndexesToCreate) != 1 {
+		t.Fatalf("expected posts_tags_idx to be recreated, got create %v, drop %v", diff.IndexesToCreate, diff.IndexesToDrop)
+	}
+	dbSchema.Tables["posts"].Indexes[1].Type = "gist"
+	rollback, err := GenerateRollbackSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateRollbackSQL failed: %v", err)
+	}
+	if !strings.Contains(rollback, `CREATE INDEX "posts_tags_idx" ON "posts" USING GIST ("tags");`) {
+		t.Errorf("rollback should recreate the previous index:\n%s", rollback)
+	}
+}
+
+// TestCompareSchema_PostgresSchemas tests that @@schema qualifies the DDL of a table,
+// creates its schema and keeps the derived constraint and index names unqualified
+func TestCompareSchema_PostgresSchemas(t *testing.T) {
+	schema, _, err := parser.Parse(`
+datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL")
+  schemas  = ["auth", "public"]
+}
+
+model User {
+  id    Int    @id
+  email String @unique
+  posts Post[]
+
+  @@map("users")
+  @@schema("auth")
+}
+
+model Post {
+  id       Int  @id
+  authorId Int
+  author   User @relation(fields: [authorId], references: [id])
+
+  @@map("posts")
+  @@schema("public")
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	diff, err := SchemaToSQL(schema, "postgresql")
+	if err != nil {
+		t.Fatalf("SchemaToSQL failed: %v", err)
+	}
+	sql, err := GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	for _, want := range []string{
+		"-- CreateSchema\nCREATE SCHEMA IF NOT EXISTS \"auth\";",
+		`CREATE TABLE "auth"."users" (`,
+		`CONSTRAINT "users_pkey" PRIMARY KEY ("id")`,
+		`CREATE TABLE "posts" (`,
+		`CREATE UNIQUE INDEX "users_email_key" ON "auth"."users" ("email");`,
+		`REFERENCES "auth"."users" ("id")`,
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("missing %q in:\n%s", want, sql)
+		}
+	}
+	if strings.Contains(sql, `"public"`) {
+		t.Errorf("the public schema should stay unqualified:\n%s", sql)
+	}
+	if schemas := GetSchemasFromSchema(schema); strings.Join(schemas, ",") != "auth,public" {
+		t.Errorf("GetSchemasFromSchema = %v", schemas)
+	}
+
+	// Um índice a mais em auth.users é removido pelo nome qualificado, e o rollback o recria
+	dbSchema := &DatabaseSchema{Tables: map[string]*TableInfo{
+		"auth.users": {
+			Name: "auth.users",
+			Columns: map[string]*ColumnInfo{
+				"id":    {Name: "id", Type: "integer", IsPrimaryKey: true},
+				"email": {Name: "email", Type: "text", IsUnique: true},
+			},
+			Indexes: []*IndexInfo{
+				{Name: "users_email_key", TableName: "auth.users", Columns: []string{"email"}, IsUnique: true},
+				{Name: "users_legacy_idx", TableName: "auth.users", Columns: []string{"email"}},
+			},
+		},
+	}}
+	diff, err = CompareSchema(schema, dbSchema, "postgresql")
+	if err != nil {
+		t.Fatalf("CompareSchema failed: %v", err)
+	}
+	if len(diff.IndexesToDrop) != 1 || diff.IndexesToDrop[0] != "auth.users_legacy_idx" {
+		t.Fatalf("expected auth.users_legacy_idx to be dropped, got %v", diff.IndexesToDrop)
+	}
+	for _, table := range diff.TablesToCreate {
+		if table.Name != "posts" {
+			t.Errorf("only posts should be created, got %s", table.Name)
+		}
+	}
+	sql, err = GenerateMigrationSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateMigrationSQL failed: %v", err)
+	}
+	if !strings.Contains(sql, `DROP INDEX "auth"."users_legacy_idx";`) || strings.Contains(sql, "CREATE SCHEMA") {
+		t.Errorf("unexpected migration:\n%s", sql)
+	}
+	rollback, err := GenerateRollbackSQL(diff, "postgresql")
+	if err != nil {
+		t.Fatalf("GenerateRollbackSQL failed: %v", err)
+	}
+	if !strings.Contains(rollback, `CREATE INDEX "users_legacy_idx" ON "auth"."users" ("email");`) {
+		t.Errorf("rollback should recreate the dropped index:\n%s", rollback)
+	}
+}
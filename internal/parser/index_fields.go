@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// IndexField é uma entrada da lista de campos de @@id, @@unique ou @@index: um campo,
+// com os modificadores opcionais (created_at(sort: Desc), name(length: 10)), ou uma
+// expressão SQL como lower(email)
+type IndexField struct {
+	Name       string                 // nome do campo; vazio quando a entrada é uma expressão
+	Sort       string                 // "Asc" ou "Desc"; vazio para a ordem padrão
+	Length     int                    // tamanho do prefixo indexado (MySQL); 0 para o valor inteiro
+	Ops        string                 // classe de operadores (PostgreSQL), ex.: text_pattern_ops
+	Expression map[string]interface{} // chamada de função da expressão, como o parser a devolve
+	Modifiers  []string               // nomes dos modificadores usados, para a validação
+}
+
+// IsExpression indica se a entrada é uma expressão e não um campo do model
+func (f IndexField) IsExpression() bool {
+	return f.Expression != nil
+}
+
+// IndexFields extrai a lista de campos de @@id, @@unique ou @@index
+func IndexFields(attr *Attribute) []IndexField {
+	for _, arg := range attr.Arguments {
+		if arg.Name != "" && arg.Name != "fields" {
+			continue
+		}
+		values, ok := arg.Value.([]interface{})
+		if !ok {
+			continue
+		}
+		fields := make([]IndexField, 0, len(values))
+		for _, value := range values {
+			if field, ok := parseIndexField(value); ok {
+				fields = append(fields, field)
+			}
+		}
+		return fields
+	}
+	return nil
+}
+
+// IndexFieldNames retorna os nomes dos campos de @@id, @@unique ou @@index, ou nil se
+// a lista tiver uma expressão
+func IndexFieldNames(attr *Attribute) []string {
+	var names []string
+	for _, field := range IndexFields(attr) {
+		if field.IsExpression() {
+			return nil
+		}
+		names = append(names, field.Name)
+	}
+	return names
+}
+
+// parseIndexField interpreta uma entrada da lista de campos. Uma chamada cujos argumentos
+// são todos nomeados é um campo com modificadores; as demais são expressões
+func parseIndexField(value interface{}) (IndexField, bool) {
+	switch v := value.(type) {
+	case string:
+		return IndexField{Name: strings.Trim(v, `"`)}, true
+	case map[string]interface{}:
+		name, ok := v["function"].(string)
+		if !ok {
+			return IndexField{}, false
+		}
+		args, _ := v["args"].([]interface{})
+		if len(args) == 0 || !allNamedArguments(args) {
+			return IndexField{Expression: v}, true
+		}
+		field := IndexField{Name: name}
+		for _, arg := range args {
+			named := arg.(map[string]interface{})
+			modifier, _ := named["name"].(string)
+			field.Modifiers = append(field.Modifiers, modifier)
+			switch modifier {
+			case "sort":
+				sort, _ := named["value"].(string)
+				field.Sort = strings.Trim(sort, `"`)
+			case "length":
+				length, _ := named["value"].(string)
+				field.Length, _ = strconv.Atoi(length)
+			case "ops":
+				field.Ops = operatorClass(named["value"])
+			}
+		}
+		return field, true
+	}
+	return IndexField{}, false
+}
+
+// allNamedArguments verifica se todos os argumentos da chamada são nomeados (nome: valor)
+func allNamedArguments(args []interface{}) bool {
+	for _, arg := range args {
+		named, ok := arg.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := named["name"].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// operatorClass converte o valor de ops: um identificador como TextPatternOps vira
+// text_pattern_ops, e nomes em minúsculas ou raw("gin_trgm_ops") são usados como estão
+func operatorClass(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		v = strings.Trim(v, `"`)
+		if strings.ToLower(v) == v {
+			return v
+		}
+		var b strings.Builder
+		for i, r := range v {
+			if unicode.IsUpper(r) {
+				if i > 0 {
+					b.WriteByte('_')
+				}
+				r = unicode.ToLower(r)
+			}
+			b.WriteRune(r)
+		}
+		return b.String()
+	case map[string]interface{}:
+		if v["function"] == "raw" {
+			if args, _ := v["args"].([]interface{}); len(args) == 1 {
+				if raw, ok := args[0].(string); ok {
+					return strings.Trim(raw, `"`)
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxIndexColumns é o número de colunas a partir do qual um índice é considerado largo
+const maxIndexColumns = 5
+
+// Lint analisa o schema em busca de padrões que costumam causar problemas de desempenho.
+// Retorna avisos, não erros: o schema continua válido e a geração prossegue.
+func Lint(schema *Schema) []string {
+	if schema == nil {
+		return nil
+	}
+
+	provider := ""
+	if len(schema.Datasources) > 0 {
+		for _, field := range schema.Datasources[0].Fields {
+			if field.Name == "provider" {
+				if str, ok := field.Value.(string); ok {
+					provider = strings.ToLower(str)
+				}
+			}
+		}
+	}
+
+	var warnings []string
+	for _, model := range schema.Models {
+		indexes := modelIndexes(model)
+
+		// Model sem chave primária
+		if !hasPrimaryKey(model) {
+			warnings = append(warnings, fmt.Sprintf("model '%s' não tem chave primária (@id ou @@id); updates e deletes por registro ficam lentos", model.Name))
+		}
+
+		// Chave estrangeira sem índice que a cubra
+		for _, field := range model.Fields {
+			fields := relationFields(field)
+			if len(fields) == 0 || isIndexed(indexes, fields) {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("chave estrangeira (%s) do campo '%s' no model '%s' não tem índice; adicione @@index([%s])",
+				strings.Join(fields, ", "), field.Name, model.Name, strings.Join(fields, ", ")))
+		}
+
+		// Índices muito largos
+		for _, index := range indexes {
+			if len(index.fields) > maxIndexColumns {
+				warnings = append(warnings, fmt.Sprintf("@@%s([%s]) no model '%s' tem %d colunas; índices largos ocupam espaço e raramente são usados por completo",
+					index.kind, strings.Join(index.fields, ", "), model.Name, len(index.fields)))
+			}
+		}
+
+		// Colunas de texto em chaves únicas no MySQL
+		if provider == "mysql" {
+			warnings = append(warnings, lintMySQLUniqueStrings(model, indexes)...)
+		}
+	}
+	return warnings
+}
+
+// lintIndex é um índice do model: @id, @unique, @@id, @@unique ou @@index
+type lintIndex struct {
+	kind    string // id, unique ou index
+	fields  []string
+	partial bool // índice parcial (where:), que só cobre parte das linhas
+	method  bool // tipo diferente de Btree (type:), como Gin ou Gist
+}
+
+// modelIndexes retorna os índices declarados no model, de campo e de model
+func modelIndexes(model *Model) []lintIndex {
+	var indexes []lintIndex
+	for _, field := range model.Fields {
+		for _, attr := range field.Attributes {
+			if attr.Name == "id" || attr.Name == "unique" {
+				indexes = append(indexes, lintIndex{kind: attr.Name, fields: []string{field.Name}})
+			}
+		}
+	}
+	for _, attr := range model.Attributes {
+		if attr.Name != "id" && attr.Name != "unique" && attr.Name != "index" {
+			continue
+		}
+		if fields := attributeFieldList(attr); len(fields) > 0 {
+			indexes = append(indexes, lintIndex{kind: attr.Name, fields: fields, partial: hasArgument(attr, "where"), method: hasIndexMethod(attr)})
+		}
+	}
+	return indexes
+}
+
+// attributeFieldList extrai a lista de campos de @@id, @@unique ou @@index até a
+// primeira expressão, que interrompe o prefixo de colunas coberto pelo índice
+func attributeFieldList(attr *Attribute) []string {
+	var fields []string
+	for _, field := range IndexFields(attr) {
+		if field.IsExpression() {
+			break
+		}
+		fields = append(fields, field.Name)
+	}
+	return fields
+}
+
+// hasArgument verifica se o atributo tem o argumento nomeado name
+func hasArgument(attr *Attribute, name string) bool {
+	for _, arg := range attr.Arguments {
+		if arg.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasIndexMethod verifica se o índice usa um tipo (type:) diferente de Btree
+func hasIndexMethod(attr *Attribute) bool {
+	for _, arg := range attr.Arguments {
+		if method, ok := arg.Value.(string); ok && arg.Name == "type" {
+			return !strings.EqualFold(method, "btree")
+		}
+	}
+	return false
+}
+
+// relationFields retorna os campos de @relation(fields: [...]) do lado que guarda a chave estrangeira
+func relationFields(field *ModelField) []string {
+	for _, attr := range field.Attributes {
+		if attr.Name != "relation" {
+			continue
+		}
+		for _, arg := range attr.Arguments {
+			if arg.Name != "fields" {
+				continue
+			}
+			var fields []string
+			if vals, ok := arg.Value.([]interface{}); ok {
+				for _, val := range vals {
+					if str, ok := val.(string); ok {
+						fields = append(fields, strings.Trim(str, `"`))
+					}
+				}
+			}
+			return fields
+		}
+	}
+	return nil
+}
+
+// hasPrimaryKey verifica se o model tem @id ou @@id
+func hasPrimaryKey(model *Model) bool {
+	for _, field := range model.Fields {
+		for _, attr := range field.Attributes {
+			if attr.Name == "id" {
+				return true
+			}
+		}
+	}
+	for _, attr := range model.Attributes {
+		if attr.Name == "id" {
+			return true
+		}
+	}
+	return false
+}
+
+// isIndexed verifica se algum índice começa pelas colunas fields (na mesma ordem),
+// o que permite ao banco usá-lo nas buscas pela chave estrangeira. Índices parciais
+// não contam, pois não cobrem todas as linhas, nem índices de outros tipos (Gin, Gist...)
+func isIndexed(indexes []lintIndex, fields []string) bool {
+	for _, index := range indexes {
+		if index.partial || index.method || len(index.fields) < len(fields) {
+			continue
+		}
+		prefix := true
+		for i, field := range fields {
+			if index.fields[i] != field {
+				prefix = false
+				break
+			}
+		}
+		if prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// lintMySQLUniqueStrings avisa sobre colunas String em chaves únicas no MySQL: TEXT não
+// pode ser indexado sem prefixo e VARCHAR(255) ocupa até 1020 bytes por coluna em utf8mb4
+func lintMySQLUniqueStrings(model *Model, indexes []lintIndex) []string {
+	fieldsByName := make(map[string]*ModelField, len(model.Fields))
+	for _, field := range model.Fields {
+		fieldsByName[field.Name] = field
+	}
+
+	var warnings []string
+	warned := make(map[string]bool)
+	for _, index := range indexes {
+		if index.kind == "index" {
+			continue
+		}
+		for _, name := range index.fields {
+			field, ok := fieldsByName[name]
+			if !ok || field.Type == nil || field.Type.Name != "String" || warned[name] {
+				continue
+			}
+			nativeType := ""
+			for _, attr := range field.Attributes {
+				if strings.HasPrefix(attr.Name, "db.") {
+					nativeType = attr.Name
+				}
+			}
+			switch nativeType {
+			case "db.Text", "db.MediumText", "db.LongText", "db.TinyText":
+				warnings = append(warnings, fmt.Sprintf("campo '%s' do model '%s' é %s e faz parte de uma chave única; o MySQL não indexa TEXT sem tamanho de prefixo, use @db.VarChar(n)",
+					name, model.Name, strings.TrimPrefix(nativeType, "db.")))
+			case "":
+				warnings = append(warnings, fmt.Sprintf("campo '%s' do model '%s' faz parte de uma chave única e usa VARCHAR(255) no MySQL (até 1020 bytes em utf8mb4); defina o tamanho com @db.VarChar(n)",
+					name, model.Name))
+			default:
+				continue
+			}
+			warned[name] = true
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func lintSchema(t *testing.T, input string) []string {
+	t.Helper()
+	schema, errs, err := Parse(input)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("failed to parse schema: %v %v", err, errs)
+	}
+	return Lint(schema)
+}
+
+func hasWarning(warnings []string, substr string) bool {
+	for _, w := range warnings {
+		if strings.Contains(w, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLint_ForeignKeyWithoutIndex(t *testing.T) {
+	warnings := lintSchema(t, `
+model User {
+  id    Int    @id
+  posts Post[]
+  tags  Tag[]
+}
+
+model Post {
+  id       Int  @id
+  authorId Int
+  author   User @relation(fields: [authorId], references: [id])
+}
+
+model Tag {
+  id     Int  @id
+  userId Int
+  user   User @relation(fields: [userId], references: [id])
+
+  @@index([userId, id])
+}
+`)
+
+	if !hasWarning(warnings, "chave estrangeira (authorId) do campo 'author' no model 'Post' não tem índice") {
+		t.Errorf("expected a warning for Post.authorId, got %v", warnings)
+	}
+	// Um índice que começa pela chave estrangeira a cobre
+	if hasWarning(warnings, "model 'Tag' não tem índice") {
+		t.Errorf("Tag.userId is the prefix of an index and should not be flagged: %v", warnings)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected exactly 1 warning, got %v", warnings)
+	}
+}
+
+func TestLint_MissingPrimaryKey(t *testing.T) {
+	warnings := lintSchema(t, `
+model Log {
+  message String
+}
+
+model Membership {
+  userId Int
+  teamId Int
+
+  @@id([userId, teamId])
+}
+`)
+
+	if !hasWarning(warnings, "model 'Log' não tem chave primária") {
+		t.Errorf("expected a missing primary key warning for Log, got %v", warnings)
+	}
+	if hasWarning(warnings, "model 'Membership' não tem chave primária") {
+		t.Errorf("@@id should count as a primary key: %v", warnings)
+	}
+}
+
+func TestLint_WideIndexAndMySQLUniqueStrings(t *testing.T) {
+	warnings := lintSchema(t, `
+datasource db {
+  provider = "mysql"
+}
+
+model Account {
+  id    Int    @id
+  email String @unique
+  code  String @unique @db.VarChar(32)
+  bio   String @unique @db.Text
+  a     Int
+  b     Int
+  c     Int
+  d     Int
+  e     Int
+  f     Int
+
+  @@index([a, b, c, d, e, f])
+}
+`)
+
+	if !hasWarning(warnings, "campo 'email' do model 'Account' faz parte de uma chave única e usa VARCHAR(255)") {
+		t.Errorf("expected a VARCHAR(255) warning for email, got %v", warnings)
+	}
+	if hasWarning(warnings, "campo 'code'") {
+		t.Errorf("a sized @db.VarChar should not be flagged: %v", warnings)
+	}
+	if !hasWarning(warnings, "campo 'bio' do model 'Account' é Text") {
+		t.Errorf("expected a TEXT warning for bio, got %v", warnings)
+	}
+	if !hasWarning(warnings, "tem 6 colunas") {
+		t.Errorf("expected a wide index warning, got %v", warnings)
+	}
+}
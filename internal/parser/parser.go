@@ -430,8 +430,9 @@ func (p *Parser) parseAttribute() *Attribute {
 func (p *Parser) parseAttributeArgument() *AttributeArgument {
 	arg := &AttributeArgument{}
 
-	// Verificar se é named argument (name: value ou name = value)
-	if p.curToken.Type == TokenIdent && (p.peekToken.Type == TokenEqual || p.peekToken.Type == TokenColon) {
+	// Verificar se é named argument (name: value ou name = value); TokenTypeKeyword
+	// permite o argumento type: de @@index
+	if (p.curToken.Type == TokenIdent || p.curToken.Type == TokenTypeKeyword) && (p.peekToken.Type == TokenEqual || p.peekToken.Type == TokenColon) {
 		arg.Name = p.curToken.Literal
 		p.nextToken() // pular nome
 		p.nextToken() // pular = ou :
@@ -53,3 +53,252 @@ model book_categories {
 		t.Error("Failed to parse field 'type' inside @@index")
 	}
 }
+
+func TestValidateSoftDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		wantErr bool
+	}{
+		{"nullable DateTime", "removed_at", false},
+		{"required field", "email", true},
+		{"missing field", "archived_at", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := Parse(`
+model User {
+  id         Int       @id
+  email      String
+  removed_at DateTime?
+
+  @@softDelete(` + tt.field + `)
+}
+`)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRenamedFrom(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute string
+		wantErr   bool
+	}{
+		{"column name", `@renamedFrom("full_name")`, false},
+		{"empty name", `@renamedFrom("")`, true},
+		{"missing name", `@renamedFrom()`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := Parse(`
+model User {
+  id   Int    @id
+  name String ` + tt.attribute + `
+}
+`)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   string
+		wantErr bool
+	}{
+		{"expression", `@@check("rating BETWEEN 1 AND 5")`, false},
+		{"expression with map", `@@check("rating >= 1", map: "reviews_rating_check")`, false},
+		{"missing expression", `@@check(map: "reviews_rating_check")`, true},
+		{"empty expression", `@@check("")`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := Parse(`
+model Review {
+  id     Int @id
+  rating Int
+
+  ` + tt.check + `
+}
+`)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateIndexWhere(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   string
+		wantErr bool
+	}{
+		{"partial index", `@@index([title], where: "deleted_at IS NULL")`, false},
+		{"partial unique", `@@unique([title], where: "deleted_at IS NULL")`, false},
+		{"empty predicate", `@@index([title], where: "")`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := Parse(`
+model Post {
+  id         Int       @id
+  title      String
+  deleted_at DateTime?
+
+  ` + tt.index + `
+}
+`)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIndexFields(t *testing.T) {
+	schema, _, err := Parse(`
+model User {
+  id        Int      @id
+  email     String
+  name      String
+  createdAt DateTime
+
+  @@index([createdAt(sort: Desc), name(length: 10, ops: TextPatternOps), lower(email), id])
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	fields := IndexFields(schema.Models[0].Attributes[0])
+	if len(fields) != 4 {
+		t.Fatalf("expected 4 fields, got %+v", fields)
+	}
+	if fields[0].Name != "createdAt" || fields[0].Sort != "Desc" {
+		t.Errorf("unexpected sort field: %+v", fields[0])
+	}
+	if fields[1].Name != "name" || fields[1].Length != 10 || fields[1].Ops != "text_pattern_ops" {
+		t.Errorf("unexpected length/ops field: %+v", fields[1])
+	}
+	if !fields[2].IsExpression() || fields[2].Name != "" {
+		t.Errorf("lower(email) should be an expression: %+v", fields[2])
+	}
+	if fields[3].Name != "id" || fields[3].IsExpression() {
+		t.Errorf("unexpected plain field: %+v", fields[3])
+	}
+	// Com uma expressão, a lista não tem só nomes de campos
+	if names := IndexFieldNames(schema.Models[0].Attributes[0]); names != nil {
+		t.Errorf("expected no field names, got %v", names)
+	}
+}
+
+func TestValidateIndexFieldModifiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   string
+		wantErr bool
+	}{
+		{"sort", `@@index([title(sort: Desc)])`, false},
+		{"length and ops", `@@index([title(length: 20, ops: raw("text_pattern_ops"))])`, false},
+		{"expression", `@@unique([lower(title)])`, false},
+		{"invalid sort", `@@index([title(sort: Down)])`, true},
+		{"invalid length", `@@index([title(length: 0)])`, true},
+		{"unknown modifier", `@@index([title(collate: C)])`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := Parse(`
+model Post {
+  id    Int    @id
+  title String
+
+  ` + tt.index + `
+}
+`)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateIndexType(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   string
+		wantErr bool
+	}{
+		{"gin", `@@index([title], type: Gin)`, false},
+		{"btree unique", `@@unique([title], type: Btree)`, false},
+		{"unknown type", `@@index([title], type: Bitmap)`, true},
+		{"gin unique", `@@unique([title], type: Gin)`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := Parse(`
+model Post {
+  id    Int    @id
+  title String
+
+  ` + tt.index + `
+}
+`)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestValidateSchemaAttribute testa @@schema: só bancos com schemas o aceitam, e a lista
+// schemas do datasource restringe os nomes
+func TestValidateSchemaAttribute(t *testing.T) {
+	tests := []struct {
+		name       string
+		datasource string
+		attr       string
+		wantErr    bool
+	}{
+		{"postgresql", `provider = "postgresql"`, `@@schema("auth")`, false},
+		{"listed", `provider = "postgresql"` + "\n  schemas = [\"auth\", \"public\"]", `@@schema("auth")`, false},
+		{"not listed", `provider = "postgresql"` + "\n  schemas = [\"public\"]", `@@schema("auth")`, true},
+		{"empty", `provider = "postgresql"`, `@@schema("")`, true},
+		{"mysql", `provider = "mysql"`, `@@schema("auth")`, true},
+		{"sqlite", `provider = "sqlite"`, `@@schema("auth")`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := Parse(`
+datasource db {
+  ` + tt.datasource + `
+  url = env("DATABASE_URL")
+}
+
+model User {
+  id    Int    @id
+  email String
+
+  ` + tt.attr + `
+}
+`)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
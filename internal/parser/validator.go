@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Validator valida um schema
@@ -66,9 +67,11 @@ func (v *Validator) validateDatasource(ds *Datasource) {
 			provider, ok := field.Value.(string)
 			if ok {
 				validProviders := map[string]bool{
-					"postgresql": true,
-					"mysql":      true,
-					"sqlite":     true,
+					"postgresql":  true,
+					"mysql":       true,
+					"sqlite":      true,
+					"sqlserver":   true,
+					"cockroachdb": true,
 				}
 				if !validProviders[provider] {
 					v.errors = append(v.errors, fmt.Sprintf("provider inválido no datasource '%s': %s", ds.Name, provider))
@@ -121,7 +124,7 @@ func (v *Validator) validateModel(model *Model) {
 
 	// Validar atributos do model
 	for _, attr := range model.Attributes {
-		v.validateModelAttribute(attr, model.Name)
+		v.validateModelAttribute(attr, model)
 	}
 
 	// Note: Primary key validation is optional, so we don't enforce it here
@@ -194,21 +197,178 @@ func (v *Validator) validateFieldAttribute(attr *Attribute, modelName, fieldName
 		if (hasFields && !hasReferences) || (!hasFields && hasReferences) {
 			v.errors = append(v.errors, fmt.Sprintf("@relation no campo '%s' do model '%s' deve ter ambos 'fields' e 'references' ou nenhum", fieldName, modelName))
 		}
+	case "renamedFrom":
+		// @renamedFrom("coluna_antiga") precisa do nome da coluna existente
+		name := ""
+		if len(attr.Arguments) > 0 {
+			name, _ = attr.Arguments[0].Value.(string)
+		}
+		if strings.TrimSpace(name) == "" {
+			v.errors = append(v.errors, fmt.Sprintf("@renamedFrom no campo '%s' do model '%s' requer o nome da coluna antiga, ex.: @renamedFrom(\"email\")", fieldName, modelName))
+		}
+	}
+}
+
+// validateIndexFields valida os campos de @@index e @@unique, como created_at(sort: Desc)
+func (v *Validator) validateIndexFields(attr *Attribute, model *Model) {
+	for _, field := range IndexFields(attr) {
+		if field.IsExpression() {
+			continue
+		}
+		for _, modifier := range field.Modifiers {
+			switch modifier {
+			case "sort":
+				if field.Sort != "Asc" && field.Sort != "Desc" {
+					v.errors = append(v.errors, fmt.Sprintf("@@%s no model '%s': sort do campo '%s' deve ser Asc ou Desc", attr.Name, model.Name, field.Name))
+				}
+			case "length":
+				if field.Length <= 0 {
+					v.errors = append(v.errors, fmt.Sprintf("@@%s no model '%s': length do campo '%s' deve ser um inteiro positivo", attr.Name, model.Name, field.Name))
+				}
+			case "ops":
+			default:
+				v.errors = append(v.errors, fmt.Sprintf("@@%s no model '%s': modificador '%s' desconhecido no campo '%s', use sort, length ou ops", attr.Name, model.Name, modifier, field.Name))
+			}
+		}
+	}
+}
+
+// indexTypes são os tipos aceitos por type: em @@index, em minúsculas
+var indexTypes = map[string]bool{"btree": true, "hash": true, "gist": true, "gin": true, "spgist": true, "brin": true}
+
+// validateIndexType valida o argumento type: de @@index. O PostgreSQL só cria índices
+// únicos Btree, então @@unique não aceita outro tipo
+func (v *Validator) validateIndexType(attr *Attribute, model *Model) {
+	for _, arg := range attr.Arguments {
+		if arg.Name != "type" {
+			continue
+		}
+		method, _ := arg.Value.(string)
+		method = strings.ToLower(strings.Trim(method, `"`))
+		if !indexTypes[method] {
+			v.errors = append(v.errors, fmt.Sprintf("@@%s no model '%s': type deve ser Btree, Hash, Gist, Gin, SpGist ou Brin", attr.Name, model.Name))
+		} else if attr.Name == "unique" && method != "btree" {
+			v.errors = append(v.errors, fmt.Sprintf("@@unique no model '%s': índices únicos só podem ser Btree", model.Name))
+		}
+	}
+}
+
+// validateSchemaAttribute valida @@schema: só PostgreSQL e CockroachDB têm schemas (no
+// MySQL o equivalente é outro banco), e se o datasource listar schemas o nome deve estar lá
+func (v *Validator) validateSchemaAttribute(attr *Attribute, model *Model) {
+	name := ""
+	if len(attr.Arguments) > 0 {
+		name, _ = attr.Arguments[0].Value.(string)
+	}
+	name = strings.TrimSpace(strings.Trim(name, `"`))
+	if name == "" || strings.Contains(name, ".") {
+		v.errors = append(v.errors, fmt.Sprintf("@@schema no model '%s' requer o nome do schema, ex.: @@schema(\"auth\")", model.Name))
+		return
+	}
+
+	provider, _ := v.datasourceValue("provider").(string)
+	switch provider {
+	case "", "postgresql", "cockroachdb":
+	case "mysql":
+		v.errors = append(v.errors, fmt.Sprintf("@@schema no model '%s': o MySQL não tem schemas; use um datasource por banco", model.Name))
+		return
+	default:
+		v.errors = append(v.errors, fmt.Sprintf("@@schema no model '%s': o provider %s não suporta schemas", model.Name, provider))
+		return
+	}
+
+	schemas, ok := v.datasourceValue("schemas").([]interface{})
+	if !ok {
+		return
 	}
+	for _, schema := range schemas {
+		if listed, _ := schema.(string); strings.Trim(listed, `"`) == name {
+			return
+		}
+	}
+	v.errors = append(v.errors, fmt.Sprintf("@@schema no model '%s': o schema '%s' não está em schemas do datasource", model.Name, name))
+}
+
+// datasourceValue retorna o valor de um campo do primeiro datasource, ou nil
+func (v *Validator) datasourceValue(name string) interface{} {
+	if len(v.schema.Datasources) == 0 {
+		return nil
+	}
+	for _, field := range v.schema.Datasources[0].Fields {
+		if field.Name == name {
+			return field.Value
+		}
+	}
+	return nil
 }
 
 // validateModelAttribute valida um atributo de model
-func (v *Validator) validateModelAttribute(attr *Attribute, modelName string) {
+func (v *Validator) validateModelAttribute(attr *Attribute, model *Model) {
 	validAttributes := map[string]bool{
-		"id":     true,
-		"unique": true,
-		"index":  true,
-		"map":    true,
+		"id":         true,
+		"unique":     true,
+		"index":      true,
+		"map":        true,
+		"softDelete": true,
+		"check":      true,
+		"schema":     true,
 	}
 
 	// Note: Unknown attributes are allowed (may be custom attributes)
 	// If strict validation is needed in the future, add validation here
 	_ = validAttributes[attr.Name]
+
+	// @@check("expressão") precisa da condição SQL como primeiro argumento
+	if attr.Name == "check" {
+		expression := ""
+		if len(attr.Arguments) > 0 && attr.Arguments[0].Name == "" {
+			expression, _ = attr.Arguments[0].Value.(string)
+		}
+		if strings.TrimSpace(expression) == "" {
+			v.errors = append(v.errors, fmt.Sprintf("@@check no model '%s' requer uma expressão SQL, ex.: @@check(\"rating BETWEEN 1 AND 5\")", model.Name))
+		}
+		return
+	}
+
+	// @@schema("auth") coloca a tabela em um schema do PostgreSQL
+	if attr.Name == "schema" {
+		v.validateSchemaAttribute(attr, model)
+		return
+	}
+
+	// where: de um índice parcial precisa da condição SQL, e type: e os modificadores
+	// dos campos (sort, length, ops) precisam de valores válidos
+	if attr.Name == "index" || attr.Name == "unique" {
+		v.validateIndexFields(attr, model)
+		for _, arg := range attr.Arguments {
+			if arg.Name != "where" {
+				continue
+			}
+			predicate, _ := arg.Value.(string)
+			if strings.TrimSpace(strings.Trim(predicate, `"`)) == "" {
+				v.errors = append(v.errors, fmt.Sprintf("@@%s no model '%s': where requer uma condição SQL, ex.: where: \"deleted_at IS NULL\"", attr.Name, model.Name))
+			}
+		}
+		v.validateIndexType(attr, model)
+		return
+	}
+
+	// @@softDelete(campo) deve apontar para um campo DateTime opcional do model
+	if attr.Name == "softDelete" {
+		fieldName := ""
+		if len(attr.Arguments) > 0 {
+			fieldName, _ = attr.Arguments[0].Value.(string)
+		}
+		for _, field := range model.Fields {
+			if field.Name == fieldName {
+				if field.Type == nil || field.Type.Name != "DateTime" || !field.Type.IsOptional || field.Type.IsArray {
+					v.errors = append(v.errors, fmt.Sprintf("@@softDelete no model '%s': o campo '%s' deve ser DateTime?", model.Name, fieldName))
+				}
+				return
+			}
+		}
+		v.errors = append(v.errors, fmt.Sprintf("@@softDelete no model '%s': campo '%s' não encontrado", model.Name, fieldName))
+	}
 }
 
 // validateEnum valida um enum